@@ -200,6 +200,9 @@ type AlertSummary struct {
 	Title             *string             `json:"title" validate:"required"`
 	LastUpdatedBy     string              `json:"lastUpdatedBy,omitempty"`
 	LastUpdatedByTime time.Time           `json:"lastUpdatedByTime,omitempty"`
+
+	// MaintenanceWindowID is set when the alert was muted by a maintenance window, so it was not sent to the outputs
+	MaintenanceWindowID *string `json:"maintenanceWindowId,omitempty"`
 }
 
 // Alert contains the details of an alert
@@ -35,6 +35,9 @@ const (
 
 	// PolicyType identifies the Alert to be for a Policy
 	PolicyType = "POLICY"
+
+	// DigestType identifies the Alert to be a digest of the alerts held back by an output rate limit
+	DigestType = "DIGEST"
 )
 
 // LambdaInput is the invocation event expected by the Lambda function.
@@ -50,6 +53,7 @@ type LambdaInput struct {
 	DispatchAlerts []*DispatchAlertsInput `json:"Records"`
 	DeliverAlert   *DeliverAlertInput     `json:"deliverAlert"`
 	SendTestAlert  *SendTestAlertInput    `json:"sendTestAlert"`
+	SendDigests    *SendDigestsInput      `json:"sendDigests"`
 }
 
 // SendTestAlertInput sends a dummy alert to the specified destinations
@@ -73,7 +77,18 @@ type SendTestAlertOutput struct {
 	DispatchedAt time.Time `json:"dispatchedAt"`
 }
 
-// DeliverAlertInput sends an alert to the specified destinations
+// SendDigestsInput sends the digests of the rate limited alerts whose window has ended,
+// and the summaries of the alerts muted by maintenance windows which have ended.
+// It is invoked on a schedule.
+//
+// Example:
+// {
+//     "sendDigests": {}
+// }
+type SendDigestsInput struct{}
+
+// DeliverAlertInput sends an alert to the specified destinations.
+// Without destinations, the alert is sent to the destinations selected by the routing rules or the severity defaults.
 //
 // Example:
 // {
@@ -84,7 +99,7 @@ type SendTestAlertOutput struct {
 // }
 type DeliverAlertInput struct {
 	AlertID   string   `json:"alertId" validate:"required,hexadecimal,len=32"` // AlertID is an MD5 hash
-	OutputIds []string `json:"outputIds" validate:"gt=0,dive,uuid4"`
+	OutputIds []string `json:"outputIds" validate:"omitempty,dive,uuid4"`
 }
 
 // DispatchAlertsInput is an alias for an SQSMessage
@@ -171,4 +186,17 @@ type Alert struct {
 
 	// IsResent is a flag set to indicate the alert is not new
 	IsResent bool `json:"isResent,omitempty"`
+
+	// ResourceID is the resource that failed the policy, only set for policy alerts
+	ResourceID *string `json:"resourceId,omitempty"`
+
+	// IntegrationID is the source integration of the failed resource, only set for policy alerts
+	IntegrationID *string `json:"integrationId,omitempty"`
+
+	// MaintenanceWindowID is set when the alert was created during a maintenance window which mutes it
+	MaintenanceWindowID *string `json:"maintenanceWindowId,omitempty"`
+
+	// SampleEvents are some of the events that triggered the alert.
+	// They are fetched before delivery, only for outputs that store them.
+	SampleEvents []string `json:"-"`
 }
@@ -18,6 +18,10 @@ package models
  * along with this program.  If not, see <https://www.gnu.org/licenses/>.
  */
 
+import (
+	deliveryModels "github.com/panther-labs/panther/api/lambda/delivery/models"
+)
+
 // LambdaInput is the invocation event expected by the Lambda function.
 //
 // Exactly one action must be specified.
@@ -28,6 +32,16 @@ type LambdaInput struct {
 	DeleteOutput          *DeleteOutputInput          `json:"deleteOutput"`
 	GetOutputs            *GetOutputsInput            `json:"getOutputs"`
 	GetOutputsWithSecrets *GetOutputsWithSecretsInput `json:"getOutputsWithSecrets"`
+	PreviewOutputTemplate *PreviewOutputTemplateInput `json:"previewOutputTemplate"`
+	AddRoutingRule        *AddRoutingRuleInput        `json:"addRoutingRule"`
+	UpdateRoutingRule     *UpdateRoutingRuleInput     `json:"updateRoutingRule"`
+	DeleteRoutingRule     *DeleteRoutingRuleInput     `json:"deleteRoutingRule"`
+	GetRoutingRules       *GetRoutingRulesInput       `json:"getRoutingRules"`
+
+	AddMaintenanceWindow    *AddMaintenanceWindowInput    `json:"addMaintenanceWindow"`
+	UpdateMaintenanceWindow *UpdateMaintenanceWindowInput `json:"updateMaintenanceWindow"`
+	DeleteMaintenanceWindow *DeleteMaintenanceWindowInput `json:"deleteMaintenanceWindow"`
+	GetMaintenanceWindows   *GetMaintenanceWindowsInput   `json:"getMaintenanceWindows"`
 }
 
 // AddOutputInput adds a new encrypted alert output to DynamoDB.
@@ -43,10 +57,12 @@ type LambdaInput struct {
 //     }
 // }
 type AddOutputInput struct {
-	UserID             *string       `json:"userId" validate:"required,uuid4"`
-	DisplayName        *string       `json:"displayName" validate:"required,min=1,excludesall='<>&\""`
-	OutputConfig       *OutputConfig `json:"outputConfig" validate:"required"`
-	DefaultForSeverity []*string     `json:"defaultForSeverity"`
+	UserID             *string          `json:"userId" validate:"required,uuid4"`
+	DisplayName        *string          `json:"displayName" validate:"required,min=1,excludesall='<>&\""`
+	OutputConfig       *OutputConfig    `json:"outputConfig" validate:"required"`
+	DefaultForSeverity []*string        `json:"defaultForSeverity"`
+	MessageTemplate    *MessageTemplate `json:"messageTemplate"`
+	RateLimit          *RateLimit       `json:"rateLimit"`
 }
 
 // AddOutputOutput returns a randomly generated UUID for the output.
@@ -81,11 +97,19 @@ type DeleteOutputInput struct {
 //     }
 // }
 type UpdateOutputInput struct {
-	UserID             *string       `json:"userId" validate:"required,uuid4"`
-	DisplayName        *string       `json:"displayName" validate:"omitempty,min=1,excludesall='<>&\""`
-	OutputID           *string       `json:"outputId" validate:"required,uuid4"`
-	OutputConfig       *OutputConfig `json:"outputConfig"`
-	DefaultForSeverity []*string     `json:"defaultForSeverity"`
+	UserID             *string          `json:"userId" validate:"required,uuid4"`
+	DisplayName        *string          `json:"displayName" validate:"omitempty,min=1,excludesall='<>&\""`
+	OutputID           *string          `json:"outputId" validate:"required,uuid4"`
+	OutputConfig       *OutputConfig    `json:"outputConfig"`
+	DefaultForSeverity []*string        `json:"defaultForSeverity"`
+	MessageTemplate    *MessageTemplate `json:"messageTemplate"`
+	RateLimit          *RateLimit       `json:"rateLimit"`
+
+	// ClearMessageTemplate removes the message template, restoring the default formatting of messages.
+	// Omitting MessageTemplate keeps the existing template.
+	ClearMessageTemplate bool `json:"clearMessageTemplate"`
+	// ClearRateLimit removes the rate limit of the output. Omitting RateLimit keeps the existing limit.
+	ClearRateLimit bool `json:"clearRateLimit"`
 }
 
 // UpdateOutputOutput returns the new updated output
@@ -131,6 +155,35 @@ type GetOutputsWithSecretsInput struct {
 // }
 type GetOutputsOutput = []*AlertOutput
 
+// PreviewOutputTemplateInput renders a message template without saving it.
+// The template is executed against the given alert, or against a sample alert if none is given.
+//
+// Example:
+// {
+//     "previewOutputTemplate": {
+//         "messageTemplate": {
+//             "title": "[{{.Severity}}] {{.Name}}",
+//             "body": "{{.Description | truncate 200}}"
+//         }
+//     }
+// }
+type PreviewOutputTemplateInput struct {
+	MessageTemplate *MessageTemplate      `json:"messageTemplate" validate:"required"`
+	Alert           *deliveryModels.Alert `json:"alert"`
+}
+
+// PreviewOutputTemplateOutput contains the rendered message template
+//
+// Example:
+// {
+//     "title": "[HIGH] AWS Root Activity",
+//     "body": "Root account activity was detected"
+// }
+type PreviewOutputTemplateOutput struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
 // AlertOutput contains the information for alert output configuration
 type AlertOutput struct {
 
@@ -161,6 +214,28 @@ type AlertOutput struct {
 
 	// DefaultForSeverity defines the alert severities that will be forwarded through this output
 	DefaultForSeverity []*string `json:"defaultForSeverity"`
+
+	// MessageTemplate optionally replaces the default formatting of alerts sent to this output
+	MessageTemplate *MessageTemplate `json:"messageTemplate,omitempty"`
+
+	// RateLimit optionally caps the notifications sent through this output, see RateLimit
+	RateLimit *RateLimit `json:"rateLimit,omitempty"`
+}
+
+// MessageTemplate customizes the title and body of the messages an output sends.
+// Both are Go text/templates, the data model and helper functions are documented in
+// the alert delivery templates package. An empty title or body keeps the default formatting.
+type MessageTemplate struct {
+	Title string `json:"title" validate:"omitempty,max=1000,template"`
+	Body  string `json:"body" validate:"omitempty,max=10000,template"`
+}
+
+// RateLimit caps the number of alerts an output sends in a fixed time window.
+// Alerts over the limit are not sent individually; they are listed in a digest
+// message which is sent to the output once the window ends.
+type RateLimit struct {
+	MaxNotifications int `json:"maxNotifications" validate:"min=1"`
+	WindowSeconds    int `json:"windowSeconds" validate:"min=60,max=86400"`
 }
 
 // OutputConfig contains the configuration for the output
@@ -194,6 +269,24 @@ type OutputConfig struct {
 
 	// CustomWebhook contains the configuration for a Custom Webhook alert output
 	CustomWebhook *CustomWebhookConfig `json:"customWebhook,omitempty"`
+
+	// Email contains the configuration for Email alert output
+	Email *EmailConfig `json:"email,omitempty"`
+
+	// ServiceNow contains the configuration for ServiceNow alert output
+	ServiceNow *ServiceNowConfig `json:"serviceNow,omitempty"`
+
+	// Splunk contains the configuration for Splunk HTTP Event Collector alert output
+	Splunk *SplunkConfig `json:"splunk,omitempty"`
+
+	// Elasticsearch contains the configuration for Elasticsearch/OpenSearch alert output
+	Elasticsearch *ElasticsearchConfig `json:"elasticsearch,omitempty"`
+
+	// Firehose contains the configuration for Kinesis Firehose alert output
+	Firehose *FirehoseConfig `json:"firehose,omitempty"`
+
+	// S3 contains the configuration for S3 archive alert output
+	S3 *S3Config `json:"s3,omitempty"`
 }
 
 // SlackConfig defines options for each Slack output.
@@ -252,3 +345,96 @@ type AsanaConfig struct {
 type CustomWebhookConfig struct {
 	WebhookURL string `json:"webhookURL" validate:"omitempty,url"`
 }
+
+// EmailConfig defines options for each Email output
+type EmailConfig struct {
+	// Mode selects the delivery mechanism, one of "smtp" or "ses"
+	Mode string   `json:"mode" validate:"omitempty,oneof=smtp ses"`
+	From string   `json:"from" validate:"omitempty,email"`
+	To   []string `json:"to" validate:"omitempty,min=1,dive,email"`
+	Cc   []string `json:"cc" validate:"omitempty,dive,email"`
+
+	// SubjectPrefixes maps an alert severity to the text prepended to the email subject
+	SubjectPrefixes map[string]string `json:"subjectPrefixes" validate:"omitempty,dive,keys,oneof=INFO LOW MEDIUM HIGH CRITICAL,endkeys"`
+
+	// SMTP settings, used when Mode is "smtp"
+	SMTPHost     string `json:"smtpHost" validate:"omitempty,hostname|ip"`
+	SMTPPort     *int   `json:"smtpPort" validate:"omitempty,min=1,max=65535"`
+	SMTPTLS      string `json:"smtpTls" validate:"omitempty,oneof=starttls tls"` // defaults to starttls
+	SMTPUsername string `json:"smtpUsername"`
+	SMTPPassword string `json:"smtpPassword"`
+
+	// SES settings, used when Mode is "ses"
+	SesRegion           string `json:"sesRegion"`
+	SesConfigurationSet string `json:"sesConfigurationSet"`
+}
+
+// ServiceNowConfig defines options for each ServiceNow output
+type ServiceNowConfig struct {
+	InstanceURL string `json:"instanceURL" validate:"omitempty,url"` // https://<instance>.service-now.com
+	// AuthType is one of "basic" or "oauth", the OAuth password grant also requires UserName and Password
+	AuthType     string `json:"authType" validate:"omitempty,oneof=basic oauth"`
+	UserName     string `json:"userName"`
+	Password     string `json:"password"`
+	ClientID     string `json:"clientId"`
+	ClientSecret string `json:"clientSecret"`
+
+	AssignmentGroup string `json:"assignmentGroup"`
+	Category        string `json:"category"`
+
+	// SeverityMapping overrides the default impact and urgency of incidents for each alert severity
+	SeverityMapping map[string]ServiceNowPriority `json:"severityMapping" validate:"omitempty,dive,keys,oneof=INFO LOW MEDIUM HIGH CRITICAL,endkeys,required"` // nolint:lll
+
+	// CustomFields sets additional incident fields, values are templates rendered with the alert notification
+	CustomFields map[string]string `json:"customFields" validate:"omitempty,dive,keys,required,endkeys,template"`
+}
+
+// ServiceNowPriority is the impact and urgency (1 - High, 2 - Medium, 3 - Low) of a ServiceNow incident
+type ServiceNowPriority struct {
+	Impact  int `json:"impact" validate:"min=1,max=3"`
+	Urgency int `json:"urgency" validate:"min=1,max=3"`
+}
+
+// SplunkConfig defines options for each Splunk HTTP Event Collector output
+type SplunkConfig struct {
+	HecURL     string `json:"hecURL" validate:"omitempty,url"` // https://<host>:8088/services/collector/event
+	Token      string `json:"token"`
+	Index      string `json:"index"`
+	SourceType string `json:"sourceType"`
+
+	// CACertificate is a PEM encoded bundle of additional certificate authorities to trust
+	CACertificate string `json:"caCertificate" validate:"omitempty,certificate"`
+	SkipTLSVerify *bool  `json:"skipTlsVerify"`
+}
+
+// ElasticsearchConfig defines options for each Elasticsearch or OpenSearch output
+type ElasticsearchConfig struct {
+	URL   string `json:"url" validate:"omitempty,url"` // https://<host>:9200
+	Index string `json:"index" validate:"omitempty,excludesall=\\/*?\"<>0x7C #0x2C"`
+	// AuthType is one of "basic" or "apikey"
+	AuthType string `json:"authType" validate:"omitempty,oneof=basic apikey"`
+	UserName string `json:"userName"`
+	Password string `json:"password"`
+	// APIKey is the base64 encoded "id:api_key" pair returned when creating an API key
+	APIKey string `json:"apiKey"`
+
+	// CACertificate is a PEM encoded bundle of additional certificate authorities to trust
+	CACertificate string `json:"caCertificate" validate:"omitempty,certificate"`
+	SkipTLSVerify *bool  `json:"skipTlsVerify"`
+}
+
+// FirehoseConfig defines options for each Kinesis Firehose delivery stream output
+type FirehoseConfig struct {
+	StreamArn string `json:"streamArn" validate:"omitempty,firehoseArn"` // arn:aws:firehose:<region>:<account>:deliverystream/<name>
+}
+
+// S3Config defines options for each S3 archive output
+type S3Config struct {
+	BucketName string `json:"bucketName" validate:"omitempty,min=3,max=63"`
+	// Region of the bucket, defaults to the region Panther is deployed in
+	Region string `json:"region"`
+	// Prefix is prepended to the date-partitioned object keys
+	Prefix string `json:"prefix"`
+	// KmsKeyID enables SSE-KMS encryption with the given key ID, ARN or alias
+	KmsKeyID string `json:"kmsKeyId"`
+}
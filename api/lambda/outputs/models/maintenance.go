@@ -0,0 +1,229 @@
+package models
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	deliveryModels "github.com/panther-labs/panther/api/lambda/delivery/models"
+)
+
+// AddMaintenanceWindowInput creates a new maintenance window.
+//
+// Example:
+// {
+//     "addMaintenanceWindow": {
+//         "userId": "f6cfad0a-9bb0-4681-9503-02c54cc979c7",
+//         "displayName": "Quarterly penetration test",
+//         "startTime": "2020-10-05T22:00:00Z",
+//         "endTime": "2020-10-06T02:00:00Z",
+//         "recurrence": {"frequency": "WEEKLY", "until": "2020-11-01T00:00:00Z"},
+//         "conditions": {
+//             "analysisIds": ["AWS.IAM.*"],
+//             "logTypes": ["AWS.CloudTrail"]
+//         }
+//     }
+// }
+type AddMaintenanceWindowInput struct {
+	UserID *string `json:"userId" validate:"required,uuid4"`
+	MaintenanceWindowSettings
+}
+
+// AddMaintenanceWindowOutput returns the new maintenance window with its randomly generated ID
+type AddMaintenanceWindowOutput = MaintenanceWindow
+
+// UpdateMaintenanceWindowInput replaces the settings of an existing maintenance window.
+type UpdateMaintenanceWindowInput struct {
+	UserID   *string `json:"userId" validate:"required,uuid4"`
+	WindowID *string `json:"windowId" validate:"required,uuid4"`
+	MaintenanceWindowSettings
+}
+
+// UpdateMaintenanceWindowOutput returns the updated maintenance window
+type UpdateMaintenanceWindowOutput = MaintenanceWindow
+
+// DeleteMaintenanceWindowInput permanently deletes a maintenance window.
+//
+// Example:
+// {
+//     "deleteMaintenanceWindow": {
+//         "windowId": "5a2f8c1e-7d3b-4e6a-9c4d-2b1e0f9a8c77"
+//     }
+// }
+type DeleteMaintenanceWindowInput struct {
+	WindowID *string `json:"windowId" validate:"required,uuid4"`
+}
+
+// GetMaintenanceWindowsInput fetches all maintenance windows, ordered by start time
+//
+// Example:
+// {
+//     "getMaintenanceWindows": {
+//     }
+// }
+type GetMaintenanceWindowsInput struct {
+}
+
+// GetMaintenanceWindowsOutput contains all maintenance windows, ordered by start time
+type GetMaintenanceWindowsOutput = []*MaintenanceWindow
+
+// MaintenanceWindow mutes the alerts created during planned changes.
+//
+// Muted alerts are still stored, but they are not sent to their outputs. When the window ends,
+// a summary of the muted alerts is sent to the outputs they would have been sent to.
+type MaintenanceWindow struct {
+	// Identifies uniquely a maintenance window (table hash key)
+	WindowID string `json:"windowId"`
+
+	MaintenanceWindowSettings
+
+	// The user ID of the user that created the maintenance window
+	CreatedBy string `json:"createdBy"`
+
+	// The time (RFC3339) when the maintenance window was created
+	CreationTime string `json:"creationTime"`
+
+	// The user ID of the user that last modified the maintenance window
+	LastModifiedBy string `json:"lastModifiedBy"`
+
+	// The time (RFC3339) when the maintenance window was last modified
+	LastModifiedTime string `json:"lastModifiedTime"`
+}
+
+// MaintenanceWindowSettings are the user-provided settings of a maintenance window
+type MaintenanceWindowSettings struct {
+	// DisplayName is the user-provided name, e.g. "IAM migration".
+	DisplayName string `json:"displayName" validate:"required,min=1,excludesall='<>&\""`
+
+	// StartTime is the inclusive start of the (first occurrence of the) window
+	StartTime time.Time `json:"startTime" validate:"required"`
+
+	// EndTime is the exclusive end of the (first occurrence of the) window
+	EndTime time.Time `json:"endTime" validate:"required,gtfield=StartTime"`
+
+	// Recurrence optionally repeats the window
+	Recurrence *MaintenanceRecurrence `json:"recurrence,omitempty"`
+
+	// Conditions that an alert must all match to be muted
+	Conditions MaintenanceConditions `json:"conditions"`
+}
+
+// MaintenanceRecurrence repeats a maintenance window every day, week or month.
+// Occurrences start at the same time of day as the first one, in the UTC offset of its start time.
+// Monthly occurrences start on the same day of the month as the first one.
+type MaintenanceRecurrence struct {
+	Frequency string `json:"frequency" validate:"oneof=DAILY WEEKLY MONTHLY"`
+
+	// Until is the time after which no occurrence starts, empty to repeat forever
+	Until *time.Time `json:"until,omitempty"`
+}
+
+// MaintenanceConditions are the conditions of a maintenance window. Empty conditions match any alert.
+type MaintenanceConditions struct {
+	// AnalysisIDs matches alerts of rules or policies whose ID matches any of the glob patterns, e.g. "AWS.IAM.*"
+	AnalysisIDs []string `json:"analysisIds" validate:"omitempty,dive,required,glob"`
+
+	// LogTypes matches alerts triggered by events of any of the log types (case insensitive)
+	LogTypes []string `json:"logTypes" validate:"omitempty,dive,required"`
+
+	// Tags matches alerts of rules or policies with any of the tags (case insensitive)
+	Tags []string `json:"tags" validate:"omitempty,dive,required"`
+
+	// ResourceIDs matches policy alerts of resources whose ID matches any of the glob patterns
+	ResourceIDs []string `json:"resourceIds" validate:"omitempty,dive,required,glob"`
+
+	// SourceIDs matches policy alerts of resources discovered by any of the cloud security sources
+	SourceIDs []string `json:"sourceIds" validate:"omitempty,dive,uuid4"`
+}
+
+// MaxDuration is the longest a recurring window can last, so that its occurrences don't overlap
+func (recurrence *MaintenanceRecurrence) MaxDuration() time.Duration {
+	switch recurrence.Frequency {
+	case "DAILY":
+		return 24 * time.Hour
+	case "WEEKLY":
+		return 7 * 24 * time.Hour
+	default: // the shortest month
+		return 28 * 24 * time.Hour
+	}
+}
+
+// occurrence returns the start of the n-th occurrence of a recurring window
+func (recurrence *MaintenanceRecurrence) occurrence(start time.Time, n int) time.Time {
+	switch recurrence.Frequency {
+	case "DAILY":
+		return start.AddDate(0, 0, n)
+	case "WEEKLY":
+		return start.AddDate(0, 0, 7*n)
+	default:
+		return start.AddDate(0, n, 0)
+	}
+}
+
+// OccurrenceAt returns the occurrence of the window in progress at the given time, if any
+func (window *MaintenanceWindow) OccurrenceAt(t time.Time) (start, end time.Time, ok bool) {
+	if t.Before(window.StartTime) {
+		return start, end, false
+	}
+
+	start = window.StartTime
+	if recurrence := window.Recurrence; recurrence != nil {
+		// Estimate the number of occurrences started since the first one, then step back
+		// to the last occurrence started at the given time (months have different lengths)
+		n := int(t.Sub(window.StartTime) / recurrence.MaxDuration())
+		for start = recurrence.occurrence(window.StartTime, n); start.After(t); {
+			n--
+			start = recurrence.occurrence(window.StartTime, n)
+		}
+		if recurrence.Until != nil && start.After(*recurrence.Until) {
+			return start, end, false
+		}
+	}
+
+	end = start.Add(window.EndTime.Sub(window.StartTime))
+	return start, end, t.Before(end)
+}
+
+// Mutes returns true if the alert was created during an occurrence of the window and matches its conditions
+func (window *MaintenanceWindow) Mutes(alert *deliveryModels.Alert) bool {
+	if _, _, ok := window.OccurrenceAt(alert.CreatedAt); !ok {
+		return false
+	}
+	return window.Conditions.Matches(alert)
+}
+
+// Matches returns true if the alert matches all the conditions
+func (conditions *MaintenanceConditions) Matches(alert *deliveryModels.Alert) bool {
+	if len(conditions.AnalysisIDs) > 0 && !MatchesAnyGlob(conditions.AnalysisIDs, alert.AnalysisID) {
+		return false
+	}
+	if len(conditions.LogTypes) > 0 && !ContainsAnyFold(conditions.LogTypes, alert.LogTypes) {
+		return false
+	}
+	if len(conditions.Tags) > 0 && !ContainsAnyFold(conditions.Tags, alert.Tags) {
+		return false
+	}
+	if len(conditions.ResourceIDs) > 0 && (alert.ResourceID == nil || !MatchesAnyGlob(conditions.ResourceIDs, *alert.ResourceID)) {
+		return false
+	}
+	if len(conditions.SourceIDs) > 0 && (alert.IntegrationID == nil || !ContainsAnyFold(conditions.SourceIDs, []string{*alert.IntegrationID})) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,132 @@
+package models
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+
+	deliveryModels "github.com/panther-labs/panther/api/lambda/delivery/models"
+)
+
+func mustParse(value string) time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestOccurrenceAtOnce(t *testing.T) {
+	window := &MaintenanceWindow{MaintenanceWindowSettings: MaintenanceWindowSettings{
+		StartTime: mustParse("2020-10-05T22:00:00Z"),
+		EndTime:   mustParse("2020-10-06T02:00:00Z"),
+	}}
+
+	start, end, ok := window.OccurrenceAt(mustParse("2020-10-06T01:59:59Z"))
+	assert.True(t, ok)
+	assert.Equal(t, mustParse("2020-10-05T22:00:00Z"), start)
+	assert.Equal(t, mustParse("2020-10-06T02:00:00Z"), end)
+
+	_, _, ok = window.OccurrenceAt(mustParse("2020-10-05T21:59:59Z"))
+	assert.False(t, ok)
+	_, _, ok = window.OccurrenceAt(mustParse("2020-10-06T02:00:00Z"))
+	assert.False(t, ok)
+	_, _, ok = window.OccurrenceAt(mustParse("2020-10-06T23:00:00Z"))
+	assert.False(t, ok)
+}
+
+func TestOccurrenceAtWeekly(t *testing.T) {
+	until := mustParse("2020-11-01T00:00:00Z")
+	window := &MaintenanceWindow{MaintenanceWindowSettings: MaintenanceWindowSettings{
+		StartTime:  mustParse("2020-10-05T22:00:00-04:00"),
+		EndTime:    mustParse("2020-10-06T02:00:00-04:00"),
+		Recurrence: &MaintenanceRecurrence{Frequency: "WEEKLY", Until: &until},
+	}}
+
+	start, end, ok := window.OccurrenceAt(mustParse("2020-10-20T03:00:00Z"))
+	assert.True(t, ok)
+	assert.True(t, mustParse("2020-10-19T22:00:00-04:00").Equal(start))
+	assert.True(t, mustParse("2020-10-20T02:00:00-04:00").Equal(end))
+
+	// Between occurrences
+	_, _, ok = window.OccurrenceAt(mustParse("2020-10-21T03:00:00Z"))
+	assert.False(t, ok)
+	// After the recurrence ended
+	_, _, ok = window.OccurrenceAt(mustParse("2020-11-03T03:00:00Z"))
+	assert.False(t, ok)
+}
+
+func TestOccurrenceAtMonthly(t *testing.T) {
+	window := &MaintenanceWindow{MaintenanceWindowSettings: MaintenanceWindowSettings{
+		StartTime:  mustParse("2020-01-15T10:00:00Z"),
+		EndTime:    mustParse("2020-01-15T12:00:00Z"),
+		Recurrence: &MaintenanceRecurrence{Frequency: "MONTHLY"},
+	}}
+
+	start, _, ok := window.OccurrenceAt(mustParse("2020-12-15T11:00:00Z"))
+	assert.True(t, ok)
+	assert.Equal(t, mustParse("2020-12-15T10:00:00Z"), start)
+
+	_, _, ok = window.OccurrenceAt(mustParse("2020-12-16T11:00:00Z"))
+	assert.False(t, ok)
+}
+
+func TestMaintenanceConditionsMatches(t *testing.T) {
+	alert := &deliveryModels.Alert{
+		AnalysisID: "AWS.IAM.RootActivity",
+		LogTypes:   []string{"AWS.CloudTrail"},
+		Tags:       []string{"IAM"},
+	}
+
+	assert.True(t, (&MaintenanceConditions{}).Matches(alert))
+	assert.True(t, (&MaintenanceConditions{
+		AnalysisIDs: []string{"AWS.IAM.*"},
+		LogTypes:    []string{"aws.cloudtrail"},
+		Tags:        []string{"iam", "pci"},
+	}).Matches(alert))
+	assert.False(t, (&MaintenanceConditions{AnalysisIDs: []string{"AWS.S3.*"}}).Matches(alert))
+	assert.False(t, (&MaintenanceConditions{LogTypes: []string{"AWS.VPCFlow"}}).Matches(alert))
+
+	// Resource and source conditions only match policy alerts
+	conditions := &MaintenanceConditions{
+		ResourceIDs: []string{"arn:aws:iam::123456789012:role/*"},
+		SourceIDs:   []string{"3601990c-b566-404b-b367-3c6eacd6fe60"},
+	}
+	assert.False(t, conditions.Matches(alert))
+	alert.ResourceID = aws.String("arn:aws:iam::123456789012:role/migration")
+	alert.IntegrationID = aws.String("3601990c-b566-404b-b367-3c6eacd6fe60")
+	assert.True(t, conditions.Matches(alert))
+}
+
+func TestMaintenanceWindowMutes(t *testing.T) {
+	window := &MaintenanceWindow{MaintenanceWindowSettings: MaintenanceWindowSettings{
+		StartTime:  mustParse("2020-10-05T22:00:00Z"),
+		EndTime:    mustParse("2020-10-06T02:00:00Z"),
+		Conditions: MaintenanceConditions{Tags: []string{"iam"}},
+	}}
+
+	alert := &deliveryModels.Alert{CreatedAt: mustParse("2020-10-05T23:00:00Z"), Tags: []string{"iam"}}
+	assert.True(t, window.Mutes(alert))
+	alert.CreatedAt = mustParse("2020-10-06T03:00:00Z")
+	assert.False(t, window.Mutes(alert))
+}
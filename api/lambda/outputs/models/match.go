@@ -0,0 +1,47 @@
+package models
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"path"
+	"strings"
+)
+
+// ContainsAnyFold returns true if any of the wanted values is in the list of values, ignoring case.
+// It is used to match alerts against the conditions of routing rules and maintenance windows.
+func ContainsAnyFold(wanted, values []string) bool {
+	for _, value := range values {
+		for _, w := range wanted {
+			if strings.EqualFold(w, value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// MatchesAnyGlob returns true if the value matches any of the glob patterns, ie "AWS.IAM.*"
+func MatchesAnyGlob(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, value); matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,170 @@
+package models
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// AddRoutingRuleInput creates a new alert routing rule.
+//
+// Example:
+// {
+//     "addRoutingRule": {
+//         "userId": "f6cfad0a-9bb0-4681-9503-02c54cc979c7",
+//         "displayName": "Critical IAM activity during business hours",
+//         "priority": 10,
+//         "conditions": {
+//             "severities": ["CRITICAL"],
+//             "logTypes": ["AWS.CloudTrail"],
+//             "tags": ["iam"],
+//             "schedule": {
+//                 "timezone": "America/New_York",
+//                 "windows": [{"days": ["MON", "TUE", "WED", "THU", "FRI"], "startTime": "09:00", "endTime": "17:00"}]
+//             }
+//         },
+//         "outputIds": ["7d1c5854-f3ea-491c-8a52-0aa0d58cb456"]
+//     }
+// }
+type AddRoutingRuleInput struct {
+	UserID *string `json:"userId" validate:"required,uuid4"`
+	RoutingRuleSettings
+}
+
+// AddRoutingRuleOutput returns the new routing rule with its randomly generated ID
+type AddRoutingRuleOutput = RoutingRule
+
+// UpdateRoutingRuleInput replaces the settings of an existing routing rule.
+type UpdateRoutingRuleInput struct {
+	UserID *string `json:"userId" validate:"required,uuid4"`
+	RuleID *string `json:"ruleId" validate:"required,uuid4"`
+	RoutingRuleSettings
+}
+
+// UpdateRoutingRuleOutput returns the updated routing rule
+type UpdateRoutingRuleOutput = RoutingRule
+
+// DeleteRoutingRuleInput permanently deletes a routing rule.
+//
+// Example:
+// {
+//     "deleteRoutingRule": {
+//         "ruleId": "2b7c7e2f-3d4c-4d1b-8a4c-0f6f7f5e8a11"
+//     }
+// }
+type DeleteRoutingRuleInput struct {
+	RuleID *string `json:"ruleId" validate:"required,uuid4"`
+}
+
+// GetRoutingRulesInput fetches all routing rules, in the order they are evaluated
+//
+// Example:
+// {
+//     "getRoutingRules": {
+//     }
+// }
+type GetRoutingRulesInput struct {
+}
+
+// GetRoutingRulesOutput contains all routing rules, in the order they are evaluated
+type GetRoutingRulesOutput = []*RoutingRule
+
+// RoutingRule selects the outputs of the alerts that match its conditions.
+//
+// Routing rules apply to alerts whose rule or policy does not override its outputs. They are evaluated
+// in ascending priority order. The outputs of every matching rule are selected, until a matching rule
+// does not allow evaluation to continue. If no rule matches, the alert is sent to the outputs that are
+// the default for its severity.
+type RoutingRule struct {
+	// Identifies uniquely a routing rule (table hash key)
+	RuleID string `json:"ruleId"`
+
+	RoutingRuleSettings
+
+	// The user ID of the user that created the routing rule
+	CreatedBy string `json:"createdBy"`
+
+	// The time (RFC3339) when the routing rule was created
+	CreationTime string `json:"creationTime"`
+
+	// The user ID of the user that last modified the routing rule
+	LastModifiedBy string `json:"lastModifiedBy"`
+
+	// The time (RFC3339) when the routing rule was last modified
+	LastModifiedTime string `json:"lastModifiedTime"`
+}
+
+// RoutingRuleSettings are the user-provided settings of a routing rule
+type RoutingRuleSettings struct {
+	// DisplayName is the user-provided name, e.g. "Critical IAM activity".
+	DisplayName string `json:"displayName" validate:"required,min=1,excludesall='<>&\""`
+
+	// Priority sets the evaluation order, rules with a lower priority are evaluated first
+	Priority int `json:"priority" validate:"min=0"`
+
+	// Disabled rules are skipped during evaluation
+	Disabled bool `json:"disabled"`
+
+	// Conditions that an alert must all match
+	Conditions RoutingConditions `json:"conditions"`
+
+	// OutputIds are the outputs that matching alerts are sent to
+	OutputIds []string `json:"outputIds" validate:"min=1,dive,uuid4"`
+
+	// Continue evaluating the next rules after this rule matches
+	Continue bool `json:"continue"`
+}
+
+// RoutingConditions are the conditions of a routing rule. Empty conditions match any alert.
+type RoutingConditions struct {
+	// Severities matches alerts with any of the severities
+	Severities []string `json:"severities" validate:"omitempty,dive,oneof=INFO LOW MEDIUM HIGH CRITICAL"`
+
+	// LogTypes matches alerts triggered by events of any of the log types (case insensitive)
+	LogTypes []string `json:"logTypes" validate:"omitempty,dive,required"`
+
+	// Tags matches alerts of rules or policies with any of the tags (case insensitive)
+	Tags []string `json:"tags" validate:"omitempty,dive,required"`
+
+	// AnalysisIDs matches alerts of rules or policies whose ID matches any of the glob patterns, e.g. "AWS.IAM.*"
+	AnalysisIDs []string `json:"analysisIds" validate:"omitempty,dive,required,glob"`
+
+	// AlertTypes matches alerts of any of the types
+	AlertTypes []string `json:"alertTypes" validate:"omitempty,dive,oneof=RULE RULE_ERROR POLICY"`
+
+	// Schedule matches alerts delivered during any of its time windows
+	Schedule *RoutingSchedule `json:"schedule,omitempty"`
+}
+
+// RoutingSchedule is a set of weekly time windows in a timezone
+type RoutingSchedule struct {
+	// Timezone is an IANA time zone name, e.g. "Europe/London"
+	Timezone string `json:"timezone" validate:"required,timezone"`
+
+	Windows []RoutingTimeWindow `json:"windows" validate:"min=1,dive"`
+}
+
+// RoutingTimeWindow is a daily time range, on some days of the week.
+// A window whose end is before its start spans midnight, and its days refer to the day it starts.
+type RoutingTimeWindow struct {
+	// Days of the week (MON TUE WED THU FRI SAT SUN) of the window, empty for every day
+	Days []string `json:"days" validate:"omitempty,dive,oneof=MON TUE WED THU FRI SAT SUN"`
+
+	// StartTime is the inclusive start of the window, e.g. "09:00"
+	StartTime string `json:"startTime" validate:"required,clock"`
+
+	// EndTime is the exclusive end of the window, e.g. "17:30"
+	EndTime string `json:"endTime" validate:"required,clock"`
+}
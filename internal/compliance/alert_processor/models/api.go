@@ -37,6 +37,9 @@ type ComplianceNotification struct {
 	// ResourceID is the ID specific to the resource
 	ResourceID string `json:"resourceId" validate:"required,min=1"`
 
+	// IntegrationID is the ID of the cloud security source which discovered the resource
+	IntegrationID string `json:"integrationId"`
+
 	// ShouldAlert indicates whether this notification should cause an alert to be send to the customer
 	ShouldAlert bool `json:"shouldAlert"`
 
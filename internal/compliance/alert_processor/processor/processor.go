@@ -233,7 +233,9 @@ func getAlertConfigPolicy(event *models.ComplianceNotification) (*alertmodel.Ale
 			AnalysisID:          event.PolicyID,
 			AnalysisName:        aws.String(string(policy.Payload.DisplayName)),
 			CreatedAt:           event.Timestamp,
+			IntegrationID:       aws.String(event.IntegrationID),
 			OutputIds:           event.OutputIds,
+			ResourceID:          aws.String(event.ResourceID),
 			Runbook:             aws.String(string(policy.Payload.Runbook)),
 			Severity:            string(policy.Payload.Severity),
 			Tags:                policy.Payload.Tags,
@@ -244,6 +244,7 @@ func (r *batchResults) analyze(resources resourceMap, policies policyMap) error
 			complianceNotification := &alertmodels.ComplianceNotification{
 				OutputIds:       policy.OutputIds,
 				ResourceID:      resource.ID,
+				IntegrationID:   resource.IntegrationID,
 				PolicyID:        string(policy.ID),
 				PolicyVersionID: string(policy.VersionID),
 				Timestamp:       time.Now(),
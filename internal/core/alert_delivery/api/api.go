@@ -31,7 +31,10 @@ import (
 	"github.com/kelseyhightower/envconfig"
 
 	analysisApiClient "github.com/panther-labs/panther/api/gateway/analysis/client"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/digest"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/maintenance"
 	"github.com/panther-labs/panther/internal/core/alert_delivery/outputs"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/ratelimit"
 	alertTable "github.com/panther-labs/panther/internal/log_analysis/alerts_api/table"
 	"github.com/panther-labs/panther/pkg/gatewayapi"
 )
@@ -52,6 +55,10 @@ type envConfig struct {
 	OutputsAPI             string        `required:"true" split_words:"true"`
 	AnalysisAPIHost        string        `required:"true" split_words:"true"`
 	AnalysisAPIPath        string        `required:"true" split_words:"true"`
+	RateLimitsTableName    string        `required:"true" split_words:"true"`
+	PendingDigestIndexName string        `required:"true" split_words:"true"`
+
+	MaintenanceSummariesTableName string `required:"true" split_words:"true"`
 }
 
 // Globals
@@ -65,6 +72,8 @@ var (
 	outputsCache      *alertOutputsCache
 	httpClient        *http.Client
 	analysisClient    *analysisApiClient.PantherAnalysisAPI
+	rateLimitTable    ratelimit.API
+	maintenanceTable  maintenance.API
 )
 
 // Setup - initialize global state
@@ -83,6 +92,16 @@ func Setup() {
 		RuleIDCreationTimeIndexName:        env.RuleIndexName,
 		TimePartitionCreationTimeIndexName: env.TimeIndexName,
 	}
+	rateLimitTable = &ratelimit.Table{Store: digest.Store{
+		Name:      env.RateLimitsTableName,
+		IndexName: env.PendingDigestIndexName,
+		Client:    dynamodb.New(awsSession),
+	}}
+	maintenanceTable = &maintenance.Table{Store: digest.Store{
+		Name:      env.MaintenanceSummariesTableName,
+		IndexName: env.PendingDigestIndexName,
+		Client:    dynamodb.New(awsSession),
+	}}
 	httpClient = gatewayapi.GatewayClient(awsSession)
 	analysisClient = analysisApiClient.NewHTTPClientWithConfig(
 		nil, analysisApiClient.DefaultTransportConfig().
@@ -26,7 +26,10 @@ import (
 
 	deliveryModels "github.com/panther-labs/panther/api/lambda/delivery/models"
 	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/digest"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/maintenance"
 	"github.com/panther-labs/panther/internal/core/alert_delivery/outputs"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/ratelimit"
 )
 
 type mockOutputsClient struct {
@@ -39,6 +42,12 @@ func (m *mockOutputsClient) Slack(alert *deliveryModels.Alert, config *outputMod
 	return args.Get(0).(*outputs.AlertDeliveryResponse)
 }
 
+func (m *mockOutputsClient) WithTemplate(messageTemplate *outputModels.MessageTemplate) (outputs.API, error) {
+	args := m.Called(messageTemplate)
+	client, _ := args.Get(0).(outputs.API)
+	return client, args.Error(1)
+}
+
 func sampleAlert() *deliveryModels.Alert {
 	return &deliveryModels.Alert{
 		AlertID:      aws.String("alert-id"),
@@ -49,3 +58,50 @@ func sampleAlert() *deliveryModels.Alert {
 		CreatedAt:    time.Now().UTC(),
 	}
 }
+
+type mockRateLimitTable struct {
+	ratelimit.API
+	mock.Mock
+}
+
+func (m *mockRateLimitTable) Admit(outputID string, limit *outputModels.RateLimit, entry *digest.Entry, now time.Time) (bool, error) {
+	args := m.Called(outputID, limit, entry, now)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockRateLimitTable) PendingDigests(now time.Time) ([]*ratelimit.Window, error) {
+	args := m.Called(now)
+	windows, _ := args.Get(0).([]*ratelimit.Window)
+	return windows, args.Error(1)
+}
+
+func (m *mockRateLimitTable) CompleteDigest(window *ratelimit.Window) error {
+	return m.Called(window).Error(0)
+}
+
+func (m *mockRateLimitTable) RetryDigest(window *ratelimit.Window) error {
+	return m.Called(window).Error(0)
+}
+
+type mockMaintenanceTable struct {
+	maintenance.API
+	mock.Mock
+}
+
+func (m *mockMaintenanceTable) Mute(occurrence *maintenance.Occurrence, outputID string, entry *digest.Entry) error {
+	return m.Called(occurrence, outputID, entry).Error(0)
+}
+
+func (m *mockMaintenanceTable) PendingSummaries(now time.Time) ([]*maintenance.Summary, error) {
+	args := m.Called(now)
+	summaries, _ := args.Get(0).([]*maintenance.Summary)
+	return summaries, args.Error(1)
+}
+
+func (m *mockMaintenanceTable) CompleteSummary(summary *maintenance.Summary) error {
+	return m.Called(summary).Error(0)
+}
+
+func (m *mockMaintenanceTable) RetrySummary(summary *maintenance.Summary) error {
+	return m.Called(summary).Error(0)
+}
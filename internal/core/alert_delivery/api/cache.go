@@ -30,6 +30,12 @@ type alertOutputsCache struct {
 	Outputs         []*outputModels.AlertOutput
 	Expiry          time.Time
 	RefreshInterval time.Duration
+	// Routing rules are fetched separately, only when an alert needs to be routed
+	RoutingRules       []*outputModels.RoutingRule
+	RoutingRulesExpiry time.Time
+	// Maintenance windows are fetched separately, only when new alerts are dispatched
+	MaintenanceWindows       []*outputModels.MaintenanceWindow
+	MaintenanceWindowsExpiry time.Time
 }
 
 // get - Gets a pointer to the outputsCache singleton
@@ -76,3 +82,43 @@ func (c *alertOutputsCache) setRefreshInterval(duration time.Duration) {
 func (c *alertOutputsCache) isExpired() bool {
 	return time.Since(c.getExpiry()) > c.getRefreshInterval()
 }
+
+// getRoutingRules - Gets the routing rules stored in the cache
+func (c *alertOutputsCache) getRoutingRules() []*outputModels.RoutingRule {
+	return c.get().RoutingRules
+}
+
+// setRoutingRules - Stores the routing rules in the cache
+func (c *alertOutputsCache) setRoutingRules(rules []*outputModels.RoutingRule) {
+	c.get().RoutingRules = rules
+}
+
+// setRoutingRulesExpiry - Sets the expiry time of the routing rules in the cache
+func (c *alertOutputsCache) setRoutingRulesExpiry(time time.Time) {
+	c.get().RoutingRulesExpiry = time
+}
+
+// routingRulesExpired - determines if the routing rules in the cache have expired
+func (c *alertOutputsCache) routingRulesExpired() bool {
+	return time.Since(c.get().RoutingRulesExpiry) > c.getRefreshInterval()
+}
+
+// getMaintenanceWindows - Gets the maintenance windows stored in the cache
+func (c *alertOutputsCache) getMaintenanceWindows() []*outputModels.MaintenanceWindow {
+	return c.get().MaintenanceWindows
+}
+
+// setMaintenanceWindows - Stores the maintenance windows in the cache
+func (c *alertOutputsCache) setMaintenanceWindows(windows []*outputModels.MaintenanceWindow) {
+	c.get().MaintenanceWindows = windows
+}
+
+// setMaintenanceWindowsExpiry - Sets the expiry time of the maintenance windows in the cache
+func (c *alertOutputsCache) setMaintenanceWindowsExpiry(time time.Time) {
+	c.get().MaintenanceWindowsExpiry = time
+}
+
+// maintenanceWindowsExpired - determines if the maintenance windows in the cache have expired
+func (c *alertOutputsCache) maintenanceWindowsExpired() bool {
+	return time.Since(c.get().MaintenanceWindowsExpiry) > c.getRefreshInterval()
+}
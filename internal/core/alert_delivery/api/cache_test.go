@@ -103,3 +103,27 @@ func TestIsExpired(t *testing.T) {
 	c.setExpiry(time.Now().Add(time.Second * time.Duration(-30)))
 	assert.True(t, c.isExpired())
 }
+
+func TestGetSetRoutingRules(t *testing.T) {
+	outputsCache = &alertOutputsCache{RefreshInterval: time.Second * time.Duration(30)}
+	c := outputsCache.get()
+	assert.True(t, c.routingRulesExpired())
+
+	rules := []*outputModels.RoutingRule{{RuleID: "rule-id"}}
+	c.setRoutingRules(rules)
+	c.setRoutingRulesExpiry(time.Now())
+	assert.Equal(t, rules, c.getRoutingRules())
+	assert.False(t, c.routingRulesExpired())
+}
+
+func TestGetSetMaintenanceWindows(t *testing.T) {
+	outputsCache = &alertOutputsCache{RefreshInterval: time.Second * time.Duration(30)}
+	c := outputsCache.get()
+	assert.True(t, c.maintenanceWindowsExpired())
+
+	windows := []*outputModels.MaintenanceWindow{{WindowID: "window-id"}}
+	c.setMaintenanceWindows(windows)
+	c.setMaintenanceWindowsExpiry(time.Now())
+	assert.Equal(t, windows, c.getMaintenanceWindows())
+	assert.False(t, c.maintenanceWindowsExpired())
+}
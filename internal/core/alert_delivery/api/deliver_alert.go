@@ -126,6 +126,7 @@ func populateAlertData(alertItem *alertTable.AlertItem) (*deliveryModels.Alert,
 		Version:             &alertItem.RuleVersion,
 		Runbook:             aws.String(string(rule.Runbook)),
 		Tags:                rule.Tags,
+		LogTypes:            alertItem.LogTypes,
 		AlertID:             &alertItem.AlertID,
 		Title:               alertItem.Title,
 		RetryCount:          0,
@@ -145,6 +146,21 @@ func getAlertOutputMapping(alert *deliveryModels.Alert, outputIds []string) (Ale
 	// is triggered by an SQS event.
 	outputsCache.setExpiry(time.Now().Add(time.Minute * time.Duration(-5)))
 
+	// Without explicit destinations, route the alert the same way as a new alert
+	if len(outputIds) == 0 {
+		outputsCache.setRoutingRulesExpiry(time.Time{})
+		routedOutputs, err := getAlertOutputs(alert)
+		if err != nil {
+			return alertOutputMap, errors.Wrapf(err, "Failed to fetch outputIds")
+		}
+		if len(routedOutputs) == 0 {
+			return alertOutputMap, &genericapi.InvalidInputError{
+				Message: "No destinations are configured for this alert"}
+		}
+		alertOutputMap[alert] = routedOutputs
+		return alertOutputMap, nil
+	}
+
 	// Fetch outputIds from ddb
 	outputs, err := getOutputs()
 	if err != nil {
@@ -42,8 +42,15 @@ func (API) DispatchAlerts(input []*deliveryModels.DispatchAlertsInput) (interfac
 		return nil, err
 	}
 
+	// Mute the alerts created during a maintenance window, they are summarized when the window ends
+	mutedStatuses := applyMaintenanceWindows(alertOutputMap)
+
+	// Hold back the alerts over the rate limit of their outputs, they are sent later in a digest
+	rateLimitedStatuses := applyRateLimits(alertOutputMap)
+
 	// Send alerts to the specified destination(s) and obtain each response status
-	dispatchStatuses := sendAlerts(alertOutputMap)
+	dispatchStatuses := append(sendAlerts(alertOutputMap), rateLimitedStatuses...)
+	dispatchStatuses = append(dispatchStatuses, mutedStatuses...)
 
 	// Record the delivery statuses to ddb. Ignore the returned output.
 	updateAlerts(dispatchStatuses)
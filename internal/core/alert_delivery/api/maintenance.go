@@ -0,0 +1,235 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	deliveryModels "github.com/panther-labs/panther/api/lambda/delivery/models"
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/digest"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/maintenance"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+// maintenanceSummaryAnalysisID identifies maintenance summaries in the delivery logs
+const maintenanceSummaryAnalysisID = "Panther.MaintenanceSummary"
+
+// getMaintenanceWindows - Gets the maintenance windows from panther (using the outputs cache)
+func getMaintenanceWindows() ([]*outputModels.MaintenanceWindow, error) {
+	if outputsCache.maintenanceWindowsExpired() {
+		windows, err := fetchMaintenanceWindows()
+		if err != nil {
+			return nil, err
+		}
+		outputsCache.setMaintenanceWindows(windows)
+		outputsCache.setMaintenanceWindowsExpiry(time.Now().UTC())
+	}
+	return outputsCache.getMaintenanceWindows(), nil
+}
+
+// fetchMaintenanceWindows - performs an API query to get the maintenance windows
+func fetchMaintenanceWindows() ([]*outputModels.MaintenanceWindow, error) {
+	zap.L().Debug("getting maintenance windows")
+	input := outputModels.LambdaInput{GetMaintenanceWindows: &outputModels.GetMaintenanceWindowsInput{}}
+	windows := outputModels.GetMaintenanceWindowsOutput{}
+	if err := genericapi.Invoke(lambdaClient, env.OutputsAPI, &input, &windows); err != nil {
+		return nil, err
+	}
+	return windows, nil
+}
+
+// applyMaintenanceWindows - removes the outputs of the alerts muted by a maintenance window from the alert output mapping
+//
+// The muted alerts are added to the summary of the window for each output and reported as delivered,
+// so they are neither retried nor shown as failed.
+func applyMaintenanceWindows(alertOutputs AlertOutputMap) []DispatchStatus {
+	if len(alertOutputs) == 0 {
+		return nil
+	}
+	windows, err := getMaintenanceWindows()
+	if err != nil {
+		// Fail open, alerts are sent as usual if the maintenance windows are unavailable
+		zap.L().Error("failed to get maintenance windows, sending alerts", zap.Error(err))
+		return nil
+	}
+
+	var mutedStatuses []DispatchStatus
+	now := time.Now().UTC()
+	for alert, alertOutputList := range alertOutputs {
+		// Retried alerts were not muted on their first attempt
+		if alert.RetryCount > 0 || alert.IsTest {
+			continue
+		}
+		occurrence := maintenanceOccurrence(alert, windows)
+		if occurrence == nil {
+			continue
+		}
+
+		unmutedOutputs := make([]*outputModels.AlertOutput, 0, len(alertOutputList))
+		for _, output := range alertOutputList {
+			if !muteAlert(alert, occurrence, output) {
+				unmutedOutputs = append(unmutedOutputs, output)
+				continue
+			}
+			mutedStatuses = append(mutedStatuses, DispatchStatus{
+				Alert:        *alert,
+				OutputID:     *output.OutputID,
+				StatusCode:   http.StatusAccepted,
+				Success:      true,
+				Message:      "muted by maintenance window " + occurrence.DisplayName,
+				NeedsRetry:   false,
+				DispatchedAt: now,
+			})
+		}
+
+		if len(unmutedOutputs) == 0 {
+			delete(alertOutputs, alert)
+			continue
+		}
+		alertOutputs[alert] = unmutedOutputs
+	}
+	return mutedStatuses
+}
+
+// maintenanceOccurrence - finds the occurrence of a maintenance window muting the alert, if any
+func maintenanceOccurrence(alert *deliveryModels.Alert, windows []*outputModels.MaintenanceWindow) *maintenance.Occurrence {
+	for _, window := range windows {
+		if alert.MaintenanceWindowID != nil {
+			// The alert forwarder already matched the alert against the window conditions
+			if window.WindowID != *alert.MaintenanceWindowID {
+				continue
+			}
+		} else if !window.Conditions.Matches(alert) {
+			continue
+		}
+
+		if start, end, ok := window.OccurrenceAt(alert.CreatedAt); ok {
+			return &maintenance.Occurrence{
+				WindowID:    window.WindowID,
+				DisplayName: window.DisplayName,
+				Start:       start,
+				End:         end,
+			}
+		}
+	}
+	return nil
+}
+
+// muteAlert - adds the alert to the summary of the maintenance window for the output, returns false if the alert should be sent
+func muteAlert(alert *deliveryModels.Alert, occurrence *maintenance.Occurrence, output *outputModels.AlertOutput) bool {
+	err := maintenanceTable.Mute(occurrence, *output.OutputID, newDigestEntry(alert))
+	if err == digest.ErrDigestSent {
+		// The alert arrived after the summary was sent, it is sent on its own
+		zap.L().Info("maintenance summary already sent, sending alert",
+			zap.Stringp("alertID", alert.AlertID),
+			zap.Stringp("outputID", output.OutputID),
+			zap.String("maintenanceWindowID", occurrence.WindowID))
+		return false
+	}
+	if err != nil {
+		// Fail open, an alert missing from the summary should still be sent
+		zap.L().Error("failed to mute alert, sending alert",
+			zap.Stringp("alertID", alert.AlertID),
+			zap.Stringp("outputID", output.OutputID),
+			zap.String("maintenanceWindowID", occurrence.WindowID),
+			zap.Error(err))
+		return false
+	}
+	alert.MaintenanceWindowID = &occurrence.WindowID
+	return true
+}
+
+// sendMaintenanceSummaries - sends the summary of each maintenance window occurrence which ended with alerts muted
+func sendMaintenanceSummaries(now time.Time) error {
+	summaries, err := maintenanceTable.PendingSummaries(now)
+	if err != nil {
+		return err
+	}
+	if len(summaries) == 0 {
+		return nil
+	}
+	zap.L().Debug("Sending maintenance summaries", zap.Int("num_summaries", len(summaries)))
+
+	alertOutputs, err := getOutputs()
+	if err != nil {
+		return errors.Wrap(err, "Failed to fetch outputs")
+	}
+
+	for _, summary := range summaries {
+		if err := sendMaintenanceSummary(summary, alertOutputs, now); err != nil {
+			// The summary stays pending and is sent on the next invocation
+			zap.L().Error("failed to update maintenance summary state",
+				zap.String("outputID", summary.OutputID),
+				zap.String("maintenanceWindowID", summary.WindowID),
+				zap.Int64("occurrenceStart", summary.OccurrenceStart),
+				zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// sendMaintenanceSummary - sends a summary to its output and updates the summary state
+func sendMaintenanceSummary(summary *maintenance.Summary, alertOutputs []*outputModels.AlertOutput, now time.Time) error {
+	commonFields := []zap.Field{
+		zap.String("outputID", summary.OutputID),
+		zap.String("maintenanceWindowID", summary.WindowID),
+		zap.Int64("occurrenceStart", summary.OccurrenceStart),
+	}
+
+	output := intersection([]string{summary.OutputID}, alertOutputs)
+	if len(output) == 0 {
+		zap.L().Warn("output was deleted, dropping maintenance summary", commonFields...)
+		return maintenanceTable.CompleteSummary(summary)
+	}
+
+	status := sendAlerts(AlertOutputMap{generateMaintenanceSummaryAlert(summary, now): output})[0]
+	switch {
+	case status.Success:
+		return maintenanceTable.CompleteSummary(summary)
+	case status.NeedsRetry && summary.DigestAttempts+1 < env.AlertRetryCount:
+		zap.L().Warn("will retry delivery of maintenance summary", append(commonFields, zap.Any("status", status))...)
+		return maintenanceTable.RetrySummary(summary)
+	default:
+		zap.L().Error("permanently failed to send maintenance summary", append(commonFields, zap.Any("status", status))...)
+		return maintenanceTable.CompleteSummary(summary)
+	}
+}
+
+// generateMaintenanceSummaryAlert - generates the alert listing the alerts muted during a maintenance window
+func generateMaintenanceSummaryAlert(summary *maintenance.Summary, now time.Time) *deliveryModels.Alert {
+	start := time.Unix(summary.OccurrenceStart, 0).UTC()
+	end := time.Unix(summary.DigestEnd, 0).UTC()
+	title := fmt.Sprintf("%d alerts muted by maintenance window %s between %s and %s",
+		summary.EntryCount, summary.DisplayName, start.Format(time.RFC3339), end.Format(time.RFC3339))
+
+	alert := newDigestAlert(title, summary.Entries, summary.EntryCount, start, end, now)
+	alert.AnalysisID = maintenanceSummaryAnalysisID
+	alert.AnalysisName = aws.String("Maintenance Summary")
+	alert.OutputIds = []string{summary.OutputID}
+	alert.MaintenanceWindowID = aws.String(summary.WindowID)
+	alert.Context["maintenanceWindowId"] = summary.WindowID
+	return alert
+}
@@ -0,0 +1,290 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	deliveryModels "github.com/panther-labs/panther/api/lambda/delivery/models"
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/digest"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/maintenance"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/outputs"
+)
+
+func sampleMaintenanceWindow(now time.Time) *outputModels.MaintenanceWindow {
+	return &outputModels.MaintenanceWindow{
+		WindowID: "window-id",
+		MaintenanceWindowSettings: outputModels.MaintenanceWindowSettings{
+			DisplayName: "IAM migration",
+			StartTime:   now.Add(-time.Hour),
+			EndTime:     now.Add(time.Hour),
+			Conditions:  outputModels.MaintenanceConditions{AnalysisIDs: []string{"test-*"}},
+		},
+	}
+}
+
+// setupMaintenance mocks the maintenance summary table and caches the maintenance windows
+func setupMaintenance(windows ...*outputModels.MaintenanceWindow) *mockMaintenanceTable {
+	mockTable := &mockMaintenanceTable{}
+	maintenanceTable = mockTable
+	outputsCache = &alertOutputsCache{
+		MaintenanceWindows:       windows,
+		MaintenanceWindowsExpiry: time.Now().UTC(),
+		RefreshInterval:          time.Minute,
+	}
+	return mockTable
+}
+
+func TestMaintenanceOccurrence(t *testing.T) {
+	now := time.Now().UTC()
+	window := sampleMaintenanceWindow(now)
+	windows := []*outputModels.MaintenanceWindow{window}
+
+	alert := sampleAlert()
+	occurrence := maintenanceOccurrence(alert, windows)
+	require.NotNil(t, occurrence)
+	assert.Equal(t, &maintenance.Occurrence{
+		WindowID:    "window-id",
+		DisplayName: "IAM migration",
+		Start:       window.StartTime,
+		End:         window.EndTime,
+	}, occurrence)
+
+	alert.AnalysisID = "other-rule-id"
+	assert.Nil(t, maintenanceOccurrence(alert, windows))
+
+	// The alert forwarder already matched the conditions
+	alert.MaintenanceWindowID = aws.String("window-id")
+	assert.NotNil(t, maintenanceOccurrence(alert, windows))
+
+	// The window was deleted in the meantime
+	alert.MaintenanceWindowID = aws.String("deleted-window-id")
+	assert.Nil(t, maintenanceOccurrence(alert, windows))
+
+	alert = sampleAlert()
+	alert.CreatedAt = now.Add(-2 * time.Hour)
+	assert.Nil(t, maintenanceOccurrence(alert, windows))
+}
+
+func TestApplyMaintenanceWindows(t *testing.T) {
+	mockTable := setupMaintenance(sampleMaintenanceWindow(time.Now().UTC()))
+	mockTable.On("Mute", mock.Anything, "output-id", mock.Anything).Return(nil).Once()
+
+	muted, retried, other := sampleAlert(), sampleAlert(), sampleAlert()
+	muted.Type = deliveryModels.RuleType
+	retried.RetryCount = 1
+	other.AnalysisID = "other-rule-id"
+	alertOutputs := AlertOutputMap{
+		muted:   {genAlertOutput()},
+		retried: {genAlertOutput()},
+		other:   {genAlertOutput()},
+	}
+
+	statuses := applyMaintenanceWindows(alertOutputs)
+	mockTable.AssertExpectations(t)
+
+	require.Len(t, statuses, 1)
+	assert.Equal(t, "output-id", statuses[0].OutputID)
+	assert.Equal(t, http.StatusAccepted, statuses[0].StatusCode)
+	assert.True(t, statuses[0].Success)
+	assert.False(t, statuses[0].NeedsRetry)
+	assert.Equal(t, "muted by maintenance window IAM migration", statuses[0].Message)
+	assert.Equal(t, aws.String("window-id"), statuses[0].Alert.MaintenanceWindowID)
+
+	assert.NotContains(t, alertOutputs, muted)
+	assert.Contains(t, alertOutputs, retried)
+	assert.Contains(t, alertOutputs, other)
+
+	entry := mockTable.Calls[0].Arguments.Get(2).(*digest.Entry)
+	assert.Equal(t, "alert-id", entry.AlertID)
+}
+
+func TestApplyMaintenanceWindowsPerOutput(t *testing.T) {
+	mockTable := setupMaintenance(sampleMaintenanceWindow(time.Now().UTC()))
+	mockTable.On("Mute", mock.Anything, "output-1", mock.Anything).Return(nil).Once()
+	mockTable.On("Mute", mock.Anything, "output-2", mock.Anything).Return(errors.New("throttled")).Once()
+
+	alert := sampleAlert()
+	alert.Type = deliveryModels.RuleType
+	output1, output2 := genAlertOutput(), genAlertOutput()
+	output1.OutputID, output2.OutputID = aws.String("output-1"), aws.String("output-2")
+	alertOutputs := AlertOutputMap{alert: {output1, output2}}
+
+	statuses := applyMaintenanceWindows(alertOutputs)
+	mockTable.AssertExpectations(t)
+
+	require.Len(t, statuses, 1)
+	assert.Equal(t, "output-1", statuses[0].OutputID)
+	// Fail open, the alert is still sent to the output it could not be muted for
+	assert.Equal(t, []*outputModels.AlertOutput{output2}, alertOutputs[alert])
+}
+
+func TestApplyMaintenanceWindowsMuteError(t *testing.T) {
+	mockTable := setupMaintenance(sampleMaintenanceWindow(time.Now().UTC()))
+	mockTable.On("Mute", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("throttled")).Once()
+
+	alert := sampleAlert()
+	alert.Type = deliveryModels.RuleType
+	alertOutputs := AlertOutputMap{alert: {genAlertOutput()}}
+
+	assert.Empty(t, applyMaintenanceWindows(alertOutputs))
+	mockTable.AssertExpectations(t)
+	// Fail open, the alert is still sent
+	assert.Contains(t, alertOutputs, alert)
+	assert.Nil(t, alert.MaintenanceWindowID)
+}
+
+func TestApplyMaintenanceWindowsSummarySent(t *testing.T) {
+	mockTable := setupMaintenance(sampleMaintenanceWindow(time.Now().UTC()))
+	mockTable.On("Mute", mock.Anything, mock.Anything, mock.Anything).Return(digest.ErrDigestSent).Once()
+
+	alert := sampleAlert()
+	alert.Type = deliveryModels.RuleType
+	alertOutputs := AlertOutputMap{alert: {genAlertOutput()}}
+
+	assert.Empty(t, applyMaintenanceWindows(alertOutputs))
+	mockTable.AssertExpectations(t)
+	// The alert missed the summary so it is sent as usual
+	assert.Contains(t, alertOutputs, alert)
+	assert.Nil(t, alert.MaintenanceWindowID)
+}
+
+func sampleSummary(outputID string) *maintenance.Summary {
+	return &maintenance.Summary{
+		OutputID:        outputID,
+		OccurrenceID:    "window-id#1600000000",
+		WindowID:        "window-id",
+		DisplayName:     "IAM migration",
+		OccurrenceStart: 1600000000,
+		Digest: digest.Digest{
+			DigestEnd:  1600003600,
+			EntryCount: 2,
+			Entries: []*digest.Entry{
+				{AlertID: "alert-1", Title: "Root login", Severity: "HIGH", Link: "https://panther/alert-1"},
+				{AlertID: "alert-2", Title: "Root login", Severity: "LOW", Link: "https://panther/alert-2"},
+			},
+		},
+	}
+}
+
+// setupSummaries mocks the tables with only maintenance summaries pending and caches two slack outputs
+func setupSummaries() (*mockMaintenanceTable, *mockOutputsClient) {
+	output := func(id string) *outputModels.AlertOutput {
+		alertOutput := genAlertOutput()
+		alertOutput.OutputID = aws.String(id)
+		alertOutput.OutputConfig = &outputModels.OutputConfig{Slack: slackConfig(id)}
+		return alertOutput
+	}
+	mockRateLimits, mockClient := setupDigests(output("output-1"), output("output-2"))
+	mockRateLimits.On("PendingDigests", mock.Anything).Return(nil, nil)
+	mockTable := &mockMaintenanceTable{}
+	maintenanceTable = mockTable
+	return mockTable, mockClient
+}
+
+func slackConfig(outputID string) *outputModels.SlackConfig {
+	return &outputModels.SlackConfig{WebhookURL: "https://" + outputID}
+}
+
+func TestGenerateMaintenanceSummaryAlert(t *testing.T) {
+	now := time.Now().UTC()
+	alert := generateMaintenanceSummaryAlert(sampleSummary("output-1"), now)
+
+	assert.Equal(t, deliveryModels.DigestType, alert.Type)
+	assert.Equal(t, maintenanceSummaryAnalysisID, alert.AnalysisID)
+	assert.Equal(t, "HIGH", alert.Severity)
+	assert.Equal(t, []string{"output-1"}, alert.OutputIds)
+	assert.Equal(t, aws.String("window-id"), alert.MaintenanceWindowID)
+	assert.Equal(t,
+		"2 alerts muted by maintenance window IAM migration between 2020-09-13T12:26:40Z and 2020-09-13T13:26:40Z",
+		*alert.Title)
+	assert.Equal(t, "[HIGH] Root login (2): https://panther/alert-1\n", *alert.AnalysisDescription)
+	assert.Equal(t, "window-id", alert.Context["maintenanceWindowId"])
+}
+
+func TestSendMaintenanceSummaries(t *testing.T) {
+	summary1, summary2 := sampleSummary("output-1"), sampleSummary("output-2")
+	mockTable, mockClient := setupSummaries()
+	mockTable.On("PendingSummaries", mock.Anything).Return([]*maintenance.Summary{summary1, summary2}, nil).Once()
+	// Each output only gets its own summary
+	mockClient.On("Slack", mock.Anything, slackConfig("output-1")).Return(&outputs.AlertDeliveryResponse{StatusCode: 200, Success: true}).Once()
+	mockClient.On("Slack", mock.Anything, slackConfig("output-2")).Return(&outputs.AlertDeliveryResponse{StatusCode: 200, Success: true}).Once()
+	mockTable.On("CompleteSummary", summary1).Return(nil).Once()
+	mockTable.On("CompleteSummary", summary2).Return(nil).Once()
+
+	require.NoError(t, API{}.SendDigests(&deliveryModels.SendDigestsInput{}))
+	mockTable.AssertExpectations(t)
+	mockClient.AssertExpectations(t)
+}
+
+func TestSendMaintenanceSummariesRetry(t *testing.T) {
+	summary1, summary2 := sampleSummary("output-1"), sampleSummary("output-2")
+	mockTable, mockClient := setupSummaries()
+	mockTable.On("PendingSummaries", mock.Anything).Return([]*maintenance.Summary{summary1, summary2}, nil).Once()
+	mockClient.On("Slack", mock.Anything, slackConfig("output-1")).Return(&outputs.AlertDeliveryResponse{StatusCode: 200, Success: true}).Once()
+	mockClient.On("Slack", mock.Anything, slackConfig("output-2")).Return(&outputs.AlertDeliveryResponse{StatusCode: 500}).Once()
+	// Only the summary of the failed output is retried
+	mockTable.On("CompleteSummary", summary1).Return(nil).Once()
+	mockTable.On("RetrySummary", summary2).Return(nil).Once()
+
+	require.NoError(t, API{}.SendDigests(&deliveryModels.SendDigestsInput{}))
+	mockTable.AssertExpectations(t)
+	mockClient.AssertExpectations(t)
+}
+
+func TestSendMaintenanceSummariesRetriesExhausted(t *testing.T) {
+	summary := sampleSummary("output-1")
+	summary.DigestAttempts = 2
+	mockTable, mockClient := setupSummaries()
+	mockTable.On("PendingSummaries", mock.Anything).Return([]*maintenance.Summary{summary}, nil).Once()
+	mockClient.On("Slack", mock.Anything, mock.Anything).Return(&outputs.AlertDeliveryResponse{StatusCode: 500}).Once()
+	mockTable.On("CompleteSummary", summary).Return(nil).Once()
+
+	require.NoError(t, API{}.SendDigests(&deliveryModels.SendDigestsInput{}))
+	mockTable.AssertExpectations(t)
+	mockClient.AssertExpectations(t)
+}
+
+func TestSendMaintenanceSummariesDeletedOutput(t *testing.T) {
+	summary := sampleSummary("deleted-output-id")
+	mockTable, mockClient := setupSummaries()
+	mockTable.On("PendingSummaries", mock.Anything).Return([]*maintenance.Summary{summary}, nil).Once()
+	mockTable.On("CompleteSummary", summary).Return(nil).Once()
+
+	require.NoError(t, API{}.SendDigests(&deliveryModels.SendDigestsInput{}))
+	mockTable.AssertExpectations(t)
+	mockClient.AssertExpectations(t)
+}
+
+func TestSendDigestsSummariesError(t *testing.T) {
+	mockTable, _ := setupSummaries()
+	mockTable.On("PendingSummaries", mock.Anything).Return(nil, errors.New("throttled")).Once()
+
+	require.Error(t, API{}.SendDigests(&deliveryModels.SendDigestsInput{}))
+	mockTable.AssertExpectations(t)
+}
@@ -28,7 +28,7 @@ import (
 	"github.com/panther-labs/panther/pkg/genericapi"
 )
 
-// getAlertOutputs - Get output ids for an alert via the specified overrides or the defaults in panther
+// getAlertOutputs - Get output ids for an alert via the specified overrides, the routing rules or the defaults in panther
 func getAlertOutputs(alert *deliveryModels.Alert) ([]*outputModels.AlertOutput, error) {
 	// fetch available panther outputs
 	outputs, err := getOutputs()
@@ -36,8 +36,17 @@ func getAlertOutputs(alert *deliveryModels.Alert) ([]*outputModels.AlertOutput,
 		return nil, err
 	}
 
-	// If alert doesn't have outputs IDs specified, return the defaults for the severity
+	// If alert doesn't have outputs IDs specified, route it with the routing rules,
+	// falling back to the defaults for the severity
 	if len(alert.OutputIds) == 0 {
+		rules, err := getRoutingRules()
+		if err != nil {
+			return nil, err
+		}
+		if routedOutputs, matched := routeAlert(alert, rules, outputs, time.Now()); matched {
+			return routedOutputs, nil
+		}
+
 		defaultsForSeverity := []*outputModels.AlertOutput{}
 		for _, output := range outputs {
 			// If `DefaultForSeverity` is nil or empty, this loop will skip
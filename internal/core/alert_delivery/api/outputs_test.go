@@ -58,6 +58,8 @@ func TestGetAlertOutputsFromDefaultSeverity(t *testing.T) {
 	outputsCache = &alertOutputsCache{
 		RefreshInterval: time.Second * time.Duration(30),
 		Expiry:          time.Now().Add(time.Minute * time.Duration(-5)),
+		// No routing rules
+		RoutingRulesExpiry: time.Now(),
 	}
 	mockClient.On("Invoke", mock.Anything).Return(mockLambdaResponse, nil).Once()
 	alert := sampleAlert()
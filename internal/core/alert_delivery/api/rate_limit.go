@@ -0,0 +1,101 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"go.uber.org/zap"
+
+	deliveryModels "github.com/panther-labs/panther/api/lambda/delivery/models"
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/digest"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/outputs"
+)
+
+// applyRateLimits - removes the outputs over their rate limit from the alert output mapping
+//
+// The alerts held back are added to the digest of the output and reported as delivered,
+// so they are neither retried nor shown as failed.
+func applyRateLimits(alertOutputs AlertOutputMap) []DispatchStatus {
+	var rateLimitedStatuses []DispatchStatus
+	now := time.Now().UTC()
+	for alert, outputIds := range alertOutputs {
+		// Retried alerts were already counted on their first attempt
+		if alert.RetryCount > 0 || alert.IsTest {
+			continue
+		}
+
+		admittedOutputs := make([]*outputModels.AlertOutput, 0, len(outputIds))
+		for _, output := range outputIds {
+			if output.RateLimit == nil || admitAlert(alert, output, now) {
+				admittedOutputs = append(admittedOutputs, output)
+				continue
+			}
+			rateLimitedStatuses = append(rateLimitedStatuses, DispatchStatus{
+				Alert:        *alert,
+				OutputID:     *output.OutputID,
+				StatusCode:   http.StatusAccepted,
+				Success:      true,
+				Message:      "rate limit exceeded, alert added to the digest",
+				NeedsRetry:   false,
+				DispatchedAt: now,
+			})
+		}
+		alertOutputs[alert] = admittedOutputs
+	}
+	return rateLimitedStatuses
+}
+
+// admitAlert - counts the alert against the rate limit of the output, returns false if the alert is held back
+func admitAlert(alert *deliveryModels.Alert, output *outputModels.AlertOutput, now time.Time) bool {
+	admitted, err := rateLimitTable.Admit(*output.OutputID, output.RateLimit, newDigestEntry(alert), now)
+	if err != nil {
+		// Fail open, an unavailable rate limit table should not hold back alerts
+		zap.L().Error("failed to apply rate limit, sending alert",
+			zap.Stringp("alertID", alert.AlertID),
+			zap.Stringp("outputID", output.OutputID),
+			zap.Error(err))
+		return true
+	}
+	return admitted
+}
+
+// newDigestEntry - summarizes an alert held back or muted, to be listed in a digest
+func newDigestEntry(alert *deliveryModels.Alert) *digest.Entry {
+	return &digest.Entry{
+		AlertID:    aws.StringValue(alert.AlertID),
+		AnalysisID: alert.AnalysisID,
+		Title:      digestEntryTitle(alert),
+		Severity:   alert.Severity,
+		Link:       outputs.AlertURL(alert),
+	}
+}
+
+func digestEntryTitle(alert *deliveryModels.Alert) string {
+	if aws.StringValue(alert.Title) != "" {
+		return *alert.Title
+	}
+	if aws.StringValue(alert.AnalysisName) != "" {
+		return *alert.AnalysisName
+	}
+	return alert.AnalysisID
+}
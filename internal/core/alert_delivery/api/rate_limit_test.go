@@ -0,0 +1,123 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	deliveryModels "github.com/panther-labs/panther/api/lambda/delivery/models"
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/digest"
+)
+
+func rateLimitedOutput() *outputModels.AlertOutput {
+	output := genAlertOutput()
+	output.OutputID = aws.String("limited-output-id")
+	output.RateLimit = &outputModels.RateLimit{MaxNotifications: 1, WindowSeconds: 60}
+	return output
+}
+
+func rateLimitedAlert() *deliveryModels.Alert {
+	alert := sampleAlert()
+	alert.Type = deliveryModels.RuleType
+	return alert
+}
+
+func TestApplyRateLimits(t *testing.T) {
+	mockTable := &mockRateLimitTable{}
+	rateLimitTable = mockTable
+
+	alert := rateLimitedAlert()
+	alert.Title = aws.String("Title")
+	limited, unlimited := rateLimitedOutput(), genAlertOutput()
+	alertOutputs := AlertOutputMap{alert: {limited, unlimited}}
+
+	expectedEntry := &digest.Entry{
+		AlertID:    "alert-id",
+		AnalysisID: "test-rule-id",
+		Title:      "Title",
+		Severity:   "INFO",
+		Link:       "alert-id",
+	}
+	mockTable.On("Admit", "limited-output-id", limited.RateLimit, expectedEntry, mock.Anything).Return(false, nil).Once()
+
+	statuses := applyRateLimits(alertOutputs)
+	mockTable.AssertExpectations(t)
+
+	assert.Equal(t, AlertOutputMap{alert: {unlimited}}, alertOutputs)
+	require.Len(t, statuses, 1)
+	assert.Equal(t, "limited-output-id", statuses[0].OutputID)
+	assert.Equal(t, http.StatusAccepted, statuses[0].StatusCode)
+	assert.True(t, statuses[0].Success)
+	assert.False(t, statuses[0].NeedsRetry)
+}
+
+func TestApplyRateLimitsAdmitted(t *testing.T) {
+	mockTable := &mockRateLimitTable{}
+	rateLimitTable = mockTable
+
+	alert, limited := rateLimitedAlert(), rateLimitedOutput()
+	alertOutputs := AlertOutputMap{alert: {limited}}
+	mockTable.On("Admit", "limited-output-id", limited.RateLimit, mock.Anything, mock.Anything).Return(true, nil).Once()
+
+	assert.Empty(t, applyRateLimits(alertOutputs))
+	assert.Equal(t, AlertOutputMap{alert: {limited}}, alertOutputs)
+	mockTable.AssertExpectations(t)
+}
+
+func TestApplyRateLimitsFailOpen(t *testing.T) {
+	mockTable := &mockRateLimitTable{}
+	rateLimitTable = mockTable
+
+	alert, limited := rateLimitedAlert(), rateLimitedOutput()
+	alertOutputs := AlertOutputMap{alert: {limited}}
+	mockTable.On("Admit", "limited-output-id", limited.RateLimit, mock.Anything, mock.Anything).
+		Return(false, errors.New("throttled")).Once()
+
+	assert.Empty(t, applyRateLimits(alertOutputs))
+	assert.Equal(t, AlertOutputMap{alert: {limited}}, alertOutputs)
+	mockTable.AssertExpectations(t)
+}
+
+func TestApplyRateLimitsSkipsRetries(t *testing.T) {
+	mockTable := &mockRateLimitTable{}
+	rateLimitTable = mockTable
+
+	alert, limited := rateLimitedAlert(), rateLimitedOutput()
+	alert.RetryCount = 1
+	alertOutputs := AlertOutputMap{alert: {limited}}
+
+	assert.Empty(t, applyRateLimits(alertOutputs))
+	assert.Equal(t, AlertOutputMap{alert: {limited}}, alertOutputs)
+	mockTable.AssertExpectations(t)
+}
+
+func TestDigestEntryTitle(t *testing.T) {
+	alert := sampleAlert()
+	assert.Equal(t, "test_rule_name", digestEntryTitle(alert))
+	alert.Title = aws.String("Title")
+	assert.Equal(t, "Title", digestEntryTitle(alert))
+}
@@ -0,0 +1,168 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+	_ "time/tzdata" // routing schedules may use any IANA time zone
+
+	"go.uber.org/zap"
+
+	deliveryModels "github.com/panther-labs/panther/api/lambda/delivery/models"
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+// getRoutingRules - Gets the routing rules from panther (using the outputs cache)
+func getRoutingRules() ([]*outputModels.RoutingRule, error) {
+	if outputsCache.routingRulesExpired() {
+		rules, err := fetchRoutingRules()
+		if err != nil {
+			return nil, err
+		}
+		outputsCache.setRoutingRules(rules)
+		outputsCache.setRoutingRulesExpiry(time.Now().UTC())
+	}
+	return outputsCache.getRoutingRules(), nil
+}
+
+// fetchRoutingRules - performs an API query to get the routing rules, in the order they are evaluated
+func fetchRoutingRules() ([]*outputModels.RoutingRule, error) {
+	zap.L().Debug("getting routing rules")
+	input := outputModels.LambdaInput{GetRoutingRules: &outputModels.GetRoutingRulesInput{}}
+	rules := outputModels.GetRoutingRulesOutput{}
+	if err := genericapi.Invoke(lambdaClient, env.OutputsAPI, &input, &rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// routeAlert - selects the outputs of an alert by evaluating the routing rules in order.
+//
+// The outputs of every matching rule are selected, until a matching rule does not allow evaluation to continue.
+// Outputs that no longer exist are ignored. The second return value is false if no routing rule matched the alert.
+func routeAlert(
+	alert *deliveryModels.Alert,
+	rules []*outputModels.RoutingRule,
+	outputs []*outputModels.AlertOutput,
+	now time.Time,
+) ([]*outputModels.AlertOutput, bool) {
+
+	routedOutputs := []*outputModels.AlertOutput{}
+	selected := make(map[string]struct{})
+	matched := false
+	for _, rule := range rules {
+		if rule.Disabled || !routingRuleMatches(rule, alert, now) {
+			continue
+		}
+		zap.L().Debug("alert matched routing rule",
+			zap.Stringp("alertId", alert.AlertID), zap.String("routingRuleId", rule.RuleID))
+		matched = true
+
+		for _, output := range intersection(rule.OutputIds, outputs) {
+			if _, ok := selected[*output.OutputID]; ok {
+				continue
+			}
+			selected[*output.OutputID] = struct{}{}
+			routedOutputs = append(routedOutputs, output)
+		}
+
+		if !rule.Continue {
+			break
+		}
+	}
+	return routedOutputs, matched
+}
+
+// routingRuleMatches - checks if an alert matches all the conditions of a routing rule
+func routingRuleMatches(rule *outputModels.RoutingRule, alert *deliveryModels.Alert, now time.Time) bool {
+	conditions := &rule.Conditions
+	if len(conditions.Severities) > 0 && !containsString(conditions.Severities, alert.Severity) {
+		return false
+	}
+	if len(conditions.AlertTypes) > 0 && !containsString(conditions.AlertTypes, alert.Type) {
+		return false
+	}
+	if len(conditions.LogTypes) > 0 && !outputModels.ContainsAnyFold(conditions.LogTypes, alert.LogTypes) {
+		return false
+	}
+	if len(conditions.Tags) > 0 && !outputModels.ContainsAnyFold(conditions.Tags, alert.Tags) {
+		return false
+	}
+	if len(conditions.AnalysisIDs) > 0 && !outputModels.MatchesAnyGlob(conditions.AnalysisIDs, alert.AnalysisID) {
+		return false
+	}
+	if conditions.Schedule != nil && !scheduleMatches(conditions.Schedule, now) {
+		return false
+	}
+	return true
+}
+
+// scheduleMatches - checks if a point in time is in any of the time windows of a schedule
+func scheduleMatches(schedule *outputModels.RoutingSchedule, now time.Time) bool {
+	location, err := time.LoadLocation(schedule.Timezone)
+	if err != nil {
+		zap.L().Warn("invalid routing schedule timezone", zap.String("timezone", schedule.Timezone), zap.Error(err))
+		return false
+	}
+	localTime := now.In(location)
+	for i := range schedule.Windows {
+		if windowMatches(&schedule.Windows[i], localTime) {
+			return true
+		}
+	}
+	return false
+}
+
+var weekdays = [...]string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}
+
+// windowMatches - checks if a local time is in a time window.
+//
+// A window whose end is not after its start spans midnight, so the early hours belong to the previous day.
+func windowMatches(window *outputModels.RoutingTimeWindow, localTime time.Time) bool {
+	start, startErr := time.Parse("15:04", window.StartTime)
+	end, endErr := time.Parse("15:04", window.EndTime)
+	if startErr != nil || endErr != nil {
+		zap.L().Warn("invalid routing time window", zap.Any("window", window))
+		return false
+	}
+	startMinute := start.Hour()*60 + start.Minute()
+	endMinute := end.Hour()*60 + end.Minute()
+	minute := localTime.Hour()*60 + localTime.Minute()
+
+	today := weekdays[localTime.Weekday()]
+	yesterday := weekdays[(localTime.Weekday()+6)%7]
+	onDay := func(day string) bool {
+		return len(window.Days) == 0 || containsString(window.Days, day)
+	}
+
+	if startMinute < endMinute {
+		return onDay(today) && minute >= startMinute && minute < endMinute
+	}
+	return (onDay(today) && minute >= startMinute) || (onDay(yesterday) && minute < endMinute)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
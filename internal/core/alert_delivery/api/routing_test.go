@@ -0,0 +1,235 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	deliveryModels "github.com/panther-labs/panther/api/lambda/delivery/models"
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+func routingOutputs() []*outputModels.AlertOutput {
+	return []*outputModels.AlertOutput{
+		{OutputID: aws.String("pagerduty"), DefaultForSeverity: aws.StringSlice([]string{"CRITICAL"})},
+		{OutputID: aws.String("slack"), DefaultForSeverity: aws.StringSlice([]string{"CRITICAL"})},
+		{OutputID: aws.String("archive")},
+	}
+}
+
+func routingAlert() *deliveryModels.Alert {
+	return &deliveryModels.Alert{
+		AlertID:    aws.String("alert-id"),
+		AnalysisID: "AWS.IAM.RootActivity",
+		Type:       deliveryModels.RuleType,
+		Severity:   "CRITICAL",
+		LogTypes:   []string{"AWS.CloudTrail"},
+		Tags:       []string{"IAM", "Identity"},
+	}
+}
+
+func routingRule(id string, conditions outputModels.RoutingConditions, outputIDs ...string) *outputModels.RoutingRule {
+	return &outputModels.RoutingRule{
+		RuleID: id,
+		RoutingRuleSettings: outputModels.RoutingRuleSettings{
+			DisplayName: id,
+			Conditions:  conditions,
+			OutputIds:   outputIDs,
+		},
+	}
+}
+
+func outputIDs(outputs []*outputModels.AlertOutput) []string {
+	ids := make([]string, len(outputs))
+	for i, output := range outputs {
+		ids[i] = *output.OutputID
+	}
+	return ids
+}
+
+// Monday 2020-06-01 10:00 in New York
+var businessHours = time.Date(2020, 6, 1, 14, 0, 0, 0, time.UTC)
+
+var nySchedule = &outputModels.RoutingSchedule{
+	Timezone: "America/New_York",
+	Windows: []outputModels.RoutingTimeWindow{
+		{Days: []string{"MON", "TUE", "WED", "THU", "FRI"}, StartTime: "09:00", EndTime: "17:00"},
+	},
+}
+
+func TestRouteAlertBusinessHours(t *testing.T) {
+	rules := []*outputModels.RoutingRule{
+		routingRule("business-hours", outputModels.RoutingConditions{
+			Severities: []string{"CRITICAL"},
+			LogTypes:   []string{"AWS.CloudTrail"},
+			Tags:       []string{"iam"},
+			Schedule:   nySchedule,
+		}, "pagerduty"),
+		routingRule("otherwise", outputModels.RoutingConditions{Severities: []string{"CRITICAL"}}, "slack"),
+	}
+
+	routed, matched := routeAlert(routingAlert(), rules, routingOutputs(), businessHours)
+	assert.True(t, matched)
+	assert.Equal(t, []string{"pagerduty"}, outputIDs(routed))
+
+	// Saturday
+	routed, matched = routeAlert(routingAlert(), rules, routingOutputs(), businessHours.AddDate(0, 0, 5))
+	assert.True(t, matched)
+	assert.Equal(t, []string{"slack"}, outputIDs(routed))
+}
+
+func TestRouteAlertContinue(t *testing.T) {
+	archiveAll := routingRule("archive", outputModels.RoutingConditions{}, "archive")
+	archiveAll.Continue = true
+	disabled := routingRule("disabled", outputModels.RoutingConditions{}, "pagerduty")
+	disabled.Disabled = true
+	rules := []*outputModels.RoutingRule{
+		archiveAll,
+		disabled,
+		routingRule("iam", outputModels.RoutingConditions{AnalysisIDs: []string{"AWS.IAM.*"}}, "slack", "archive", "deleted"),
+		routingRule("never reached", outputModels.RoutingConditions{}, "pagerduty"),
+	}
+
+	routed, matched := routeAlert(routingAlert(), rules, routingOutputs(), businessHours)
+	assert.True(t, matched)
+	assert.Equal(t, []string{"archive", "slack"}, outputIDs(routed))
+}
+
+func TestRouteAlertNoMatch(t *testing.T) {
+	rules := []*outputModels.RoutingRule{
+		routingRule("policies", outputModels.RoutingConditions{AlertTypes: []string{"POLICY"}}, "slack"),
+		routingRule("s3", outputModels.RoutingConditions{LogTypes: []string{"AWS.S3ServerAccess"}}, "slack"),
+		routingRule("low", outputModels.RoutingConditions{Severities: []string{"LOW"}}, "slack"),
+		routingRule("gcp", outputModels.RoutingConditions{AnalysisIDs: []string{"GCP.*"}}, "slack"),
+		routingRule("tags", outputModels.RoutingConditions{Tags: []string{"Network"}}, "slack"),
+	}
+
+	routed, matched := routeAlert(routingAlert(), rules, routingOutputs(), businessHours)
+	assert.False(t, matched)
+	assert.Empty(t, routed)
+}
+
+func TestWindowMatchesOvernight(t *testing.T) {
+	window := &outputModels.RoutingTimeWindow{Days: []string{"FRI"}, StartTime: "22:00", EndTime: "06:00"}
+	friday := time.Date(2020, 6, 5, 0, 0, 0, 0, time.UTC)
+
+	assert.False(t, windowMatches(window, friday.Add(21*time.Hour+59*time.Minute)))
+	assert.True(t, windowMatches(window, friday.Add(22*time.Hour)))
+	// Early Saturday belongs to the window that started on Friday
+	assert.True(t, windowMatches(window, friday.Add(29*time.Hour)))
+	assert.False(t, windowMatches(window, friday.Add(30*time.Hour)))
+	// Early Friday belongs to a Thursday window
+	assert.False(t, windowMatches(window, friday.Add(time.Hour)))
+}
+
+func TestScheduleMatchesInvalidTimezone(t *testing.T) {
+	schedule := &outputModels.RoutingSchedule{
+		Timezone: "Mars/Olympus_Mons",
+		Windows:  []outputModels.RoutingTimeWindow{{StartTime: "00:00", EndTime: "00:00"}},
+	}
+	assert.False(t, scheduleMatches(schedule, businessHours))
+	schedule.Timezone = "UTC"
+	// A window that ends when it starts spans the whole day
+	assert.True(t, scheduleMatches(schedule, businessHours))
+}
+
+// mockOutputsAPI responds to the outputs and routing rules queries of alert delivery
+func mockOutputsAPI(t *testing.T, mockClient *testutils.LambdaMock, outputs, rules interface{}) {
+	outputsPayload, err := jsoniter.Marshal(outputs)
+	require.NoError(t, err)
+	rulesPayload, err := jsoniter.Marshal(rules)
+	require.NoError(t, err)
+
+	isRoutingQuery := func(input *lambda.InvokeInput) bool {
+		return strings.Contains(string(input.Payload), `"getRoutingRules":{}`)
+	}
+	mockClient.On("Invoke", mock.MatchedBy(isRoutingQuery)).Return(&lambda.InvokeOutput{Payload: rulesPayload}, nil)
+	mockClient.On("Invoke", mock.MatchedBy(func(input *lambda.InvokeInput) bool {
+		return !isRoutingQuery(input)
+	})).Return(&lambda.InvokeOutput{Payload: outputsPayload}, nil)
+}
+
+func TestGetAlertOutputsFromRoutingRules(t *testing.T) {
+	mockClient := &testutils.LambdaMock{}
+	lambdaClient = mockClient
+	outputsCache = &alertOutputsCache{RefreshInterval: time.Second * time.Duration(30)}
+
+	rules := []*outputModels.RoutingRule{
+		routingRule("iam", outputModels.RoutingConditions{Tags: []string{"IAM"}}, "archive"),
+	}
+	mockOutputsAPI(t, mockClient, routingOutputs(), rules)
+
+	result, err := getAlertOutputs(routingAlert())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"archive"}, outputIDs(result))
+
+	// Alerts that match no rule are sent to the defaults for the severity
+	alert := routingAlert()
+	alert.Tags = nil
+	result, err = getAlertOutputs(alert)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pagerduty", "slack"}, outputIDs(result))
+
+	// Outputs and routing rules are cached
+	mockClient.AssertNumberOfCalls(t, "Invoke", 2)
+}
+
+func TestGetAlertOutputMappingRouted(t *testing.T) {
+	mockClient := &testutils.LambdaMock{}
+	lambdaClient = mockClient
+	outputsCache = &alertOutputsCache{
+		RefreshInterval: time.Second * time.Duration(30),
+		// Stale rules are refreshed when an alert is delivered on demand
+		RoutingRules:       []*outputModels.RoutingRule{routingRule("stale", outputModels.RoutingConditions{}, "slack")},
+		RoutingRulesExpiry: time.Now(),
+	}
+
+	rules := []*outputModels.RoutingRule{
+		routingRule("iam", outputModels.RoutingConditions{Tags: []string{"IAM"}}, "archive"),
+	}
+	mockOutputsAPI(t, mockClient, routingOutputs(), rules)
+
+	alert := routingAlert()
+	result, err := getAlertOutputMapping(alert, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"archive"}, outputIDs(result[alert]))
+}
+
+func TestGetAlertOutputMappingNoRoutedOutputs(t *testing.T) {
+	mockClient := &testutils.LambdaMock{}
+	lambdaClient = mockClient
+	outputsCache = &alertOutputsCache{RefreshInterval: time.Second * time.Duration(30)}
+	mockOutputsAPI(t, mockClient, routingOutputs(), []*outputModels.RoutingRule{})
+
+	alert := routingAlert()
+	alert.Severity = "INFO"
+	result, err := getAlertOutputMapping(alert, []string{})
+	assert.Empty(t, result)
+	assert.EqualError(t, err, "No destinations are configured for this alert")
+}
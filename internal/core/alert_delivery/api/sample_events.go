@@ -0,0 +1,83 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"go.uber.org/zap"
+
+	alertModels "github.com/panther-labs/panther/api/lambda/alerts/models"
+	deliveryModels "github.com/panther-labs/panther/api/lambda/delivery/models"
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+// sampleEventsPageSize is the number of events fetched for outputs that store samples of the events with the alert
+const sampleEventsPageSize = 10
+
+// addSampleEvents fetches (in parallel) the sample events of alerts delivered to outputs that store them.
+// Alerts are delivered without samples if the events cannot be fetched.
+func addSampleEvents(alertOutputs AlertOutputMap) {
+	var wg sync.WaitGroup
+	for alert, outputs := range alertOutputs {
+		if !needsSampleEvents(alert, outputs) {
+			continue
+		}
+		wg.Add(1)
+		go func(alert *deliveryModels.Alert) {
+			defer wg.Done()
+			alert.SampleEvents = getSampleEvents(*alert.AlertID)
+		}(alert)
+	}
+	wg.Wait()
+}
+
+func needsSampleEvents(alert *deliveryModels.Alert, outputs []*outputModels.AlertOutput) bool {
+	if alert.AlertID == nil || alert.IsTest || alert.SampleEvents != nil {
+		return false
+	}
+	if alert.Type != deliveryModels.RuleType && alert.Type != deliveryModels.RuleErrorType {
+		return false
+	}
+	for _, output := range outputs {
+		switch aws.StringValue(output.OutputType) {
+		case "splunk", "elasticsearch", "firehose", "s3":
+			return true
+		}
+	}
+	return false
+}
+
+// getSampleEvents returns the first events of an alert from the alerts-api
+func getSampleEvents(alertID string) []string {
+	input := alertModels.LambdaInput{
+		GetAlert: &alertModels.GetAlertInput{
+			AlertID:        alertID,
+			EventsPageSize: aws.Int(sampleEventsPageSize),
+		},
+	}
+	var output alertModels.GetAlertOutput
+	if err := genericapi.Invoke(lambdaClient, env.AlertsAPI, &input, &output); err != nil {
+		zap.L().Warn("failed to fetch sample events", zap.String("alertID", alertID), zap.Error(err))
+		return nil
+	}
+	return aws.StringValueSlice(output.Events)
+}
@@ -0,0 +1,81 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	alertModels "github.com/panther-labs/panther/api/lambda/alerts/models"
+	deliveryModels "github.com/panther-labs/panther/api/lambda/delivery/models"
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+func TestAddSampleEvents(t *testing.T) {
+	mockClient := &testutils.LambdaMock{}
+	lambdaClient = mockClient
+
+	splunkAlert := &deliveryModels.Alert{AlertID: aws.String("alert-1"), Type: deliveryModels.RuleType}
+	slackAlert := &deliveryModels.Alert{AlertID: aws.String("alert-2"), Type: deliveryModels.RuleType}
+	policyAlert := &deliveryModels.Alert{Type: deliveryModels.PolicyType}
+	splunkOutput := &outputModels.AlertOutput{OutputID: aws.String("splunk-id"), OutputType: aws.String("splunk")}
+	slackOutput := &outputModels.AlertOutput{OutputID: aws.String("slack-id"), OutputType: aws.String("slack")}
+
+	payload, err := jsoniter.Marshal(&alertModels.GetAlertOutput{
+		AlertSummary: alertModels.AlertSummary{AlertID: "alert-1"},
+		Events:       aws.StringSlice([]string{`{"user":"alice"}`, `{"user":"bob"}`}),
+	})
+	require.NoError(t, err)
+	mockClient.On("Invoke", mock.Anything).Return(&lambda.InvokeOutput{Payload: payload}, nil).Once()
+
+	addSampleEvents(AlertOutputMap{
+		splunkAlert: {slackOutput, splunkOutput},
+		slackAlert:  {slackOutput},
+		policyAlert: {splunkOutput},
+	})
+	mockClient.AssertExpectations(t)
+	assert.Equal(t, []string{`{"user":"alice"}`, `{"user":"bob"}`}, splunkAlert.SampleEvents)
+	assert.Nil(t, slackAlert.SampleEvents)
+	assert.Nil(t, policyAlert.SampleEvents)
+
+	var input alertModels.LambdaInput
+	require.NoError(t, jsoniter.Unmarshal(mockClient.Calls[0].Arguments.Get(0).(*lambda.InvokeInput).Payload, &input))
+	assert.Equal(t, &alertModels.GetAlertInput{AlertID: "alert-1", EventsPageSize: aws.Int(sampleEventsPageSize)}, input.GetAlert)
+}
+
+func TestAddSampleEventsError(t *testing.T) {
+	mockClient := &testutils.LambdaMock{}
+	lambdaClient = mockClient
+
+	alert := &deliveryModels.Alert{AlertID: aws.String("alert-1"), Type: deliveryModels.RuleType}
+	output := &outputModels.AlertOutput{OutputID: aws.String("es-id"), OutputType: aws.String("elasticsearch")}
+	mockClient.On("Invoke", mock.Anything).Return(&lambda.InvokeOutput{}, assert.AnError).Once()
+
+	// The alert is delivered without samples
+	addSampleEvents(AlertOutputMap{alert: {output}})
+	mockClient.AssertExpectations(t)
+	assert.Nil(t, alert.SampleEvents)
+}
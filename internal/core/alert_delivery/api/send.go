@@ -44,6 +44,8 @@ type DispatchStatus struct {
 
 // sendAlerts - dispatches alerts to their associated outputIds in parallel
 func sendAlerts(alertOutputs AlertOutputMap) []DispatchStatus {
+	addSampleEvents(alertOutputs)
+
 	// Initialize the channel to dispatch all outputs in parallel.
 	statusChannel := make(chan DispatchStatus)
 
@@ -94,28 +96,51 @@ func sendAlert(alert *deliveryModels.Alert, output *outputModels.AlertOutput, di
 		}
 	}()
 
+	client := outputClient
+	if output.MessageTemplate != nil {
+		templated, err := outputClient.WithTemplate(output.MessageTemplate)
+		if err != nil {
+			// Templates are validated when they are saved, so deliver the alert with the default format
+			zap.L().Warn("invalid message template", append(commonFields, zap.Error(err))...)
+		} else {
+			client = templated
+		}
+	}
+
 	response := (*outputs.AlertDeliveryResponse)(nil)
 	switch *output.OutputType {
 	case "slack":
-		response = outputClient.Slack(alert, output.OutputConfig.Slack)
+		response = client.Slack(alert, output.OutputConfig.Slack)
 	case "pagerduty":
-		response = outputClient.PagerDuty(alert, output.OutputConfig.PagerDuty)
+		response = client.PagerDuty(alert, output.OutputConfig.PagerDuty)
 	case "github":
-		response = outputClient.Github(alert, output.OutputConfig.Github)
+		response = client.Github(alert, output.OutputConfig.Github)
 	case "opsgenie":
-		response = outputClient.Opsgenie(alert, output.OutputConfig.Opsgenie)
+		response = client.Opsgenie(alert, output.OutputConfig.Opsgenie)
 	case "jira":
-		response = outputClient.Jira(alert, output.OutputConfig.Jira)
+		response = client.Jira(alert, output.OutputConfig.Jira)
 	case "msteams":
-		response = outputClient.MsTeams(alert, output.OutputConfig.MsTeams)
+		response = client.MsTeams(alert, output.OutputConfig.MsTeams)
 	case "sqs":
-		response = outputClient.Sqs(alert, output.OutputConfig.Sqs)
+		response = client.Sqs(alert, output.OutputConfig.Sqs)
 	case "sns":
-		response = outputClient.Sns(alert, output.OutputConfig.Sns)
+		response = client.Sns(alert, output.OutputConfig.Sns)
 	case "asana":
-		response = outputClient.Asana(alert, output.OutputConfig.Asana)
+		response = client.Asana(alert, output.OutputConfig.Asana)
 	case "customwebhook":
-		response = outputClient.CustomWebhook(alert, output.OutputConfig.CustomWebhook)
+		response = client.CustomWebhook(alert, output.OutputConfig.CustomWebhook)
+	case "email":
+		response = client.Email(alert, output.OutputConfig.Email)
+	case "servicenow":
+		response = client.ServiceNow(alert, output.OutputConfig.ServiceNow)
+	case "splunk":
+		response = client.Splunk(alert, output.OutputConfig.Splunk)
+	case "elasticsearch":
+		response = client.Elasticsearch(alert, output.OutputConfig.Elasticsearch)
+	case "firehose":
+		response = client.Firehose(alert, output.OutputConfig.Firehose)
+	case "s3":
+		response = client.S3(alert, output.OutputConfig.S3)
 	default:
 		zap.L().Warn("unsupported output type", commonFields...)
 		statusChannel <- DispatchStatus{
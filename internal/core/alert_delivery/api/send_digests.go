@@ -0,0 +1,178 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	deliveryModels "github.com/panther-labs/panther/api/lambda/delivery/models"
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/digest"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/ratelimit"
+)
+
+// digestAnalysisID identifies digests in the delivery logs, since they are not triggered by a rule or policy
+const digestAnalysisID = "Panther.AlertDigest"
+
+var severityRank = map[string]int{
+	"INFO":     0,
+	"LOW":      1,
+	"MEDIUM":   2,
+	"HIGH":     3,
+	"CRITICAL": 4,
+}
+
+// digestGroup lists the alerts with the same title in a digest
+type digestGroup struct {
+	Title    string `json:"title"`
+	Severity string `json:"severity"`
+	Count    int    `json:"count"`
+	Link     string `json:"link"`
+}
+
+// SendDigests sends the digest of each rate limit window which ended with alerts held back,
+// and the summary of each maintenance window occurrence which ended with alerts muted.
+func (API) SendDigests(_ *deliveryModels.SendDigestsInput) error {
+	now := time.Now().UTC()
+	return multierr.Combine(sendRateLimitDigests(now), sendMaintenanceSummaries(now))
+}
+
+// sendRateLimitDigests - sends the digest of each rate limit window which ended with alerts held back
+func sendRateLimitDigests(now time.Time) error {
+	windows, err := rateLimitTable.PendingDigests(now)
+	if err != nil {
+		return err
+	}
+	if len(windows) == 0 {
+		return nil
+	}
+	zap.L().Debug("Sending digests", zap.Int("num_digests", len(windows)))
+
+	alertOutputs, err := getOutputs()
+	if err != nil {
+		return errors.Wrap(err, "Failed to fetch outputs")
+	}
+
+	for _, window := range windows {
+		if err := sendDigest(window, alertOutputs, now); err != nil {
+			// The digest stays pending and is sent on the next invocation
+			zap.L().Error("failed to update digest state",
+				zap.String("outputID", window.OutputID), zap.Int64("windowStart", window.WindowStart), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// sendDigest - sends the digest of a window to its output and updates the digest state
+func sendDigest(window *ratelimit.Window, alertOutputs []*outputModels.AlertOutput, now time.Time) error {
+	commonFields := []zap.Field{
+		zap.String("outputID", window.OutputID),
+		zap.Int64("windowStart", window.WindowStart),
+	}
+
+	output := intersection([]string{window.OutputID}, alertOutputs)
+	if len(output) == 0 {
+		zap.L().Warn("output was deleted, dropping digest", commonFields...)
+		return rateLimitTable.CompleteDigest(window)
+	}
+
+	digestAlert := generateDigestAlert(window, now)
+	status := sendAlerts(AlertOutputMap{digestAlert: output})[0]
+	switch {
+	case status.Success:
+		return rateLimitTable.CompleteDigest(window)
+	case status.NeedsRetry && window.DigestAttempts+1 < env.AlertRetryCount:
+		zap.L().Warn("will retry delivery of digest", append(commonFields, zap.Any("status", status))...)
+		return rateLimitTable.RetryDigest(window)
+	default:
+		zap.L().Error("permanently failed to send digest", append(commonFields, zap.Any("status", status))...)
+		return rateLimitTable.CompleteDigest(window)
+	}
+}
+
+// generateDigestAlert - generates the alert listing the alerts held back during a window
+func generateDigestAlert(window *ratelimit.Window, now time.Time) *deliveryModels.Alert {
+	windowStart := time.Unix(window.WindowStart, 0).UTC()
+	windowEnd := time.Unix(window.DigestEnd, 0).UTC()
+	title := fmt.Sprintf("%d alerts held back by the rate limit between %s and %s",
+		window.EntryCount, windowStart.Format(time.RFC3339), windowEnd.Format(time.RFC3339))
+
+	alert := newDigestAlert(title, window.Entries, window.EntryCount, windowStart, windowEnd, now)
+	alert.OutputIds = []string{window.OutputID}
+	return alert
+}
+
+// newDigestAlert - generates an alert listing up to count alerts, grouped by title.
+func newDigestAlert(title string, entries []*digest.Entry, count int, start, end, now time.Time) *deliveryModels.Alert {
+	groups := groupDigestEntries(entries)
+
+	severity := "INFO"
+	var description strings.Builder
+	for _, group := range groups {
+		if severityRank[group.Severity] > severityRank[severity] {
+			severity = group.Severity
+		}
+		fmt.Fprintf(&description, "[%s] %s (%d): %s\n", group.Severity, group.Title, group.Count, group.Link)
+	}
+	if omitted := count - len(entries); omitted > 0 {
+		fmt.Fprintf(&description, "... and %d more\n", omitted)
+	}
+
+	return &deliveryModels.Alert{
+		AnalysisID:          digestAnalysisID,
+		AnalysisName:        aws.String("Alert Digest"),
+		Type:                deliveryModels.DigestType,
+		CreatedAt:           now,
+		Severity:            severity,
+		Title:               aws.String(title),
+		AnalysisDescription: aws.String(description.String()),
+		Context: map[string]interface{}{
+			"windowStart": start,
+			"windowEnd":   end,
+			"alertCount":  count,
+			"alerts":      groups,
+		},
+	}
+}
+
+// groupDigestEntries - groups the digest entries by title, in order of first occurrence
+func groupDigestEntries(entries []*digest.Entry) []*digestGroup {
+	var groups []*digestGroup
+	byTitle := make(map[string]*digestGroup)
+	for _, entry := range entries {
+		group, ok := byTitle[entry.Title]
+		if !ok {
+			group = &digestGroup{Title: entry.Title, Severity: entry.Severity, Link: entry.Link}
+			byTitle[entry.Title] = group
+			groups = append(groups, group)
+		}
+		group.Count++
+		if severityRank[entry.Severity] > severityRank[group.Severity] {
+			group.Severity = entry.Severity
+		}
+	}
+	return groups
+}
@@ -0,0 +1,152 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	deliveryModels "github.com/panther-labs/panther/api/lambda/delivery/models"
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/digest"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/outputs"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/ratelimit"
+)
+
+func sampleWindow() *ratelimit.Window {
+	return &ratelimit.Window{
+		OutputID:      "output-id",
+		WindowStart:   1600000000,
+		Notifications: 10,
+		Digest: digest.Digest{
+			DigestEnd:  1600000300,
+			EntryCount: 4,
+			Entries: []*digest.Entry{
+				{AlertID: "alert-1", Title: "Brute force", Severity: "MEDIUM", Link: "https://panther/alert-1"},
+				{AlertID: "alert-2", Title: "Root login", Severity: "CRITICAL", Link: "https://panther/alert-2"},
+				{AlertID: "alert-3", Title: "Brute force", Severity: "HIGH", Link: "https://panther/alert-3"},
+			},
+		},
+	}
+}
+
+// setupDigests mocks the rate limit table and caches the outputs used by the digests
+func setupDigests(alertOutputs ...*outputModels.AlertOutput) (*mockRateLimitTable, *mockOutputsClient) {
+	mockTable, mockClient := &mockRateLimitTable{}, &mockOutputsClient{}
+	rateLimitTable, outputClient = mockTable, mockClient
+	noSummaries := &mockMaintenanceTable{}
+	noSummaries.On("PendingSummaries", mock.Anything).Return(nil, nil)
+	maintenanceTable = noSummaries
+	env.AlertRetryCount = 3
+	outputsCache = &alertOutputsCache{
+		Outputs:         alertOutputs,
+		Expiry:          time.Now().UTC(),
+		RefreshInterval: time.Minute,
+	}
+	return mockTable, mockClient
+}
+
+func TestGenerateDigestAlert(t *testing.T) {
+	now := time.Now().UTC()
+	alert := generateDigestAlert(sampleWindow(), now)
+
+	assert.Equal(t, deliveryModels.DigestType, alert.Type)
+	assert.Equal(t, "CRITICAL", alert.Severity)
+	assert.Equal(t, []string{"output-id"}, alert.OutputIds)
+	assert.Equal(t, now, alert.CreatedAt)
+	assert.Nil(t, alert.AlertID)
+	assert.Equal(t, "4 alerts held back by the rate limit between 2020-09-13T12:26:40Z and 2020-09-13T12:31:40Z", *alert.Title)
+	assert.Equal(t,
+		"[HIGH] Brute force (2): https://panther/alert-1\n"+
+			"[CRITICAL] Root login (1): https://panther/alert-2\n"+
+			"... and 1 more\n",
+		*alert.AnalysisDescription)
+	assert.Equal(t, 4, alert.Context["alertCount"])
+	assert.Equal(t, []*digestGroup{
+		{Title: "Brute force", Severity: "HIGH", Count: 2, Link: "https://panther/alert-1"},
+		{Title: "Root login", Severity: "CRITICAL", Count: 1, Link: "https://panther/alert-2"},
+	}, alert.Context["alerts"])
+}
+
+func TestSendDigests(t *testing.T) {
+	window := sampleWindow()
+	mockTable, mockClient := setupDigests(genAlertOutput())
+	mockTable.On("PendingDigests", mock.Anything).Return([]*ratelimit.Window{window}, nil).Once()
+	mockClient.On("Slack", mock.Anything, mock.Anything).Return(&outputs.AlertDeliveryResponse{StatusCode: 200, Success: true}).Once()
+	mockTable.On("CompleteDigest", window).Return(nil).Once()
+
+	require.NoError(t, API{}.SendDigests(&deliveryModels.SendDigestsInput{}))
+	mockTable.AssertExpectations(t)
+	mockClient.AssertExpectations(t)
+
+	digest := mockClient.Calls[0].Arguments.Get(0).(*deliveryModels.Alert)
+	assert.Equal(t, deliveryModels.DigestType, digest.Type)
+}
+
+func TestSendDigestsRetry(t *testing.T) {
+	window := sampleWindow()
+	mockTable, mockClient := setupDigests(genAlertOutput())
+	mockTable.On("PendingDigests", mock.Anything).Return([]*ratelimit.Window{window}, nil).Once()
+	mockClient.On("Slack", mock.Anything, mock.Anything).Return(&outputs.AlertDeliveryResponse{StatusCode: 500}).Once()
+	mockTable.On("RetryDigest", window).Return(nil).Once()
+
+	require.NoError(t, API{}.SendDigests(&deliveryModels.SendDigestsInput{}))
+	mockTable.AssertExpectations(t)
+	mockClient.AssertExpectations(t)
+}
+
+func TestSendDigestsRetriesExhausted(t *testing.T) {
+	window := sampleWindow()
+	window.DigestAttempts = 2
+	mockTable, mockClient := setupDigests(genAlertOutput())
+	mockTable.On("PendingDigests", mock.Anything).Return([]*ratelimit.Window{window}, nil).Once()
+	mockClient.On("Slack", mock.Anything, mock.Anything).Return(&outputs.AlertDeliveryResponse{StatusCode: 500}).Once()
+	mockTable.On("CompleteDigest", window).Return(nil).Once()
+
+	require.NoError(t, API{}.SendDigests(&deliveryModels.SendDigestsInput{}))
+	mockTable.AssertExpectations(t)
+	mockClient.AssertExpectations(t)
+}
+
+func TestSendDigestsDeletedOutput(t *testing.T) {
+	window := sampleWindow()
+	window.OutputID = "deleted-output-id"
+	mockTable, mockClient := setupDigests(genAlertOutput())
+	mockTable.On("PendingDigests", mock.Anything).Return([]*ratelimit.Window{window}, nil).Once()
+	mockTable.On("CompleteDigest", window).Return(nil).Once()
+
+	require.NoError(t, API{}.SendDigests(&deliveryModels.SendDigestsInput{}))
+	mockTable.AssertExpectations(t)
+	mockClient.AssertExpectations(t)
+}
+
+func TestSendDigestsNonePending(t *testing.T) {
+	mockTable, mockClient := setupDigests()
+	// Outputs are not fetched when no digest is pending
+	outputsCache.Expiry = time.Time{}
+	mockTable.On("PendingDigests", mock.Anything).Return(nil, nil).Once()
+
+	require.NoError(t, API{}.SendDigests(&deliveryModels.SendDigestsInput{}))
+	mockTable.AssertExpectations(t)
+	mockClient.AssertExpectations(t)
+}
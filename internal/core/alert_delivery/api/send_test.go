@@ -19,6 +19,7 @@ package api
  */
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -214,3 +215,43 @@ func TestSendSuccess(t *testing.T) {
 	assert.Equal(t, expectedResponse, <-ch)
 	mockClient.AssertExpectations(t)
 }
+
+func TestSendWithTemplate(t *testing.T) {
+	mockClient := &mockOutputsClient{}
+	templatedClient := &mockOutputsClient{}
+	outputClient = mockClient
+
+	ch := make(chan DispatchStatus, 1)
+	alert := sampleAlert()
+	alertOutput := genAlertOutput()
+	alertOutput.MessageTemplate = &outputModels.MessageTemplate{Title: "{{.Name}}"}
+	dispatchedAt := time.Now().UTC()
+
+	response := &outputs.AlertDeliveryResponse{StatusCode: 200, Success: true}
+	mockClient.On("WithTemplate", alertOutput.MessageTemplate).Return(templatedClient, nil)
+	templatedClient.On("Slack", alert, alertOutput.OutputConfig.Slack).Return(response)
+	sendAlert(alert, alertOutput, dispatchedAt, ch)
+	status := <-ch
+	assert.True(t, status.Success)
+	mockClient.AssertExpectations(t)
+	templatedClient.AssertExpectations(t)
+}
+
+func TestSendWithInvalidTemplate(t *testing.T) {
+	mockClient := &mockOutputsClient{}
+	outputClient = mockClient
+
+	ch := make(chan DispatchStatus, 1)
+	alert := sampleAlert()
+	alertOutput := genAlertOutput()
+	alertOutput.MessageTemplate = &outputModels.MessageTemplate{Title: "{{.Name"}
+	dispatchedAt := time.Now().UTC()
+
+	response := &outputs.AlertDeliveryResponse{StatusCode: 200, Success: true}
+	mockClient.On("WithTemplate", alertOutput.MessageTemplate).Return(nil, errors.New("invalid template"))
+	mockClient.On("Slack", alert, alertOutput.OutputConfig.Slack).Return(response)
+	sendAlert(alert, alertOutput, dispatchedAt, ch)
+	status := <-ch
+	assert.True(t, status.Success)
+	mockClient.AssertExpectations(t)
+}
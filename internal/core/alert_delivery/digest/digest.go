@@ -0,0 +1,218 @@
+// Package digest stores the alerts held back from an output until they are sent together in a digest.
+package digest
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"github.com/pkg/errors"
+)
+
+const (
+	// pendingDigest is the partition key of the sparse index listing the digests to send
+	pendingDigest = "PENDING"
+
+	// maxEntries caps the alerts listed in a digest to keep the item well under the
+	// Dynamo item size limit. Alerts past the cap are only counted.
+	maxEntries = 100
+
+	// Retention is how long items are kept after their digest ends to help troubleshooting
+	Retention = 7 * 24 * time.Hour
+)
+
+// ErrDigestSent is returned when adding an alert to a digest which was already sent
+var ErrDigestSent = errors.New("digest already sent")
+
+// Key is the primary key of a table item
+type Key = map[string]*dynamodb.AttributeValue
+
+// Entry is an alert held back from an output, as listed in the digest
+type Entry struct {
+	AlertID    string `json:"alertId"`
+	AnalysisID string `json:"analysisId"`
+	Title      string `json:"title"`
+	Severity   string `json:"severity"`
+	Link       string `json:"link"`
+}
+
+// Digest is the part of a table item listing the alerts held back from an output.
+// It is embedded in the items of the tables storing digests.
+type Digest struct {
+	// DigestEnd is the time (seconds since epoch) the digest is sent at
+	DigestEnd int64 `json:"digestEnd"`
+
+	// EntryCount is the number of alerts held back, Entries lists up to maxEntries of them
+	EntryCount int      `json:"entryCount"`
+	Entries    []*Entry `json:"entries"`
+
+	// DigestAttempts is the number of failed attempts to send the digest
+	DigestAttempts int `json:"digestAttempts"`
+}
+
+// Store encapsulates a connection to a Dynamo table storing digests.
+//
+// Items with a digest to send carry the pendingDigest attribute, which is the partition key
+// of the sparse index (sorted by digestEnd) used to find the digests to send.
+type Store struct {
+	Name      string
+	IndexName string
+	Client    dynamodbiface.DynamoDBAPI
+}
+
+// Add adds an alert to the digest of an item, which is sent at the given end time.
+// The attributes are set on the item along with the digest.
+//
+// It returns ErrDigestSent if the digest of the item was already sent, the alert is not added
+// so it does not bring the digest back to pending.
+func (store *Store) Add(key Key, end time.Time, entry *Entry, attributes map[string]interface{}) error {
+	newEntry, err := dynamodbattribute.Marshal(entry)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal digest entry")
+	}
+	emptyList := &dynamodb.AttributeValue{L: []*dynamodb.AttributeValue{}}
+
+	entries := expression.Name("entries")
+	update := countEntry(end, attributes).
+		Set(entries, expression.ListAppend(
+			expression.IfNotExists(entries, expression.Value(emptyList)),
+			expression.Value(&dynamodb.AttributeValue{L: []*dynamodb.AttributeValue{newEntry}})))
+	condition := digestNotSent().And(entries.AttributeNotExists().Or(entries.Size().LessThan(expression.Value(maxEntries))))
+
+	err = store.Update(key, update, &condition)
+	if err != nil && IsConditionalCheckFailed(err) {
+		// The digest is full, only count the alert
+		notSent := digestNotSent()
+		err = store.Update(key, countEntry(end, attributes), &notSent)
+		if err != nil && IsConditionalCheckFailed(err) {
+			return ErrDigestSent
+		}
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to add alert to digest")
+	}
+	return nil
+}
+
+// digestNotSent - builds the condition met by new items and items with a digest still pending
+func digestNotSent() expression.ConditionBuilder {
+	return expression.Name("digestEnd").AttributeNotExists().Or(expression.Name("pendingDigest").AttributeExists())
+}
+
+// countEntry - builds the update counting an alert held back
+func countEntry(end time.Time, attributes map[string]interface{}) expression.UpdateBuilder {
+	update := expression.
+		Set(expression.Name("digestEnd"), expression.Value(end.Unix())).
+		Set(expression.Name("pendingDigest"), expression.Value(pendingDigest)).
+		Set(expression.Name("expiresAt"), expression.Value(end.Add(Retention).Unix())).
+		Add(expression.Name("entryCount"), expression.Value(1))
+	for name, value := range attributes {
+		update = update.Set(expression.Name(name), expression.Value(value))
+	}
+	return update
+}
+
+// Pending unmarshals the items with a digest ended by now into items, a pointer to a slice.
+func (store *Store) Pending(now time.Time, items interface{}) error {
+	keyCondition := expression.Key("pendingDigest").Equal(expression.Value(pendingDigest)).
+		And(expression.Key("digestEnd").LessThanEqual(expression.Value(now.Unix())))
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCondition).Build()
+	if err != nil {
+		return errors.Wrap(err, "failed to build query expression")
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		TableName:                 aws.String(store.Name),
+		IndexName:                 aws.String(store.IndexName),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	}
+
+	var pending []map[string]*dynamodb.AttributeValue
+	for {
+		queryOutput, err := store.Client.Query(queryInput)
+		if err != nil {
+			return errors.Wrap(err, "failed to query pending digests")
+		}
+		pending = append(pending, queryOutput.Items...)
+
+		if queryOutput.LastEvaluatedKey == nil {
+			break
+		}
+		queryInput.ExclusiveStartKey = queryOutput.LastEvaluatedKey
+	}
+
+	if err = dynamodbattribute.UnmarshalListOfMaps(pending, items); err != nil {
+		return errors.Wrap(err, "failed to unmarshal pending digests")
+	}
+	return nil
+}
+
+// Complete marks the digest of an item as sent.
+func (store *Store) Complete(key Key) error {
+	update := expression.Remove(expression.Name("pendingDigest"))
+	if err := store.Update(key, update, nil); err != nil {
+		return errors.Wrap(err, "failed to complete digest")
+	}
+	return nil
+}
+
+// Retry records a failed attempt to send the digest of an item, which stays pending.
+func (store *Store) Retry(key Key) error {
+	update := expression.Add(expression.Name("digestAttempts"), expression.Value(1))
+	if err := store.Update(key, update, nil); err != nil {
+		return errors.Wrap(err, "failed to record digest attempt")
+	}
+	return nil
+}
+
+// Update updates an item of the table, failing if the condition is not met
+func (store *Store) Update(key Key, update expression.UpdateBuilder, condition *expression.ConditionBuilder) error {
+	builder := expression.NewBuilder().WithUpdate(update)
+	if condition != nil {
+		builder = builder.WithCondition(*condition)
+	}
+	expr, err := builder.Build()
+	if err != nil {
+		return errors.Wrap(err, "failed to build update expression")
+	}
+
+	_, err = store.Client.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName:                 aws.String(store.Name),
+		Key:                       key,
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	return err
+}
+
+// IsConditionalCheckFailed returns true if an update failed because its condition was not met
+func IsConditionalCheckFailed(err error) bool {
+	awsErr, ok := err.(awserr.Error)
+	return ok && awsErr.Code() == dynamodb.ErrCodeConditionalCheckFailedException
+}
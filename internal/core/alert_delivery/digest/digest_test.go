@@ -0,0 +1,182 @@
+package digest
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+var (
+	testKey   = Key{"id": {S: aws.String("item-id")}}
+	testEntry = &Entry{AlertID: "alert-id", AnalysisID: "Rule.ID", Title: "Title", Severity: "HIGH", Link: "https://panther/alert-id"}
+	testEnd   = time.Unix(1200, 0)
+
+	conditionalCheckFailed = awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "", nil)
+)
+
+type testItem struct {
+	ID string `json:"id"`
+	Digest
+}
+
+func testStore() (*Store, *testutils.DynamoDBMock) {
+	client := &testutils.DynamoDBMock{}
+	return &Store{Name: "digests", IndexName: "pending-digests", Client: client}, client
+}
+
+func updateInput(client *testutils.DynamoDBMock, call int) *dynamodb.UpdateItemInput {
+	return client.Calls[call].Arguments.Get(0).(*dynamodb.UpdateItemInput)
+}
+
+func TestAdd(t *testing.T) {
+	store, client := testStore()
+	client.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{}, nil).Once()
+
+	require.NoError(t, store.Add(testKey, testEnd, testEntry, map[string]interface{}{"title": "Digest"}))
+	client.AssertExpectations(t)
+
+	input := updateInput(client, 0)
+	assert.Equal(t, "digests", *input.TableName)
+	assert.Equal(t, testKey, input.Key)
+	assert.Contains(t, *input.UpdateExpression, "list_append")
+	assert.NotNil(t, input.ConditionExpression)
+
+	var names, numbers, strings []string
+	for _, name := range input.ExpressionAttributeNames {
+		names = append(names, *name)
+	}
+	for _, value := range input.ExpressionAttributeValues {
+		if value.N != nil {
+			numbers = append(numbers, *value.N)
+		}
+		if value.S != nil {
+			strings = append(strings, *value.S)
+		}
+	}
+	assert.Subset(t, names, []string{"entries", "entryCount", "digestEnd", "pendingDigest", "expiresAt", "title"})
+	assert.Subset(t, numbers, []string{"1200", "606000", "100"}) // digestEnd, expiresAt and maxEntries
+	assert.Subset(t, strings, []string{pendingDigest, "Digest"})
+}
+
+func TestAddDigestFull(t *testing.T) {
+	store, client := testStore()
+	client.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{}, conditionalCheckFailed).Once()
+	client.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{}, nil).Once()
+
+	require.NoError(t, store.Add(testKey, testEnd, testEntry, nil))
+	client.AssertExpectations(t)
+
+	input := updateInput(client, 1)
+	assert.NotContains(t, *input.UpdateExpression, "list_append")
+	assert.Contains(t, *input.UpdateExpression, "ADD")
+	assert.Contains(t, *input.ConditionExpression, "attribute_exists")
+}
+
+func TestAddDigestSent(t *testing.T) {
+	store, client := testStore()
+	client.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{}, conditionalCheckFailed).Twice()
+
+	// The alert must not bring a sent digest back to pending
+	assert.Equal(t, ErrDigestSent, store.Add(testKey, testEnd, testEntry, nil))
+	client.AssertExpectations(t)
+}
+
+func TestAddError(t *testing.T) {
+	store, client := testStore()
+	client.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{}, errors.New("throttled")).Once()
+
+	require.Error(t, store.Add(testKey, testEnd, testEntry, nil))
+	client.AssertExpectations(t)
+}
+
+func TestPending(t *testing.T) {
+	store, client := testStore()
+	item := func(id string) map[string]*dynamodb.AttributeValue {
+		return map[string]*dynamodb.AttributeValue{
+			"id":             {S: aws.String(id)},
+			"digestEnd":      {N: aws.String("1200")},
+			"entryCount":     {N: aws.String("2")},
+			"digestAttempts": {N: aws.String("1")},
+			"entries": {L: []*dynamodb.AttributeValue{
+				{M: map[string]*dynamodb.AttributeValue{"alertId": {S: aws.String("alert-id")}}},
+			}},
+		}
+	}
+	client.On("Query", mock.Anything).Return(&dynamodb.QueryOutput{
+		Items:            []map[string]*dynamodb.AttributeValue{item("item-1")},
+		LastEvaluatedKey: item("item-1"),
+	}, nil).Once()
+	client.On("Query", mock.Anything).Return(&dynamodb.QueryOutput{
+		Items: []map[string]*dynamodb.AttributeValue{item("item-2")},
+	}, nil).Once()
+
+	var items []*testItem
+	require.NoError(t, store.Pending(testEnd, &items))
+	client.AssertExpectations(t)
+
+	digest := Digest{DigestEnd: 1200, EntryCount: 2, Entries: []*Entry{{AlertID: "alert-id"}}, DigestAttempts: 1}
+	assert.Equal(t, []*testItem{{ID: "item-1", Digest: digest}, {ID: "item-2", Digest: digest}}, items)
+
+	assert.Equal(t, "pending-digests", *client.Calls[0].Arguments.Get(0).(*dynamodb.QueryInput).IndexName)
+}
+
+func TestPendingError(t *testing.T) {
+	store, client := testStore()
+	client.On("Query", mock.Anything).Return(&dynamodb.QueryOutput{}, errors.New("throttled")).Once()
+
+	var items []*testItem
+	require.Error(t, store.Pending(testEnd, &items))
+	client.AssertExpectations(t)
+}
+
+func TestComplete(t *testing.T) {
+	store, client := testStore()
+	client.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{}, nil).Once()
+
+	require.NoError(t, store.Complete(testKey))
+	client.AssertExpectations(t)
+
+	input := updateInput(client, 0)
+	assert.Equal(t, testKey, input.Key)
+	assert.Contains(t, *input.UpdateExpression, "REMOVE")
+}
+
+func TestRetry(t *testing.T) {
+	store, client := testStore()
+	client.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{}, nil).Once()
+
+	require.NoError(t, store.Retry(testKey))
+	client.AssertExpectations(t)
+
+	input := updateInput(client, 0)
+	assert.Equal(t, testKey, input.Key)
+	assert.Contains(t, *input.UpdateExpression, "ADD")
+	assert.NotContains(t, *input.UpdateExpression, "REMOVE")
+}
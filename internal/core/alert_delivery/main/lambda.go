@@ -39,6 +39,7 @@ var router = genericapi.NewRouter("api", "delivery", nil, api.API{})
 // 1. SQSMessage trigger that takes data from the queue or can be directly invoked
 // 2. HTTP API for re-sending an alert to the specified outputs
 // 3. HTTP API for sending a test alert
+// 4. Scheduled event sending the digests of rate limited alerts and the summaries of muted alerts
 func lambdaHandler(ctx context.Context, input json.RawMessage) (output interface{}, err error) {
 	lc, _ := lambdalogger.ConfigureGlobal(ctx, nil)
 	operation := oplog.NewManager("core", "alert_delivery").Start(lc.InvokedFunctionArn).WithMemUsed(lambdacontext.MemoryLimitInMB)
@@ -0,0 +1,112 @@
+// Package maintenance collects the alerts muted by maintenance windows, so that each output can be sent
+// a summary of the alerts it would have received when an occurrence of a window ends.
+package maintenance
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+
+	"github.com/panther-labs/panther/internal/core/alert_delivery/digest"
+)
+
+// API defines the interface for the maintenance summary table which can be used for mocking.
+type API interface {
+	Mute(occurrence *Occurrence, outputID string, entry *digest.Entry) error
+	PendingSummaries(now time.Time) ([]*Summary, error)
+	CompleteSummary(summary *Summary) error
+	RetrySummary(summary *Summary) error
+}
+
+// Occurrence identifies an occurrence of a maintenance window
+type Occurrence struct {
+	WindowID    string
+	DisplayName string
+	Start       time.Time
+	End         time.Time
+}
+
+// Summary lists the alerts muted during an occurrence of a maintenance window which would have
+// been sent to an output (one item in the table). The summary is sent when the occurrence ends.
+type Summary struct {
+	OutputID string `json:"outputId"`
+
+	// OccurrenceID identifies the occurrence by the window ID and the occurrence start
+	OccurrenceID string `json:"occurrenceId"`
+
+	WindowID    string `json:"windowId"`
+	DisplayName string `json:"displayName"`
+
+	// OccurrenceStart is in seconds since epoch, the occurrence ends at DigestEnd
+	OccurrenceStart int64 `json:"occurrenceStart"`
+
+	digest.Digest
+}
+
+// Table encapsulates a connection to the Dynamo maintenance summary table.
+//
+// The table is keyed by outputId and occurrenceId. Summaries not sent yet are listed
+// in the pending digest index, see digest.Store.
+type Table struct {
+	digest.Store
+}
+
+// Mute adds an alert to the summary of the occurrence of a maintenance window for an output.
+func (table *Table) Mute(occurrence *Occurrence, outputID string, entry *digest.Entry) error {
+	key := summaryKey(outputID, occurrenceID(occurrence.WindowID, occurrence.Start.Unix()))
+	attributes := map[string]interface{}{
+		"windowId":        occurrence.WindowID,
+		"displayName":     occurrence.DisplayName,
+		"occurrenceStart": occurrence.Start.Unix(),
+	}
+	return table.Add(key, occurrence.End, entry, attributes)
+}
+
+// PendingSummaries returns the summaries of the occurrences ended by now.
+func (table *Table) PendingSummaries(now time.Time) ([]*Summary, error) {
+	var summaries []*Summary
+	if err := table.Pending(now, &summaries); err != nil {
+		return nil, err
+	}
+	return summaries, nil
+}
+
+// CompleteSummary marks a summary as sent.
+func (table *Table) CompleteSummary(summary *Summary) error {
+	return table.Complete(summaryKey(summary.OutputID, summary.OccurrenceID))
+}
+
+// RetrySummary records a failed attempt to send a summary, which stays pending.
+func (table *Table) RetrySummary(summary *Summary) error {
+	return table.Retry(summaryKey(summary.OutputID, summary.OccurrenceID))
+}
+
+func occurrenceID(windowID string, occurrenceStart int64) string {
+	return windowID + "#" + strconv.FormatInt(occurrenceStart, 10)
+}
+
+func summaryKey(outputID, occurrenceID string) digest.Key {
+	return digest.Key{
+		"outputId":     {S: aws.String(outputID)},
+		"occurrenceId": {S: aws.String(occurrenceID)},
+	}
+}
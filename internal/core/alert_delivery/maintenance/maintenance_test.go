@@ -0,0 +1,156 @@
+package maintenance
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/core/alert_delivery/digest"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+var (
+	testOccurrence = &Occurrence{
+		WindowID:    "window-id",
+		DisplayName: "IAM migration",
+		Start:       time.Unix(3600, 0),
+		End:         time.Unix(7200, 0),
+	}
+	testEntry = &digest.Entry{AlertID: "alert-id", AnalysisID: "Rule.ID", Title: "Title", Severity: "HIGH"}
+)
+
+func testTable() (*Table, *testutils.DynamoDBMock) {
+	client := &testutils.DynamoDBMock{}
+	return &Table{Store: digest.Store{Name: "maintenance-summaries", IndexName: "pending-digests", Client: client}}, client
+}
+
+func updateInput(client *testutils.DynamoDBMock, call int) *dynamodb.UpdateItemInput {
+	return client.Calls[call].Arguments.Get(0).(*dynamodb.UpdateItemInput)
+}
+
+func TestMute(t *testing.T) {
+	table, client := testTable()
+	client.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{}, nil).Once()
+
+	require.NoError(t, table.Mute(testOccurrence, "output-id", testEntry))
+	client.AssertExpectations(t)
+
+	input := updateInput(client, 0)
+	assert.Equal(t, "output-id", *input.Key["outputId"].S)
+	assert.Equal(t, "window-id#3600", *input.Key["occurrenceId"].S)
+	assert.Contains(t, *input.UpdateExpression, "list_append")
+
+	var names, values []string
+	for _, name := range input.ExpressionAttributeNames {
+		names = append(names, *name)
+	}
+	for _, value := range input.ExpressionAttributeValues {
+		values = append(values, aws.StringValue(value.S)+aws.StringValue(value.N))
+	}
+	assert.Subset(t, names, []string{"windowId", "displayName", "occurrenceStart"})
+	assert.Subset(t, values, []string{"window-id", "IAM migration", "3600", "7200"}) // 7200 is digestEnd
+}
+
+func TestMuteError(t *testing.T) {
+	table, client := testTable()
+	client.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{}, errors.New("throttled")).Once()
+
+	require.Error(t, table.Mute(testOccurrence, "output-id", testEntry))
+	client.AssertExpectations(t)
+}
+
+func TestPendingSummaries(t *testing.T) {
+	table, client := testTable()
+	item := func(outputID string) map[string]*dynamodb.AttributeValue {
+		return map[string]*dynamodb.AttributeValue{
+			"outputId":        {S: aws.String(outputID)},
+			"occurrenceId":    {S: aws.String("window-id#3600")},
+			"windowId":        {S: aws.String("window-id")},
+			"displayName":     {S: aws.String("IAM migration")},
+			"occurrenceStart": {N: aws.String("3600")},
+			"digestEnd":       {N: aws.String("7200")},
+			"entryCount":      {N: aws.String("2")},
+			"entries": {L: []*dynamodb.AttributeValue{
+				{M: map[string]*dynamodb.AttributeValue{"alertId": {S: aws.String("alert-id")}}},
+			}},
+		}
+	}
+	client.On("Query", mock.Anything).Return(&dynamodb.QueryOutput{
+		Items: []map[string]*dynamodb.AttributeValue{item("output-1"), item("output-2")},
+	}, nil).Once()
+
+	summaries, err := table.PendingSummaries(time.Unix(7200, 0))
+	require.NoError(t, err)
+	client.AssertExpectations(t)
+
+	summary := func(outputID string) *Summary {
+		return &Summary{
+			OutputID:        outputID,
+			OccurrenceID:    "window-id#3600",
+			WindowID:        "window-id",
+			DisplayName:     "IAM migration",
+			OccurrenceStart: 3600,
+			Digest:          digest.Digest{DigestEnd: 7200, EntryCount: 2, Entries: []*digest.Entry{{AlertID: "alert-id"}}},
+		}
+	}
+	assert.Equal(t, []*Summary{summary("output-1"), summary("output-2")}, summaries)
+}
+
+func TestPendingSummariesError(t *testing.T) {
+	table, client := testTable()
+	client.On("Query", mock.Anything).Return(&dynamodb.QueryOutput{}, errors.New("throttled")).Once()
+
+	summaries, err := table.PendingSummaries(time.Unix(7200, 0))
+	require.Error(t, err)
+	assert.Nil(t, summaries)
+	client.AssertExpectations(t)
+}
+
+func TestCompleteSummary(t *testing.T) {
+	table, client := testTable()
+	client.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{}, nil).Once()
+
+	require.NoError(t, table.CompleteSummary(&Summary{OutputID: "output-id", OccurrenceID: "window-id#3600"}))
+	client.AssertExpectations(t)
+
+	input := updateInput(client, 0)
+	assert.Equal(t, "output-id", *input.Key["outputId"].S)
+	assert.Equal(t, "window-id#3600", *input.Key["occurrenceId"].S)
+	assert.Contains(t, *input.UpdateExpression, "REMOVE")
+}
+
+func TestRetrySummary(t *testing.T) {
+	table, client := testTable()
+	client.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{}, nil).Once()
+
+	require.NoError(t, table.RetrySummary(&Summary{OutputID: "output-id", OccurrenceID: "window-id#3600"}))
+	client.AssertExpectations(t)
+
+	input := updateInput(client, 0)
+	assert.Equal(t, "window-id#3600", *input.Key["occurrenceId"].S)
+	assert.Contains(t, *input.UpdateExpression, "ADD")
+}
@@ -37,9 +37,9 @@ func (client *OutputClient) Asana(alert *alertModels.Alert, config *outputModels
 	zap.L().Debug("sending alert to Asana")
 	payload := map[string]interface{}{
 		"data": map[string]interface{}{
-			"name":     generateAlertTitle(alert),
+			"name":     client.alertTitle(alert),
 			"projects": config.ProjectGids,
-			"notes":    generateDetailedAlertMessage(alert),
+			"notes":    client.detailedAlertMessage(alert),
 		},
 	}
 
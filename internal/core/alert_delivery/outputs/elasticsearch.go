@@ -0,0 +1,156 @@
+package outputs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	alertModels "github.com/panther-labs/panther/api/lambda/delivery/models"
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+)
+
+const (
+	elasticsearchBulkEndpoint = "/_bulk"
+	elasticsearchContentType  = "application/x-ndjson"
+)
+
+// elasticsearchDocument adds the timestamp field expected by data streams and index patterns
+type elasticsearchDocument struct {
+	notificationRecord
+	Timestamp time.Time `json:"@timestamp"`
+}
+
+// elasticsearchBulkAction is the action line preceding each document in a bulk request
+type elasticsearchBulkAction struct {
+	Index struct {
+		Index string `json:"_index"`
+		ID    string `json:"_id,omitempty"`
+	} `json:"index"`
+}
+
+// elasticsearchBulkResponse is the part of the bulk API response needed to detect failed items.
+// The bulk API responds with 200 even if some (or all) of the items failed.
+type elasticsearchBulkResponse struct {
+	Errors bool `json:"errors"`
+	Items  []map[string]struct {
+		Status int `json:"status"`
+		Error  struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		} `json:"error"`
+	} `json:"items"`
+}
+
+// Elasticsearch indexes an alert into an Elasticsearch or OpenSearch index using the bulk API.
+// The AlertID is used as the document ID so that retried deliveries do not create duplicates.
+func (client *OutputClient) Elasticsearch(
+	alert *alertModels.Alert, config *outputModels.ElasticsearchConfig) *AlertDeliveryResponse {
+
+	httpWrapper, err := client.httpWrapperWithTLS(config.CACertificate, aws.BoolValue(config.SkipTLSVerify))
+	if err != nil {
+		errorMsg := "Invalid TLS configuration for Elasticsearch"
+		zap.L().Error(errorMsg, zap.Error(err))
+		return &AlertDeliveryResponse{
+			StatusCode: 500,
+			Message:    errorMsg,
+			Permanent:  true,
+			Success:    false,
+		}
+	}
+
+	body, err := buildElasticsearchBulkBody(alert, client.notificationRecord(alert), config.Index)
+	if err != nil {
+		errorMsg := "Failed to serialize Elasticsearch bulk request"
+		zap.L().Error(errorMsg, zap.Error(errors.WithStack(err)))
+		return &AlertDeliveryResponse{
+			StatusCode: 500,
+			Message:    errorMsg,
+			Permanent:  true,
+			Success:    false,
+		}
+	}
+
+	postInput := &PostInput{
+		url:     strings.TrimSuffix(config.URL, "/") + elasticsearchBulkEndpoint,
+		body:    &rawBody{contentType: elasticsearchContentType, data: body},
+		headers: map[string]string{},
+	}
+	switch {
+	case config.AuthType == "apikey":
+		postInput.headers[AuthorizationHTTPHeader] = "ApiKey " + config.APIKey
+	case config.UserName != "":
+		auth := config.UserName + ":" + config.Password
+		postInput.headers[AuthorizationHTTPHeader] = "Basic " + base64.StdEncoding.EncodeToString([]byte(auth))
+	}
+
+	response := httpWrapper.post(postInput)
+	if response == nil || !response.Success {
+		return response
+	}
+	return getAlertResponseFromBulkResponse(response)
+}
+
+func buildElasticsearchBulkBody(alert *alertModels.Alert, record notificationRecord, index string) ([]byte, error) {
+	action := &elasticsearchBulkAction{}
+	action.Index.Index = index
+	action.Index.ID = aws.StringValue(alert.AlertID)
+	document := &elasticsearchDocument{
+		notificationRecord: record,
+		Timestamp:          alert.CreatedAt,
+	}
+
+	var body bytes.Buffer
+	stream := jsoniter.NewEncoder(&body)
+	if err := stream.Encode(action); err != nil {
+		return nil, err
+	}
+	if err := stream.Encode(document); err != nil {
+		return nil, err
+	}
+	return body.Bytes(), nil
+}
+
+// getAlertResponseFromBulkResponse reports the first failed item of a successful bulk request.
+// Items rejected with a client error other than 429 are not retried.
+func getAlertResponseFromBulkResponse(response *AlertDeliveryResponse) *AlertDeliveryResponse {
+	var bulkResponse elasticsearchBulkResponse
+	if err := jsoniter.UnmarshalFromString(response.Message, &bulkResponse); err != nil || !bulkResponse.Errors {
+		return response
+	}
+	for _, item := range bulkResponse.Items {
+		for _, result := range item {
+			if result.Status >= 200 && result.Status <= 299 {
+				continue
+			}
+			itemResponse := getResponse(result.Status, "bulk index failed: "+result.Error.Type+": "+result.Error.Reason)
+			// Client errors (ie a mapping conflict) fail on every retry, only rejections due to load are retried
+			itemResponse.Permanent = result.Status >= 400 && result.Status <= 499 && result.Status != 429
+			return itemResponse
+		}
+	}
+	return response
+}
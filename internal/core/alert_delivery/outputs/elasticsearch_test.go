@@ -0,0 +1,163 @@
+package outputs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	alertModels "github.com/panther-labs/panther/api/lambda/delivery/models"
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+)
+
+var elasticsearchAlert = &alertModels.Alert{
+	AlertID:      aws.String("alertId"),
+	AnalysisID:   "ruleId",
+	Type:         alertModels.RuleType,
+	CreatedAt:    time.Date(2020, 11, 5, 12, 0, 0, 0, time.UTC),
+	Severity:     "HIGH",
+	Context:      map[string]interface{}{"user": "alice"},
+	SampleEvents: []string{`{"user":"alice","action":"login"}`},
+}
+
+func TestElasticsearchAlert(t *testing.T) {
+	httpWrapper := &mockHTTPWrapper{}
+	client := &OutputClient{httpWrapper: httpWrapper}
+	config := &outputModels.ElasticsearchConfig{
+		URL:      "https://elastic.example.com:9200/",
+		Index:    "panther-alerts",
+		AuthType: "apikey",
+		APIKey:   "a2V5OnNlY3JldA==",
+	}
+
+	httpWrapper.On("post", mock.Anything).Return(&AlertDeliveryResponse{
+		StatusCode: 200,
+		Message:    `{"took":3,"errors":false,"items":[{"index":{"_id":"alertId","status":201}}]}`,
+		Success:    true,
+	})
+	response := client.Elasticsearch(elasticsearchAlert, config)
+	assert.True(t, response.Success)
+	httpWrapper.AssertExpectations(t)
+
+	postInput := httpWrapper.Calls[0].Arguments.Get(0).(*PostInput)
+	assert.Equal(t, "https://elastic.example.com:9200/_bulk", postInput.url)
+	assert.Equal(t, map[string]string{AuthorizationHTTPHeader: "ApiKey a2V5OnNlY3JldA=="}, postInput.headers)
+
+	body := postInput.body.(*rawBody)
+	assert.Equal(t, "application/x-ndjson", body.contentType)
+	lines := strings.Split(string(body.data), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, `{"index":{"_index":"panther-alerts","_id":"alertId"}}`, lines[0])
+	assert.Empty(t, lines[2])
+
+	var document map[string]interface{}
+	require.NoError(t, jsoniter.UnmarshalFromString(lines[1], &document))
+	assert.Equal(t, "2020-11-05T12:00:00Z", document["@timestamp"])
+	assert.Equal(t, "ruleId", document["id"])
+	assert.Equal(t, map[string]interface{}{"user": "alice"}, document["alertContext"])
+	assert.Equal(t, []interface{}{}, document["logTypes"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"user": "alice", "action": "login"},
+	}, document["sampleEvents"])
+}
+
+func TestElasticsearchTemplate(t *testing.T) {
+	httpWrapper := &mockHTTPWrapper{}
+	templated, err := (&OutputClient{httpWrapper: httpWrapper}).WithTemplate(&outputModels.MessageTemplate{
+		Title: `{{.Severity}} alert for {{index .AlertContext "user"}}`,
+	})
+	require.NoError(t, err)
+	config := &outputModels.ElasticsearchConfig{URL: "https://elastic.example.com:9200", Index: "panther-alerts"}
+
+	httpWrapper.On("post", mock.Anything).Return(&AlertDeliveryResponse{StatusCode: 200, Success: true})
+	assert.True(t, templated.(*OutputClient).Elasticsearch(elasticsearchAlert, config).Success)
+
+	body := httpWrapper.Calls[0].Arguments.Get(0).(*PostInput).body.(*rawBody)
+	var document map[string]interface{}
+	require.NoError(t, jsoniter.UnmarshalFromString(strings.Split(string(body.data), "\n")[1], &document))
+	assert.Equal(t, "HIGH alert for alice", document["title"])
+}
+
+func TestElasticsearchBasicAuth(t *testing.T) {
+	httpWrapper := &mockHTTPWrapper{}
+	client := &OutputClient{httpWrapper: httpWrapper}
+	config := &outputModels.ElasticsearchConfig{
+		URL:      "https://elastic.example.com:9200",
+		Index:    "panther-alerts",
+		UserName: "username",
+		Password: "password",
+	}
+
+	httpWrapper.On("post", mock.Anything).Return(&AlertDeliveryResponse{StatusCode: 401, Success: false})
+	assert.Equal(t, &AlertDeliveryResponse{StatusCode: 401, Success: false}, client.Elasticsearch(elasticsearchAlert, config))
+
+	postInput := httpWrapper.Calls[0].Arguments.Get(0).(*PostInput)
+	assert.Equal(t, map[string]string{AuthorizationHTTPHeader: "Basic dXNlcm5hbWU6cGFzc3dvcmQ="}, postInput.headers)
+}
+
+func TestElasticsearchBulkItemFailure(t *testing.T) {
+	httpWrapper := &mockHTTPWrapper{}
+	client := &OutputClient{httpWrapper: httpWrapper}
+	config := &outputModels.ElasticsearchConfig{
+		URL:   "https://elastic.example.com:9200",
+		Index: "panther-alerts",
+	}
+
+	httpWrapper.On("post", mock.Anything).Return(&AlertDeliveryResponse{
+		StatusCode: 200,
+		Message: `{"took":3,"errors":true,"items":[{"index":{"_id":"alertId","status":429,` +
+			`"error":{"type":"es_rejected_execution_exception","reason":"queue is full"}}}]}`,
+		Success: true,
+	})
+	assert.Equal(t, &AlertDeliveryResponse{
+		StatusCode: 429,
+		Message:    "bulk index failed: es_rejected_execution_exception: queue is full",
+		Permanent:  false,
+		Success:    false,
+	}, client.Elasticsearch(elasticsearchAlert, config))
+}
+
+func TestElasticsearchBulkItemPermanentFailure(t *testing.T) {
+	httpWrapper := &mockHTTPWrapper{}
+	client := &OutputClient{httpWrapper: httpWrapper}
+	config := &outputModels.ElasticsearchConfig{
+		URL:   "https://elastic.example.com:9200",
+		Index: "panther-alerts",
+	}
+
+	httpWrapper.On("post", mock.Anything).Return(&AlertDeliveryResponse{
+		StatusCode: 200,
+		Message: `{"took":3,"errors":true,"items":[{"index":{"_id":"alertId","status":400,` +
+			`"error":{"type":"mapper_parsing_exception","reason":"failed to parse field [alertContext]"}}}]}`,
+		Success: true,
+	})
+	assert.Equal(t, &AlertDeliveryResponse{
+		StatusCode: 400,
+		Message:    "bulk index failed: mapper_parsing_exception: failed to parse field [alertContext]",
+		Permanent:  true,
+		Success:    false,
+	}, client.Elasticsearch(elasticsearchAlert, config))
+}
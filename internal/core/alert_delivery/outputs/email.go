@@ -0,0 +1,361 @@
+package outputs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"crypto/tls"
+	"html/template"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"sort"
+	"strconv"
+	"strings"
+	textTemplate "text/template"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ses"
+	"github.com/aws/aws-sdk-go/service/ses/sesiface"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	alertModels "github.com/panther-labs/panther/api/lambda/delivery/models"
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+)
+
+const (
+	emailModeSMTP = "smtp"
+	emailModeSES  = "ses"
+
+	smtpTLSImplicit = "tls"
+	smtpTimeout     = 30 * time.Second
+)
+
+// Tests can replace these with mock implementations
+var (
+	getSesClient = buildSesClient
+	sendSMTPMail = dialAndSendSMTP
+)
+
+// Email sends an alert as a multipart (plaintext and HTML) email through an SMTP server or Amazon SES.
+func (client *OutputClient) Email(alert *alertModels.Alert, config *outputModels.EmailConfig) *AlertDeliveryResponse {
+	notification := client.notification(alert)
+	templatedBody, _ := client.alertBody(alert)
+	message, err := buildEmailMessage(&notification, templatedBody, config)
+	if err != nil {
+		errorMsg := "Failed to build email message"
+		zap.L().Error(errorMsg, zap.Error(errors.WithStack(err)))
+		return &AlertDeliveryResponse{
+			StatusCode: 500,
+			Message:    errorMsg,
+			Permanent:  true,
+			Success:    false,
+		}
+	}
+
+	recipients := make([]string, 0, len(config.To)+len(config.Cc))
+	recipients = append(recipients, config.To...)
+	recipients = append(recipients, config.Cc...)
+
+	switch config.Mode {
+	case emailModeSMTP:
+		if err := sendSMTPMail(config, recipients, message); err != nil {
+			zap.L().Error("Failed to send email through SMTP", zap.Error(err))
+			return getAlertResponseFromSMTPError(err)
+		}
+		return &AlertDeliveryResponse{
+			StatusCode: 200,
+			Message:    "email sent to " + strconv.Itoa(len(recipients)) + " recipient(s)",
+			Permanent:  false,
+			Success:    true,
+		}
+	case emailModeSES:
+		return client.sendSesEmail(config, recipients, message)
+	default:
+		return &AlertDeliveryResponse{
+			StatusCode: 400,
+			Message:    "unsupported email mode " + config.Mode,
+			Permanent:  true,
+			Success:    false,
+		}
+	}
+}
+
+func (client *OutputClient) sendSesEmail(
+	config *outputModels.EmailConfig, recipients []string, message []byte) *AlertDeliveryResponse {
+
+	input := &ses.SendRawEmailInput{
+		Source:       aws.String(config.From),
+		Destinations: aws.StringSlice(recipients),
+		RawMessage:   &ses.RawMessage{Data: message},
+	}
+	if config.SesConfigurationSet != "" {
+		input.ConfigurationSetName = aws.String(config.SesConfigurationSet)
+	}
+
+	response, err := getSesClient(client.session, config.SesRegion).SendRawEmail(input)
+	if err != nil {
+		zap.L().Error("Failed to send email through SES", zap.Error(err))
+		return getAlertResponseFromSESError(err)
+	}
+
+	if response == nil || response.MessageId == nil {
+		return &AlertDeliveryResponse{
+			StatusCode: 500,
+			Message:    "ses messageId was nil",
+			Permanent:  false,
+			Success:    false,
+		}
+	}
+
+	return &AlertDeliveryResponse{
+		StatusCode: 200,
+		Message:    aws.StringValue(response.MessageId),
+		Permanent:  false,
+		Success:    true,
+	}
+}
+
+func buildSesClient(awsSession *session.Session, region string) sesiface.SESAPI {
+	config := aws.NewConfig()
+	if region != "" {
+		config = config.WithRegion(region)
+	}
+	return ses.New(awsSession, config)
+}
+
+// dialAndSendSMTP delivers the message using either implicit TLS or a mandatory STARTTLS upgrade.
+// Plaintext connections are never used since the credentials would be sent in the clear.
+func dialAndSendSMTP(config *outputModels.EmailConfig, recipients []string, message []byte) error {
+	implicitTLS := config.SMTPTLS == smtpTLSImplicit
+	port := aws.IntValue(config.SMTPPort)
+	if port == 0 {
+		port = 587
+		if implicitTLS {
+			port = 465
+		}
+	}
+	address := net.JoinHostPort(config.SMTPHost, strconv.Itoa(port))
+	tlsConfig := &tls.Config{
+		ServerName: config.SMTPHost,
+		MinVersion: tls.VersionTLS12,
+	}
+
+	dialer := &net.Dialer{Timeout: smtpTimeout}
+	var conn net.Conn
+	var err error
+	if implicitTLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", address, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", address)
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to smtp server")
+	}
+	if err = conn.SetDeadline(time.Now().Add(smtpTimeout)); err != nil {
+		_ = conn.Close()
+		return err
+	}
+
+	smtpClient, err := smtp.NewClient(conn, config.SMTPHost)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	defer smtpClient.Close()
+
+	if !implicitTLS {
+		if ok, _ := smtpClient.Extension("STARTTLS"); !ok {
+			return errors.New("smtp server does not support STARTTLS")
+		}
+		if err = smtpClient.StartTLS(tlsConfig); err != nil {
+			return err
+		}
+	}
+	if config.SMTPUsername != "" {
+		auth := smtp.PlainAuth("", config.SMTPUsername, config.SMTPPassword, config.SMTPHost)
+		if err = smtpClient.Auth(auth); err != nil {
+			return err
+		}
+	}
+	if err = smtpClient.Mail(config.From); err != nil {
+		return err
+	}
+	for _, recipient := range recipients {
+		if err = smtpClient.Rcpt(recipient); err != nil {
+			return err
+		}
+	}
+	writer, err := smtpClient.Data()
+	if err != nil {
+		return err
+	}
+	if _, err = writer.Write(message); err != nil {
+		return err
+	}
+	if err = writer.Close(); err != nil {
+		return err
+	}
+	return smtpClient.Quit()
+}
+
+// emailContent is the view of a Notification rendered by the email body templates
+type emailContent struct {
+	Title       string
+	Severity    string
+	Link        string
+	Runbook     string
+	Description string
+	Context     []emailContextRow
+	// Body replaces the default layout when the output has a message template
+	Body string
+}
+
+type emailContextRow struct {
+	Key   string
+	Value string
+}
+
+var emailTextTemplate = textTemplate.Must(textTemplate.New("text").Parse(`{{.Title}}
+
+{{if .Body}}{{.Body}}
+{{else}}Severity: {{.Severity}}
+Link: {{.Link}}
+{{with .Runbook}}Runbook: {{.}}
+{{end}}{{with .Description}}Description: {{.}}
+{{end}}{{with .Context}}
+Alert Context:
+{{range .}}  {{.Key}}: {{.Value}}
+{{end}}{{end}}{{end}}`))
+
+var emailHTMLTemplate = template.Must(template.New("html").Parse(`<!DOCTYPE html>
+<html>
+<body style="font-family: Helvetica, Arial, sans-serif; font-size: 14px;">
+<h2>{{.Title}}</h2>
+{{if .Body}}<p style="white-space: pre-wrap;">{{.Body}}</p>
+{{else}}<table cellpadding="4">
+<tr><td><b>Severity</b></td><td>{{.Severity}}</td></tr>
+<tr><td><b>Link</b></td><td><a href="{{.Link}}">{{.Link}}</a></td></tr>
+{{with .Runbook}}<tr><td><b>Runbook</b></td><td style="white-space: pre-wrap;">{{.}}</td></tr>
+{{end}}{{with .Description}}<tr><td><b>Description</b></td><td style="white-space: pre-wrap;">{{.}}</td></tr>
+{{end}}</table>
+{{with .Context}}<h3>Alert Context</h3>
+<table border="1" cellpadding="4" style="border-collapse: collapse;">
+{{range .}}<tr><td><b>{{.Key}}</b></td><td><code>{{.Value}}</code></td></tr>
+{{end}}</table>
+{{end}}{{end}}</body>
+</html>
+`))
+
+func newEmailContent(notification *Notification) *emailContent {
+	content := &emailContent{
+		Title:       notification.Title,
+		Severity:    notification.Severity,
+		Link:        notification.Link,
+		Runbook:     aws.StringValue(notification.Runbook),
+		Description: aws.StringValue(notification.Description),
+	}
+	keys := make([]string, 0, len(notification.AlertContext))
+	for key := range notification.AlertContext {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value, ok := notification.AlertContext[key].(string)
+		if !ok {
+			// Best effort to marshal non-string values
+			value, _ = jsoniter.MarshalToString(notification.AlertContext[key])
+		}
+		content.Context = append(content.Context, emailContextRow{Key: key, Value: value})
+	}
+	return content
+}
+
+func generateEmailSubject(notification *Notification, config *outputModels.EmailConfig) string {
+	subject := notification.Title
+	if prefix := config.SubjectPrefixes[notification.Severity]; prefix != "" {
+		subject = prefix + " " + subject
+	}
+	// Collapse any line breaks so that the subject cannot inject extra headers
+	return strings.Join(strings.Fields(subject), " ")
+}
+
+// buildEmailMessage renders a MIME multipart/alternative message with plaintext and HTML bodies.
+// A non-empty templatedBody replaces the default layout below the title.
+func buildEmailMessage(notification *Notification, templatedBody string, config *outputModels.EmailConfig) ([]byte, error) {
+	content := newEmailContent(notification)
+	content.Body = templatedBody
+	var textBody, htmlBody bytes.Buffer
+	if err := emailTextTemplate.Execute(&textBody, content); err != nil {
+		return nil, err
+	}
+	if err := emailHTMLTemplate.Execute(&htmlBody, content); err != nil {
+		return nil, err
+	}
+
+	var message bytes.Buffer
+	body := multipart.NewWriter(&message)
+	headers := []string{
+		"From: " + config.From,
+		"To: " + strings.Join(config.To, ", "),
+	}
+	if len(config.Cc) > 0 {
+		headers = append(headers, "Cc: "+strings.Join(config.Cc, ", "))
+	}
+	headers = append(headers,
+		"Subject: "+mime.QEncoding.Encode("utf-8", generateEmailSubject(notification, config)),
+		"MIME-Version: 1.0",
+		`Content-Type: multipart/alternative; boundary="`+body.Boundary()+`"`,
+	)
+	message.WriteString(strings.Join(headers, "\r\n") + "\r\n\r\n")
+
+	for _, part := range []struct {
+		contentType string
+		data        []byte
+	}{
+		{contentType: `text/plain; charset="utf-8"`, data: textBody.Bytes()},
+		{contentType: `text/html; charset="utf-8"`, data: htmlBody.Bytes()},
+	} {
+		writer, err := body.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		encoder := quotedprintable.NewWriter(writer)
+		if _, err = encoder.Write(part.data); err != nil {
+			return nil, err
+		}
+		if err = encoder.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := body.Close(); err != nil {
+		return nil, err
+	}
+	return message.Bytes(), nil
+}
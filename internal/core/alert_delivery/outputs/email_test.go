@@ -0,0 +1,230 @@
+package outputs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ses"
+	"github.com/aws/aws-sdk-go/service/ses/sesiface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	alertModels "github.com/panther-labs/panther/api/lambda/delivery/models"
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+var emailAlert = &alertModels.Alert{
+	AlertID:             aws.String("alertId"),
+	AnalysisID:          "ruleId",
+	AnalysisName:        aws.String("ruleName"),
+	AnalysisDescription: aws.String("ruleDescription"),
+	Type:                alertModels.RuleType,
+	Severity:            "CRITICAL",
+	Runbook:             aws.String("Check <the> logs"),
+	CreatedAt:           time.Now(),
+	Context: map[string]interface{}{
+		"user":  "alice",
+		"count": 3,
+	},
+}
+
+// parseEmail returns the headers of a raw message along with its plaintext and HTML bodies
+func parseEmail(t *testing.T, raw []byte) (mail.Header, string, string) {
+	msg, err := mail.ReadMessage(strings.NewReader(string(raw)))
+	require.NoError(t, err)
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	require.Equal(t, "multipart/alternative", mediaType)
+
+	var bodies []string
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := reader.NextRawPart()
+		if err != nil {
+			break
+		}
+		require.Equal(t, "quoted-printable", part.Header.Get("Content-Transfer-Encoding"))
+		body, err := ioutil.ReadAll(quotedprintable.NewReader(part))
+		require.NoError(t, err)
+		// Quoted-printable bodies use CRLF line endings on the wire
+		bodies = append(bodies, strings.ReplaceAll(string(body), "\r\n", "\n"))
+	}
+	require.Len(t, bodies, 2)
+	return msg.Header, bodies[0], bodies[1]
+}
+
+func TestSendEmailSes(t *testing.T) {
+	client := &testutils.SesMock{}
+	getSesClient = func(*session.Session, string) sesiface.SESAPI {
+		return client
+	}
+	defer func() { getSesClient = buildSesClient }()
+
+	config := &outputModels.EmailConfig{
+		Mode:                "ses",
+		From:                "alerts@example.com",
+		To:                  []string{"oncall@example.com", "security@example.com"},
+		Cc:                  []string{"compliance@example.com"},
+		SubjectPrefixes:     map[string]string{"CRITICAL": "[PAGE]"},
+		SesConfigurationSet: "alerts",
+	}
+	client.On("SendRawEmail", mock.Anything).Return(&ses.SendRawEmailOutput{MessageId: aws.String("messageId")}, nil)
+
+	result := (&OutputClient{}).Email(emailAlert, config)
+	assert.Equal(t, &AlertDeliveryResponse{
+		StatusCode: 200,
+		Message:    "messageId",
+		Permanent:  false,
+		Success:    true,
+	}, result)
+	client.AssertExpectations(t)
+
+	input := client.Calls[0].Arguments.Get(0).(*ses.SendRawEmailInput)
+	assert.Equal(t, "alerts@example.com", aws.StringValue(input.Source))
+	assert.Equal(t, "alerts", aws.StringValue(input.ConfigurationSetName))
+	assert.Equal(t,
+		[]string{"oncall@example.com", "security@example.com", "compliance@example.com"},
+		aws.StringValueSlice(input.Destinations))
+
+	header, text, html := parseEmail(t, input.RawMessage.Data)
+	assert.Equal(t, "alerts@example.com", header.Get("From"))
+	assert.Equal(t, "oncall@example.com, security@example.com", header.Get("To"))
+	assert.Equal(t, "compliance@example.com", header.Get("Cc"))
+	assert.Equal(t, "[PAGE] New Alert: ruleName", header.Get("Subject"))
+
+	assert.Equal(t, "New Alert: ruleName\n\n"+
+		"Severity: CRITICAL\n"+
+		"Link: https://panther.io/alerts/alertId\n"+
+		"Runbook: Check <the> logs\n"+
+		"Description: ruleDescription\n\n"+
+		"Alert Context:\n"+
+		"  count: 3\n"+
+		"  user: alice\n", text)
+	assert.Contains(t, html, "<h2>New Alert: ruleName</h2>")
+	assert.Contains(t, html, `<a href="https://panther.io/alerts/alertId">`)
+	assert.Contains(t, html, "Check &lt;the&gt; logs")
+	assert.Contains(t, html, "<tr><td><b>count</b></td><td><code>3</code></td></tr>")
+}
+
+func TestSendEmailSesError(t *testing.T) {
+	client := &testutils.SesMock{}
+	getSesClient = func(*session.Session, string) sesiface.SESAPI {
+		return client
+	}
+	defer func() { getSesClient = buildSesClient }()
+
+	config := &outputModels.EmailConfig{
+		Mode: "ses",
+		From: "alerts@example.com",
+		To:   []string{"oncall@example.com"},
+	}
+	client.On("SendRawEmail", mock.Anything).Return(
+		(*ses.SendRawEmailOutput)(nil), awserr.New(ses.ErrCodeMessageRejected, "rejected", nil))
+
+	result := (&OutputClient{}).Email(emailAlert, config)
+	assert.Equal(t, 400, result.StatusCode)
+	assert.False(t, result.Success)
+	client.AssertExpectations(t)
+}
+
+func TestSendEmailSMTP(t *testing.T) {
+	var recipients []string
+	var message []byte
+	sendSMTPMail = func(_ *outputModels.EmailConfig, to []string, msg []byte) error {
+		recipients, message = to, msg
+		return nil
+	}
+	defer func() { sendSMTPMail = dialAndSendSMTP }()
+
+	config := &outputModels.EmailConfig{
+		Mode:            "smtp",
+		From:            "alerts@example.com",
+		To:              []string{"oncall@example.com"},
+		Cc:              []string{"compliance@example.com"},
+		SubjectPrefixes: map[string]string{"LOW": "[FYI]"},
+		SMTPHost:        "smtp.example.com",
+	}
+
+	result := (&OutputClient{}).Email(emailAlert, config)
+	assert.Equal(t, &AlertDeliveryResponse{
+		StatusCode: 200,
+		Message:    "email sent to 2 recipient(s)",
+		Permanent:  false,
+		Success:    true,
+	}, result)
+	assert.Equal(t, []string{"oncall@example.com", "compliance@example.com"}, recipients)
+
+	header, _, _ := parseEmail(t, message)
+	// No prefix configured for this severity
+	assert.Equal(t, "New Alert: ruleName", header.Get("Subject"))
+}
+
+func TestSendEmailSMTPErrors(t *testing.T) {
+	defer func() { sendSMTPMail = dialAndSendSMTP }()
+	config := &outputModels.EmailConfig{
+		Mode:     "smtp",
+		From:     "alerts@example.com",
+		To:       []string{"oncall@example.com"},
+		SMTPHost: "smtp.example.com",
+	}
+
+	authErr := &textproto.Error{Code: 535, Msg: "authentication failed"}
+	sendSMTPMail = func(*outputModels.EmailConfig, []string, []byte) error {
+		return authErr
+	}
+	result := (&OutputClient{}).Email(emailAlert, config)
+	assert.Equal(t, &AlertDeliveryResponse{
+		StatusCode: 400,
+		Message:    authErr.Error(),
+		Permanent:  true,
+		Success:    false,
+	}, result)
+
+	sendSMTPMail = func(*outputModels.EmailConfig, []string, []byte) error {
+		return errors.New("connection refused")
+	}
+	result = (&OutputClient{}).Email(emailAlert, config)
+	assert.Equal(t, &AlertDeliveryResponse{
+		StatusCode: 503,
+		Message:    "connection refused",
+		Permanent:  false,
+		Success:    false,
+	}, result)
+}
+
+func TestGenerateEmailSubjectStripsLineBreaks(t *testing.T) {
+	notification := &Notification{Title: "New Alert: evil\r\nBcc: attacker@example.com", Severity: "HIGH"}
+	config := &outputModels.EmailConfig{SubjectPrefixes: map[string]string{"HIGH": "[HIGH]"}}
+	assert.Equal(t, "[HIGH] New Alert: evil Bcc: attacker@example.com", generateEmailSubject(notification, config))
+}
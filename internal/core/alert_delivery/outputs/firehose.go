@@ -0,0 +1,100 @@
+package outputs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/firehose"
+	"github.com/aws/aws-sdk-go/service/firehose/firehoseiface"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	alertModels "github.com/panther-labs/panther/api/lambda/delivery/models"
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+)
+
+// Tests can replace this with a mock implementation
+var getFirehoseClient = buildFirehoseClient
+
+// Firehose puts an alert notification record onto a Kinesis Firehose delivery stream.
+// Records are newline delimited so that the delivered objects can be queried directly.
+func (client *OutputClient) Firehose(alert *alertModels.Alert, config *outputModels.FirehoseConfig) *AlertDeliveryResponse {
+	record, err := jsoniter.Marshal(client.notificationRecord(alert))
+	if err != nil {
+		errorMsg := "Failed to serialize record"
+		zap.L().Error(errorMsg, zap.Error(errors.WithStack(err)))
+		return &AlertDeliveryResponse{
+			StatusCode: 500,
+			Message:    errorMsg,
+			Permanent:  true,
+			Success:    false,
+		}
+	}
+
+	// Stream ARN is like "arn:aws:firehose:us-west-2:123456789012:deliverystream/panther-alerts"
+	streamArn, err := arn.Parse(config.StreamArn)
+	if err != nil {
+		errorMsg := "Failed to parse delivery stream ARN"
+		zap.L().Error(errorMsg, zap.Error(err))
+		return &AlertDeliveryResponse{
+			StatusCode: 400,
+			Message:    errorMsg,
+			Permanent:  true,
+			Success:    false,
+		}
+	}
+
+	putRecordInput := &firehose.PutRecordInput{
+		DeliveryStreamName: aws.String(strings.TrimPrefix(streamArn.Resource, "deliverystream/")),
+		Record: &firehose.Record{
+			Data: append(record, '\n'),
+		},
+	}
+
+	response, err := getFirehoseClient(client.session, streamArn.Region).PutRecord(putRecordInput)
+	if err != nil {
+		zap.L().Error("Failed to put record to Firehose delivery stream", zap.Error(err))
+		return getAlertResponseFromFirehoseError(err)
+	}
+
+	if response == nil || response.RecordId == nil {
+		return &AlertDeliveryResponse{
+			StatusCode: 500,
+			Message:    "firehose recordId was nil",
+			Permanent:  false,
+			Success:    false,
+		}
+	}
+
+	return &AlertDeliveryResponse{
+		StatusCode: 200,
+		Message:    aws.StringValue(response.RecordId),
+		Permanent:  false,
+		Success:    true,
+	}
+}
+
+func buildFirehoseClient(awsSession *session.Session, region string) firehoseiface.FirehoseAPI {
+	return firehose.New(awsSession, aws.NewConfig().WithRegion(region))
+}
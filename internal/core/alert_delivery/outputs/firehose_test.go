@@ -0,0 +1,106 @@
+package outputs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/firehose"
+	"github.com/aws/aws-sdk-go/service/firehose/firehoseiface"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	alertModels "github.com/panther-labs/panther/api/lambda/delivery/models"
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+var archiveAlert = &alertModels.Alert{
+	AlertID:    aws.String("alertId"),
+	AnalysisID: "ruleId",
+	Type:       alertModels.RuleType,
+	CreatedAt:  time.Date(2020, 11, 5, 7, 30, 0, 0, time.UTC),
+	Severity:   "HIGH",
+	LogTypes:   []string{"AWS.CloudTrail"},
+	Context:    map[string]interface{}{"user": "alice"},
+}
+
+func TestSendFirehose(t *testing.T) {
+	client := &testutils.FirehoseMock{}
+	var region string
+	getFirehoseClient = func(_ *session.Session, r string) firehoseiface.FirehoseAPI {
+		region = r
+		return client
+	}
+	defer func() { getFirehoseClient = buildFirehoseClient }()
+
+	config := &outputModels.FirehoseConfig{
+		StreamArn: "arn:aws:firehose:us-east-2:123456789012:deliverystream/panther-alerts",
+	}
+	record, err := jsoniter.Marshal(generateNotificationRecordFromAlert(archiveAlert))
+	require.NoError(t, err)
+	client.On("PutRecord", &firehose.PutRecordInput{
+		DeliveryStreamName: aws.String("panther-alerts"),
+		Record:             &firehose.Record{Data: append(record, '\n')},
+	}).Return(&firehose.PutRecordOutput{RecordId: aws.String("recordId")}, nil)
+
+	assert.Equal(t, &AlertDeliveryResponse{
+		StatusCode: 200,
+		Message:    "recordId",
+		Permanent:  false,
+		Success:    true,
+	}, (&OutputClient{}).Firehose(archiveAlert, config))
+	assert.Equal(t, "us-east-2", region)
+	client.AssertExpectations(t)
+}
+
+func TestSendFirehoseError(t *testing.T) {
+	client := &testutils.FirehoseMock{}
+	getFirehoseClient = func(*session.Session, string) firehoseiface.FirehoseAPI {
+		return client
+	}
+	defer func() { getFirehoseClient = buildFirehoseClient }()
+
+	config := &outputModels.FirehoseConfig{
+		StreamArn: "arn:aws:firehose:us-east-2:123456789012:deliverystream/panther-alerts",
+	}
+	client.On("PutRecord", mock.Anything).Return(
+		(*firehose.PutRecordOutput)(nil), awserr.New(firehose.ErrCodeServiceUnavailableException, "slow down", nil))
+
+	response := (&OutputClient{}).Firehose(archiveAlert, config)
+	assert.Equal(t, 503, response.StatusCode)
+	assert.False(t, response.Success)
+	assert.False(t, response.Permanent)
+}
+
+func TestSendFirehoseInvalidArn(t *testing.T) {
+	response := (&OutputClient{}).Firehose(archiveAlert, &outputModels.FirehoseConfig{StreamArn: "panther-alerts"})
+	assert.Equal(t, &AlertDeliveryResponse{
+		StatusCode: 400,
+		Message:    "Failed to parse delivery stream ARN",
+		Permanent:  true,
+		Success:    false,
+	}, response)
+}
@@ -47,9 +47,14 @@ func (client *OutputClient) Github(
 	marshaledContext, _ := jsoniter.MarshalToString(alert.Context)
 	alertContext := "\n **AlertContext:** " + marshaledContext
 
+	body := description + link + runBook + severity + tags + alertContext
+	if templated, ok := client.alertBody(alert); ok {
+		body = templated
+	}
+
 	githubRequest := map[string]interface{}{
-		"title": generateAlertTitle(alert),
-		"body":  description + link + runBook + severity + tags + alertContext,
+		"title": client.alertTitle(alert),
+		"body":  body,
 	}
 
 	token := "token " + config.Token
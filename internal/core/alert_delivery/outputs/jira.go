@@ -46,9 +46,14 @@ func (client *OutputClient) Jira(
 	marshaledContext, _ := jsoniter.MarshalToString(alert.Context)
 	alertContext := "\n *AlertContext:* " + marshaledContext
 
+	body := description + link + runBook + severity + tags + alertContext
+	if templated, ok := client.alertBody(alert); ok {
+		body = templated
+	}
+
 	fields := map[string]interface{}{
-		"summary":     generateAlertTitle(alert),
-		"description": description + link + runBook + severity + tags + alertContext,
+		"summary":     client.alertTitle(alert),
+		"description": body,
 		"project": map[string]*string{
 			"key": aws.String(config.ProjectKey),
 		},
@@ -37,22 +37,25 @@ func (client *OutputClient) MsTeams(
 	// Best effort attempt to marshal Alert Context
 	marshaledContext, _ := jsoniter.MarshalToString(alert.Context)
 
+	section := map[string]interface{}{
+		"facts": []interface{}{
+			map[string]string{"name": "Description", "value": aws.StringValue(alert.AnalysisDescription)},
+			map[string]string{"name": "Runbook", "value": aws.StringValue(alert.Runbook)},
+			map[string]string{"name": "Severity", "value": alert.Severity},
+			map[string]string{"name": "Tags", "value": strings.Join(alert.Tags, ", ")},
+			map[string]string{"name": "AlertContext", "value": marshaledContext},
+		},
+		"text": link,
+	}
+	if templated, ok := client.alertBody(alert); ok {
+		section = map[string]interface{}{"text": templated}
+	}
+
 	msTeamsRequestBody := map[string]interface{}{
 		"@context": "http://schema.org/extensions",
 		"@type":    "MessageCard",
-		"text":     generateAlertTitle(alert),
-		"sections": []interface{}{
-			map[string]interface{}{
-				"facts": []interface{}{
-					map[string]string{"name": "Description", "value": aws.StringValue(alert.AnalysisDescription)},
-					map[string]string{"name": "Runbook", "value": aws.StringValue(alert.Runbook)},
-					map[string]string{"name": "Severity", "value": alert.Severity},
-					map[string]string{"name": "Tags", "value": strings.Join(alert.Tags, ", ")},
-					map[string]string{"name": "AlertContext", "value": marshaledContext},
-				},
-				"text": link,
-			},
-		},
+		"text":     client.alertTitle(alert),
+		"sections": []interface{}{section},
 		"potentialAction": []interface{}{
 			map[string]interface{}{
 				"@type": "OpenUri",
@@ -49,9 +49,14 @@ func (client *OutputClient) Opsgenie(
 	marshaledContext, _ := jsoniter.MarshalToString(alert.Context)
 	alertContext := "\n <strong>AlertContext:</strong> " + marshaledContext
 
+	body := description + link + runBook + severity + alertContext
+	if templated, ok := client.alertBody(alert); ok {
+		body = templated
+	}
+
 	opsgenieRequest := map[string]interface{}{
-		"message":     generateAlertTitle(alert),
-		"description": description + link + runBook + severity + alertContext,
+		"message":     client.alertTitle(alert),
+		"description": body,
 		"tags":        alert.Tags,
 		"priority":    pantherToOpsGeniePriority[alert.Severity],
 	}
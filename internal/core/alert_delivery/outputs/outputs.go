@@ -30,6 +30,7 @@ import (
 
 	alertModels "github.com/panther-labs/panther/api/lambda/delivery/models"
 	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/templates"
 	"github.com/panther-labs/panther/pkg/gatewayapi"
 )
 
@@ -51,9 +52,17 @@ type PostInput struct {
 	headers map[string]string
 }
 
+// GetInput type
+type GetInput struct {
+	url     string
+	headers map[string]string
+}
+
 // HTTPWrapperiface is the interface for our wrapper around Golang's http client
 type HTTPWrapperiface interface {
 	post(*PostInput) *AlertDeliveryResponse
+	patch(*PostInput) *AlertDeliveryResponse
+	get(*GetInput) *AlertDeliveryResponse
 }
 
 // HTTPiface is an interface for http.Client to simplify unit testing.
@@ -73,6 +82,13 @@ type API interface {
 	Sns(*alertModels.Alert, *outputModels.SnsConfig) *AlertDeliveryResponse
 	Asana(*alertModels.Alert, *outputModels.AsanaConfig) *AlertDeliveryResponse
 	CustomWebhook(*alertModels.Alert, *outputModels.CustomWebhookConfig) *AlertDeliveryResponse
+	Email(*alertModels.Alert, *outputModels.EmailConfig) *AlertDeliveryResponse
+	ServiceNow(*alertModels.Alert, *outputModels.ServiceNowConfig) *AlertDeliveryResponse
+	Splunk(*alertModels.Alert, *outputModels.SplunkConfig) *AlertDeliveryResponse
+	Elasticsearch(*alertModels.Alert, *outputModels.ElasticsearchConfig) *AlertDeliveryResponse
+	Firehose(*alertModels.Alert, *outputModels.FirehoseConfig) *AlertDeliveryResponse
+	S3(*alertModels.Alert, *outputModels.S3Config) *AlertDeliveryResponse
+	WithTemplate(*outputModels.MessageTemplate) (API, error)
 }
 
 // OutputClient encapsulates the clients that allow sending alerts to multiple outputs
@@ -81,6 +97,7 @@ type OutputClient struct {
 	// Do not mutate any fields in the goroutines, and do not use maps without proper locking.
 	session     *session.Session // safe for concurrent reads, not writes
 	httpWrapper HTTPWrapperiface
+	template    *templates.Template // nil unless the client was created with WithTemplate
 }
 
 // OutputClient must satisfy the API interface.
@@ -140,6 +157,42 @@ type Notification struct {
 	Version *string `json:"version"`
 }
 
+// notificationRecord augments the Notification with fields that are useful when alerts are stored
+// and searched in other systems.
+type notificationRecord struct {
+	Notification
+	// The log types of the events that triggered the alert
+	LogTypes []string `json:"logTypes"`
+	// Some of the events that triggered the alert
+	SampleEvents []jsoniter.RawMessage `json:"sampleEvents"`
+}
+
+// maxSampleEventsSize limits the size of the sample events included in a notification record
+const maxSampleEventsSize = 64 * 1024
+
+func generateNotificationRecordFromAlert(alert *alertModels.Alert) notificationRecord {
+	record := notificationRecord{
+		Notification: generateNotificationFromAlert(alert),
+		LogTypes:     alert.LogTypes,
+		SampleEvents: []jsoniter.RawMessage{},
+	}
+	if record.LogTypes == nil {
+		record.LogTypes = []string{}
+	}
+	size := 0
+	for _, event := range alert.SampleEvents {
+		// Skip invalid JSON since it would fail to serialize the whole record
+		if !jsoniter.Valid([]byte(event)) {
+			continue
+		}
+		if size += len(event); size > maxSampleEventsSize {
+			break
+		}
+		record.SampleEvents = append(record.SampleEvents, jsoniter.RawMessage(event))
+	}
+	return record
+}
+
 func generateNotificationFromAlert(alert *alertModels.Alert) Notification {
 	notification := Notification{
 		ID:           alert.AnalysisID,
@@ -169,6 +222,8 @@ func generateAlertMessage(alert *alertModels.Alert) string {
 		return getDisplayName(alert) + " encountered an error"
 	case alertModels.PolicyType:
 		return getDisplayName(alert) + " failed on new resources"
+	case alertModels.DigestType:
+		return *alert.Title
 	default:
 		panic("uknown alert type " + alert.Type)
 	}
@@ -204,6 +259,8 @@ func generateAlertTitle(alert *alertModels.Alert) string {
 		return "New rule error: " + *alert.Title
 	case alertModels.PolicyType:
 		return "Policy Failure: " + getDisplayName(alert)
+	case alertModels.DigestType:
+		return "Alert Digest: " + *alert.Title
 	default:
 		panic("uknown alert type " + alert.Type)
 	}
@@ -216,6 +273,11 @@ func getDisplayName(alert *alertModels.Alert) string {
 	return alert.AnalysisID
 }
 
+// AlertURL returns the link to an alert in the Panther UI
+func AlertURL(alert *alertModels.Alert) string {
+	return generateURL(alert)
+}
+
 func generateURL(alert *alertModels.Alert) string {
 	if alert.IsTest {
 		return appDomainURL
@@ -225,6 +287,9 @@ func generateURL(alert *alertModels.Alert) string {
 		return alertURLPrefix + *alert.AlertID
 	case alertModels.PolicyType:
 		return policyURLPrefix + alert.AnalysisID
+	case alertModels.DigestType:
+		// Digests span many alerts, link to the alerts list
+		return alertURLPrefix
 	default:
 		panic("uknown alert type" + alert.Type)
 	}
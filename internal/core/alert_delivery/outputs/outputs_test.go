@@ -19,11 +19,14 @@ package outputs
  */
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	alertModel "github.com/panther-labs/panther/api/lambda/delivery/models"
 )
@@ -43,6 +46,16 @@ func (m *mockHTTPWrapper) post(postInput *PostInput) *AlertDeliveryResponse {
 	return args.Get(0).(*AlertDeliveryResponse)
 }
 
+func (m *mockHTTPWrapper) patch(patchInput *PostInput) *AlertDeliveryResponse {
+	args := m.Called(patchInput)
+	return args.Get(0).(*AlertDeliveryResponse)
+}
+
+func (m *mockHTTPWrapper) get(getInput *GetInput) *AlertDeliveryResponse {
+	args := m.Called(getInput)
+	return args.Get(0).(*AlertDeliveryResponse)
+}
+
 func TestGenerateAlertTitleReturnGivenTitle(t *testing.T) {
 	alert := &alertModel.Alert{
 		Title: aws.String("my title"),
@@ -83,3 +96,36 @@ func TestGenerateAlertTitlePolicyId(t *testing.T) {
 	}
 	assert.Equal(t, "Policy Failure: policy.id", generateAlertTitle(alert))
 }
+
+func TestGenerateDigest(t *testing.T) {
+	alert := &alertModel.Alert{
+		Type:  alertModel.DigestType,
+		Title: aws.String("12 alerts were rate limited"),
+	}
+	assert.Equal(t, "Alert Digest: 12 alerts were rate limited", generateAlertTitle(alert))
+	assert.Equal(t, "12 alerts were rate limited", generateAlertMessage(alert))
+	assert.Equal(t, "https://panther.io/alerts/", generateURL(alert))
+}
+
+func TestGenerateNotificationRecordSampleEvents(t *testing.T) {
+	event := `{"data":"` + strings.Repeat("x", maxSampleEventsSize/4) + `"}`
+	alert := &alertModel.Alert{
+		AlertID:      aws.String("alertId"),
+		AnalysisID:   "ruleId",
+		Type:         alertModel.RuleType,
+		SampleEvents: []string{event, "not json", event, event, event, event},
+	}
+	record := generateNotificationRecordFromAlert(alert)
+	// Invalid events are skipped and the samples are limited in size
+	require.Len(t, record.SampleEvents, 3)
+	for _, sample := range record.SampleEvents {
+		assert.Equal(t, event, string(sample))
+	}
+
+	record = generateNotificationRecordFromAlert(&alertModel.Alert{
+		AlertID:    aws.String("alertId"),
+		AnalysisID: "ruleId",
+		Type:       alertModel.RuleType,
+	})
+	assert.Equal(t, []jsoniter.RawMessage{}, record.SampleEvents)
+}
@@ -38,11 +38,11 @@ func (client *OutputClient) PagerDuty(alert *alertModels.Alert, config *outputMo
 	}
 
 	payload := map[string]interface{}{
-		"summary":        generateAlertTitle(alert),
+		"summary":        client.alertTitle(alert),
 		"severity":       severity,
 		"timestamp":      alert.CreatedAt.Format(time.RFC3339),
 		"source":         "pantherlabs",
-		"custom_details": generateNotificationFromAlert(alert),
+		"custom_details": client.notification(alert),
 	}
 
 	pagerDutyRequest := map[string]interface{}{
@@ -20,31 +20,122 @@ package outputs
 
 import (
 	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
 
 	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
 )
 
 const (
 	AuthorizationHTTPHeader = "Authorization"
 )
 
+// rawBody is sent as is with its own content type, instead of being marshaled to JSON
+type rawBody struct {
+	contentType string
+	data        []byte
+}
+
+// Wrappers with custom TLS settings are reused across deliveries, keyed by their settings
+var tlsHTTPWrappers sync.Map
+
+// httpWrapperWithTLS returns a wrapper that also trusts the PEM encoded CA certificates and optionally skips
+// certificate verification altogether (for lab environments). Without custom settings the shared wrapper is used.
+func (client *OutputClient) httpWrapperWithTLS(caCertificate string, skipVerify bool) (HTTPWrapperiface, error) {
+	if caCertificate == "" && !skipVerify {
+		return client.httpWrapper, nil
+	}
+	key := strconv.FormatBool(skipVerify) + caCertificate
+	if wrapper, ok := tlsHTTPWrappers.Load(key); ok {
+		return wrapper.(HTTPWrapperiface), nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: skipVerify, // nolint: gosec
+	}
+	if caCertificate != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM([]byte(caCertificate)) {
+			return nil, errors.New("no valid PEM certificates found in CA certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	wrapper, _ := tlsHTTPWrappers.LoadOrStore(key, &HTTPWrapper{
+		httpClient: &http.Client{Transport: transport},
+	})
+	return wrapper.(HTTPWrapperiface), nil
+}
+
 // post sends a JSON body to an endpoint.
+// If the body is url.Values it is sent as a form instead, and a rawBody is sent unmodified.
 func (client *HTTPWrapper) post(input *PostInput) *AlertDeliveryResponse {
-	payload, err := jsoniter.Marshal(input.body)
+	return client.send(http.MethodPost, input)
+}
+
+// patch sends a JSON body to an endpoint to partially update an existing resource.
+func (client *HTTPWrapper) patch(input *PostInput) *AlertDeliveryResponse {
+	return client.send(http.MethodPatch, input)
+}
+
+// get fetches a resource from an endpoint, the response body is returned in the Message field.
+func (client *HTTPWrapper) get(input *GetInput) *AlertDeliveryResponse {
+	request, err := http.NewRequest(http.MethodGet, input.url, nil)
 
-	// If there was an error marshaling the input
+	// If there was an error creating the request
 	if err != nil {
 		return &AlertDeliveryResponse{
 			StatusCode: 500, // Internal server error
 			Success:    false,
-			Message:    "json marshal error: " + err.Error(),
+			Message:    "http request error: " + err.Error(),
 			Permanent:  true,
 		}
 	}
 
-	request, err := http.NewRequest("POST", input.url, bytes.NewBuffer(payload))
+	request.Header.Set("Accept", "application/json")
+	return client.do(request, input.headers)
+}
+
+func (client *HTTPWrapper) send(method string, input *PostInput) *AlertDeliveryResponse {
+	contentType := "application/json"
+	var payload io.Reader
+	switch body := input.body.(type) {
+	case url.Values:
+		contentType = "application/x-www-form-urlencoded"
+		payload = strings.NewReader(body.Encode())
+	case *rawBody:
+		contentType = body.contentType
+		payload = bytes.NewReader(body.data)
+	default:
+		data, err := jsoniter.Marshal(input.body)
+
+		// If there was an error marshaling the input
+		if err != nil {
+			return &AlertDeliveryResponse{
+				StatusCode: 500, // Internal server error
+				Success:    false,
+				Message:    "json marshal error: " + err.Error(),
+				Permanent:  true,
+			}
+		}
+		payload = bytes.NewBuffer(data)
+	}
+
+	request, err := http.NewRequest(method, input.url, payload)
 
 	// If there was an error creating the request
 	if err != nil {
@@ -56,11 +147,14 @@ func (client *HTTPWrapper) post(input *PostInput) *AlertDeliveryResponse {
 		}
 	}
 
-	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Content-Type", contentType)
 	request.Header.Set("Accept", "application/json")
+	return client.do(request, input.headers)
+}
 
+func (client *HTTPWrapper) do(request *http.Request, headers map[string]string) *AlertDeliveryResponse {
 	//Adding dynamic headers
-	for key, value := range input.headers {
+	for key, value := range headers {
 		request.Header.Set(key, value)
 	}
 
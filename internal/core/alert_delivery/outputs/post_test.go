@@ -20,12 +20,17 @@ package outputs
 
 import (
 	"bytes"
+	"encoding/pem"
 	"errors"
 	"io/ioutil"
+	"log"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type mockHTTPClient struct {
@@ -33,6 +38,7 @@ type mockHTTPClient struct {
 	statusCode   int
 	requestError bool
 	requestBody  string // Request body is saved here for tests to verify
+	request      *http.Request
 }
 
 const requestEndpoint = "https://runpanther.io"
@@ -41,11 +47,14 @@ func (m *mockHTTPClient) Do(request *http.Request) (*http.Response, error) {
 	if m.requestError {
 		return nil, errors.New("endpoint unreachable")
 	}
-	requestBytes, err := ioutil.ReadAll(request.Body)
-	if err != nil {
-		panic(err)
+	m.request = request
+	if request.Body != nil {
+		requestBytes, err := ioutil.ReadAll(request.Body)
+		if err != nil {
+			panic(err)
+		}
+		m.requestBody = string(requestBytes)
 	}
-	m.requestBody = string(requestBytes)
 
 	responseBody := ioutil.NopCloser(bytes.NewReader([]byte("response")))
 	return &http.Response{Body: responseBody, StatusCode: m.statusCode}, nil
@@ -106,3 +115,89 @@ func TestPostCreated(t *testing.T) {
 		Permanent:  false,
 	}, c.post(postInput))
 }
+
+func TestPostForm(t *testing.T) {
+	httpClient := &mockHTTPClient{statusCode: http.StatusOK}
+	c := &HTTPWrapper{httpClient: httpClient}
+	postInput := &PostInput{
+		url:  requestEndpoint,
+		body: url.Values{"grant_type": {"password"}},
+	}
+	assert.True(t, c.post(postInput).Success)
+	assert.Equal(t, "grant_type=password", httpClient.requestBody)
+	assert.Equal(t, "application/x-www-form-urlencoded", httpClient.request.Header.Get("Content-Type"))
+}
+
+func TestPatchOk(t *testing.T) {
+	httpClient := &mockHTTPClient{statusCode: http.StatusOK}
+	c := &HTTPWrapper{httpClient: httpClient}
+	patchInput := &PostInput{
+		url:     requestEndpoint,
+		body:    map[string]interface{}{"abc": 123},
+		headers: map[string]string{AuthorizationHTTPHeader: "token"},
+	}
+	assert.Equal(t, &AlertDeliveryResponse{
+		StatusCode: 200,
+		Success:    true,
+		Message:    "response",
+		Permanent:  false,
+	}, c.patch(patchInput))
+	assert.Equal(t, http.MethodPatch, httpClient.request.Method)
+	assert.Equal(t, `{"abc":123}`, httpClient.requestBody)
+	assert.Equal(t, "token", httpClient.request.Header.Get(AuthorizationHTTPHeader))
+}
+
+func TestGetOk(t *testing.T) {
+	httpClient := &mockHTTPClient{statusCode: http.StatusOK}
+	c := &HTTPWrapper{httpClient: httpClient}
+	assert.Equal(t, &AlertDeliveryResponse{
+		StatusCode: 200,
+		Success:    true,
+		Message:    "response",
+		Permanent:  false,
+	}, c.get(&GetInput{url: requestEndpoint}))
+	assert.Equal(t, http.MethodGet, httpClient.request.Method)
+}
+
+func TestGetNotOk(t *testing.T) {
+	c := &HTTPWrapper{httpClient: &mockHTTPClient{statusCode: http.StatusNotFound}}
+	response := c.get(&GetInput{url: requestEndpoint})
+	assert.False(t, response.Success)
+	assert.Equal(t, 404, response.StatusCode)
+}
+
+func TestHTTPWrapperWithTLS(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	// Silence the expected handshake failure
+	server.Config.ErrorLog = log.New(ioutil.Discard, "", 0)
+	server.StartTLS()
+	defer server.Close()
+	caCertificate := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+	client := &OutputClient{httpWrapper: &HTTPWrapper{httpClient: &http.Client{}}}
+	postInput := &PostInput{
+		url:  server.URL,
+		body: map[string]interface{}{"abc": 123},
+	}
+
+	// The shared wrapper does not trust the test server certificate
+	wrapper, err := client.httpWrapperWithTLS("", false)
+	require.NoError(t, err)
+	assert.Equal(t, client.httpWrapper, wrapper)
+	assert.False(t, wrapper.post(postInput).Success)
+
+	wrapper, err = client.httpWrapperWithTLS(caCertificate, false)
+	require.NoError(t, err)
+	assert.True(t, wrapper.post(postInput).Success)
+	cached, err := client.httpWrapperWithTLS(caCertificate, false)
+	require.NoError(t, err)
+	assert.Same(t, wrapper, cached)
+
+	wrapper, err = client.httpWrapperWithTLS("", true)
+	require.NoError(t, err)
+	assert.True(t, wrapper.post(postInput).Success)
+
+	_, err = client.httpWrapperWithTLS("not a certificate", false)
+	assert.Error(t, err)
+}
@@ -0,0 +1,103 @@
+package outputs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	alertModels "github.com/panther-labs/panther/api/lambda/delivery/models"
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue"
+)
+
+// Tests can replace this with a mock implementation
+var getS3Client = buildS3Client
+
+// S3 archives an alert as a JSON object, under a prefix partitioned by the alert creation time.
+func (client *OutputClient) S3(alert *alertModels.Alert, config *outputModels.S3Config) *AlertDeliveryResponse {
+	body, err := jsoniter.Marshal(client.notificationRecord(alert))
+	if err != nil {
+		errorMsg := "Failed to serialize object"
+		zap.L().Error(errorMsg, zap.Error(errors.WithStack(err)))
+		return &AlertDeliveryResponse{
+			StatusCode: 500,
+			Message:    errorMsg,
+			Permanent:  true,
+			Success:    false,
+		}
+	}
+
+	key := s3ObjectKey(alert, config.Prefix)
+	putObjectInput := &s3.PutObjectInput{
+		Bucket:      aws.String(config.BucketName),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(body),
+		ContentType: aws.String("application/json"),
+	}
+	if config.KmsKeyID != "" {
+		putObjectInput.ServerSideEncryption = aws.String(s3.ServerSideEncryptionAwsKms)
+		putObjectInput.SSEKMSKeyId = aws.String(config.KmsKeyID)
+	}
+
+	if _, err = getS3Client(client.session, config.Region).PutObject(putObjectInput); err != nil {
+		zap.L().Error("Failed to put object to S3 bucket", zap.Error(err))
+		return getAlertResponseFromS3Error(err)
+	}
+
+	return &AlertDeliveryResponse{
+		StatusCode: 200,
+		Message:    "s3://" + config.BucketName + "/" + key,
+		Permanent:  false,
+		Success:    true,
+	}
+}
+
+// s3ObjectKey uses the same hourly partitions as the data lake, so that the archive can be queried with Athena.
+// Repeated deliveries of an alert overwrite the same object.
+func s3ObjectKey(alert *alertModels.Alert, prefix string) string {
+	createdAt := alert.CreatedAt.UTC()
+	name := aws.StringValue(alert.AlertID)
+	if name == "" {
+		// Policy alerts have no AlertID
+		name = alert.AnalysisID + "-" + strconv.FormatInt(createdAt.UnixNano(), 10)
+	}
+	key := awsglue.GlueTableHourly.PartitionPathS3(createdAt) + name + ".json"
+	if prefix = strings.Trim(prefix, "/"); prefix != "" {
+		key = prefix + "/" + key
+	}
+	return key
+}
+
+func buildS3Client(awsSession *session.Session, region string) s3iface.S3API {
+	config := aws.NewConfig()
+	if region != "" {
+		config = config.WithRegion(region)
+	}
+	return s3.New(awsSession, config)
+}
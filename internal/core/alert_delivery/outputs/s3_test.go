@@ -0,0 +1,101 @@
+package outputs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	alertModels "github.com/panther-labs/panther/api/lambda/delivery/models"
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+func TestSendS3(t *testing.T) {
+	client := &testutils.S3Mock{}
+	getS3Client = func(*session.Session, string) s3iface.S3API {
+		return client
+	}
+	defer func() { getS3Client = buildS3Client }()
+
+	config := &outputModels.S3Config{
+		BucketName: "alert-archive",
+		Prefix:     "/panther/alerts/",
+		KmsKeyID:   "alias/alert-archive",
+	}
+	client.On("PutObject", mock.Anything).Return(&s3.PutObjectOutput{}, nil)
+
+	assert.Equal(t, &AlertDeliveryResponse{
+		StatusCode: 200,
+		Message:    "s3://alert-archive/panther/alerts/year=2020/month=11/day=05/hour=07/alertId.json",
+		Permanent:  false,
+		Success:    true,
+	}, (&OutputClient{}).S3(archiveAlert, config))
+	client.AssertExpectations(t)
+
+	input := client.Calls[0].Arguments.Get(0).(*s3.PutObjectInput)
+	assert.Equal(t, "alert-archive", aws.StringValue(input.Bucket))
+	assert.Equal(t, "panther/alerts/year=2020/month=11/day=05/hour=07/alertId.json", aws.StringValue(input.Key))
+	assert.Equal(t, "application/json", aws.StringValue(input.ContentType))
+	assert.Equal(t, s3.ServerSideEncryptionAwsKms, aws.StringValue(input.ServerSideEncryption))
+	assert.Equal(t, "alias/alert-archive", aws.StringValue(input.SSEKMSKeyId))
+
+	body, err := ioutil.ReadAll(input.Body)
+	require.NoError(t, err)
+	var record map[string]interface{}
+	require.NoError(t, jsoniter.Unmarshal(body, &record))
+	assert.Equal(t, "alertId", record["alertId"])
+	assert.Equal(t, []interface{}{"AWS.CloudTrail"}, record["logTypes"])
+}
+
+func TestSendS3Error(t *testing.T) {
+	client := &testutils.S3Mock{}
+	getS3Client = func(*session.Session, string) s3iface.S3API {
+		return client
+	}
+	defer func() { getS3Client = buildS3Client }()
+
+	client.On("PutObject", mock.Anything).Return(
+		(*s3.PutObjectOutput)(nil), awserr.New(s3.ErrCodeNoSuchBucket, "no such bucket", nil))
+
+	response := (&OutputClient{}).S3(archiveAlert, &outputModels.S3Config{BucketName: "alert-archive"})
+	assert.Equal(t, 404, response.StatusCode)
+	assert.False(t, response.Success)
+	input := client.Calls[0].Arguments.Get(0).(*s3.PutObjectInput)
+	assert.Nil(t, input.ServerSideEncryption)
+}
+
+func TestS3ObjectKeyPolicyAlert(t *testing.T) {
+	alert := &alertModels.Alert{
+		AnalysisID: "policyId",
+		Type:       alertModels.PolicyType,
+		CreatedAt:  archiveAlert.CreatedAt,
+	}
+	assert.Equal(t, "year=2020/month=11/day=05/hour=07/policyId-1604561400000000000.json", s3ObjectKey(alert, ""))
+}
@@ -0,0 +1,231 @@
+package outputs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"encoding/base64"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	alertModels "github.com/panther-labs/panther/api/lambda/delivery/models"
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/templates"
+)
+
+const (
+	serviceNowIncidentEndpoint = "/api/now/table/incident"
+	serviceNowOAuthEndpoint    = "/oauth_token.do"
+	// ServiceNow truncates longer incident short descriptions
+	serviceNowShortDescriptionLimit = 160
+)
+
+// defaultServiceNowPriorities result in incident priorities from 1 - Critical to 5 - Planning
+var defaultServiceNowPriorities = map[string]outputModels.ServiceNowPriority{
+	"CRITICAL": {Impact: 1, Urgency: 1},
+	"HIGH":     {Impact: 1, Urgency: 2},
+	"MEDIUM":   {Impact: 2, Urgency: 2},
+	"LOW":      {Impact: 2, Urgency: 3},
+	"INFO":     {Impact: 3, Urgency: 3},
+}
+
+// ServiceNow creates an incident through the ServiceNow Table API.
+//
+// The AlertID is stored in the incident correlation_id, so that repeated deliveries of the same alert
+// update the existing incident instead of creating a duplicate.
+func (client *OutputClient) ServiceNow(
+	alert *alertModels.Alert, config *outputModels.ServiceNowConfig) *AlertDeliveryResponse {
+
+	notification := client.notification(alert)
+	customFields, err := renderServiceNowFields(generateTemplateData(alert), config.CustomFields)
+	if err != nil {
+		errorMsg := "Failed to render ServiceNow custom fields"
+		zap.L().Error(errorMsg, zap.Error(errors.WithStack(err)))
+		return &AlertDeliveryResponse{
+			StatusCode: 500,
+			Message:    errorMsg,
+			Permanent:  true,
+			Success:    false,
+		}
+	}
+
+	authorization, failure := client.serviceNowAuthorization(config)
+	if failure != nil {
+		return failure
+	}
+	headers := map[string]string{
+		AuthorizationHTTPHeader: authorization,
+	}
+	incidentURL := strings.TrimSuffix(config.InstanceURL, "/") + serviceNowIncidentEndpoint
+
+	shortDescription := notification.Title
+	// Truncate by characters to keep multi-byte characters intact
+	if runes := []rune(shortDescription); len(runes) > serviceNowShortDescriptionLimit {
+		shortDescription = string(runes[:serviceNowShortDescriptionLimit])
+	}
+	incident := map[string]interface{}{
+		"short_description": shortDescription,
+		"description":       client.detailedAlertMessage(alert),
+	}
+
+	correlationID := aws.StringValue(alert.AlertID)
+	if correlationID != "" {
+		sysID, failure := client.findServiceNowIncident(incidentURL, correlationID, headers)
+		if failure != nil {
+			return failure
+		}
+		if sysID != "" {
+			// Leave the assignment and priority alone, they may have been changed during triage
+			incident["work_notes"] = "Alert delivered again from Panther: " + notification.Link
+			for field, value := range customFields {
+				incident[field] = value
+			}
+			return client.httpWrapper.patch(&PostInput{
+				url:     incidentURL + "/" + url.PathEscape(sysID),
+				body:    incident,
+				headers: headers,
+			})
+		}
+	}
+
+	priority, ok := config.SeverityMapping[alert.Severity]
+	if !ok {
+		priority = defaultServiceNowPriorities[alert.Severity]
+	}
+	if priority.Impact != 0 {
+		incident["impact"] = strconv.Itoa(priority.Impact)
+	}
+	if priority.Urgency != 0 {
+		incident["urgency"] = strconv.Itoa(priority.Urgency)
+	}
+	if config.AssignmentGroup != "" {
+		incident["assignment_group"] = config.AssignmentGroup
+	}
+	if config.Category != "" {
+		incident["category"] = config.Category
+	}
+	for field, value := range customFields {
+		incident[field] = value
+	}
+	if correlationID != "" {
+		incident["correlation_id"] = correlationID
+		incident["correlation_display"] = "Panther"
+	}
+
+	return client.httpWrapper.post(&PostInput{
+		url:     incidentURL,
+		body:    incident,
+		headers: headers,
+	})
+}
+
+// serviceNowAuthorization returns the Authorization header value, requesting an OAuth access token if needed
+func (client *OutputClient) serviceNowAuthorization(
+	config *outputModels.ServiceNowConfig) (string, *AlertDeliveryResponse) {
+
+	if config.AuthType != "oauth" {
+		auth := config.UserName + ":" + config.Password
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(auth)), nil
+	}
+
+	response := client.httpWrapper.post(&PostInput{
+		url: strings.TrimSuffix(config.InstanceURL, "/") + serviceNowOAuthEndpoint,
+		body: url.Values{
+			"grant_type":    {"password"},
+			"client_id":     {config.ClientID},
+			"client_secret": {config.ClientSecret},
+			"username":      {config.UserName},
+			"password":      {config.Password},
+		},
+	})
+	if response == nil || !response.Success {
+		return "", response
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := jsoniter.UnmarshalFromString(response.Message, &token); err != nil || token.AccessToken == "" {
+		return "", &AlertDeliveryResponse{
+			StatusCode: 500,
+			Message:    "ServiceNow OAuth response did not contain an access token",
+			Permanent:  false,
+			Success:    false,
+		}
+	}
+	return "Bearer " + token.AccessToken, nil
+}
+
+// findServiceNowIncident returns the sys_id of the incident for an alert, or an empty string if there is none
+func (client *OutputClient) findServiceNowIncident(
+	incidentURL, correlationID string, headers map[string]string) (string, *AlertDeliveryResponse) {
+
+	query := url.Values{
+		"sysparm_query":  {"correlation_id=" + correlationID},
+		"sysparm_fields": {"sys_id"},
+		"sysparm_limit":  {"1"},
+	}
+	response := client.httpWrapper.get(&GetInput{
+		url:     incidentURL + "?" + query.Encode(),
+		headers: headers,
+	})
+	if response == nil || !response.Success {
+		return "", response
+	}
+
+	var incidents struct {
+		Result []struct {
+			SysID string `json:"sys_id"`
+		} `json:"result"`
+	}
+	if err := jsoniter.UnmarshalFromString(response.Message, &incidents); err != nil {
+		return "", &AlertDeliveryResponse{
+			StatusCode: 500,
+			Message:    "Failed to parse ServiceNow incident query response",
+			Permanent:  false,
+			Success:    false,
+		}
+	}
+	if len(incidents.Result) == 0 {
+		return "", nil
+	}
+	return incidents.Result[0].SysID, nil
+}
+
+// renderServiceNowFields executes the custom field templates against the alert template data
+func renderServiceNowFields(data *templates.Data, fields map[string]string) (map[string]string, error) {
+	rendered := make(map[string]string, len(fields))
+	for field, text := range fields {
+		tpl, err := templates.New(field, text)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid template for field %q", field)
+		}
+		value, err := templates.Render(tpl, data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to render field %q", field)
+		}
+		rendered[field] = value
+	}
+	return rendered, nil
+}
@@ -0,0 +1,242 @@
+package outputs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"encoding/base64"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	alertModels "github.com/panther-labs/panther/api/lambda/delivery/models"
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+)
+
+var serviceNowConfig = &outputModels.ServiceNowConfig{
+	InstanceURL:     "https://panther.service-now.com/",
+	UserName:        "username",
+	Password:        "password",
+	AssignmentGroup: "Security Operations",
+	Category:        "security",
+}
+
+const serviceNowIncidentURL = "https://panther.service-now.com/api/now/table/incident"
+
+var serviceNowAlert = &alertModels.Alert{
+	AlertID:             aws.String("b25dc23fb2a0b362da8428dbec1381a8"),
+	AnalysisID:          "ruleId",
+	Type:                alertModels.RuleType,
+	Title:               aws.String("Suspicious login"),
+	CreatedAt:           time.Date(2020, 11, 5, 12, 0, 0, 0, time.UTC),
+	AnalysisDescription: aws.String("ruleDescription"),
+	Severity:            "HIGH",
+	Context:             map[string]interface{}{"user": "alice"},
+}
+
+func serviceNowBasicHeaders() map[string]string {
+	return map[string]string{
+		AuthorizationHTTPHeader: "Basic " + base64.StdEncoding.EncodeToString([]byte("username:password")),
+	}
+}
+
+func serviceNowLookup(headers map[string]string) *GetInput {
+	return &GetInput{
+		url: serviceNowIncidentURL + "?" + url.Values{
+			"sysparm_query":  {"correlation_id=b25dc23fb2a0b362da8428dbec1381a8"},
+			"sysparm_fields": {"sys_id"},
+			"sysparm_limit":  {"1"},
+		}.Encode(),
+		headers: headers,
+	}
+}
+
+func TestServiceNowCreatesIncident(t *testing.T) {
+	httpWrapper := &mockHTTPWrapper{}
+	client := &OutputClient{httpWrapper: httpWrapper}
+	headers := serviceNowBasicHeaders()
+
+	httpWrapper.On("get", serviceNowLookup(headers)).Return(&AlertDeliveryResponse{
+		StatusCode: 200,
+		Message:    `{"result":[]}`,
+		Success:    true,
+	})
+	expectedPostInput := &PostInput{
+		url: serviceNowIncidentURL,
+		body: map[string]interface{}{
+			"short_description":   "New Alert: Suspicious login",
+			"description":         generateDetailedAlertMessage(serviceNowAlert),
+			"impact":              "1",
+			"urgency":             "2",
+			"assignment_group":    "Security Operations",
+			"category":            "security",
+			"correlation_id":      "b25dc23fb2a0b362da8428dbec1381a8",
+			"correlation_display": "Panther",
+		},
+		headers: headers,
+	}
+	httpWrapper.On("post", expectedPostInput).Return((*AlertDeliveryResponse)(nil))
+
+	assert.Nil(t, client.ServiceNow(serviceNowAlert, serviceNowConfig))
+	httpWrapper.AssertExpectations(t)
+}
+
+func TestServiceNowUpdatesExistingIncident(t *testing.T) {
+	httpWrapper := &mockHTTPWrapper{}
+	client := &OutputClient{httpWrapper: httpWrapper}
+	headers := serviceNowBasicHeaders()
+
+	httpWrapper.On("get", serviceNowLookup(headers)).Return(&AlertDeliveryResponse{
+		StatusCode: 200,
+		Message:    `{"result":[{"sys_id":"9d385017c611228701d22104cc95c371"}]}`,
+		Success:    true,
+	})
+	expectedPatchInput := &PostInput{
+		url: serviceNowIncidentURL + "/9d385017c611228701d22104cc95c371",
+		body: map[string]interface{}{
+			"short_description": "New Alert: Suspicious login",
+			"description":       generateDetailedAlertMessage(serviceNowAlert),
+			"work_notes":        "Alert delivered again from Panther: https://panther.io/alerts/b25dc23fb2a0b362da8428dbec1381a8",
+		},
+		headers: headers,
+	}
+	httpWrapper.On("patch", expectedPatchInput).Return(&AlertDeliveryResponse{StatusCode: 200, Success: true})
+
+	assert.Equal(t, &AlertDeliveryResponse{StatusCode: 200, Success: true},
+		client.ServiceNow(serviceNowAlert, serviceNowConfig))
+	httpWrapper.AssertExpectations(t)
+}
+
+func TestServiceNowLookupFailure(t *testing.T) {
+	httpWrapper := &mockHTTPWrapper{}
+	client := &OutputClient{httpWrapper: httpWrapper}
+
+	failure := &AlertDeliveryResponse{StatusCode: 401, Message: "request failed: 401 Unauthorized"}
+	httpWrapper.On("get", serviceNowLookup(serviceNowBasicHeaders())).Return(failure)
+
+	assert.Equal(t, failure, client.ServiceNow(serviceNowAlert, serviceNowConfig))
+	httpWrapper.AssertExpectations(t)
+}
+
+func TestServiceNowOAuthMappingAndCustomFields(t *testing.T) {
+	httpWrapper := &mockHTTPWrapper{}
+	client := &OutputClient{httpWrapper: httpWrapper}
+	config := &outputModels.ServiceNowConfig{
+		InstanceURL:  "https://panther.service-now.com",
+		AuthType:     "oauth",
+		UserName:     "username",
+		Password:     "password",
+		ClientID:     "clientId",
+		ClientSecret: "clientSecret",
+		SeverityMapping: map[string]outputModels.ServiceNowPriority{
+			"INFO": {Impact: 2, Urgency: 3},
+		},
+		CustomFields: map[string]string{
+			"u_panther_rule": "{{.ID}} ({{.Severity}})",
+			"u_user":         "{{.AlertContext.user}}",
+		},
+	}
+	alert := &alertModels.Alert{
+		AnalysisID: "policyId",
+		Type:       alertModels.PolicyType,
+		CreatedAt:  time.Date(2020, 11, 5, 12, 0, 0, 0, time.UTC),
+		Severity:   "INFO",
+		Context:    map[string]interface{}{"user": "bob"},
+		OutputIds:  []string{"output-id"},
+	}
+
+	httpWrapper.On("post", &PostInput{
+		url: "https://panther.service-now.com/oauth_token.do",
+		body: url.Values{
+			"grant_type":    {"password"},
+			"client_id":     {"clientId"},
+			"client_secret": {"clientSecret"},
+			"username":      {"username"},
+			"password":      {"password"},
+		},
+	}).Return(&AlertDeliveryResponse{
+		StatusCode: 200,
+		Message:    `{"access_token":"token","token_type":"Bearer"}`,
+		Success:    true,
+	})
+	// Policy alerts have no AlertID, so there is no incident to look up
+	httpWrapper.On("post", &PostInput{
+		url: serviceNowIncidentURL,
+		body: map[string]interface{}{
+			"short_description": "Policy Failure: policyId",
+			"description":       generateDetailedAlertMessage(alert),
+			"impact":            "2",
+			"urgency":           "3",
+			"u_panther_rule":    "policyId (INFO)",
+			"u_user":            "bob",
+		},
+		headers: map[string]string{AuthorizationHTTPHeader: "Bearer token"},
+	}).Return(&AlertDeliveryResponse{StatusCode: 201, Success: true})
+
+	assert.Equal(t, &AlertDeliveryResponse{StatusCode: 201, Success: true}, client.ServiceNow(alert, config))
+	httpWrapper.AssertExpectations(t)
+}
+
+func TestServiceNowOAuthMissingToken(t *testing.T) {
+	httpWrapper := &mockHTTPWrapper{}
+	client := &OutputClient{httpWrapper: httpWrapper}
+	config := &outputModels.ServiceNowConfig{
+		InstanceURL: "https://panther.service-now.com",
+		AuthType:    "oauth",
+		ClientID:    "clientId",
+	}
+
+	httpWrapper.On("post", &PostInput{
+		url: "https://panther.service-now.com/oauth_token.do",
+		body: url.Values{
+			"grant_type":    {"password"},
+			"client_id":     {"clientId"},
+			"client_secret": {""},
+			"username":      {""},
+			"password":      {""},
+		},
+	}).Return(&AlertDeliveryResponse{StatusCode: 200, Message: `{}`, Success: true})
+
+	response := client.ServiceNow(serviceNowAlert, config)
+	assert.False(t, response.Success)
+	assert.False(t, response.Permanent)
+	httpWrapper.AssertExpectations(t)
+}
+
+func TestServiceNowTruncatesShortDescription(t *testing.T) {
+	httpWrapper := &mockHTTPWrapper{}
+	client := &OutputClient{httpWrapper: httpWrapper}
+	alert := *serviceNowAlert
+	// "New Alert: " followed by multi-byte characters, a byte limit would split the last one
+	alert.Title = aws.String(strings.Repeat("é", serviceNowShortDescriptionLimit))
+
+	httpWrapper.On("get", mock.Anything).Return(&AlertDeliveryResponse{StatusCode: 200, Message: `{"result":[]}`, Success: true})
+	httpWrapper.On("post", mock.Anything).Return((*AlertDeliveryResponse)(nil))
+	assert.Nil(t, client.ServiceNow(&alert, serviceNowConfig))
+
+	incident := httpWrapper.Calls[1].Arguments.Get(0).(*PostInput).body.(map[string]interface{})
+	shortDescription := incident["short_description"].(string)
+	assert.True(t, utf8.ValidString(shortDescription))
+	assert.Equal(t, "New Alert: "+strings.Repeat("é", serviceNowShortDescriptionLimit-len("New Alert: ")), shortDescription)
+}
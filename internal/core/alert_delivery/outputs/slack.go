@@ -58,15 +58,20 @@ func (client *OutputClient) Slack(alert *alertModels.Alert, config *outputModels
 		},
 	}
 
+	title := client.alertTitle(alert)
+	attachment := map[string]interface{}{
+		"fallback": title,
+		"color":    severityColors[alert.Severity],
+		"title":    title,
+		"fields":   fields,
+	}
+	if templated, ok := client.alertBody(alert); ok {
+		delete(attachment, "fields")
+		attachment["text"] = templated
+	}
+
 	payload := map[string]interface{}{
-		"attachments": []map[string]interface{}{
-			{
-				"fallback": generateAlertTitle(alert),
-				"color":    severityColors[alert.Severity],
-				"title":    generateAlertTitle(alert),
-				"fields":   fields,
-			},
-		},
+		"attachments": []map[string]interface{}{attachment},
 	}
 	postInput := &PostInput{
 		url:  config.WebhookURL,
@@ -80,3 +80,41 @@ func TestSlackAlert(t *testing.T) {
 	require.Nil(t, client.Slack(alert, slackConfig))
 	httpWrapper.AssertExpectations(t)
 }
+
+func TestSlackAlertWithTemplate(t *testing.T) {
+	httpWrapper := &mockHTTPWrapper{}
+	client, err := (&OutputClient{httpWrapper: httpWrapper}).WithTemplate(&outputModels.MessageTemplate{
+		Title: "{{.Severity}}: {{.Name}}",
+		Body:  "{{markdown .Name}} failed, see {{.Link}}",
+	})
+	require.NoError(t, err)
+
+	alert := &alertModels.Alert{
+		AnalysisID:   "policyId",
+		Type:         alertModels.PolicyType,
+		CreatedAt:    time.Now(),
+		OutputIds:    []string{"output-id"},
+		AnalysisName: aws.String("policy_name"),
+		Severity:     "INFO",
+	}
+
+	expectedPostPayload := map[string]interface{}{
+		"attachments": []map[string]interface{}{
+			{
+				"color":    "#47b881",
+				"fallback": "INFO: policy_name",
+				"title":    "INFO: policy_name",
+				"text":     `policy\_name failed, see https://panther.io/policies/policyId`,
+			},
+		},
+	}
+	expectedPostInput := &PostInput{
+		url:  slackConfig.WebhookURL,
+		body: expectedPostPayload,
+	}
+
+	httpWrapper.On("post", expectedPostInput).Return((*AlertDeliveryResponse)(nil))
+
+	require.Nil(t, client.Slack(alert, slackConfig))
+	httpWrapper.AssertExpectations(t)
+}
@@ -44,7 +44,7 @@ var getSnsClient = buildSnsClient
 // Sns sends an alert to an SNS Topic.
 // nolint: dupl
 func (client *OutputClient) Sns(alert *alertModels.Alert, config *outputModels.SnsConfig) *AlertDeliveryResponse {
-	notification := generateNotificationFromAlert(alert)
+	notification := client.notification(alert)
 	serializedDefaultMessage, err := jsoniter.MarshalToString(notification)
 	if err != nil {
 		errorMsg := "Failed to serialize default message"
@@ -59,7 +59,7 @@ func (client *OutputClient) Sns(alert *alertModels.Alert, config *outputModels.S
 
 	outputMessage := &snsMessage{
 		DefaultMessage: serializedDefaultMessage,
-		EmailMessage:   generateDetailedAlertMessage(alert),
+		EmailMessage:   client.detailedAlertMessage(alert),
 	}
 
 	serializedMessage, err := jsoniter.MarshalToString(outputMessage)
@@ -74,7 +74,7 @@ func (client *OutputClient) Sns(alert *alertModels.Alert, config *outputModels.S
 		}
 	}
 
-	title := generateAlertTitle(alert)
+	title := notification.Title
 	if len(title) > 100 {
 		title = title[0:100]
 	}
@@ -0,0 +1,76 @@
+package outputs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"go.uber.org/zap"
+
+	alertModels "github.com/panther-labs/panther/api/lambda/delivery/models"
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+)
+
+const (
+	splunkAuthorizationHeaderFormat = "Splunk %s"
+	splunkSource                    = "panther"
+)
+
+// splunkEvent is the HTTP Event Collector envelope for an alert
+type splunkEvent struct {
+	// Epoch seconds with millisecond precision
+	Time       float64            `json:"time"`
+	Source     string             `json:"source"`
+	SourceType string             `json:"sourcetype,omitempty"`
+	Index      string             `json:"index,omitempty"`
+	Event      notificationRecord `json:"event"`
+}
+
+// Splunk sends an alert to a Splunk HTTP Event Collector
+func (client *OutputClient) Splunk(alert *alertModels.Alert, config *outputModels.SplunkConfig) *AlertDeliveryResponse {
+	httpWrapper, err := client.httpWrapperWithTLS(config.CACertificate, aws.BoolValue(config.SkipTLSVerify))
+	if err != nil {
+		errorMsg := "Invalid TLS configuration for Splunk"
+		zap.L().Error(errorMsg, zap.Error(err))
+		return &AlertDeliveryResponse{
+			StatusCode: 500,
+			Message:    errorMsg,
+			Permanent:  true,
+			Success:    false,
+		}
+	}
+
+	event := &splunkEvent{
+		Time:       float64(alert.CreatedAt.UnixNano()/1e6) / 1e3,
+		Source:     splunkSource,
+		SourceType: config.SourceType,
+		Index:      config.Index,
+		Event:      client.notificationRecord(alert),
+	}
+
+	postInput := &PostInput{
+		url:  config.HecURL,
+		body: event,
+		headers: map[string]string{
+			AuthorizationHTTPHeader: fmt.Sprintf(splunkAuthorizationHeaderFormat, config.Token),
+		},
+	}
+	return httpWrapper.post(postInput)
+}
@@ -0,0 +1,97 @@
+package outputs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	alertModels "github.com/panther-labs/panther/api/lambda/delivery/models"
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+)
+
+func TestSplunkAlert(t *testing.T) {
+	httpWrapper := &mockHTTPWrapper{}
+	client := &OutputClient{httpWrapper: httpWrapper}
+	config := &outputModels.SplunkConfig{
+		HecURL:     "https://splunk.example.com:8088/services/collector/event",
+		Token:      "token",
+		Index:      "security",
+		SourceType: "panther:alert",
+	}
+	alert := &alertModels.Alert{
+		AlertID:    aws.String("alertId"),
+		AnalysisID: "ruleId",
+		Type:       alertModels.RuleType,
+		CreatedAt:  time.Date(2020, 11, 5, 12, 0, 0, 123456789, time.UTC),
+		Severity:   "HIGH",
+		LogTypes:   []string{"AWS.CloudTrail"},
+		Context:    map[string]interface{}{},
+		SampleEvents: []string{
+			`{"eventName":"ConsoleLogin","sourceIPAddress":"203.0.113.10"}`,
+			`{"eventName":"ConsoleLogin","sourceIPAddress":"203.0.113.11"}`,
+		},
+	}
+
+	httpWrapper.On("post", mock.Anything).Return(&AlertDeliveryResponse{StatusCode: 200, Success: true})
+	assert.Equal(t, &AlertDeliveryResponse{StatusCode: 200, Success: true}, client.Splunk(alert, config))
+	httpWrapper.AssertExpectations(t)
+
+	postInput := httpWrapper.Calls[0].Arguments.Get(0).(*PostInput)
+	assert.Equal(t, config.HecURL, postInput.url)
+	assert.Equal(t, map[string]string{AuthorizationHTTPHeader: "Splunk token"}, postInput.headers)
+
+	body, err := jsoniter.Marshal(postInput.body)
+	require.NoError(t, err)
+	var event map[string]interface{}
+	require.NoError(t, jsoniter.Unmarshal(body, &event))
+	assert.Equal(t, 1604577600.123, event["time"])
+	assert.Equal(t, "panther", event["source"])
+	assert.Equal(t, "panther:alert", event["sourcetype"])
+	assert.Equal(t, "security", event["index"])
+	notification := event["event"].(map[string]interface{})
+	assert.Equal(t, "ruleId", notification["id"])
+	assert.Equal(t, "alertId", notification["alertId"])
+	assert.Equal(t, []interface{}{"AWS.CloudTrail"}, notification["logTypes"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"eventName": "ConsoleLogin", "sourceIPAddress": "203.0.113.10"},
+		map[string]interface{}{"eventName": "ConsoleLogin", "sourceIPAddress": "203.0.113.11"},
+	}, notification["sampleEvents"])
+}
+
+func TestSplunkInvalidCA(t *testing.T) {
+	client := &OutputClient{httpWrapper: &mockHTTPWrapper{}}
+	config := &outputModels.SplunkConfig{
+		HecURL:        "https://splunk.example.com:8088/services/collector/event",
+		CACertificate: "not a certificate",
+	}
+	response := client.Splunk(&alertModels.Alert{Type: alertModels.RuleType, AnalysisID: "ruleId"}, config)
+	assert.Equal(t, &AlertDeliveryResponse{
+		StatusCode: 500,
+		Message:    "Invalid TLS configuration for Splunk",
+		Permanent:  true,
+		Success:    false,
+	}, response)
+}
@@ -0,0 +1,110 @@
+package outputs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"go.uber.org/zap"
+
+	alertModels "github.com/panther-labs/panther/api/lambda/delivery/models"
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/templates"
+)
+
+// WithTemplate returns a copy of the client that formats alerts with the given message template
+func (client *OutputClient) WithTemplate(messageTemplate *outputModels.MessageTemplate) (API, error) {
+	tpl, err := templates.Parse(messageTemplate)
+	if err != nil {
+		return nil, err
+	}
+	templated := *client
+	templated.template = tpl
+	return &templated, nil
+}
+
+// RenderTemplate renders a message template for an alert the same way outputs do.
+// The default title and message are returned for the parts the template does not set.
+func RenderTemplate(messageTemplate *outputModels.MessageTemplate, alert *alertModels.Alert) (title, body string, err error) {
+	tpl, err := templates.Parse(messageTemplate)
+	if err != nil {
+		return "", "", err
+	}
+	data := generateTemplateData(alert)
+	title, ok, err := tpl.Title(data)
+	if err != nil {
+		return "", "", err
+	}
+	if !ok {
+		title = data.Title
+	}
+	body, ok, err = tpl.Body(data)
+	if err != nil {
+		return "", "", err
+	}
+	if !ok {
+		body = generateDetailedAlertMessage(alert)
+	}
+	return title, body, nil
+}
+
+func generateTemplateData(alert *alertModels.Alert) *templates.Data {
+	return templates.NewData(alert, generateAlertTitle(alert), generateURL(alert))
+}
+
+// alertTitle renders the title template of the output, falling back to the default title
+func (client *OutputClient) alertTitle(alert *alertModels.Alert) string {
+	title, ok, err := client.template.Title(generateTemplateData(alert))
+	if err != nil {
+		zap.L().Warn("failed to render title template, using default title", zap.Error(err))
+	}
+	if !ok {
+		return generateAlertTitle(alert)
+	}
+	return title
+}
+
+// alertBody renders the body template of the output, returning false if the default format should be used
+func (client *OutputClient) alertBody(alert *alertModels.Alert) (string, bool) {
+	body, ok, err := client.template.Body(generateTemplateData(alert))
+	if err != nil {
+		zap.L().Warn("failed to render body template, using default format", zap.Error(err))
+	}
+	return body, ok
+}
+
+// detailedAlertMessage renders the body template of the output, falling back to the default detailed message
+func (client *OutputClient) detailedAlertMessage(alert *alertModels.Alert) string {
+	if body, ok := client.alertBody(alert); ok {
+		return body
+	}
+	return generateDetailedAlertMessage(alert)
+}
+
+// notification is the default payload with the title rendered from the template of the output
+func (client *OutputClient) notification(alert *alertModels.Alert) Notification {
+	notification := generateNotificationFromAlert(alert)
+	notification.Title = client.alertTitle(alert)
+	return notification
+}
+
+// notificationRecord is the notification record with the title rendered from the template of the output
+func (client *OutputClient) notificationRecord(alert *alertModels.Alert) notificationRecord {
+	record := generateNotificationRecordFromAlert(alert)
+	record.Title = client.alertTitle(alert)
+	return record
+}
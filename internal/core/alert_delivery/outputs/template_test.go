@@ -0,0 +1,88 @@
+package outputs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	alertModels "github.com/panther-labs/panther/api/lambda/delivery/models"
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+)
+
+var templateAlert = &alertModels.Alert{
+	AlertID:      aws.String("alertId"),
+	AnalysisID:   "ruleId",
+	AnalysisName: aws.String("Rule Name"),
+	Type:         alertModels.RuleType,
+	Severity:     "HIGH",
+	CreatedAt:    time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+	Context:      map[string]interface{}{"user": "alice"},
+}
+
+func TestWithTemplateInvalid(t *testing.T) {
+	client, err := (&OutputClient{}).WithTemplate(&outputModels.MessageTemplate{Body: "{{.Name"})
+	assert.Nil(t, client)
+	assert.Error(t, err)
+}
+
+func TestWithTemplateDoesNotChangeClient(t *testing.T) {
+	client := &OutputClient{}
+	_, err := client.WithTemplate(&outputModels.MessageTemplate{Title: "{{.Name}}"})
+	require.NoError(t, err)
+	assert.Nil(t, client.template)
+	assert.Equal(t, "New Alert: Rule Name", client.alertTitle(templateAlert))
+}
+
+func TestTemplatedNotification(t *testing.T) {
+	templated, err := (&OutputClient{}).WithTemplate(&outputModels.MessageTemplate{
+		Title: `{{.Name}} by {{index .AlertContext "user"}}`,
+	})
+	require.NoError(t, err)
+	client := templated.(*OutputClient)
+
+	notification := client.notification(templateAlert)
+	assert.Equal(t, "Rule Name by alice", notification.Title)
+	// Without a body template, the default message is used
+	assert.Equal(t, generateDetailedAlertMessage(templateAlert), client.detailedAlertMessage(templateAlert))
+}
+
+func TestTemplateRenderErrorFallsBack(t *testing.T) {
+	templated, err := (&OutputClient{}).WithTemplate(&outputModels.MessageTemplate{
+		Title: "{{.Tags | truncate 5}}",
+		Body:  "{{.Tags | truncate 5}}",
+	})
+	require.NoError(t, err)
+	client := templated.(*OutputClient)
+
+	assert.Equal(t, "New Alert: Rule Name", client.alertTitle(templateAlert))
+	assert.Equal(t, generateDetailedAlertMessage(templateAlert), client.detailedAlertMessage(templateAlert))
+}
+
+func TestRenderTemplate(t *testing.T) {
+	messageTemplate := &outputModels.MessageTemplate{Body: `{{.AlertID}} {{.CreatedAt | formatTime "2006-01-02"}}`}
+	title, body, err := RenderTemplate(messageTemplate, templateAlert)
+	require.NoError(t, err)
+	assert.Equal(t, "New Alert: Rule Name", title)
+	assert.Equal(t, "alertId 2020-01-01", body)
+}
@@ -18,7 +18,12 @@ package outputs
  * along with this program.  If not, see <https://www.gnu.org/licenses/>.
  */
 import (
+	"net/textproto"
+
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/firehose"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/ses"
 	"github.com/aws/aws-sdk-go/service/sns"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/pkg/errors"
@@ -42,6 +47,48 @@ func getAlertResponseFromSNSError(err error) *AlertDeliveryResponse {
 	return getResponse(500, err.Error())
 }
 
+func getAlertResponseFromSESError(err error) *AlertDeliveryResponse {
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		statusCode := mapSESSendRawEmailErrorCodeToStatusCode(awsErr)
+		return getResponse(statusCode, awsErr.Error())
+	}
+	return getResponse(500, err.Error())
+}
+
+func getAlertResponseFromFirehoseError(err error) *AlertDeliveryResponse {
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		statusCode := mapFirehosePutRecordErrorCodeToStatusCode(awsErr)
+		return getResponse(statusCode, awsErr.Error())
+	}
+	return getResponse(500, err.Error())
+}
+
+func getAlertResponseFromS3Error(err error) *AlertDeliveryResponse {
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		statusCode := mapS3PutObjectErrorCodeToStatusCode(awsErr)
+		return getResponse(statusCode, awsErr.Error())
+	}
+	return getResponse(500, err.Error())
+}
+
+// getAlertResponseFromSMTPError treats permanent (5xx) SMTP replies, such as rejected credentials
+// or recipients, as permanent failures. Everything else, including network errors, can be retried.
+func getAlertResponseFromSMTPError(err error) *AlertDeliveryResponse {
+	var protoErr *textproto.Error
+	if errors.As(err, &protoErr) && protoErr.Code >= 500 {
+		return &AlertDeliveryResponse{
+			StatusCode: 400,
+			Message:    protoErr.Error(),
+			Permanent:  true,
+			Success:    false,
+		}
+	}
+	return getResponse(503, err.Error())
+}
+
 // getResponse - generates a failed response that can be retried
 func getResponse(statusCode int, message string) *AlertDeliveryResponse {
 	return &AlertDeliveryResponse{
@@ -99,3 +146,53 @@ func mapSQSSendMessageErrorCodeToStatusCode(awsErr awserr.Error) int {
 		return 500
 	}
 }
+
+// Maps SES.SendRawEmail error codes to response status codes
+func mapSESSendRawEmailErrorCodeToStatusCode(awsErr awserr.Error) int {
+	switch awsErr.Code() {
+	case ses.ErrCodeMessageRejected:
+		return 400
+	case ses.ErrCodeMailFromDomainNotVerifiedException:
+		return 403
+	case ses.ErrCodeAccountSendingPausedException:
+		return 403
+	case ses.ErrCodeConfigurationSetSendingPausedException:
+		return 403
+	case ses.ErrCodeConfigurationSetDoesNotExistException:
+		return 404
+	case "Throttling":
+		return 429
+	default:
+		return 500
+	}
+}
+
+// Maps Firehose.PutRecord error codes to response status codes
+func mapFirehosePutRecordErrorCodeToStatusCode(awsErr awserr.Error) int {
+	switch awsErr.Code() {
+	case firehose.ErrCodeInvalidArgumentException:
+		return 400
+	case firehose.ErrCodeInvalidKMSResourceException:
+		return 400
+	case firehose.ErrCodeResourceNotFoundException:
+		return 404
+	case firehose.ErrCodeServiceUnavailableException:
+		return 503
+	default:
+		return 500
+	}
+}
+
+// Maps S3.PutObject error codes to response status codes
+func mapS3PutObjectErrorCodeToStatusCode(awsErr awserr.Error) int {
+	switch awsErr.Code() {
+	case s3.ErrCodeNoSuchBucket:
+		return 404
+	case "AccessDenied", "KMS.DisabledException", "KMS.NotFoundException":
+		return 403
+	case "SlowDown":
+		return 503
+	default:
+		return 500
+	}
+}
@@ -0,0 +1,118 @@
+// Package ratelimit tracks the notifications sent through rate limited outputs and collects the
+// alerts held back by the limits, so they can be sent later as a single digest per output.
+package ratelimit
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"github.com/pkg/errors"
+
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/digest"
+)
+
+// API defines the interface for the rate limit table which can be used for mocking.
+type API interface {
+	Admit(outputID string, limit *outputModels.RateLimit, entry *digest.Entry, now time.Time) (bool, error)
+	PendingDigests(now time.Time) ([]*Window, error)
+	CompleteDigest(window *Window) error
+	RetryDigest(window *Window) error
+}
+
+// Window is the rate limit state of an output for a fixed time window (one item in the table).
+// The digest of the alerts held back is sent when the window ends.
+type Window struct {
+	OutputID string `json:"outputId"`
+
+	// WindowStart is in seconds since epoch
+	WindowStart int64 `json:"windowStart"`
+
+	// Notifications is the number of alerts sent through the output during the window
+	Notifications int `json:"notifications"`
+
+	digest.Digest
+}
+
+// Table encapsulates a connection to the Dynamo rate limit table.
+//
+// The table is keyed by outputId and windowStart. Windows with alerts held back are listed
+// in the pending digest index, see digest.Store.
+type Table struct {
+	digest.Store
+}
+
+// Admit counts a notification against the current window of the output.
+//
+// It returns false when the window is full, in which case the alert is added to the window digest.
+func (table *Table) Admit(outputID string, limit *outputModels.RateLimit, entry *digest.Entry, now time.Time) (bool, error) {
+	windowSeconds := int64(limit.WindowSeconds)
+	windowStart := now.Unix() - now.Unix()%windowSeconds
+	windowEnd := time.Unix(windowStart+windowSeconds, 0)
+	key := windowKey(outputID, windowStart)
+
+	notifications := expression.Name("notifications")
+	update := expression.
+		Add(notifications, expression.Value(1)).
+		Set(expression.Name("expiresAt"), expression.Value(windowEnd.Add(digest.Retention).Unix()))
+	condition := notifications.AttributeNotExists().Or(notifications.LessThan(expression.Value(limit.MaxNotifications)))
+
+	err := table.Update(key, update, &condition)
+	if err == nil {
+		return true, nil
+	}
+	if !digest.IsConditionalCheckFailed(err) {
+		return false, errors.Wrap(err, "failed to count notification")
+	}
+
+	if err = table.Add(key, windowEnd, entry, nil); err != nil {
+		return false, err
+	}
+	return false, nil
+}
+
+// PendingDigests returns the windows ended by now with alerts held back.
+func (table *Table) PendingDigests(now time.Time) ([]*Window, error) {
+	var windows []*Window
+	if err := table.Pending(now, &windows); err != nil {
+		return nil, err
+	}
+	return windows, nil
+}
+
+// CompleteDigest marks the digest of a window as sent.
+func (table *Table) CompleteDigest(window *Window) error {
+	return table.Complete(windowKey(window.OutputID, window.WindowStart))
+}
+
+// RetryDigest records a failed attempt to send the digest of a window, which stays pending.
+func (table *Table) RetryDigest(window *Window) error {
+	return table.Retry(windowKey(window.OutputID, window.WindowStart))
+}
+
+func windowKey(outputID string, windowStart int64) digest.Key {
+	return digest.Key{
+		"outputId":    {S: aws.String(outputID)},
+		"windowStart": {N: aws.String(strconv.FormatInt(windowStart, 10))},
+	}
+}
@@ -0,0 +1,167 @@
+package ratelimit
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/digest"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+var (
+	testLimit = &outputModels.RateLimit{MaxNotifications: 5, WindowSeconds: 300}
+	testEntry = &digest.Entry{AlertID: "alert-id", AnalysisID: "Rule.ID", Title: "Title", Severity: "HIGH", Link: "https://panther/alert-id"}
+	// 1000 seconds since epoch falls in the [900, 1200) window
+	testNow = time.Unix(1000, 0)
+
+	conditionalCheckFailed = awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "", nil)
+)
+
+func testTable() (*Table, *testutils.DynamoDBMock) {
+	client := &testutils.DynamoDBMock{}
+	return &Table{Store: digest.Store{Name: "rate-limits", IndexName: "pending-digests", Client: client}}, client
+}
+
+func updateInput(client *testutils.DynamoDBMock, call int) *dynamodb.UpdateItemInput {
+	return client.Calls[call].Arguments.Get(0).(*dynamodb.UpdateItemInput)
+}
+
+func TestAdmit(t *testing.T) {
+	table, client := testTable()
+	client.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{}, nil).Once()
+
+	admitted, err := table.Admit("output-id", testLimit, testEntry, testNow)
+	require.NoError(t, err)
+	assert.True(t, admitted)
+	client.AssertExpectations(t)
+
+	input := updateInput(client, 0)
+	assert.Equal(t, "output-id", *input.Key["outputId"].S)
+	assert.Equal(t, "900", *input.Key["windowStart"].N)
+	assert.NotNil(t, input.ConditionExpression)
+	var values []string
+	for _, value := range input.ExpressionAttributeValues {
+		values = append(values, aws.StringValue(value.N))
+	}
+	assert.Contains(t, values, "606000") // expiresAt, a week after the window ends
+}
+
+func TestAdmitOverLimit(t *testing.T) {
+	table, client := testTable()
+	client.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{}, conditionalCheckFailed).Once()
+	client.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{}, nil).Once()
+
+	admitted, err := table.Admit("output-id", testLimit, testEntry, testNow)
+	require.NoError(t, err)
+	assert.False(t, admitted)
+	client.AssertExpectations(t)
+
+	input := updateInput(client, 1)
+	assert.Equal(t, "900", *input.Key["windowStart"].N)
+	assert.Contains(t, *input.UpdateExpression, "list_append")
+	var values []string
+	for _, value := range input.ExpressionAttributeValues {
+		values = append(values, aws.StringValue(value.N))
+	}
+	assert.Contains(t, values, "1200") // digestEnd
+}
+
+func TestAdmitError(t *testing.T) {
+	table, client := testTable()
+	client.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{}, errors.New("throttled")).Once()
+
+	admitted, err := table.Admit("output-id", testLimit, testEntry, testNow)
+	require.Error(t, err)
+	assert.False(t, admitted)
+	client.AssertExpectations(t)
+}
+
+func TestPendingDigests(t *testing.T) {
+	table, client := testTable()
+	item := func(windowStart string) map[string]*dynamodb.AttributeValue {
+		return map[string]*dynamodb.AttributeValue{
+			"outputId":      {S: aws.String("output-id")},
+			"windowStart":   {N: aws.String(windowStart)},
+			"notifications": {N: aws.String("5")},
+			"digestEnd":     {N: aws.String("1200")},
+			"entryCount":    {N: aws.String("2")},
+			"entries": {L: []*dynamodb.AttributeValue{
+				{M: map[string]*dynamodb.AttributeValue{"alertId": {S: aws.String("alert-id")}}},
+			}},
+		}
+	}
+	client.On("Query", mock.Anything).Return(&dynamodb.QueryOutput{
+		Items:            []map[string]*dynamodb.AttributeValue{item("300")},
+		LastEvaluatedKey: item("300"),
+	}, nil).Once()
+	client.On("Query", mock.Anything).Return(&dynamodb.QueryOutput{
+		Items: []map[string]*dynamodb.AttributeValue{item("600")},
+	}, nil).Once()
+
+	windows, err := table.PendingDigests(testNow)
+	require.NoError(t, err)
+	client.AssertExpectations(t)
+
+	window := func(windowStart int64) *Window {
+		return &Window{
+			OutputID:      "output-id",
+			WindowStart:   windowStart,
+			Notifications: 5,
+			Digest:        digest.Digest{DigestEnd: 1200, EntryCount: 2, Entries: []*digest.Entry{{AlertID: "alert-id"}}},
+		}
+	}
+	assert.Equal(t, []*Window{window(300), window(600)}, windows)
+	assert.Equal(t, "pending-digests", *client.Calls[0].Arguments.Get(0).(*dynamodb.QueryInput).IndexName)
+}
+
+func TestCompleteDigest(t *testing.T) {
+	table, client := testTable()
+	client.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{}, nil).Once()
+
+	require.NoError(t, table.CompleteDigest(&Window{OutputID: "output-id", WindowStart: 900}))
+	client.AssertExpectations(t)
+
+	input := updateInput(client, 0)
+	assert.Equal(t, "output-id", *input.Key["outputId"].S)
+	assert.Equal(t, "900", *input.Key["windowStart"].N)
+	assert.Contains(t, *input.UpdateExpression, "REMOVE")
+}
+
+func TestRetryDigest(t *testing.T) {
+	table, client := testTable()
+	client.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{}, nil).Once()
+
+	require.NoError(t, table.RetryDigest(&Window{OutputID: "output-id", WindowStart: 900}))
+	client.AssertExpectations(t)
+
+	input := updateInput(client, 0)
+	assert.Equal(t, "900", *input.Key["windowStart"].N)
+	assert.Contains(t, *input.UpdateExpression, "ADD")
+}
@@ -0,0 +1,256 @@
+// Package templates renders the user-defined message templates of alert outputs.
+//
+// Templates use Go text/template syntax and are executed against Data, for example:
+//
+//	[{{.Severity}}] {{.Name}} triggered by {{index .AlertContext "user" | default "unknown"}}
+//
+// See Funcs for the helper functions that are available in templates.
+package templates
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+	"text/template"
+	"time"
+	"unicode/utf8"
+
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+
+	alertModels "github.com/panther-labs/panther/api/lambda/delivery/models"
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+)
+
+// Data is the data model message templates are executed against.
+type Data struct {
+	// ID of the rule or policy
+	ID string
+	// AlertID is empty for policy failures
+	AlertID string
+	// Name of the rule or policy, defaults to the ID if the rule has no display name
+	Name string
+	// Title is the default title of the alert, e.g. "New Alert: Root login"
+	Title string
+	// Severity is one of INFO LOW MEDIUM HIGH CRITICAL
+	Severity string
+	// Type is one of RULE RULE_ERROR POLICY DIGEST
+	Type string
+	// Link to the alert (or the policy) in Panther UI
+	Link string
+	// Description of the rule or policy
+	Description string
+	// Runbook is the user-provided triage information
+	Runbook string
+	// Tags of the rule or policy
+	Tags []string
+	// LogTypes of the events that triggered the alert
+	LogTypes []string
+	// Version is the S3 object version of the rule or policy
+	Version string
+	// CreatedAt is the time the alert was created
+	CreatedAt time.Time
+	// AlertContext is the value returned by the alert_context function of the rule
+	AlertContext map[string]interface{}
+	// IsTest is set for test alerts sent from Panther UI
+	IsTest bool
+	// IsResent is set if the alert was delivered before
+	IsResent bool
+}
+
+// NewData builds the template data for an alert, given its default title and link
+func NewData(alert *alertModels.Alert, title, link string) *Data {
+	data := &Data{
+		ID:           alert.AnalysisID,
+		AlertID:      aws.StringValue(alert.AlertID),
+		Name:         aws.StringValue(alert.AnalysisName),
+		Title:        title,
+		Severity:     alert.Severity,
+		Type:         alert.Type,
+		Link:         link,
+		Description:  aws.StringValue(alert.AnalysisDescription),
+		Runbook:      aws.StringValue(alert.Runbook),
+		Tags:         alert.Tags,
+		LogTypes:     alert.LogTypes,
+		Version:      aws.StringValue(alert.Version),
+		CreatedAt:    alert.CreatedAt,
+		AlertContext: alert.Context,
+		IsTest:       alert.IsTest,
+		IsResent:     alert.IsResent,
+	}
+	if data.Name == "" {
+		data.Name = alert.AnalysisID
+	}
+	if data.Tags == nil {
+		data.Tags = []string{}
+	}
+	if data.LogTypes == nil {
+		data.LogTypes = []string{}
+	}
+	if data.AlertContext == nil {
+		data.AlertContext = map[string]interface{}{}
+	}
+	return data
+}
+
+// Funcs are the helper functions available to templates, in addition to the text/template builtins.
+//
+//	json           encodes a value as JSON, e.g. {{json .AlertContext}}
+//	truncate N S   shortens S to at most N characters, ending with "..." when cut, e.g. {{.Description | truncate 200}}
+//	markdown       escapes markdown control characters, e.g. {{markdown .Name}}
+//	join SEP LIST  joins a list of strings, e.g. {{.Tags | join ", "}}
+//	default D V    returns D if V is empty, e.g. {{index .AlertContext "user" | default "unknown"}}
+//	upper, lower   change the case of a string
+//	formatTime L T formats a time using a Go layout, e.g. {{.CreatedAt | formatTime "2006-01-02 15:04"}}
+var Funcs = template.FuncMap{
+	"json":       toJSON,
+	"truncate":   truncate,
+	"markdown":   escapeMarkdown,
+	"join":       join,
+	"default":    defaultValue,
+	"upper":      strings.ToUpper,
+	"lower":      strings.ToLower,
+	"formatTime": formatTime,
+}
+
+// New parses a single template with the helper functions
+func New(name, text string) (*template.Template, error) {
+	return template.New(name).Funcs(Funcs).Parse(text)
+}
+
+// Render executes a template against the data
+func Render(tpl *template.Template, data *Data) (string, error) {
+	var result strings.Builder
+	if err := tpl.Execute(&result, data); err != nil {
+		return "", err
+	}
+	return result.String(), nil
+}
+
+// Template is a parsed message template of an output
+type Template struct {
+	title *template.Template
+	body  *template.Template
+}
+
+// Parse parses a message template. Empty title and body are left unset, so the output keeps its defaults.
+func Parse(messageTemplate *outputModels.MessageTemplate) (*Template, error) {
+	result := &Template{}
+	if messageTemplate == nil {
+		return result, nil
+	}
+	var err error
+	if messageTemplate.Title != "" {
+		if result.title, err = New("title", messageTemplate.Title); err != nil {
+			return nil, errors.Wrap(err, "invalid title template")
+		}
+	}
+	if messageTemplate.Body != "" {
+		if result.body, err = New("body", messageTemplate.Body); err != nil {
+			return nil, errors.Wrap(err, "invalid body template")
+		}
+	}
+	return result, nil
+}
+
+// Title renders the title template, returning false if no title template is set
+func (t *Template) Title(data *Data) (string, bool, error) {
+	if t == nil {
+		return "", false, nil
+	}
+	return t.render(t.title, data)
+}
+
+// Body renders the body template, returning false if no body template is set
+func (t *Template) Body(data *Data) (string, bool, error) {
+	if t == nil {
+		return "", false, nil
+	}
+	return t.render(t.body, data)
+}
+
+func (t *Template) render(tpl *template.Template, data *Data) (string, bool, error) {
+	if tpl == nil {
+		return "", false, nil
+	}
+	result, err := Render(tpl, data)
+	if err != nil {
+		return "", false, err
+	}
+	return result, true, nil
+}
+
+func toJSON(value interface{}) (string, error) {
+	return jsoniter.ConfigCompatibleWithStandardLibrary.MarshalToString(value)
+}
+
+func truncate(length int, value string) string {
+	const ellipsis = "..."
+	if utf8.RuneCountInString(value) <= length {
+		return value
+	}
+	runes := []rune(value)
+	if length <= len(ellipsis) {
+		return string(runes[:length])
+	}
+	return string(runes[:length-len(ellipsis)]) + ellipsis
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", `*`, `\*`, `_`, `\_`, `{`, `\{`, `}`, `\}`, `[`, `\[`, `]`, `\]`,
+	`(`, `\(`, `)`, `\)`, `#`, `\#`, `+`, `\+`, `-`, `\-`, `.`, `\.`, `!`, `\!`, `|`, `\|`,
+	`<`, `\<`, `>`, `\>`, `~`, `\~`,
+)
+
+func escapeMarkdown(value string) string {
+	return markdownEscaper.Replace(value)
+}
+
+func join(separator string, values []string) string {
+	return strings.Join(values, separator)
+}
+
+func defaultValue(fallback, value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil:
+		return fallback
+	case string:
+		if v == "" {
+			return fallback
+		}
+	case []string:
+		if len(v) == 0 {
+			return fallback
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			return fallback
+		}
+	case map[string]interface{}:
+		if len(v) == 0 {
+			return fallback
+		}
+	}
+	return value
+}
+
+func formatTime(layout string, value time.Time) string {
+	return value.Format(layout)
+}
@@ -0,0 +1,138 @@
+package templates
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	alertModels "github.com/panther-labs/panther/api/lambda/delivery/models"
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+)
+
+func render(t *testing.T, text string, data *Data) string {
+	tpl, err := New("test", text)
+	require.NoError(t, err)
+	result, err := Render(tpl, data)
+	require.NoError(t, err)
+	return result
+}
+
+func TestNewData(t *testing.T) {
+	createdAt := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	data := NewData(&alertModels.Alert{
+		AnalysisID:          "ruleId",
+		AlertID:             aws.String("alertId"),
+		AnalysisDescription: aws.String("description"),
+		Type:                alertModels.RuleType,
+		Severity:            "HIGH",
+		CreatedAt:           createdAt,
+	}, "New Alert: ruleId", "https://panther.io/alerts/alertId")
+
+	assert.Equal(t, &Data{
+		ID:           "ruleId",
+		AlertID:      "alertId",
+		Name:         "ruleId",
+		Title:        "New Alert: ruleId",
+		Severity:     "HIGH",
+		Type:         alertModels.RuleType,
+		Link:         "https://panther.io/alerts/alertId",
+		Description:  "description",
+		Tags:         []string{},
+		LogTypes:     []string{},
+		CreatedAt:    createdAt,
+		AlertContext: map[string]interface{}{},
+	}, data)
+}
+
+func TestFuncs(t *testing.T) {
+	data := &Data{
+		Name:         "my_rule *prod*",
+		Description:  "A rather long description",
+		Tags:         []string{"AWS", "IAM"},
+		AlertContext: map[string]interface{}{"user": "alice", "ports": []interface{}{22, 443}},
+		CreatedAt:    time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	assert.Equal(t, `{"ports":[22,443],"user":"alice"}`, render(t, "{{json .AlertContext}}", data))
+	assert.Equal(t, "A rather...", render(t, "{{.Description | truncate 11}}", data))
+	assert.Equal(t, "A rather long description", render(t, "{{.Description | truncate 100}}", data))
+	assert.Equal(t, "A r", render(t, "{{truncate 3 .Description}}", data))
+	assert.Equal(t, `my\_rule \*prod\*`, render(t, "{{markdown .Name}}", data))
+	assert.Equal(t, "AWS, IAM", render(t, `{{.Tags | join ", "}}`, data))
+	assert.Equal(t, "unknown", render(t, `{{index .AlertContext "host" | default "unknown"}}`, data))
+	assert.Equal(t, "alice", render(t, `{{index .AlertContext "user" | default "unknown"}}`, data))
+	assert.Equal(t, "none", render(t, `{{.Runbook | default "none"}}`, data))
+	assert.Equal(t, "MY_RULE *PROD*", render(t, "{{upper .Name}}", data))
+	assert.Equal(t, "2020-01-02 03:04", render(t, `{{.CreatedAt | formatTime "2006-01-02 15:04"}}`, data))
+}
+
+func TestTruncateMultibyte(t *testing.T) {
+	assert.Equal(t, "héllo", truncate(5, "héllo"))
+	assert.Equal(t, "hé...", truncate(5, "héllo wörld"))
+}
+
+func TestParse(t *testing.T) {
+	tpl, err := Parse(&outputModels.MessageTemplate{Title: "{{.Severity}} {{.Name}}"})
+	require.NoError(t, err)
+
+	data := &Data{Name: "rule", Severity: "LOW"}
+	title, ok, err := tpl.Title(data)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "LOW rule", title)
+
+	_, ok, err = tpl.Body(data)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestParseNil(t *testing.T) {
+	tpl, err := Parse(nil)
+	require.NoError(t, err)
+	_, ok, err := tpl.Title(&Data{})
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// A nil template behaves like an empty one
+	_, ok, err = (*Template)(nil).Body(&Data{})
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestParseInvalid(t *testing.T) {
+	_, err := Parse(&outputModels.MessageTemplate{Body: "{{.Name"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid body template")
+
+	_, err = Parse(&outputModels.MessageTemplate{Title: "{{nope .Name}}"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid title template")
+}
+
+func TestRenderError(t *testing.T) {
+	tpl, err := Parse(&outputModels.MessageTemplate{Title: "{{.Missing}}"})
+	require.NoError(t, err)
+	_, _, err = tpl.Title(&Data{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,47 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/lambda/outputs/models"
+)
+
+// AddMaintenanceWindow stores a new maintenance window
+func (API) AddMaintenanceWindow(input *models.AddMaintenanceWindowInput) (*models.AddMaintenanceWindowOutput, error) {
+	now := time.Now().Format(time.RFC3339)
+	window := &models.MaintenanceWindow{
+		WindowID:                  uuid.New().String(),
+		MaintenanceWindowSettings: input.MaintenanceWindowSettings,
+		CreatedBy:                 *input.UserID,
+		CreationTime:              now,
+		LastModifiedBy:            *input.UserID,
+		LastModifiedTime:          now,
+	}
+	if err := maintenanceWindowsTable.AddMaintenanceWindow(window); err != nil {
+		return nil, err
+	}
+
+	zap.L().Debug("stored new maintenance window", zap.String("windowId", window.WindowID))
+	return window, nil
+}
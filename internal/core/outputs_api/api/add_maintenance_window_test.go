@@ -0,0 +1,56 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/outputs/models"
+)
+
+var maintenanceWindowSettings = models.MaintenanceWindowSettings{
+	DisplayName: "IAM migration",
+	StartTime:   time.Date(2020, 10, 5, 22, 0, 0, 0, time.UTC),
+	EndTime:     time.Date(2020, 10, 6, 2, 0, 0, 0, time.UTC),
+	Conditions:  models.MaintenanceConditions{AnalysisIDs: []string{"AWS.IAM.*"}},
+}
+
+func TestAddMaintenanceWindow(t *testing.T) {
+	mockMaintenanceWindows := &mockMaintenanceWindowsTable{}
+	maintenanceWindowsTable = mockMaintenanceWindows
+	mockMaintenanceWindows.On("AddMaintenanceWindow", mock.Anything).Return(nil)
+
+	result, err := (API{}).AddMaintenanceWindow(&models.AddMaintenanceWindowInput{
+		UserID:                    aws.String("userId"),
+		MaintenanceWindowSettings: maintenanceWindowSettings,
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, result.WindowID)
+	assert.Equal(t, maintenanceWindowSettings, result.MaintenanceWindowSettings)
+	assert.Equal(t, "userId", result.CreatedBy)
+	assert.Equal(t, "userId", result.LastModifiedBy)
+	assert.Equal(t, result.CreationTime, result.LastModifiedTime)
+	mockMaintenanceWindows.AssertCalled(t, "AddMaintenanceWindow", result)
+}
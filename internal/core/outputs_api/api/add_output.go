@@ -61,6 +61,8 @@ func (API) AddOutput(input *models.AddOutputInput) (*models.AddOutputOutput, err
 		OutputType:         outputType,
 		OutputConfig:       input.OutputConfig,
 		DefaultForSeverity: input.DefaultForSeverity,
+		MessageTemplate:    input.MessageTemplate,
+		RateLimit:          input.RateLimit,
 	}
 
 	alertOutputItem, err := AlertOutputToItem(alertOutput)
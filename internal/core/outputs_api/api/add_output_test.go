@@ -278,3 +278,66 @@ func TestAddOutputAsana(t *testing.T) {
 	_, err = uuid.Parse(*result.OutputID)
 	assert.NoError(t, err)
 }
+
+func TestAddOutputEmail(t *testing.T) {
+	mockEncryptionKey := &mockEncryptionKey{}
+	encryptionKey = mockEncryptionKey
+	mockOutputTable := &mockOutputTable{}
+	outputsTable = mockOutputTable
+
+	mockOutputTable.On("GetOutputByName", aws.String("my-email-destination")).Return(nil, nil)
+	mockEncryptionKey.On("EncryptConfig", mock.Anything).Return(make([]byte, 1), nil)
+	mockOutputTable.On("PutOutput", mock.Anything).Return(nil)
+
+	input := &models.AddOutputInput{
+		UserID:      aws.String("userId"),
+		DisplayName: aws.String("my-email-destination"),
+		OutputConfig: &models.OutputConfig{
+			Email: &models.EmailConfig{
+				Mode:         "smtp",
+				From:         "alerts@example.com",
+				To:           []string{"oncall@example.com"},
+				SMTPHost:     "smtp.example.com",
+				SMTPUsername: "alerts",
+				SMTPPassword: "secret",
+			},
+		},
+	}
+
+	result, err := (API{}).AddOutput(input)
+	require.NoError(t, err)
+
+	assert.Equal(t, aws.String("email"), result.OutputType)
+	assert.Equal(t, &models.OutputConfig{
+		Email: &models.EmailConfig{
+			Mode:         "smtp",
+			From:         "alerts@example.com",
+			To:           []string{"oncall@example.com"},
+			SMTPHost:     "smtp.example.com",
+			SMTPUsername: "alerts",
+			SMTPPassword: "",
+		},
+	}, result.OutputConfig)
+}
+
+func TestAddOutputEmailMissingHost(t *testing.T) {
+	mockOutputTable := &mockOutputTable{}
+	outputsTable = mockOutputTable
+	mockOutputTable.On("GetOutputByName", aws.String("my-email-destination")).Return(nil, nil)
+
+	input := &models.AddOutputInput{
+		UserID:      aws.String("userId"),
+		DisplayName: aws.String("my-email-destination"),
+		OutputConfig: &models.OutputConfig{
+			Email: &models.EmailConfig{
+				Mode: "smtp",
+				From: "alerts@example.com",
+				To:   []string{"oncall@example.com"},
+			},
+		},
+	}
+
+	result, err := (API{}).AddOutput(input)
+	require.Error(t, err)
+	assert.Nil(t, result)
+}
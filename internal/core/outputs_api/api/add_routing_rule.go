@@ -0,0 +1,51 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/lambda/outputs/models"
+)
+
+// AddRoutingRule stores a new alert routing rule
+func (API) AddRoutingRule(input *models.AddRoutingRuleInput) (*models.AddRoutingRuleOutput, error) {
+	if err := validateRoutingOutputs(input.OutputIds); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Format(time.RFC3339)
+	rule := &models.RoutingRule{
+		RuleID:              uuid.New().String(),
+		RoutingRuleSettings: input.RoutingRuleSettings,
+		CreatedBy:           *input.UserID,
+		CreationTime:        now,
+		LastModifiedBy:      *input.UserID,
+		LastModifiedTime:    now,
+	}
+	if err := routingRulesTable.AddRoutingRule(rule); err != nil {
+		return nil, err
+	}
+
+	zap.L().Debug("stored new routing rule", zap.String("ruleId", rule.RuleID))
+	return rule, nil
+}
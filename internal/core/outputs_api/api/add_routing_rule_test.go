@@ -0,0 +1,81 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/outputs/models"
+	"github.com/panther-labs/panther/internal/core/outputs_api/table"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+var routingRuleSettings = models.RoutingRuleSettings{
+	DisplayName: "critical",
+	Priority:    10,
+	Conditions:  models.RoutingConditions{Severities: []string{"CRITICAL"}},
+	OutputIds:   []string{"outputId"},
+}
+
+func TestAddRoutingRule(t *testing.T) {
+	mockOutputsTable := &mockOutputTable{}
+	outputsTable = mockOutputsTable
+	mockRoutingRules := &mockRoutingRulesTable{}
+	routingRulesTable = mockRoutingRules
+
+	mockOutputsTable.On("GetOutput", aws.String("outputId")).Return(&table.AlertOutputItem{}, nil)
+	mockRoutingRules.On("AddRoutingRule", mock.Anything).Return(nil)
+
+	result, err := (API{}).AddRoutingRule(&models.AddRoutingRuleInput{
+		UserID:              aws.String("userId"),
+		RoutingRuleSettings: routingRuleSettings,
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, result.RuleID)
+	assert.Equal(t, routingRuleSettings, result.RoutingRuleSettings)
+	assert.Equal(t, "userId", result.CreatedBy)
+	assert.Equal(t, "userId", result.LastModifiedBy)
+	assert.Equal(t, result.CreationTime, result.LastModifiedTime)
+	mockOutputsTable.AssertExpectations(t)
+	mockRoutingRules.AssertExpectations(t)
+}
+
+func TestAddRoutingRuleUnknownOutput(t *testing.T) {
+	mockOutputsTable := &mockOutputTable{}
+	outputsTable = mockOutputsTable
+	mockRoutingRules := &mockRoutingRulesTable{}
+	routingRulesTable = mockRoutingRules
+
+	mockOutputsTable.On("GetOutput", aws.String("outputId")).Return(
+		(*table.AlertOutputItem)(nil), &genericapi.DoesNotExistError{})
+
+	result, err := (API{}).AddRoutingRule(&models.AddRoutingRuleInput{
+		UserID:              aws.String("userId"),
+		RoutingRuleSettings: routingRuleSettings,
+	})
+	assert.Nil(t, result)
+	assert.IsType(t, &genericapi.InvalidInputError{}, err)
+	mockOutputsTable.AssertExpectations(t)
+	mockRoutingRules.AssertNotCalled(t, "AddRoutingRule", mock.Anything)
+}
@@ -40,4 +40,9 @@ var (
 		os.Getenv("OUTPUTS_TABLE_NAME"),
 		os.Getenv("OUTPUTS_DISPLAY_NAME_INDEX_NAME"),
 		awsSession)
+
+	routingRulesTable table.RoutingRulesAPI = table.NewRoutingRules(os.Getenv("ROUTING_RULES_TABLE_NAME"), awsSession)
+
+	maintenanceWindowsTable table.MaintenanceWindowsAPI = table.NewMaintenanceWindows(
+		os.Getenv("MAINTENANCE_WINDOWS_TABLE_NAME"), awsSession)
 )
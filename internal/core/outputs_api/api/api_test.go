@@ -22,6 +22,7 @@ import (
 	jsoniter "github.com/json-iterator/go"
 	"github.com/stretchr/testify/mock"
 
+	"github.com/panther-labs/panther/api/lambda/outputs/models"
 	"github.com/panther-labs/panther/internal/core/outputs_api/table"
 	"github.com/panther-labs/panther/pkg/encryption"
 )
@@ -46,8 +47,8 @@ func (m *mockOutputTable) GetOutputs() ([]*table.AlertOutputItem, error) {
 	return args.Get(0).([]*table.AlertOutputItem), args.Error(1)
 }
 
-func (m *mockOutputTable) UpdateOutput(input *table.AlertOutputItem) (*table.AlertOutputItem, error) {
-	args := m.Called(input)
+func (m *mockOutputTable) UpdateOutput(input *table.AlertOutputItem, removeAttributes ...string) (*table.AlertOutputItem, error) {
+	args := m.Called(input, removeAttributes)
 	return args.Get(0).(*table.AlertOutputItem), args.Error(1)
 }
 
@@ -65,6 +66,70 @@ func (m *mockOutputTable) PutOutput(output *table.AlertOutputItem) error {
 	return args.Error(0)
 }
 
+type mockRoutingRulesTable struct {
+	table.RoutingRulesTable
+	mock.Mock
+}
+
+func (m *mockRoutingRulesTable) AddRoutingRule(rule *models.RoutingRule) error {
+	args := m.Called(rule)
+	return args.Error(0)
+}
+
+func (m *mockRoutingRulesTable) ReplaceRoutingRule(rule *models.RoutingRule) error {
+	args := m.Called(rule)
+	return args.Error(0)
+}
+
+func (m *mockRoutingRulesTable) DeleteRoutingRule(ruleID *string) error {
+	args := m.Called(ruleID)
+	return args.Error(0)
+}
+
+func (m *mockRoutingRulesTable) GetRoutingRule(ruleID *string) (*models.RoutingRule, error) {
+	args := m.Called(ruleID)
+	rule, _ := args.Get(0).(*models.RoutingRule)
+	return rule, args.Error(1)
+}
+
+func (m *mockRoutingRulesTable) GetRoutingRules() ([]*models.RoutingRule, error) {
+	args := m.Called()
+	rules, _ := args.Get(0).([]*models.RoutingRule)
+	return rules, args.Error(1)
+}
+
+type mockMaintenanceWindowsTable struct {
+	table.MaintenanceWindowsTable
+	mock.Mock
+}
+
+func (m *mockMaintenanceWindowsTable) AddMaintenanceWindow(window *models.MaintenanceWindow) error {
+	args := m.Called(window)
+	return args.Error(0)
+}
+
+func (m *mockMaintenanceWindowsTable) ReplaceMaintenanceWindow(window *models.MaintenanceWindow) error {
+	args := m.Called(window)
+	return args.Error(0)
+}
+
+func (m *mockMaintenanceWindowsTable) DeleteMaintenanceWindow(windowID *string) error {
+	args := m.Called(windowID)
+	return args.Error(0)
+}
+
+func (m *mockMaintenanceWindowsTable) GetMaintenanceWindow(windowID *string) (*models.MaintenanceWindow, error) {
+	args := m.Called(windowID)
+	window, _ := args.Get(0).(*models.MaintenanceWindow)
+	return window, args.Error(1)
+}
+
+func (m *mockMaintenanceWindowsTable) GetMaintenanceWindows() ([]*models.MaintenanceWindow, error) {
+	args := m.Called()
+	windows, _ := args.Get(0).([]*models.MaintenanceWindow)
+	return windows, args.Error(1)
+}
+
 type mockEncryptionKey struct {
 	encryption.Key
 	mock.Mock
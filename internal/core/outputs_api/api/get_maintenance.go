@@ -0,0 +1,45 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"sort"
+
+	"github.com/panther-labs/panther/api/lambda/outputs/models"
+)
+
+// GetMaintenanceWindows returns all the maintenance windows, ordered by start time
+func (API) GetMaintenanceWindows(_ *models.GetMaintenanceWindowsInput) (models.GetMaintenanceWindowsOutput, error) {
+	windows, err := maintenanceWindowsTable.GetMaintenanceWindows()
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(windows, func(i, j int) bool {
+		if !windows[i].StartTime.Equal(windows[j].StartTime) {
+			return windows[i].StartTime.Before(windows[j].StartTime)
+		}
+		return windows[i].WindowID < windows[j].WindowID
+	})
+
+	if windows == nil {
+		windows = []*models.MaintenanceWindow{}
+	}
+	return windows, nil
+}
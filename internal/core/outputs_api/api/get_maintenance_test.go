@@ -0,0 +1,68 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/outputs/models"
+)
+
+func TestGetMaintenanceWindowsSorted(t *testing.T) {
+	mockMaintenanceWindows := &mockMaintenanceWindowsTable{}
+	maintenanceWindowsTable = mockMaintenanceWindows
+
+	window := func(id string, start time.Time) *models.MaintenanceWindow {
+		return &models.MaintenanceWindow{WindowID: id, MaintenanceWindowSettings: models.MaintenanceWindowSettings{StartTime: start}}
+	}
+	october := time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+	late := window("late", october.AddDate(0, 1, 0))
+	b := window("b", october)
+	a := window("a", october)
+	mockMaintenanceWindows.On("GetMaintenanceWindows").Return([]*models.MaintenanceWindow{late, b, a}, nil)
+
+	result, err := (API{}).GetMaintenanceWindows(&models.GetMaintenanceWindowsInput{})
+	require.NoError(t, err)
+	assert.Equal(t, models.GetMaintenanceWindowsOutput{a, b, late}, result)
+	mockMaintenanceWindows.AssertExpectations(t)
+}
+
+func TestGetMaintenanceWindowsEmpty(t *testing.T) {
+	mockMaintenanceWindows := &mockMaintenanceWindowsTable{}
+	maintenanceWindowsTable = mockMaintenanceWindows
+	mockMaintenanceWindows.On("GetMaintenanceWindows").Return(nil, nil)
+
+	result, err := (API{}).GetMaintenanceWindows(&models.GetMaintenanceWindowsInput{})
+	require.NoError(t, err)
+	assert.Equal(t, models.GetMaintenanceWindowsOutput{}, result)
+}
+
+func TestDeleteMaintenanceWindow(t *testing.T) {
+	mockMaintenanceWindows := &mockMaintenanceWindowsTable{}
+	maintenanceWindowsTable = mockMaintenanceWindows
+	mockMaintenanceWindows.On("DeleteMaintenanceWindow", aws.String("windowId")).Return(nil)
+
+	assert.NoError(t, (API{}).DeleteMaintenanceWindow(&models.DeleteMaintenanceWindowInput{WindowID: aws.String("windowId")}))
+	mockMaintenanceWindows.AssertExpectations(t)
+}
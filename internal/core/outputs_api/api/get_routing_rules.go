@@ -0,0 +1,49 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"sort"
+
+	"github.com/panther-labs/panther/api/lambda/outputs/models"
+)
+
+// GetRoutingRules returns all the routing rules, in the order alert delivery evaluates them
+func (API) GetRoutingRules(_ *models.GetRoutingRulesInput) (models.GetRoutingRulesOutput, error) {
+	rules, err := routingRulesTable.GetRoutingRules()
+	if err != nil {
+		return nil, err
+	}
+
+	// Ties in priority are broken by creation time, so the order is stable
+	sort.SliceStable(rules, func(i, j int) bool {
+		if rules[i].Priority != rules[j].Priority {
+			return rules[i].Priority < rules[j].Priority
+		}
+		if rules[i].CreationTime != rules[j].CreationTime {
+			return rules[i].CreationTime < rules[j].CreationTime
+		}
+		return rules[i].RuleID < rules[j].RuleID
+	})
+
+	if rules == nil {
+		rules = []*models.RoutingRule{}
+	}
+	return rules, nil
+}
@@ -0,0 +1,65 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/outputs/models"
+)
+
+func TestGetRoutingRulesSorted(t *testing.T) {
+	mockRoutingRules := &mockRoutingRulesTable{}
+	routingRulesTable = mockRoutingRules
+
+	late := &models.RoutingRule{RuleID: "late", CreationTime: "2020-02-01T00:00:00Z",
+		RoutingRuleSettings: models.RoutingRuleSettings{Priority: 1}}
+	early := &models.RoutingRule{RuleID: "early", CreationTime: "2020-01-01T00:00:00Z",
+		RoutingRuleSettings: models.RoutingRuleSettings{Priority: 1}}
+	first := &models.RoutingRule{RuleID: "first", CreationTime: "2020-03-01T00:00:00Z"}
+	mockRoutingRules.On("GetRoutingRules").Return([]*models.RoutingRule{late, early, first}, nil)
+
+	result, err := (API{}).GetRoutingRules(&models.GetRoutingRulesInput{})
+	require.NoError(t, err)
+	assert.Equal(t, models.GetRoutingRulesOutput{first, early, late}, result)
+	mockRoutingRules.AssertExpectations(t)
+}
+
+func TestGetRoutingRulesEmpty(t *testing.T) {
+	mockRoutingRules := &mockRoutingRulesTable{}
+	routingRulesTable = mockRoutingRules
+	mockRoutingRules.On("GetRoutingRules").Return(nil, nil)
+
+	result, err := (API{}).GetRoutingRules(&models.GetRoutingRulesInput{})
+	require.NoError(t, err)
+	assert.Equal(t, models.GetRoutingRulesOutput{}, result)
+}
+
+func TestDeleteRoutingRule(t *testing.T) {
+	mockRoutingRules := &mockRoutingRulesTable{}
+	routingRulesTable = mockRoutingRules
+	mockRoutingRules.On("DeleteRoutingRule", aws.String("ruleId")).Return(nil)
+
+	assert.NoError(t, (API{}).DeleteRoutingRule(&models.DeleteRoutingRuleInput{RuleID: aws.String("ruleId")}))
+	mockRoutingRules.AssertExpectations(t)
+}
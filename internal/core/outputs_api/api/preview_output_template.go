@@ -0,0 +1,66 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+
+	deliveryModels "github.com/panther-labs/panther/api/lambda/delivery/models"
+	"github.com/panther-labs/panther/api/lambda/outputs/models"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/outputs"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+// PreviewOutputTemplate renders a message template the way alert delivery would, without saving it
+func (API) PreviewOutputTemplate(input *models.PreviewOutputTemplateInput) (*models.PreviewOutputTemplateOutput, error) {
+	alert := input.Alert
+	if alert == nil {
+		alert = samplePreviewAlert()
+	}
+
+	title, body, err := outputs.RenderTemplate(input.MessageTemplate, alert)
+	if err != nil {
+		return nil, &genericapi.InvalidInputError{Message: "failed to render message template: " + err.Error()}
+	}
+	return &models.PreviewOutputTemplateOutput{Title: title, Body: body}, nil
+}
+
+// samplePreviewAlert is the alert templates are previewed with when the caller does not provide one
+func samplePreviewAlert() *deliveryModels.Alert {
+	return &deliveryModels.Alert{
+		AlertID:             aws.String("sample-alert-id"),
+		AnalysisID:          "Sample.Rule",
+		AnalysisName:        aws.String("Sample Rule"),
+		AnalysisDescription: aws.String("This is a sample alert used to preview message templates"),
+		Runbook:             aws.String("Check the activity of the user"),
+		Title:               aws.String("Suspicious activity by sample-user"),
+		Type:                deliveryModels.RuleType,
+		Severity:            "HIGH",
+		CreatedAt:           time.Now().UTC(),
+		Tags:                []string{"Sample"},
+		LogTypes:            []string{"AWS.CloudTrail"},
+		Version:             aws.String("sample-version"),
+		Context: map[string]interface{}{
+			"user":     "sample-user",
+			"sourceIP": "192.0.2.1",
+		},
+	}
+}
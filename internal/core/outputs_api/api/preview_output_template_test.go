@@ -0,0 +1,64 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/outputs/models"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+func TestPreviewOutputTemplate(t *testing.T) {
+	result, err := (API{}).PreviewOutputTemplate(&models.PreviewOutputTemplateInput{
+		MessageTemplate: &models.MessageTemplate{
+			Title: "[{{.Severity}}] {{.Name}}",
+			Body:  `{{index .AlertContext "user"}} from {{index .AlertContext "sourceIP"}}`,
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &models.PreviewOutputTemplateOutput{
+		Title: "[HIGH] Sample Rule",
+		Body:  "sample-user from 192.0.2.1",
+	}, result)
+}
+
+func TestPreviewOutputTemplateDefaults(t *testing.T) {
+	alert := samplePreviewAlert()
+	alert.Context = nil
+	result, err := (API{}).PreviewOutputTemplate(&models.PreviewOutputTemplateInput{
+		MessageTemplate: &models.MessageTemplate{Body: "{{.Runbook}}"},
+		Alert:           alert,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "New Alert: Suspicious activity by sample-user", result.Title)
+	assert.Equal(t, "Check the activity of the user", result.Body)
+}
+
+func TestPreviewOutputTemplateRenderError(t *testing.T) {
+	result, err := (API{}).PreviewOutputTemplate(&models.PreviewOutputTemplateInput{
+		MessageTemplate: &models.MessageTemplate{Body: "{{.Tags | truncate 10}}"},
+	})
+	assert.Nil(t, result)
+	require.Error(t, err)
+	assert.IsType(t, &genericapi.InvalidInputError{}, err)
+}
@@ -0,0 +1,46 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/api/lambda/outputs/models"
+)
+
+// UpdateMaintenanceWindow replaces the settings of a maintenance window
+func (API) UpdateMaintenanceWindow(input *models.UpdateMaintenanceWindowInput) (*models.UpdateMaintenanceWindowOutput, error) {
+	existingWindow, err := maintenanceWindowsTable.GetMaintenanceWindow(input.WindowID)
+	if err != nil {
+		return nil, err
+	}
+
+	window := &models.MaintenanceWindow{
+		WindowID:                  existingWindow.WindowID,
+		MaintenanceWindowSettings: input.MaintenanceWindowSettings,
+		CreatedBy:                 existingWindow.CreatedBy,
+		CreationTime:              existingWindow.CreationTime,
+		LastModifiedBy:            *input.UserID,
+		LastModifiedTime:          time.Now().Format(time.RFC3339),
+	}
+	if err = maintenanceWindowsTable.ReplaceMaintenanceWindow(window); err != nil {
+		return nil, err
+	}
+	return window, nil
+}
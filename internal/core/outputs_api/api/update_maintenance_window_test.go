@@ -0,0 +1,74 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/outputs/models"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+func TestUpdateMaintenanceWindow(t *testing.T) {
+	mockMaintenanceWindows := &mockMaintenanceWindowsTable{}
+	maintenanceWindowsTable = mockMaintenanceWindows
+
+	existing := &models.MaintenanceWindow{
+		WindowID:       "windowId",
+		CreatedBy:      "creator",
+		CreationTime:   "2020-01-01T00:00:00Z",
+		LastModifiedBy: "creator",
+	}
+	mockMaintenanceWindows.On("GetMaintenanceWindow", aws.String("windowId")).Return(existing, nil)
+	mockMaintenanceWindows.On("ReplaceMaintenanceWindow", mock.Anything).Return(nil)
+
+	result, err := (API{}).UpdateMaintenanceWindow(&models.UpdateMaintenanceWindowInput{
+		UserID:                    aws.String("userId"),
+		WindowID:                  aws.String("windowId"),
+		MaintenanceWindowSettings: maintenanceWindowSettings,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "windowId", result.WindowID)
+	assert.Equal(t, maintenanceWindowSettings, result.MaintenanceWindowSettings)
+	assert.Equal(t, "creator", result.CreatedBy)
+	assert.Equal(t, "2020-01-01T00:00:00Z", result.CreationTime)
+	assert.Equal(t, "userId", result.LastModifiedBy)
+	mockMaintenanceWindows.AssertExpectations(t)
+}
+
+func TestUpdateMaintenanceWindowDoesNotExist(t *testing.T) {
+	mockMaintenanceWindows := &mockMaintenanceWindowsTable{}
+	maintenanceWindowsTable = mockMaintenanceWindows
+
+	mockMaintenanceWindows.On("GetMaintenanceWindow", aws.String("windowId")).Return(nil, &genericapi.DoesNotExistError{})
+
+	result, err := (API{}).UpdateMaintenanceWindow(&models.UpdateMaintenanceWindowInput{
+		UserID:                    aws.String("userId"),
+		WindowID:                  aws.String("windowId"),
+		MaintenanceWindowSettings: maintenanceWindowSettings,
+	})
+	assert.Nil(t, result)
+	assert.IsType(t, &genericapi.DoesNotExistError{}, err)
+	mockMaintenanceWindows.AssertExpectations(t)
+}
@@ -24,11 +24,26 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 
 	"github.com/panther-labs/panther/api/lambda/outputs/models"
+	"github.com/panther-labs/panther/internal/core/outputs_api/table"
 	"github.com/panther-labs/panther/pkg/genericapi"
 )
 
 // UpdateOutput updates the alert output with the new values
 func (API) UpdateOutput(input *models.UpdateOutputInput) (*models.UpdateOutputOutput, error) {
+	var removeAttributes []string
+	if input.ClearMessageTemplate {
+		if input.MessageTemplate != nil {
+			return nil, &genericapi.InvalidInputError{Message: "messageTemplate cannot be set and cleared at the same time"}
+		}
+		removeAttributes = append(removeAttributes, table.MessageTemplateAttribute)
+	}
+	if input.ClearRateLimit {
+		if input.RateLimit != nil {
+			return nil, &genericapi.InvalidInputError{Message: "rateLimit cannot be set and cleared at the same time"}
+		}
+		removeAttributes = append(removeAttributes, table.RateLimitAttribute)
+	}
+
 	existingOutput, err := outputsTable.GetOutputByName(input.DisplayName)
 	if err != nil {
 		return nil, err
@@ -71,6 +86,8 @@ func (API) UpdateOutput(input *models.UpdateOutputInput) (*models.UpdateOutputOu
 		OutputID:           input.OutputID,
 		OutputConfig:       newConfig,
 		DefaultForSeverity: input.DefaultForSeverity,
+		MessageTemplate:    input.MessageTemplate,
+		RateLimit:          input.RateLimit,
 	}
 
 	alertOutputItem, err := AlertOutputToItem(alertOutput)
@@ -78,7 +95,7 @@ func (API) UpdateOutput(input *models.UpdateOutputInput) (*models.UpdateOutputOu
 		return nil, err
 	}
 
-	if alertOutputItem, err = outputsTable.UpdateOutput(alertOutputItem); err != nil {
+	if alertOutputItem, err = outputsTable.UpdateOutput(alertOutputItem, removeAttributes...); err != nil {
 		return nil, err
 	}
 
@@ -28,6 +28,7 @@ import (
 
 	"github.com/panther-labs/panther/api/lambda/outputs/models"
 	"github.com/panther-labs/panther/internal/core/outputs_api/table"
+	"github.com/panther-labs/panther/pkg/genericapi"
 )
 
 var mockUpdateOutputInput = &models.UpdateOutputInput{
@@ -54,7 +55,7 @@ func TestUpdateOutput(t *testing.T) {
 		EncryptedConfig: make([]byte, 1),
 	}
 
-	mockOutputsTable.On("UpdateOutput", mock.Anything).Return(alertOutputItem, nil)
+	mockOutputsTable.On("UpdateOutput", mock.Anything, mock.Anything).Return(alertOutputItem, nil)
 	mockOutputsTable.On("GetOutputByName", aws.String("displayName")).Return(nil, nil)
 	mockOutputsTable.On("GetOutput", aws.String("outputId")).Return(alertOutputItem, nil)
 	mockEncryptionKey.On("EncryptConfig", mock.Anything).Return(make([]byte, 1), nil)
@@ -110,7 +111,7 @@ func TestUpdateSameOutputOutput(t *testing.T) {
 		OutputID: mockUpdateOutputInput.OutputID,
 	}
 
-	mockOutputsTable.On("UpdateOutput", mock.Anything).Return(alertOutputItem, nil)
+	mockOutputsTable.On("UpdateOutput", mock.Anything, mock.Anything).Return(alertOutputItem, nil)
 	mockOutputsTable.On("GetOutputByName", aws.String("displayName")).Return(preExistingAlertItem, nil)
 	mockOutputsTable.On("GetOutput", aws.String("outputId")).Return(preExistingAlertItem, nil)
 	mockEncryptionKey.On("EncryptConfig", mock.Anything).Return(make([]byte, 1), nil)
@@ -128,3 +129,189 @@ func TestUpdateSameOutputOutput(t *testing.T) {
 
 	mockOutputsTable.AssertExpectations(t)
 }
+
+func TestMergeConfigsKeepsRedactedEmailPassword(t *testing.T) {
+	oldConfig := &models.OutputConfig{
+		Email: &models.EmailConfig{
+			Mode:         "smtp",
+			From:         "alerts@example.com",
+			To:           []string{"oncall@example.com"},
+			SMTPHost:     "smtp.example.com",
+			SMTPPort:     aws.Int(587),
+			SMTPUsername: "alerts",
+			SMTPPassword: "secret",
+		},
+	}
+	newConfig := &models.OutputConfig{
+		Email: &models.EmailConfig{
+			Mode:         "smtp",
+			From:         "alerts@example.com",
+			To:           []string{"oncall@example.com", "security@example.com"},
+			SMTPHost:     "smtp.example.com",
+			SMTPPort:     aws.Int(465),
+			SMTPTLS:      "tls",
+			SMTPUsername: "alerts",
+		},
+	}
+
+	result, err := mergeConfigs(oldConfig, newConfig)
+	require.NoError(t, err)
+	assert.Equal(t, &models.OutputConfig{
+		Email: &models.EmailConfig{
+			Mode:         "smtp",
+			From:         "alerts@example.com",
+			To:           []string{"oncall@example.com", "security@example.com"},
+			SMTPHost:     "smtp.example.com",
+			SMTPPort:     aws.Int(465),
+			SMTPTLS:      "tls",
+			SMTPUsername: "alerts",
+			SMTPPassword: "secret",
+		},
+	}, result)
+}
+
+func TestMergeConfigsKeepsOmittedPortAndFlags(t *testing.T) {
+	oldConfig := &models.OutputConfig{
+		Email: &models.EmailConfig{
+			Mode:     "smtp",
+			From:     "alerts@example.com",
+			To:       []string{"oncall@example.com"},
+			SMTPHost: "smtp.example.com",
+			SMTPPort: aws.Int(2525),
+		},
+	}
+	newConfig := &models.OutputConfig{
+		Email: &models.EmailConfig{
+			Mode:     "smtp",
+			From:     "alerts@example.com",
+			To:       []string{"security@example.com"},
+			SMTPHost: "smtp.example.com",
+		},
+	}
+
+	result, err := mergeConfigs(oldConfig, newConfig)
+	require.NoError(t, err)
+	assert.Equal(t, aws.Int(2525), result.Email.SMTPPort)
+	assert.Equal(t, []string{"security@example.com"}, result.Email.To)
+
+	oldConfig = &models.OutputConfig{
+		Splunk: &models.SplunkConfig{
+			HecURL:        "https://splunk.example.com:8088/services/collector/event",
+			Token:         "token",
+			SkipTLSVerify: aws.Bool(true),
+		},
+	}
+	newConfig = &models.OutputConfig{
+		Splunk: &models.SplunkConfig{
+			HecURL: "https://splunk.example.com:8088/services/collector/event",
+			Index:  "panther",
+		},
+	}
+	result, err = mergeConfigs(oldConfig, newConfig)
+	require.NoError(t, err)
+	assert.Equal(t, aws.Bool(true), result.Splunk.SkipTLSVerify)
+	assert.Equal(t, "token", result.Splunk.Token)
+	assert.Equal(t, "panther", result.Splunk.Index)
+
+	// An explicit false turns the flag off
+	newConfig.Splunk.SkipTLSVerify = aws.Bool(false)
+	result, err = mergeConfigs(oldConfig, newConfig)
+	require.NoError(t, err)
+	assert.Equal(t, aws.Bool(false), result.Splunk.SkipTLSVerify)
+}
+
+func TestUpdateOutputClearMessageTemplate(t *testing.T) {
+	mockOutputsTable := &mockOutputTable{}
+	outputsTable = mockOutputsTable
+	mockEncryptionKey := &mockEncryptionKey{}
+	encryptionKey = mockEncryptionKey
+
+	alertOutputItem := &table.AlertOutputItem{
+		OutputID:        aws.String("outputId"),
+		DisplayName:     aws.String("displayName"),
+		OutputType:      aws.String("sns"),
+		EncryptedConfig: make([]byte, 1),
+	}
+	input := &models.UpdateOutputInput{
+		OutputID:             aws.String("outputId"),
+		DisplayName:          aws.String("displayName"),
+		UserID:               aws.String("userId"),
+		ClearMessageTemplate: true,
+	}
+
+	mockOutputsTable.On("GetOutputByName", aws.String("displayName")).Return(nil, nil)
+	mockOutputsTable.On("UpdateOutput", mock.Anything, []string{table.MessageTemplateAttribute}).Return(alertOutputItem, nil)
+	mockEncryptionKey.On("DecryptConfig", mock.Anything, mock.Anything).Return(nil)
+
+	result, err := (API{}).UpdateOutput(input)
+	require.NoError(t, err)
+	assert.Nil(t, result.MessageTemplate)
+	mockOutputsTable.AssertExpectations(t)
+}
+
+func TestUpdateOutputSetAndClearMessageTemplate(t *testing.T) {
+	mockOutputsTable := &mockOutputTable{}
+	outputsTable = mockOutputsTable
+
+	input := &models.UpdateOutputInput{
+		OutputID:             aws.String("outputId"),
+		DisplayName:          aws.String("displayName"),
+		UserID:               aws.String("userId"),
+		MessageTemplate:      &models.MessageTemplate{Title: "{{.Name}}"},
+		ClearMessageTemplate: true,
+	}
+
+	result, err := (API{}).UpdateOutput(input)
+	assert.Nil(t, result)
+	assert.IsType(t, &genericapi.InvalidInputError{}, err)
+	mockOutputsTable.AssertExpectations(t)
+}
+
+func TestUpdateOutputClearRateLimit(t *testing.T) {
+	mockOutputsTable := &mockOutputTable{}
+	outputsTable = mockOutputsTable
+	mockEncryptionKey := &mockEncryptionKey{}
+	encryptionKey = mockEncryptionKey
+
+	alertOutputItem := &table.AlertOutputItem{
+		OutputID:        aws.String("outputId"),
+		DisplayName:     aws.String("displayName"),
+		OutputType:      aws.String("sns"),
+		EncryptedConfig: make([]byte, 1),
+		MessageTemplate: &models.MessageTemplate{Title: "{{.Name}}"},
+	}
+	input := &models.UpdateOutputInput{
+		OutputID:       aws.String("outputId"),
+		DisplayName:    aws.String("displayName"),
+		UserID:         aws.String("userId"),
+		ClearRateLimit: true,
+	}
+
+	mockOutputsTable.On("GetOutputByName", aws.String("displayName")).Return(nil, nil)
+	mockOutputsTable.On("UpdateOutput", mock.Anything, []string{table.RateLimitAttribute}).Return(alertOutputItem, nil)
+	mockEncryptionKey.On("DecryptConfig", mock.Anything, mock.Anything).Return(nil)
+
+	result, err := (API{}).UpdateOutput(input)
+	require.NoError(t, err)
+	assert.Nil(t, result.RateLimit)
+	assert.Equal(t, &models.MessageTemplate{Title: "{{.Name}}"}, result.MessageTemplate)
+	mockOutputsTable.AssertExpectations(t)
+}
+
+func TestUpdateOutputSetAndClearRateLimit(t *testing.T) {
+	mockOutputsTable := &mockOutputTable{}
+	outputsTable = mockOutputsTable
+
+	input := &models.UpdateOutputInput{
+		OutputID:       aws.String("outputId"),
+		DisplayName:    aws.String("displayName"),
+		UserID:         aws.String("userId"),
+		RateLimit:      &models.RateLimit{MaxNotifications: 10, WindowSeconds: 300},
+		ClearRateLimit: true,
+	}
+
+	result, err := (API{}).UpdateOutput(input)
+	assert.Nil(t, result)
+	assert.IsType(t, &genericapi.InvalidInputError{}, err)
+	mockOutputsTable.AssertExpectations(t)
+}
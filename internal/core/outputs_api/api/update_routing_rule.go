@@ -0,0 +1,50 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/api/lambda/outputs/models"
+)
+
+// UpdateRoutingRule replaces the settings of a routing rule
+func (API) UpdateRoutingRule(input *models.UpdateRoutingRuleInput) (*models.UpdateRoutingRuleOutput, error) {
+	existingRule, err := routingRulesTable.GetRoutingRule(input.RuleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = validateRoutingOutputs(input.OutputIds); err != nil {
+		return nil, err
+	}
+
+	rule := &models.RoutingRule{
+		RuleID:              existingRule.RuleID,
+		RoutingRuleSettings: input.RoutingRuleSettings,
+		CreatedBy:           existingRule.CreatedBy,
+		CreationTime:        existingRule.CreationTime,
+		LastModifiedBy:      *input.UserID,
+		LastModifiedTime:    time.Now().Format(time.RFC3339),
+	}
+	if err = routingRulesTable.ReplaceRoutingRule(rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
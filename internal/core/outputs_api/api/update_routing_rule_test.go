@@ -0,0 +1,79 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/outputs/models"
+	"github.com/panther-labs/panther/internal/core/outputs_api/table"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+func TestUpdateRoutingRule(t *testing.T) {
+	mockOutputsTable := &mockOutputTable{}
+	outputsTable = mockOutputsTable
+	mockRoutingRules := &mockRoutingRulesTable{}
+	routingRulesTable = mockRoutingRules
+
+	existing := &models.RoutingRule{
+		RuleID:         "ruleId",
+		CreatedBy:      "creator",
+		CreationTime:   "2020-01-01T00:00:00Z",
+		LastModifiedBy: "creator",
+	}
+	mockRoutingRules.On("GetRoutingRule", aws.String("ruleId")).Return(existing, nil)
+	mockOutputsTable.On("GetOutput", aws.String("outputId")).Return(&table.AlertOutputItem{}, nil)
+	mockRoutingRules.On("ReplaceRoutingRule", mock.Anything).Return(nil)
+
+	result, err := (API{}).UpdateRoutingRule(&models.UpdateRoutingRuleInput{
+		UserID:              aws.String("userId"),
+		RuleID:              aws.String("ruleId"),
+		RoutingRuleSettings: routingRuleSettings,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "ruleId", result.RuleID)
+	assert.Equal(t, routingRuleSettings, result.RoutingRuleSettings)
+	assert.Equal(t, "creator", result.CreatedBy)
+	assert.Equal(t, "2020-01-01T00:00:00Z", result.CreationTime)
+	assert.Equal(t, "userId", result.LastModifiedBy)
+	mockOutputsTable.AssertExpectations(t)
+	mockRoutingRules.AssertExpectations(t)
+}
+
+func TestUpdateRoutingRuleDoesNotExist(t *testing.T) {
+	mockRoutingRules := &mockRoutingRulesTable{}
+	routingRulesTable = mockRoutingRules
+
+	mockRoutingRules.On("GetRoutingRule", aws.String("ruleId")).Return(nil, &genericapi.DoesNotExistError{})
+
+	result, err := (API{}).UpdateRoutingRule(&models.UpdateRoutingRuleInput{
+		UserID:              aws.String("userId"),
+		RuleID:              aws.String("ruleId"),
+		RoutingRuleSettings: routingRuleSettings,
+	})
+	assert.Nil(t, result)
+	assert.IsType(t, &genericapi.DoesNotExistError{}, err)
+	mockRoutingRules.AssertExpectations(t)
+}
@@ -42,6 +42,8 @@ func AlertOutputToItem(input *models.AlertOutput) (*table.AlertOutputItem, error
 		OutputID:           input.OutputID,
 		OutputType:         input.OutputType,
 		DefaultForSeverity: input.DefaultForSeverity,
+		MessageTemplate:    input.MessageTemplate,
+		RateLimit:          input.RateLimit,
 	}
 
 	if input.OutputConfig != nil {
@@ -66,6 +68,8 @@ func ItemToAlertOutput(input *table.AlertOutputItem) (alertOutput *models.AlertO
 		OutputID:           input.OutputID,
 		OutputType:         input.OutputType,
 		DefaultForSeverity: input.DefaultForSeverity,
+		MessageTemplate:    input.MessageTemplate,
+		RateLimit:          input.RateLimit,
 	}
 
 	// Decrypt the output before returning to the caller
@@ -103,6 +107,20 @@ func redactOutput(outputConfig *models.OutputConfig) {
 	if outputConfig.CustomWebhook != nil {
 		outputConfig.CustomWebhook.WebhookURL = redacted
 	}
+	if outputConfig.Email != nil {
+		outputConfig.Email.SMTPPassword = redacted
+	}
+	if outputConfig.ServiceNow != nil {
+		outputConfig.ServiceNow.Password = redacted
+		outputConfig.ServiceNow.ClientSecret = redacted
+	}
+	if outputConfig.Splunk != nil {
+		outputConfig.Splunk.Token = redacted
+	}
+	if outputConfig.Elasticsearch != nil {
+		outputConfig.Elasticsearch.Password = redacted
+		outputConfig.Elasticsearch.APIKey = redacted
+	}
 }
 
 func getOutputType(outputConfig *models.OutputConfig) (*string, error) {
@@ -136,6 +154,24 @@ func getOutputType(outputConfig *models.OutputConfig) (*string, error) {
 	if outputConfig.CustomWebhook != nil {
 		return aws.String("customwebhook"), nil
 	}
+	if outputConfig.Email != nil {
+		return aws.String("email"), nil
+	}
+	if outputConfig.ServiceNow != nil {
+		return aws.String("servicenow"), nil
+	}
+	if outputConfig.Splunk != nil {
+		return aws.String("splunk"), nil
+	}
+	if outputConfig.Elasticsearch != nil {
+		return aws.String("elasticsearch"), nil
+	}
+	if outputConfig.Firehose != nil {
+		return aws.String("firehose"), nil
+	}
+	if outputConfig.S3 != nil {
+		return aws.String("s3"), nil
+	}
 
 	return nil, errors.New("no valid output configuration specified for alert output")
 }
@@ -152,7 +188,7 @@ func mergeConfigs(oldConfig, newConfig *models.OutputConfig) (*models.OutputConf
 		}
 	}
 	// Turn the bytes into a map so we can work with it more easily
-	var oldMap map[string]map[string]string
+	var oldMap map[string]map[string]interface{}
 	err = jsoniter.Unmarshal(oldBytes, &oldMap)
 	if err != nil {
 		return nil, &genericapi.InternalError{
@@ -167,7 +203,7 @@ func mergeConfigs(oldConfig, newConfig *models.OutputConfig) (*models.OutputConf
 			Message: "Unable to extract the new configuration",
 		}
 	}
-	var newMap map[string]map[string]string
+	var newMap map[string]map[string]interface{}
 	err = jsoniter.Unmarshal(newBytes, &newMap)
 	if err != nil {
 		return nil, &genericapi.InternalError{
@@ -178,7 +214,9 @@ func mergeConfigs(oldConfig, newConfig *models.OutputConfig) (*models.OutputConf
 	// Overwrite the existing configurations with the new configurations
 	for configType, configMap := range newMap {
 		for configKey, configValue := range configMap {
-			if configValue == "" {
+			// Empty strings (such as redacted secrets) and nulls (such as omitted optional numbers and flags)
+			// keep the existing value
+			if configValue == "" || configValue == nil {
 				continue
 			}
 			oldMap[configType][configKey] = configValue
@@ -248,7 +286,56 @@ func validateConfigByType(config *models.OutputConfig, outputType *string) error
 		if config.CustomWebhook.WebhookURL != "" {
 			return nil
 		}
+	case "email":
+		email := config.Email
+		if email.From == "" || len(email.To) == 0 {
+			break
+		}
+		if email.Mode == "ses" || (email.Mode == "smtp" && email.SMTPHost != "") {
+			return nil
+		}
+	case "servicenow":
+		serviceNow := config.ServiceNow
+		if serviceNow.InstanceURL == "" || serviceNow.UserName == "" || serviceNow.Password == "" {
+			break
+		}
+		if serviceNow.AuthType != "oauth" || (serviceNow.ClientID != "" && serviceNow.ClientSecret != "") {
+			return nil
+		}
+	case "splunk":
+		if config.Splunk.HecURL != "" && config.Splunk.Token != "" {
+			return nil
+		}
+	case "elasticsearch":
+		elastic := config.Elasticsearch
+		if elastic.URL == "" || elastic.Index == "" {
+			break
+		}
+		if elastic.AuthType != "apikey" || elastic.APIKey != "" {
+			return nil
+		}
+	case "firehose":
+		if config.Firehose.StreamArn != "" {
+			return nil
+		}
+	case "s3":
+		if config.S3.BucketName != "" {
+			return nil
+		}
 	}
 
 	return errors.New("invalid output configuration specified for alert output, missing required fields")
 }
+
+// validateRoutingOutputs checks that the outputs selected by a routing rule exist
+func validateRoutingOutputs(outputIDs []string) error {
+	for _, outputID := range outputIDs {
+		if _, err := outputsTable.GetOutput(aws.String(outputID)); err != nil {
+			if _, ok := err.(*genericapi.DoesNotExistError); ok {
+				return &genericapi.InvalidInputError{Message: "Destination " + outputID + " does not exist"}
+			}
+			return err
+		}
+	}
+	return nil
+}
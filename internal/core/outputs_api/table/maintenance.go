@@ -0,0 +1,152 @@
+package table
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+
+	"github.com/panther-labs/panther/api/lambda/outputs/models"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+// MaintenanceWindowsAPI defines the interface for the maintenance windows table which can be used for mocking.
+type MaintenanceWindowsAPI interface {
+	AddMaintenanceWindow(*models.MaintenanceWindow) error
+	ReplaceMaintenanceWindow(*models.MaintenanceWindow) error
+	DeleteMaintenanceWindow(*string) error
+	GetMaintenanceWindow(*string) (*models.MaintenanceWindow, error)
+	GetMaintenanceWindows() ([]*models.MaintenanceWindow, error)
+}
+
+// MaintenanceWindowsTable encapsulates a connection to the Dynamo maintenance windows table.
+type MaintenanceWindowsTable struct {
+	Name   *string
+	client dynamodbiface.DynamoDBAPI
+}
+
+// NewMaintenanceWindows creates an AWS client to interface with the maintenance windows table.
+func NewMaintenanceWindows(name string, sess *session.Session) *MaintenanceWindowsTable {
+	return &MaintenanceWindowsTable{
+		Name:   aws.String(name),
+		client: dynamodb.New(sess),
+	}
+}
+
+// AddMaintenanceWindow saves a new maintenance window to the table.
+func (table *MaintenanceWindowsTable) AddMaintenanceWindow(window *models.MaintenanceWindow) error {
+	return table.putMaintenanceWindow(window, "attribute_not_exists(windowId)")
+}
+
+// ReplaceMaintenanceWindow overwrites an existing maintenance window.
+func (table *MaintenanceWindowsTable) ReplaceMaintenanceWindow(window *models.MaintenanceWindow) error {
+	return table.putMaintenanceWindow(window, "attribute_exists(windowId)")
+}
+
+func (table *MaintenanceWindowsTable) putMaintenanceWindow(window *models.MaintenanceWindow, condition string) error {
+	item, err := dynamodbattribute.MarshalMap(window)
+	if err != nil {
+		return &genericapi.InternalError{Message: "failed to marshal MaintenanceWindow to a dynamo item: " + err.Error()}
+	}
+
+	input := &dynamodb.PutItemInput{
+		Item:                item,
+		TableName:           table.Name,
+		ConditionExpression: aws.String(condition),
+	}
+
+	if _, err = table.client.PutItem(input); err != nil {
+		aerr, ok := err.(awserr.Error)
+		if ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return &genericapi.DoesNotExistError{Message: "windowId=" + window.WindowID}
+		}
+		return &genericapi.AWSError{Method: "dynamodb.PutItem", Err: err}
+	}
+	return nil
+}
+
+// DeleteMaintenanceWindow removes a maintenance window from the table.
+func (table *MaintenanceWindowsTable) DeleteMaintenanceWindow(windowID *string) error {
+	_, err := table.client.DeleteItem(&dynamodb.DeleteItemInput{
+		TableName:           table.Name,
+		Key:                 DynamoItem{"windowId": {S: windowID}},
+		ConditionExpression: aws.String("attribute_exists(windowId)"),
+	})
+
+	if err != nil {
+		aerr, ok := err.(awserr.Error)
+		if ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return &genericapi.DoesNotExistError{Message: "windowId=" + *windowID + " does not exist"}
+		}
+		return &genericapi.AWSError{Method: "dynamodb.DeleteItem", Err: err}
+	}
+	return nil
+}
+
+// GetMaintenanceWindow returns a single maintenance window
+func (table *MaintenanceWindowsTable) GetMaintenanceWindow(windowID *string) (*models.MaintenanceWindow, error) {
+	result, err := table.client.GetItem(&dynamodb.GetItemInput{
+		TableName: table.Name,
+		Key:       DynamoItem{"windowId": {S: windowID}},
+	})
+	if err != nil {
+		return nil, &genericapi.AWSError{Method: "dynamodb.GetItem", Err: err}
+	}
+
+	if result.Item == nil {
+		return nil, &genericapi.DoesNotExistError{Message: "windowId=" + *windowID}
+	}
+
+	var window models.MaintenanceWindow
+	if err = dynamodbattribute.UnmarshalMap(result.Item, &window); err != nil {
+		return nil, &genericapi.InternalError{
+			Message: "failed to unmarshal dynamo item to a MaintenanceWindow: " + err.Error()}
+	}
+	return &window, nil
+}
+
+// GetMaintenanceWindows returns all the maintenance windows, in no particular order
+func (table *MaintenanceWindowsTable) GetMaintenanceWindows() (windows []*models.MaintenanceWindow, err error) {
+	scanInput := &dynamodb.ScanInput{
+		TableName: table.Name,
+	}
+
+	for {
+		var scanOutput *dynamodb.ScanOutput
+		if scanOutput, err = table.client.Scan(scanInput); err != nil {
+			return nil, &genericapi.AWSError{Method: "dynamodb.Scan", Err: err}
+		}
+
+		var partial []*models.MaintenanceWindow
+		if err = dynamodbattribute.UnmarshalListOfMaps(scanOutput.Items, &partial); err != nil {
+			return nil, &genericapi.InternalError{
+				Message: "failed to unmarshal dynamo item to a MaintenanceWindow: " + err.Error()}
+		}
+		windows = append(windows, partial...)
+
+		if scanOutput.LastEvaluatedKey == nil {
+			return windows, nil
+		}
+		scanInput.ExclusiveStartKey = scanOutput.LastEvaluatedKey
+	}
+}
@@ -0,0 +1,96 @@
+package table
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/outputs/models"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+var testMaintenanceWindow = &models.MaintenanceWindow{
+	WindowID: "5a2f8c1e-7d3b-4e6a-9c4d-2b1e0f9a8c77",
+	MaintenanceWindowSettings: models.MaintenanceWindowSettings{
+		DisplayName: "IAM migration",
+		StartTime:   time.Date(2020, 10, 5, 22, 0, 0, 0, time.UTC),
+		EndTime:     time.Date(2020, 10, 6, 2, 0, 0, 0, time.UTC),
+		Recurrence:  &models.MaintenanceRecurrence{Frequency: "WEEKLY"},
+		Conditions:  models.MaintenanceConditions{AnalysisIDs: []string{"AWS.IAM.*"}},
+	},
+	CreatedBy: "userId",
+}
+
+func TestAddMaintenanceWindow(t *testing.T) {
+	dynamoDBClient := &mockDynamoDB{}
+	table := &MaintenanceWindowsTable{client: dynamoDBClient, Name: aws.String("TableName")}
+
+	item, err := dynamodbattribute.MarshalMap(testMaintenanceWindow)
+	require.NoError(t, err)
+	// Times are stored as RFC3339 strings
+	assert.Equal(t, "2020-10-05T22:00:00Z", aws.StringValue(item["startTime"].S))
+
+	expectedPutItemInput := &dynamodb.PutItemInput{
+		Item:                item,
+		TableName:           aws.String("TableName"),
+		ConditionExpression: aws.String("attribute_not_exists(windowId)"),
+	}
+	dynamoDBClient.On("PutItem", expectedPutItemInput).Return(&dynamodb.PutItemOutput{}, nil)
+
+	assert.NoError(t, table.AddMaintenanceWindow(testMaintenanceWindow))
+	dynamoDBClient.AssertExpectations(t)
+}
+
+func TestReplaceMaintenanceWindowDoesNotExist(t *testing.T) {
+	dynamoDBClient := &mockDynamoDB{}
+	table := &MaintenanceWindowsTable{client: dynamoDBClient, Name: aws.String("TableName")}
+
+	dynamoDBClient.On("PutItem", mock.MatchedBy(func(input *dynamodb.PutItemInput) bool {
+		return aws.StringValue(input.ConditionExpression) == "attribute_exists(windowId)"
+	})).Return(&dynamodb.PutItemOutput{},
+		awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "attribute does not exist", nil))
+
+	err := table.ReplaceMaintenanceWindow(testMaintenanceWindow)
+	assert.IsType(t, &genericapi.DoesNotExistError{}, err)
+	dynamoDBClient.AssertExpectations(t)
+}
+
+func TestGetMaintenanceWindows(t *testing.T) {
+	dynamoDBClient := &mockDynamoDB{}
+	table := &MaintenanceWindowsTable{client: dynamoDBClient, Name: aws.String("TableName")}
+
+	item, err := dynamodbattribute.MarshalMap(testMaintenanceWindow)
+	require.NoError(t, err)
+	dynamoDBClient.On("Scan", &dynamodb.ScanInput{TableName: aws.String("TableName")}).Return(
+		&dynamodb.ScanOutput{Items: []DynamoItem{item}}, nil).Once()
+
+	result, err := table.GetMaintenanceWindows()
+	require.NoError(t, err)
+	assert.Equal(t, []*models.MaintenanceWindow{testMaintenanceWindow}, result)
+	dynamoDBClient.AssertExpectations(t)
+}
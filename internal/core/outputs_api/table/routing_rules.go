@@ -0,0 +1,152 @@
+package table
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+
+	"github.com/panther-labs/panther/api/lambda/outputs/models"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+// RoutingRulesAPI defines the interface for the routing rules table which can be used for mocking.
+type RoutingRulesAPI interface {
+	AddRoutingRule(*models.RoutingRule) error
+	ReplaceRoutingRule(*models.RoutingRule) error
+	DeleteRoutingRule(*string) error
+	GetRoutingRule(*string) (*models.RoutingRule, error)
+	GetRoutingRules() ([]*models.RoutingRule, error)
+}
+
+// RoutingRulesTable encapsulates a connection to the Dynamo routing rules table.
+type RoutingRulesTable struct {
+	Name   *string
+	client dynamodbiface.DynamoDBAPI
+}
+
+// NewRoutingRules creates an AWS client to interface with the routing rules table.
+func NewRoutingRules(name string, sess *session.Session) *RoutingRulesTable {
+	return &RoutingRulesTable{
+		Name:   aws.String(name),
+		client: dynamodb.New(sess),
+	}
+}
+
+// AddRoutingRule saves a new routing rule to the table.
+func (table *RoutingRulesTable) AddRoutingRule(rule *models.RoutingRule) error {
+	return table.putRoutingRule(rule, "attribute_not_exists(ruleId)")
+}
+
+// ReplaceRoutingRule overwrites an existing routing rule.
+func (table *RoutingRulesTable) ReplaceRoutingRule(rule *models.RoutingRule) error {
+	return table.putRoutingRule(rule, "attribute_exists(ruleId)")
+}
+
+func (table *RoutingRulesTable) putRoutingRule(rule *models.RoutingRule, condition string) error {
+	item, err := dynamodbattribute.MarshalMap(rule)
+	if err != nil {
+		return &genericapi.InternalError{Message: "failed to marshal RoutingRule to a dynamo item: " + err.Error()}
+	}
+
+	input := &dynamodb.PutItemInput{
+		Item:                item,
+		TableName:           table.Name,
+		ConditionExpression: aws.String(condition),
+	}
+
+	if _, err = table.client.PutItem(input); err != nil {
+		aerr, ok := err.(awserr.Error)
+		if ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return &genericapi.DoesNotExistError{Message: "ruleId=" + rule.RuleID}
+		}
+		return &genericapi.AWSError{Method: "dynamodb.PutItem", Err: err}
+	}
+	return nil
+}
+
+// DeleteRoutingRule removes a routing rule from the table.
+func (table *RoutingRulesTable) DeleteRoutingRule(ruleID *string) error {
+	_, err := table.client.DeleteItem(&dynamodb.DeleteItemInput{
+		TableName:           table.Name,
+		Key:                 DynamoItem{"ruleId": {S: ruleID}},
+		ConditionExpression: aws.String("attribute_exists(ruleId)"),
+	})
+
+	if err != nil {
+		aerr, ok := err.(awserr.Error)
+		if ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return &genericapi.DoesNotExistError{Message: "ruleId=" + *ruleID + " does not exist"}
+		}
+		return &genericapi.AWSError{Method: "dynamodb.DeleteItem", Err: err}
+	}
+	return nil
+}
+
+// GetRoutingRule returns a single routing rule
+func (table *RoutingRulesTable) GetRoutingRule(ruleID *string) (*models.RoutingRule, error) {
+	result, err := table.client.GetItem(&dynamodb.GetItemInput{
+		TableName: table.Name,
+		Key:       DynamoItem{"ruleId": {S: ruleID}},
+	})
+	if err != nil {
+		return nil, &genericapi.AWSError{Method: "dynamodb.GetItem", Err: err}
+	}
+
+	if result.Item == nil {
+		return nil, &genericapi.DoesNotExistError{Message: "ruleId=" + *ruleID}
+	}
+
+	var rule models.RoutingRule
+	if err = dynamodbattribute.UnmarshalMap(result.Item, &rule); err != nil {
+		return nil, &genericapi.InternalError{
+			Message: "failed to unmarshal dynamo item to a RoutingRule: " + err.Error()}
+	}
+	return &rule, nil
+}
+
+// GetRoutingRules returns all the routing rules, in no particular order
+func (table *RoutingRulesTable) GetRoutingRules() (rules []*models.RoutingRule, err error) {
+	scanInput := &dynamodb.ScanInput{
+		TableName: table.Name,
+	}
+
+	for {
+		var scanOutput *dynamodb.ScanOutput
+		if scanOutput, err = table.client.Scan(scanInput); err != nil {
+			return nil, &genericapi.AWSError{Method: "dynamodb.Scan", Err: err}
+		}
+
+		var partial []*models.RoutingRule
+		if err = dynamodbattribute.UnmarshalListOfMaps(scanOutput.Items, &partial); err != nil {
+			return nil, &genericapi.InternalError{
+				Message: "failed to unmarshal dynamo item to a RoutingRule: " + err.Error()}
+		}
+		rules = append(rules, partial...)
+
+		if scanOutput.LastEvaluatedKey == nil {
+			return rules, nil
+		}
+		scanInput.ExclusiveStartKey = scanOutput.LastEvaluatedKey
+	}
+}
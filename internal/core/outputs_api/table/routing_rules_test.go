@@ -0,0 +1,143 @@
+package table
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/outputs/models"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+var testRoutingRule = &models.RoutingRule{
+	RuleID: "2b7c7e2f-3d4c-4d1b-8a4c-0f6f7f5e8a11",
+	RoutingRuleSettings: models.RoutingRuleSettings{
+		DisplayName: "critical",
+		Priority:    10,
+		Conditions: models.RoutingConditions{
+			Severities: []string{"CRITICAL"},
+			Schedule: &models.RoutingSchedule{
+				Timezone: "UTC",
+				Windows:  []models.RoutingTimeWindow{{StartTime: "09:00", EndTime: "17:00"}},
+			},
+		},
+		OutputIds: []string{"7d1c5854-f3ea-491c-8a52-0aa0d58cb456"},
+	},
+	CreatedBy: "userId",
+}
+
+func TestAddRoutingRule(t *testing.T) {
+	dynamoDBClient := &mockDynamoDB{}
+	table := &RoutingRulesTable{client: dynamoDBClient, Name: aws.String("TableName")}
+
+	item, err := dynamodbattribute.MarshalMap(testRoutingRule)
+	require.NoError(t, err)
+	// Settings are stored as top level attributes
+	assert.Equal(t, "critical", aws.StringValue(item["displayName"].S))
+
+	expectedPutItemInput := &dynamodb.PutItemInput{
+		Item:                item,
+		TableName:           aws.String("TableName"),
+		ConditionExpression: aws.String("attribute_not_exists(ruleId)"),
+	}
+	dynamoDBClient.On("PutItem", expectedPutItemInput).Return(&dynamodb.PutItemOutput{}, nil)
+
+	assert.NoError(t, table.AddRoutingRule(testRoutingRule))
+	dynamoDBClient.AssertExpectations(t)
+}
+
+func TestReplaceRoutingRuleDoesNotExist(t *testing.T) {
+	dynamoDBClient := &mockDynamoDB{}
+	table := &RoutingRulesTable{client: dynamoDBClient, Name: aws.String("TableName")}
+
+	dynamoDBClient.On("PutItem", mock.MatchedBy(func(input *dynamodb.PutItemInput) bool {
+		return aws.StringValue(input.ConditionExpression) == "attribute_exists(ruleId)"
+	})).Return(&dynamodb.PutItemOutput{},
+		awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "attribute does not exist", nil))
+
+	err := table.ReplaceRoutingRule(testRoutingRule)
+	assert.IsType(t, &genericapi.DoesNotExistError{}, err)
+	dynamoDBClient.AssertExpectations(t)
+}
+
+func TestDeleteRoutingRuleServiceError(t *testing.T) {
+	dynamoDBClient := &mockDynamoDB{}
+	table := &RoutingRulesTable{client: dynamoDBClient, Name: aws.String("TableName")}
+
+	dynamoDBClient.On("DeleteItem", &dynamodb.DeleteItemInput{
+		TableName:           aws.String("TableName"),
+		Key:                 DynamoItem{"ruleId": {S: aws.String("ruleId")}},
+		ConditionExpression: aws.String("attribute_exists(ruleId)"),
+	}).Return(&dynamodb.DeleteItemOutput{}, awserr.New(dynamodb.ErrCodeResourceNotFoundException, "no table", nil))
+
+	err := table.DeleteRoutingRule(aws.String("ruleId"))
+	assert.IsType(t, &genericapi.AWSError{}, err)
+	dynamoDBClient.AssertExpectations(t)
+}
+
+func TestGetRoutingRule(t *testing.T) {
+	dynamoDBClient := &mockDynamoDB{}
+	table := &RoutingRulesTable{client: dynamoDBClient, Name: aws.String("TableName")}
+
+	item, err := dynamodbattribute.MarshalMap(testRoutingRule)
+	require.NoError(t, err)
+	dynamoDBClient.On("GetItem", mock.Anything).Return(&dynamodb.GetItemOutput{Item: item}, nil)
+
+	result, err := table.GetRoutingRule(aws.String(testRoutingRule.RuleID))
+	require.NoError(t, err)
+	assert.Equal(t, testRoutingRule, result)
+	dynamoDBClient.AssertExpectations(t)
+}
+
+func TestGetRoutingRuleDoesNotExist(t *testing.T) {
+	dynamoDBClient := &mockDynamoDB{}
+	table := &RoutingRulesTable{client: dynamoDBClient, Name: aws.String("TableName")}
+
+	dynamoDBClient.On("GetItem", mock.Anything).Return(&dynamodb.GetItemOutput{}, nil)
+
+	result, err := table.GetRoutingRule(aws.String("ruleId"))
+	assert.Nil(t, result)
+	assert.IsType(t, &genericapi.DoesNotExistError{}, err)
+}
+
+func TestGetRoutingRules(t *testing.T) {
+	dynamoDBClient := &mockDynamoDB{}
+	table := &RoutingRulesTable{client: dynamoDBClient, Name: aws.String("TableName")}
+
+	item, err := dynamodbattribute.MarshalMap(testRoutingRule)
+	require.NoError(t, err)
+	lastKey := DynamoItem{"ruleId": {S: aws.String("ruleId")}}
+	dynamoDBClient.On("Scan", &dynamodb.ScanInput{TableName: aws.String("TableName")}).Return(
+		&dynamodb.ScanOutput{Items: []DynamoItem{item}, LastEvaluatedKey: lastKey}, nil).Once()
+	dynamoDBClient.On("Scan", &dynamodb.ScanInput{TableName: aws.String("TableName"), ExclusiveStartKey: lastKey}).Return(
+		&dynamodb.ScanOutput{Items: []DynamoItem{item}}, nil).Once()
+
+	result, err := table.GetRoutingRules()
+	require.NoError(t, err)
+	assert.Equal(t, []*models.RoutingRule{testRoutingRule, testRoutingRule}, result)
+	dynamoDBClient.AssertExpectations(t)
+}
@@ -24,6 +24,8 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+
+	"github.com/panther-labs/panther/api/lambda/outputs/models"
 )
 
 // OutputsAPI defines the interface for the outputs table which can be used for mocking.
@@ -33,7 +35,7 @@ type OutputsAPI interface {
 	GetOutputs() ([]*AlertOutputItem, error)
 	GetOutput(*string) (*AlertOutputItem, error)
 	PutOutput(*AlertOutputItem) error
-	UpdateOutput(*AlertOutputItem) (*AlertOutputItem, error)
+	UpdateOutput(*AlertOutputItem, ...string) (*AlertOutputItem, error)
 }
 
 // OutputsTable encapsulates a connection to the Dynamo rules table.
@@ -52,6 +54,12 @@ func NewOutputs(name string, displayNameIndex string, sess *session.Session) *Ou
 	}
 }
 
+// Names of the optional attributes of an output item that can be cleared by an update
+const (
+	MessageTemplateAttribute = "messageTemplate"
+	RateLimitAttribute       = "rateLimit"
+)
+
 // DynamoItem is a type alias for the item format expected by the Dynamo SDK.
 type DynamoItem = map[string]*dynamodb.AttributeValue
 
@@ -84,4 +92,9 @@ type AlertOutputItem struct {
 	OutputType *string `json:"outputType"`
 
 	DefaultForSeverity []*string `json:"defaultForSeverity" dynamodbav:"defaultForSeverity,stringset"`
+
+	// MessageTemplate is stored unencrypted, since templates hold no secrets
+	MessageTemplate *models.MessageTemplate `json:"messageTemplate,omitempty"`
+
+	RateLimit *models.RateLimit `json:"rateLimit,omitempty"`
 }
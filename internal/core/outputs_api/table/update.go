@@ -28,8 +28,9 @@ import (
 	"github.com/panther-labs/panther/pkg/genericapi"
 )
 
-// UpdateOutput updates an existing item in the table
-func (table *OutputsTable) UpdateOutput(alertOutput *AlertOutputItem) (*AlertOutputItem, error) {
+// UpdateOutput updates an existing item in the table.
+// Nil fields keep their existing value, optional attributes are cleared by listing them in removeAttributes.
+func (table *OutputsTable) UpdateOutput(alertOutput *AlertOutputItem, removeAttributes ...string) (*AlertOutputItem, error) {
 	// These fields will always be sent
 	updateExpression := expression.
 		Set(expression.Name("lastModifiedBy"), expression.Value(alertOutput.LastModifiedBy)).
@@ -44,6 +45,15 @@ func (table *OutputsTable) UpdateOutput(alertOutput *AlertOutputItem) (*AlertOut
 	if alertOutput.DefaultForSeverity != nil {
 		updateExpression.Set(expression.Name("defaultForSeverity"), expression.Value(alertOutput.DefaultForSeverity))
 	}
+	if alertOutput.MessageTemplate != nil {
+		updateExpression.Set(expression.Name(MessageTemplateAttribute), expression.Value(alertOutput.MessageTemplate))
+	}
+	if alertOutput.RateLimit != nil {
+		updateExpression.Set(expression.Name(RateLimitAttribute), expression.Value(alertOutput.RateLimit))
+	}
+	for _, name := range removeAttributes {
+		updateExpression.Remove(expression.Name(name))
+	}
 
 	conditionExpression := expression.Name("outputId").Equal(expression.Value(alertOutput.OutputID))
 	combinedExpression, err := expression.NewBuilder().
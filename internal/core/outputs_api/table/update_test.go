@@ -27,7 +27,9 @@ import (
 	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
+	"github.com/panther-labs/panther/api/lambda/outputs/models"
 	"github.com/panther-labs/panther/pkg/genericapi"
 )
 
@@ -46,6 +48,8 @@ var mockUpdateItemAlertOutput = &AlertOutputItem{
 	OutputType:         aws.String("outputType"),
 	DefaultForSeverity: aws.StringSlice([]string{"INFO", "WARN"}),
 	EncryptedConfig:    make([]byte, 1),
+	MessageTemplate:    &models.MessageTemplate{Title: "{{.Name}}"},
+	RateLimit:          &models.RateLimit{MaxNotifications: 10, WindowSeconds: 300},
 }
 
 func TestUpdateOutput(t *testing.T) {
@@ -57,7 +61,9 @@ func TestUpdateOutput(t *testing.T) {
 		Set(expression.Name("lastModifiedTime"), expression.Value(mockUpdateItemAlertOutput.LastModifiedTime)).
 		Set(expression.Name("displayName"), expression.Value(mockUpdateItemAlertOutput.DisplayName)).
 		Set(expression.Name("encryptedConfig"), expression.Value(mockUpdateItemAlertOutput.EncryptedConfig)).
-		Set(expression.Name("defaultForSeverity"), expression.Value(mockUpdateItemAlertOutput.DefaultForSeverity))
+		Set(expression.Name("defaultForSeverity"), expression.Value(mockUpdateItemAlertOutput.DefaultForSeverity)).
+		Set(expression.Name("messageTemplate"), expression.Value(mockUpdateItemAlertOutput.MessageTemplate)).
+		Set(expression.Name("rateLimit"), expression.Value(mockUpdateItemAlertOutput.RateLimit))
 
 	expectedConditionExpression := expression.Name("outputId").Equal(expression.Value(mockUpdateItemAlertOutput.OutputID))
 
@@ -131,3 +137,45 @@ func TestUpdateMarshallingError(t *testing.T) {
 	assert.NotNil(t, err.(*genericapi.InternalError))
 	dynamoDBClient.AssertExpectations(t)
 }
+
+func TestUpdateOutputRemoveAttributes(t *testing.T) {
+	dynamoDBClient := &mockDynamoDB{}
+	table := &OutputsTable{client: dynamoDBClient, Name: aws.String("TableName")}
+	alertOutput := &AlertOutputItem{
+		OutputID:         aws.String("outputId"),
+		LastModifiedBy:   aws.String("lastModifiedBy"),
+		LastModifiedTime: aws.String("lastModifiedTime"),
+	}
+
+	expectedUpdateExpression := expression.
+		Set(expression.Name("lastModifiedBy"), expression.Value(alertOutput.LastModifiedBy)).
+		Set(expression.Name("lastModifiedTime"), expression.Value(alertOutput.LastModifiedTime)).
+		Remove(expression.Name("messageTemplate")).
+		Remove(expression.Name("rateLimit"))
+	expectedExpression, err := expression.NewBuilder().
+		WithCondition(expression.Name("outputId").Equal(expression.Value(alertOutput.OutputID))).
+		WithUpdate(expectedUpdateExpression).
+		Build()
+	require.NoError(t, err)
+
+	expectedUpdateItemInput := &dynamodb.UpdateItemInput{
+		Key: DynamoItem{
+			"outputId": {S: aws.String("outputId")},
+		},
+		TableName:                 aws.String("TableName"),
+		UpdateExpression:          expectedExpression.Update(),
+		ConditionExpression:       expectedExpression.Condition(),
+		ExpressionAttributeNames:  expectedExpression.Names(),
+		ExpressionAttributeValues: expectedExpression.Values(),
+		ReturnValues:              aws.String(dynamodb.ReturnValueAllNew),
+	}
+
+	updateItemOutput := &dynamodb.UpdateItemOutput{
+		Attributes: DynamoItem{"outputId": {S: aws.String("outputId")}},
+	}
+	dynamoDBClient.On("UpdateItem", expectedUpdateItemInput).Return(updateItemOutput, nil)
+	result, err := table.UpdateOutput(alertOutput, MessageTemplateAttribute, RateLimitAttribute)
+	require.NoError(t, err)
+	assert.Equal(t, &AlertOutputItem{OutputID: aws.String("outputId")}, result)
+	dynamoDBClient.AssertExpectations(t)
+}
@@ -19,8 +19,17 @@ package validator
  */
 
 import (
+	"crypto/x509"
+	"path"
+	"strings"
+	"time"
+	_ "time/tzdata" // routing schedules are validated against the embedded timezone database
+
 	"github.com/aws/aws-sdk-go/aws/arn"
 	"gopkg.in/go-playground/validator.v9"
+
+	"github.com/panther-labs/panther/api/lambda/outputs/models"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/templates"
 )
 
 // Validator builds a custom struct validator.
@@ -29,6 +38,28 @@ func Validator() (*validator.Validate, error) {
 	if err := result.RegisterValidation("snsArn", validateAwsArn); err != nil {
 		return nil, err
 	}
+	if err := result.RegisterValidation("firehoseArn", validateFirehoseArn); err != nil {
+		return nil, err
+	}
+	if err := result.RegisterValidation("template", validateTemplate); err != nil {
+		return nil, err
+	}
+	if err := result.RegisterValidation("certificate", validateCertificate); err != nil {
+		return nil, err
+	}
+	if err := result.RegisterValidation("timezone", validateTimezone); err != nil {
+		return nil, err
+	}
+	if err := result.RegisterValidation("clock", validateClock); err != nil {
+		return nil, err
+	}
+	if err := result.RegisterValidation("glob", validateGlob); err != nil {
+		return nil, err
+	}
+	result.RegisterStructValidation(validateEmailConfig, models.EmailConfig{})
+	result.RegisterStructValidation(validateServiceNowConfig, models.ServiceNowConfig{})
+	result.RegisterStructValidation(validateElasticsearchConfig, models.ElasticsearchConfig{})
+	result.RegisterStructValidation(validateMaintenanceWindowSettings, models.MaintenanceWindowSettings{})
 	return result, nil
 }
 
@@ -36,3 +67,89 @@ func validateAwsArn(fl validator.FieldLevel) bool {
 	fieldArn, err := arn.Parse(fl.Field().String())
 	return err == nil && fieldArn.Service == "sns"
 }
+
+func validateFirehoseArn(fl validator.FieldLevel) bool {
+	fieldArn, err := arn.Parse(fl.Field().String())
+	return err == nil && fieldArn.Service == "firehose" && strings.HasPrefix(fieldArn.Resource, "deliverystream/")
+}
+
+// validateTemplate checks that a field is a valid text/template, which may use the alert template helpers
+func validateTemplate(fl validator.FieldLevel) bool {
+	_, err := templates.New("", fl.Field().String())
+	return err == nil
+}
+
+// validateCertificate checks that a field contains at least one PEM encoded certificate
+func validateCertificate(fl validator.FieldLevel) bool {
+	return x509.NewCertPool().AppendCertsFromPEM([]byte(fl.Field().String()))
+}
+
+// validateTimezone checks that a field is an IANA time zone name
+func validateTimezone(fl validator.FieldLevel) bool {
+	name := fl.Field().String()
+	if name == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(name)
+	return err == nil
+}
+
+// validateClock checks that a field is a time of day formatted as HH:MM
+func validateClock(fl validator.FieldLevel) bool {
+	_, err := time.Parse("15:04", fl.Field().String())
+	return err == nil
+}
+
+// validateGlob checks that a field is a valid glob pattern
+func validateGlob(fl validator.FieldLevel) bool {
+	_, err := path.Match(fl.Field().String(), "")
+	return err == nil
+}
+
+// validateEmailConfig checks the settings that depend on the selected delivery mode
+func validateEmailConfig(sl validator.StructLevel) {
+	config := sl.Current().Interface().(models.EmailConfig)
+	switch config.Mode {
+	case "smtp":
+		if config.SMTPHost == "" {
+			sl.ReportError(config.SMTPHost, "SMTPHost", "SMTPHost", "required_with_smtp", "")
+		}
+		if config.SMTPPassword != "" && config.SMTPUsername == "" {
+			sl.ReportError(config.SMTPUsername, "SMTPUsername", "SMTPUsername", "required_with_password", "")
+		}
+	case "ses":
+		if config.SMTPHost != "" {
+			sl.ReportError(config.SMTPHost, "SMTPHost", "SMTPHost", "excluded_with_ses", "")
+		}
+	}
+}
+
+// validateServiceNowConfig checks the settings required by the selected authentication type
+func validateServiceNowConfig(sl validator.StructLevel) {
+	config := sl.Current().Interface().(models.ServiceNowConfig)
+	if config.AuthType == "oauth" && config.ClientID == "" {
+		sl.ReportError(config.ClientID, "ClientID", "ClientID", "required_with_oauth", "")
+	}
+}
+
+// validateElasticsearchConfig checks the settings required by the selected authentication type
+func validateElasticsearchConfig(sl validator.StructLevel) {
+	config := sl.Current().Interface().(models.ElasticsearchConfig)
+	if config.AuthType == "basic" && config.UserName == "" {
+		sl.ReportError(config.UserName, "UserName", "UserName", "required_with_basic", "")
+	}
+}
+
+// validateMaintenanceWindowSettings checks that the occurrences of a recurring window don't overlap
+func validateMaintenanceWindowSettings(sl validator.StructLevel) {
+	settings := sl.Current().Interface().(models.MaintenanceWindowSettings)
+	if settings.Recurrence == nil {
+		return
+	}
+	if settings.EndTime.Sub(settings.StartTime) > settings.Recurrence.MaxDuration() {
+		sl.ReportError(settings.EndTime, "EndTime", "EndTime", "max_recurrence_duration", "")
+	}
+	if until := settings.Recurrence.Until; until != nil && until.Before(settings.StartTime) {
+		sl.ReportError(until, "Recurrence", "Recurrence", "until_after_start", "")
+	}
+}
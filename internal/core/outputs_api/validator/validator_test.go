@@ -21,6 +21,7 @@ package validator
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/stretchr/testify/assert"
@@ -87,3 +88,426 @@ func TestAddNonSnsArn(t *testing.T) {
 	require.Error(t, err)
 	assert.Equal(t, expectedMsg("AddOutputInput.OutputConfig.Sns", "TopicArn", "snsArn"), err.Error())
 }
+
+func TestAddEmailValid(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	assert.NoError(t, validator.Struct(&models.AddOutputInput{
+		UserID:      aws.String("3601990c-b566-404b-b367-3c6eacd6fe60"),
+		DisplayName: aws.String("on-call"),
+		OutputConfig: &models.OutputConfig{
+			Email: &models.EmailConfig{
+				Mode:            "smtp",
+				From:            "alerts@example.com",
+				To:              []string{"oncall@example.com", "security@example.com"},
+				Cc:              []string{"compliance@example.com"},
+				SubjectPrefixes: map[string]string{"CRITICAL": "[PAGE]"},
+				SMTPHost:        "smtp.example.com",
+				SMTPPort:        aws.Int(465),
+				SMTPTLS:         "tls",
+				SMTPUsername:    "alerts",
+				SMTPPassword:    "secret",
+			},
+		},
+	}))
+	assert.NoError(t, validator.Struct(&models.AddOutputInput{
+		UserID:      aws.String("3601990c-b566-404b-b367-3c6eacd6fe60"),
+		DisplayName: aws.String("compliance"),
+		OutputConfig: &models.OutputConfig{
+			Email: &models.EmailConfig{
+				Mode:      "ses",
+				From:      "alerts@example.com",
+				To:        []string{"compliance@example.com"},
+				SesRegion: "us-west-2",
+			},
+		},
+	}))
+}
+
+func TestAddEmailInvalidRecipient(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	err = validator.Struct(&models.AddOutputInput{
+		UserID:      aws.String("3601990c-b566-404b-b367-3c6eacd6fe60"),
+		DisplayName: aws.String("on-call"),
+		OutputConfig: &models.OutputConfig{
+			Email: &models.EmailConfig{
+				Mode: "ses",
+				From: "alerts@example.com",
+				To:   []string{"not-an-email"},
+			},
+		},
+	})
+	require.Error(t, err)
+	assert.Equal(t, expectedMsg("AddOutputInput.OutputConfig.Email", "To[0]", "email"), err.Error())
+}
+
+func TestAddEmailInvalidSeverityPrefix(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	err = validator.Struct(&models.AddOutputInput{
+		UserID:      aws.String("3601990c-b566-404b-b367-3c6eacd6fe60"),
+		DisplayName: aws.String("on-call"),
+		OutputConfig: &models.OutputConfig{
+			Email: &models.EmailConfig{
+				Mode:            "ses",
+				From:            "alerts@example.com",
+				To:              []string{"oncall@example.com"},
+				SubjectPrefixes: map[string]string{"URGENT": "[PAGE]"},
+			},
+		},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "'oneof' tag")
+}
+
+func TestAddEmailSMTPMissingHost(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	err = validator.Struct(&models.AddOutputInput{
+		UserID:      aws.String("3601990c-b566-404b-b367-3c6eacd6fe60"),
+		DisplayName: aws.String("on-call"),
+		OutputConfig: &models.OutputConfig{
+			Email: &models.EmailConfig{
+				Mode: "smtp",
+				From: "alerts@example.com",
+				To:   []string{"oncall@example.com"},
+			},
+		},
+	})
+	require.Error(t, err)
+	assert.Equal(t, expectedMsg("AddOutputInput.OutputConfig.Email", "SMTPHost", "required_with_smtp"), err.Error())
+}
+
+func TestAddServiceNowValid(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	assert.NoError(t, validator.Struct(&models.AddOutputInput{
+		UserID:      aws.String("3601990c-b566-404b-b367-3c6eacd6fe60"),
+		DisplayName: aws.String("incidents"),
+		OutputConfig: &models.OutputConfig{
+			ServiceNow: &models.ServiceNowConfig{
+				InstanceURL:     "https://panther.service-now.com",
+				AuthType:        "oauth",
+				UserName:        "username",
+				Password:        "password",
+				ClientID:        "clientId",
+				ClientSecret:    "clientSecret",
+				AssignmentGroup: "Security Operations",
+				SeverityMapping: map[string]models.ServiceNowPriority{"CRITICAL": {Impact: 1, Urgency: 1}},
+				CustomFields:    map[string]string{"u_rule": "{{.ID}}"},
+			},
+		},
+	}))
+}
+
+func TestAddServiceNowInvalidTemplate(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	err = validator.Struct(&models.AddOutputInput{
+		UserID:      aws.String("3601990c-b566-404b-b367-3c6eacd6fe60"),
+		DisplayName: aws.String("incidents"),
+		OutputConfig: &models.OutputConfig{
+			ServiceNow: &models.ServiceNowConfig{
+				InstanceURL:  "https://panther.service-now.com",
+				CustomFields: map[string]string{"u_rule": "{{.ID"},
+			},
+		},
+	})
+	require.Error(t, err)
+	assert.Equal(t, expectedMsg("AddOutputInput.OutputConfig.ServiceNow", "CustomFields[u_rule]", "template"), err.Error())
+}
+
+func TestAddServiceNowInvalidPriority(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	err = validator.Struct(&models.AddOutputInput{
+		UserID:      aws.String("3601990c-b566-404b-b367-3c6eacd6fe60"),
+		DisplayName: aws.String("incidents"),
+		OutputConfig: &models.OutputConfig{
+			ServiceNow: &models.ServiceNowConfig{
+				InstanceURL:     "https://panther.service-now.com",
+				SeverityMapping: map[string]models.ServiceNowPriority{"HIGH": {Impact: 4, Urgency: 1}},
+			},
+		},
+	})
+	require.Error(t, err)
+	assert.Equal(t, expectedMsg("AddOutputInput.OutputConfig.ServiceNow.SeverityMapping[HIGH]", "Impact", "max"), err.Error())
+}
+
+func TestAddServiceNowOAuthMissingClient(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	err = validator.Struct(&models.AddOutputInput{
+		UserID:      aws.String("3601990c-b566-404b-b367-3c6eacd6fe60"),
+		DisplayName: aws.String("incidents"),
+		OutputConfig: &models.OutputConfig{
+			ServiceNow: &models.ServiceNowConfig{
+				InstanceURL: "https://panther.service-now.com",
+				AuthType:    "oauth",
+			},
+		},
+	})
+	require.Error(t, err)
+	assert.Equal(t, expectedMsg("AddOutputInput.OutputConfig.ServiceNow", "ClientID", "required_with_oauth"), err.Error())
+}
+
+func TestAddElasticsearchInvalidIndex(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	err = validator.Struct(&models.AddOutputInput{
+		UserID:      aws.String("3601990c-b566-404b-b367-3c6eacd6fe60"),
+		DisplayName: aws.String("elastic"),
+		OutputConfig: &models.OutputConfig{
+			Elasticsearch: &models.ElasticsearchConfig{
+				URL:   "https://elastic.example.com:9200",
+				Index: "alerts,logs",
+			},
+		},
+	})
+	require.Error(t, err)
+	assert.Equal(t, expectedMsg("AddOutputInput.OutputConfig.Elasticsearch", "Index", "excludesall"), err.Error())
+}
+
+func TestAddSplunkInvalidCertificate(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	err = validator.Struct(&models.AddOutputInput{
+		UserID:      aws.String("3601990c-b566-404b-b367-3c6eacd6fe60"),
+		DisplayName: aws.String("splunk"),
+		OutputConfig: &models.OutputConfig{
+			Splunk: &models.SplunkConfig{
+				HecURL:        "https://splunk.example.com:8088/services/collector/event",
+				Token:         "token",
+				CACertificate: "-----BEGIN CERTIFICATE-----\nnot base64\n-----END CERTIFICATE-----\n",
+			},
+		},
+	})
+	require.Error(t, err)
+	assert.Equal(t, expectedMsg("AddOutputInput.OutputConfig.Splunk", "CACertificate", "certificate"), err.Error())
+}
+
+func TestAddElasticsearchBasicMissingUser(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	err = validator.Struct(&models.AddOutputInput{
+		UserID:      aws.String("3601990c-b566-404b-b367-3c6eacd6fe60"),
+		DisplayName: aws.String("elastic"),
+		OutputConfig: &models.OutputConfig{
+			Elasticsearch: &models.ElasticsearchConfig{
+				URL:      "https://elastic.example.com:9200",
+				Index:    "panther-alerts",
+				AuthType: "basic",
+			},
+		},
+	})
+	require.Error(t, err)
+	assert.Equal(t, expectedMsg("AddOutputInput.OutputConfig.Elasticsearch", "UserName", "required_with_basic"), err.Error())
+}
+
+func TestAddFirehoseInvalidArn(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	err = validator.Struct(&models.AddOutputInput{
+		UserID:      aws.String("3601990c-b566-404b-b367-3c6eacd6fe60"),
+		DisplayName: aws.String("archive"),
+		OutputConfig: &models.OutputConfig{
+			Firehose: &models.FirehoseConfig{StreamArn: "arn:aws:kinesis:us-west-2:123456789012:stream/panther-alerts"},
+		},
+	})
+	require.Error(t, err)
+	assert.Equal(t, expectedMsg("AddOutputInput.OutputConfig.Firehose", "StreamArn", "firehoseArn"), err.Error())
+
+	assert.NoError(t, validator.Struct(&models.AddOutputInput{
+		UserID:      aws.String("3601990c-b566-404b-b367-3c6eacd6fe60"),
+		DisplayName: aws.String("archive"),
+		OutputConfig: &models.OutputConfig{
+			Firehose: &models.FirehoseConfig{StreamArn: "arn:aws:firehose:us-west-2:123456789012:deliverystream/panther-alerts"},
+		},
+	}))
+}
+
+func TestAddS3InvalidBucket(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	err = validator.Struct(&models.AddOutputInput{
+		UserID:      aws.String("3601990c-b566-404b-b367-3c6eacd6fe60"),
+		DisplayName: aws.String("archive"),
+		OutputConfig: &models.OutputConfig{
+			S3: &models.S3Config{BucketName: "ab"},
+		},
+	})
+	require.Error(t, err)
+	assert.Equal(t, expectedMsg("AddOutputInput.OutputConfig.S3", "BucketName", "min"), err.Error())
+}
+
+func TestAddOutputMessageTemplate(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	assert.NoError(t, validator.Struct(&models.AddOutputInput{
+		UserID:       aws.String("3601990c-b566-404b-b367-3c6eacd6fe60"),
+		DisplayName:  aws.String("alerts"),
+		OutputConfig: &models.OutputConfig{Sns: &models.SnsConfig{TopicArn: "arn:aws:sns:us-west-2:123456789012:alerts"}},
+		MessageTemplate: &models.MessageTemplate{
+			Title: "[{{.Severity}}] {{markdown .Name}}",
+			Body:  "{{.Description | truncate 200}}\n{{json .AlertContext}}",
+		},
+	}))
+}
+
+func TestAddOutputInvalidMessageTemplate(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	err = validator.Struct(&models.AddOutputInput{
+		UserID:          aws.String("3601990c-b566-404b-b367-3c6eacd6fe60"),
+		DisplayName:     aws.String("alerts"),
+		OutputConfig:    &models.OutputConfig{Sns: &models.SnsConfig{TopicArn: "arn:aws:sns:us-west-2:123456789012:alerts"}},
+		MessageTemplate: &models.MessageTemplate{Title: "{{.Name | unknownFunc}}"},
+	})
+	require.Error(t, err)
+	assert.Equal(t, expectedMsg("AddOutputInput.MessageTemplate", "Title", "template"), err.Error())
+}
+
+func TestAddOutputRateLimit(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	assert.NoError(t, validator.Struct(&models.AddOutputInput{
+		UserID:       aws.String("3601990c-b566-404b-b367-3c6eacd6fe60"),
+		DisplayName:  aws.String("alerts"),
+		OutputConfig: &models.OutputConfig{Sns: &models.SnsConfig{TopicArn: "arn:aws:sns:us-west-2:123456789012:alerts"}},
+		RateLimit:    &models.RateLimit{MaxNotifications: 10, WindowSeconds: 300},
+	}))
+}
+
+func TestAddOutputInvalidRateLimit(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	err = validator.Struct(&models.AddOutputInput{
+		UserID:       aws.String("3601990c-b566-404b-b367-3c6eacd6fe60"),
+		DisplayName:  aws.String("alerts"),
+		OutputConfig: &models.OutputConfig{Sns: &models.SnsConfig{TopicArn: "arn:aws:sns:us-west-2:123456789012:alerts"}},
+		RateLimit:    &models.RateLimit{MaxNotifications: 10, WindowSeconds: 10},
+	})
+	require.Error(t, err)
+	assert.Equal(t, expectedMsg("AddOutputInput.RateLimit", "WindowSeconds", "min"), err.Error())
+}
+
+func validRoutingRuleInput() *models.AddRoutingRuleInput {
+	return &models.AddRoutingRuleInput{
+		UserID: aws.String("3601990c-b566-404b-b367-3c6eacd6fe60"),
+		RoutingRuleSettings: models.RoutingRuleSettings{
+			DisplayName: "critical iam",
+			Conditions: models.RoutingConditions{
+				Severities:  []string{"CRITICAL"},
+				AnalysisIDs: []string{"AWS.IAM.*"},
+				AlertTypes:  []string{"RULE"},
+				Schedule: &models.RoutingSchedule{
+					Timezone: "America/New_York",
+					Windows: []models.RoutingTimeWindow{
+						{Days: []string{"MON", "FRI"}, StartTime: "09:00", EndTime: "17:30"},
+					},
+				},
+			},
+			OutputIds: []string{"7d1c5854-f3ea-491c-8a52-0aa0d58cb456"},
+		},
+	}
+}
+
+func TestAddRoutingRule(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	assert.NoError(t, validator.Struct(validRoutingRuleInput()))
+}
+
+func TestAddRoutingRuleInvalidTimezone(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	input := validRoutingRuleInput()
+	input.Conditions.Schedule.Timezone = "Mars/Olympus_Mons"
+	err = validator.Struct(input)
+	require.Error(t, err)
+	assert.Equal(t, expectedMsg("AddRoutingRuleInput.RoutingRuleSettings.Conditions.Schedule", "Timezone", "timezone"), err.Error())
+}
+
+func TestAddRoutingRuleInvalidWindow(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	input := validRoutingRuleInput()
+	input.Conditions.Schedule.Windows[0].EndTime = "25:00"
+	err = validator.Struct(input)
+	require.Error(t, err)
+	assert.Equal(t, expectedMsg("AddRoutingRuleInput.RoutingRuleSettings.Conditions.Schedule.Windows[0]", "EndTime", "clock"), err.Error())
+}
+
+func TestAddRoutingRuleInvalidGlob(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	input := validRoutingRuleInput()
+	input.Conditions.AnalysisIDs = []string{"AWS.[IAM"}
+	err = validator.Struct(input)
+	require.Error(t, err)
+	assert.Equal(t, expectedMsg("AddRoutingRuleInput.RoutingRuleSettings.Conditions", "AnalysisIDs[0]", "glob"), err.Error())
+}
+
+func TestAddRoutingRuleNoOutputs(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	input := validRoutingRuleInput()
+	input.OutputIds = nil
+	err = validator.Struct(input)
+	require.Error(t, err)
+	assert.Equal(t, expectedMsg("AddRoutingRuleInput.RoutingRuleSettings", "OutputIds", "min"), err.Error())
+}
+
+func validMaintenanceWindowInput() *models.AddMaintenanceWindowInput {
+	return &models.AddMaintenanceWindowInput{
+		UserID: aws.String("3601990c-b566-404b-b367-3c6eacd6fe60"),
+		MaintenanceWindowSettings: models.MaintenanceWindowSettings{
+			DisplayName: "pen test",
+			StartTime:   time.Date(2020, 10, 5, 22, 0, 0, 0, time.UTC),
+			EndTime:     time.Date(2020, 10, 6, 2, 0, 0, 0, time.UTC),
+			Recurrence:  &models.MaintenanceRecurrence{Frequency: "DAILY"},
+			Conditions: models.MaintenanceConditions{
+				ResourceIDs: []string{"arn:aws:iam::*"},
+				SourceIDs:   []string{"3601990c-b566-404b-b367-3c6eacd6fe60"},
+			},
+		},
+	}
+}
+
+func TestAddMaintenanceWindow(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	assert.NoError(t, validator.Struct(validMaintenanceWindowInput()))
+}
+
+func TestAddMaintenanceWindowEndBeforeStart(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	input := validMaintenanceWindowInput()
+	input.EndTime = input.StartTime.Add(-time.Hour)
+	err = validator.Struct(input)
+	require.Error(t, err)
+	assert.Equal(t, expectedMsg("AddMaintenanceWindowInput.MaintenanceWindowSettings", "EndTime", "gtfield"), err.Error())
+}
+
+func TestAddMaintenanceWindowOverlappingRecurrence(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	input := validMaintenanceWindowInput()
+	input.EndTime = input.StartTime.Add(25 * time.Hour)
+	err = validator.Struct(input)
+	require.Error(t, err)
+	assert.Equal(t, expectedMsg("AddMaintenanceWindowInput.MaintenanceWindowSettings", "EndTime", "max_recurrence_duration"), err.Error())
+}
+
+func TestAddMaintenanceWindowUntilBeforeStart(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	input := validMaintenanceWindowInput()
+	until := input.StartTime.AddDate(0, 0, -1)
+	input.Recurrence.Until = &until
+	err = validator.Struct(input)
+	require.Error(t, err)
+	assert.Equal(t, expectedMsg("AddMaintenanceWindowInput.MaintenanceWindowSettings", "Recurrence", "until_after_start"), err.Error())
+}
@@ -48,6 +48,9 @@ type Handler struct {
 	AlertTable       string
 	AlertingQueueURL string
 	MetricsLogger    metrics.Logger
+
+	// MaintenanceWindows is optional, alerts are not checked against maintenance windows without it
+	MaintenanceWindows *MaintenanceWindowCache
 }
 
 func (h *Handler) Do(oldAlertDedupEvent, newAlertDedupEvent *AlertDedupEvent) (err error) {
@@ -95,11 +98,15 @@ func needToCreateNewAlert(oldRule *ruleModel.Rule, oldAlertDedupEvent, newAlertD
 }
 
 func (h *Handler) handleNewAlert(rule *ruleModel.Rule, event *AlertDedupEvent) error {
-	if err := h.storeNewAlert(rule, event); err != nil {
+	alertNotification := newAlertNotification(rule, event)
+	// Muted alerts are stored and sent as usual, alert delivery summarizes them instead of sending them
+	alertNotification.MaintenanceWindowID = h.maintenanceWindowID(alertNotification)
+
+	if err := h.storeNewAlert(rule, event, alertNotification.MaintenanceWindowID); err != nil {
 		return errors.Wrap(err, "failed to store new alert in DDB")
 	}
 
-	err := h.sendAlertNotification(rule, event)
+	err := h.sendAlertNotification(alertNotification)
 	if err == nil && event.Type == alertModel.RuleType {
 		h.logStats(rule)
 	}
@@ -152,7 +159,7 @@ func (h *Handler) updateExistingAlert(event *AlertDedupEvent) error {
 	return nil
 }
 
-func (h *Handler) storeNewAlert(rule *ruleModel.Rule, alertDedup *AlertDedupEvent) error {
+func (h *Handler) storeNewAlert(rule *ruleModel.Rule, alertDedup *AlertDedupEvent, maintenanceWindowID *string) error {
 	alert := &Alert{
 		ID:                  generateAlertID(alertDedup),
 		TimePartition:       defaultTimePartition,
@@ -161,6 +168,7 @@ func (h *Handler) storeNewAlert(rule *ruleModel.Rule, alertDedup *AlertDedupEven
 		Title:               getAlertTitle(rule, alertDedup),
 		FirstEventMatchTime: alertDedup.CreationTime,
 		LogTypes:            alertDedup.LogTypes,
+		MaintenanceWindowID: maintenanceWindowID,
 		AlertDedupEvent: AlertDedupEvent{
 			RuleID:              alertDedup.RuleID,
 			RuleVersion:         alertDedup.RuleVersion,
@@ -192,7 +200,7 @@ func (h *Handler) storeNewAlert(rule *ruleModel.Rule, alertDedup *AlertDedupEven
 	return nil
 }
 
-func (h *Handler) sendAlertNotification(rule *ruleModel.Rule, alertDedup *AlertDedupEvent) error {
+func newAlertNotification(rule *ruleModel.Rule, alertDedup *AlertDedupEvent) *alertModel.Alert {
 	alertNotification := &alertModel.Alert{
 		AlertID:             aws.String(generateAlertID(alertDedup)),
 		AnalysisDescription: aws.String(string(rule.Description)),
@@ -201,6 +209,7 @@ func (h *Handler) sendAlertNotification(rule *ruleModel.Rule, alertDedup *AlertD
 		// as the update time -> the time that an update(new event) caused the matched events to exceed threshold
 		// In case the rule doesnt' have a threshold, the two are anyway the same
 		CreatedAt:    alertDedup.UpdateTime,
+		LogTypes:     alertDedup.LogTypes,
 		OutputIds:    rule.OutputIds,
 		AnalysisName: getRuleDisplayName(rule),
 		Runbook:      aws.String(string(rule.Runbook)),
@@ -221,7 +230,10 @@ func (h *Handler) sendAlertNotification(rule *ruleModel.Rule, alertDedup *AlertD
 			alertNotification.Context = context
 		}
 	}
+	return alertNotification
+}
 
+func (h *Handler) sendAlertNotification(alertNotification *alertModel.Alert) error {
 	msgBody, err := jsoniter.MarshalToString(alertNotification)
 	if err != nil {
 		return errors.Wrap(err, "failed to marshal alert notification")
@@ -30,6 +30,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
 	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"github.com/aws/aws-sdk-go/service/lambda"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/stretchr/testify/assert"
@@ -39,6 +40,7 @@ import (
 	policiesclient "github.com/panther-labs/panther/api/gateway/analysis/client"
 	ruleModel "github.com/panther-labs/panther/api/gateway/analysis/models"
 	alertModel "github.com/panther-labs/panther/api/lambda/delivery/models"
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
 	"github.com/panther-labs/panther/pkg/metrics"
 	"github.com/panther-labs/panther/pkg/testutils"
 )
@@ -120,6 +122,7 @@ func TestHandleStoreAndSendNotification(t *testing.T) {
 		CreatedAt:           newAlertDedupEvent.UpdateTime,
 		AnalysisDescription: aws.String(string(testRuleResponse.Description)),
 		AnalysisID:          newAlertDedupEvent.RuleID,
+		LogTypes:            newAlertDedupEvent.LogTypes,
 		Version:             &newAlertDedupEvent.RuleVersion,
 		AnalysisName:        aws.String(string(testRuleResponse.DisplayName)),
 		Runbook:             aws.String(string(testRuleResponse.Runbook)),
@@ -215,6 +218,7 @@ func TestHandleStoreAndSendNotificationNoRuleDisplayNameNoTitle(t *testing.T) {
 		CreatedAt:           newAlertDedupEventWithoutTitle.UpdateTime,
 		AnalysisDescription: aws.String(string(testRuleResponse.Description)),
 		AnalysisID:          newAlertDedupEventWithoutTitle.RuleID,
+		LogTypes:            newAlertDedupEventWithoutTitle.LogTypes,
 		Version:             aws.String(newAlertDedupEventWithoutTitle.RuleVersion),
 		Runbook:             aws.String(string(testRuleResponse.Runbook)),
 		Severity:            string(testRuleResponse.Severity),
@@ -305,6 +309,7 @@ func TestHandleStoreAndSendNotificationNoGeneratedTitle(t *testing.T) {
 		CreatedAt:           newAlertDedupEvent.UpdateTime,
 		AnalysisDescription: aws.String(string(testRuleResponse.Description)),
 		AnalysisID:          newAlertDedupEvent.RuleID,
+		LogTypes:            newAlertDedupEvent.LogTypes,
 		Version:             aws.String(newAlertDedupEvent.RuleVersion),
 		AnalysisName:        aws.String(string(testRuleResponse.DisplayName)),
 		Runbook:             aws.String(string(testRuleResponse.Runbook)),
@@ -401,6 +406,7 @@ func TestHandleStoreAndSendNotificationNilOldDedup(t *testing.T) {
 		CreatedAt:           newAlertDedupEvent.UpdateTime,
 		AnalysisDescription: aws.String(string(testRuleResponse.Description)),
 		AnalysisID:          newAlertDedupEvent.RuleID,
+		LogTypes:            newAlertDedupEvent.LogTypes,
 		AnalysisName:        aws.String(string(testRuleResponse.DisplayName)),
 		Version:             aws.String(newAlertDedupEvent.RuleVersion),
 		Runbook:             aws.String(string(testRuleResponse.Runbook)),
@@ -699,6 +705,102 @@ func TestHandleError(t *testing.T) {
 	metricsMock.AssertExpectations(t)
 }
 
+func TestHandleStoreAndSendMutedAlert(t *testing.T) {
+	t.Parallel()
+	ddbMock := &testutils.DynamoDBMock{}
+	sqsMock := &testutils.SqsMock{}
+	lambdaMock := &testutils.LambdaMock{}
+	metricsMock := &testutils.LoggerMock{}
+	mockRoundTripper := &mockRoundTripper{}
+	httpClient := &http.Client{Transport: mockRoundTripper}
+	policyClient := policiesclient.NewHTTPClientWithConfig(nil, policiesclient.DefaultTransportConfig())
+	handler := &Handler{
+		AlertTable:         "alertsTable",
+		AlertingQueueURL:   "queueUrl",
+		Cache:              NewCache(httpClient, policyClient),
+		DdbClient:          ddbMock,
+		SqsClient:          sqsMock,
+		MetricsLogger:      metricsMock,
+		MaintenanceWindows: NewMaintenanceWindowCache(lambdaMock, "outputs-api"),
+	}
+
+	windows := outputModels.GetMaintenanceWindowsOutput{
+		{
+			WindowID: "other-window-id",
+			MaintenanceWindowSettings: outputModels.MaintenanceWindowSettings{
+				StartTime:  newAlertDedupEvent.UpdateTime.Add(-time.Hour),
+				EndTime:    newAlertDedupEvent.UpdateTime.Add(time.Hour),
+				Conditions: outputModels.MaintenanceConditions{LogTypes: []string{"Other.Log.Type"}},
+			},
+		},
+		{
+			WindowID: "window-id",
+			MaintenanceWindowSettings: outputModels.MaintenanceWindowSettings{
+				StartTime:  newAlertDedupEvent.UpdateTime.Add(-time.Hour),
+				EndTime:    newAlertDedupEvent.UpdateTime.Add(time.Hour),
+				Conditions: outputModels.MaintenanceConditions{LogTypes: []string{"log.type.1"}},
+			},
+		},
+	}
+	payload, err := jsoniter.Marshal(windows)
+	require.NoError(t, err)
+
+	mockRoundTripper.On("RoundTrip", mock.Anything).Return(generateResponse(testRuleResponse, http.StatusOK), nil).Once()
+	lambdaMock.On("Invoke", mock.Anything).Return(&lambda.InvokeOutput{Payload: payload}, nil).Once()
+	ddbMock.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, nil).Once()
+	sqsMock.On("SendMessage", mock.Anything).Return(&sqs.SendMessageOutput{}, nil).Once()
+	metricsMock.On("Log", expectedDimensions, expectedMetric).Once()
+
+	assert.NoError(t, handler.Do(oldAlertDedupEvent, newAlertDedupEvent))
+	ddbMock.AssertExpectations(t)
+	sqsMock.AssertExpectations(t)
+	lambdaMock.AssertExpectations(t)
+
+	// The muted alert is still stored and sent to alert delivery
+	putItemInput := ddbMock.Calls[0].Arguments.Get(0).(*dynamodb.PutItemInput)
+	assert.Equal(t, aws.String("window-id"), putItemInput.Item["maintenanceWindowId"].S)
+
+	var alertNotification alertModel.Alert
+	sendMessageInput := sqsMock.Calls[0].Arguments.Get(0).(*sqs.SendMessageInput)
+	require.NoError(t, jsoniter.UnmarshalFromString(*sendMessageInput.MessageBody, &alertNotification))
+	assert.Equal(t, aws.String("window-id"), alertNotification.MaintenanceWindowID)
+}
+
+func TestHandleMaintenanceWindowsError(t *testing.T) {
+	t.Parallel()
+	ddbMock := &testutils.DynamoDBMock{}
+	sqsMock := &testutils.SqsMock{}
+	lambdaMock := &testutils.LambdaMock{}
+	metricsMock := &testutils.LoggerMock{}
+	mockRoundTripper := &mockRoundTripper{}
+	httpClient := &http.Client{Transport: mockRoundTripper}
+	policyClient := policiesclient.NewHTTPClientWithConfig(nil, policiesclient.DefaultTransportConfig())
+	handler := &Handler{
+		AlertTable:         "alertsTable",
+		AlertingQueueURL:   "queueUrl",
+		Cache:              NewCache(httpClient, policyClient),
+		DdbClient:          ddbMock,
+		SqsClient:          sqsMock,
+		MetricsLogger:      metricsMock,
+		MaintenanceWindows: NewMaintenanceWindowCache(lambdaMock, "outputs-api"),
+	}
+
+	mockRoundTripper.On("RoundTrip", mock.Anything).Return(generateResponse(testRuleResponse, http.StatusOK), nil).Once()
+	lambdaMock.On("Invoke", mock.Anything).Return(&lambda.InvokeOutput{}, errors.New("error")).Once()
+	ddbMock.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, nil).Once()
+	sqsMock.On("SendMessage", mock.Anything).Return(&sqs.SendMessageOutput{}, nil).Once()
+	metricsMock.On("Log", expectedDimensions, expectedMetric).Once()
+
+	// The alert is stored and sent as usual
+	assert.NoError(t, handler.Do(oldAlertDedupEvent, newAlertDedupEvent))
+	ddbMock.AssertExpectations(t)
+	sqsMock.AssertExpectations(t)
+	lambdaMock.AssertExpectations(t)
+
+	putItemInput := ddbMock.Calls[0].Arguments.Get(0).(*dynamodb.PutItemInput)
+	assert.NotContains(t, putItemInput.Item, "maintenanceWindowId")
+}
+
 func generateResponse(body interface{}, httpCode int) *http.Response {
 	serializedBody, _ := jsoniter.MarshalToString(body)
 	return &http.Response{StatusCode: httpCode, Body: ioutil.NopCloser(strings.NewReader(serializedBody))}
@@ -0,0 +1,82 @@
+package forwarder
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
+	"go.uber.org/zap"
+
+	alertModel "github.com/panther-labs/panther/api/lambda/delivery/models"
+	outputModels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+// maintenanceWindowsRefreshInterval is how long the maintenance windows are cached
+const maintenanceWindowsRefreshInterval = 30 * time.Second
+
+// MaintenanceWindowCache keeps the maintenance windows fetched from the outputs-api for a short time
+type MaintenanceWindowCache struct {
+	lambdaClient lambdaiface.LambdaAPI
+	outputsAPI   string
+	windows      []*outputModels.MaintenanceWindow
+	expiry       time.Time
+}
+
+func NewMaintenanceWindowCache(lambdaClient lambdaiface.LambdaAPI, outputsAPI string) *MaintenanceWindowCache {
+	return &MaintenanceWindowCache{
+		lambdaClient: lambdaClient,
+		outputsAPI:   outputsAPI,
+	}
+}
+
+func (c *MaintenanceWindowCache) Get() ([]*outputModels.MaintenanceWindow, error) {
+	if time.Now().Before(c.expiry) {
+		return c.windows, nil
+	}
+
+	zap.L().Debug("calling outputs API to retrieve the maintenance windows")
+	input := outputModels.LambdaInput{GetMaintenanceWindows: &outputModels.GetMaintenanceWindowsInput{}}
+	windows := outputModels.GetMaintenanceWindowsOutput{}
+	if err := genericapi.Invoke(c.lambdaClient, c.outputsAPI, &input, &windows); err != nil {
+		return nil, err
+	}
+	c.windows, c.expiry = windows, time.Now().Add(maintenanceWindowsRefreshInterval)
+	return c.windows, nil
+}
+
+// maintenanceWindowID returns the ID of the maintenance window muting the alert, if any
+func (h *Handler) maintenanceWindowID(alert *alertModel.Alert) *string {
+	if h.MaintenanceWindows == nil {
+		return nil
+	}
+	windows, err := h.MaintenanceWindows.Get()
+	if err != nil {
+		// best effort, alert delivery checks the maintenance windows again
+		zap.L().Warn("failed to get maintenance windows", zap.Error(err))
+		return nil
+	}
+	for _, window := range windows {
+		if window.Mutes(alert) {
+			return &window.WindowID
+		}
+	}
+	return nil
+}
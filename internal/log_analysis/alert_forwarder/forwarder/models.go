@@ -62,6 +62,7 @@ type Alert struct {
 	LogTypes            []string  `dynamodbav:"logTypes,stringset"`
 	Title               string    `dynamodbav:"title,string"` // The alert title. It will be the Python-generated title or a default one if
 	// no Python-generated title is available.
+	MaintenanceWindowID *string `dynamodbav:"maintenanceWindowId,omitempty"` // The maintenance window muting the alert, if any
 	AlertDedupEvent
 }
 
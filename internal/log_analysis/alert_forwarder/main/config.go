@@ -24,6 +24,8 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
 	"github.com/kelseyhightower/envconfig"
@@ -33,10 +35,11 @@ import (
 )
 
 var (
-	env        envConfig
-	awsSession *session.Session
-	ddbClient  dynamodbiface.DynamoDBAPI
-	sqsClient  sqsiface.SQSAPI
+	env          envConfig
+	awsSession   *session.Session
+	ddbClient    dynamodbiface.DynamoDBAPI
+	sqsClient    sqsiface.SQSAPI
+	lambdaClient lambdaiface.LambdaAPI
 
 	httpClient   *http.Client
 	policyClient *policiesclient.PantherAnalysisAPI
@@ -48,6 +51,7 @@ type envConfig struct {
 	AlertingQueueURL string `required:"true" split_words:"true"`
 	AnalysisAPIHost  string `required:"true" split_words:"true"`
 	AnalysisAPIPath  string `required:"true" split_words:"true"`
+	OutputsAPI       string `required:"true" split_words:"true"`
 }
 
 // Setup parses the environment and builds the AWS and http clients.
@@ -57,6 +61,7 @@ func Setup() {
 	awsSession = session.Must(session.NewSession())
 	ddbClient = dynamodb.New(awsSession)
 	sqsClient = sqs.New(awsSession)
+	lambdaClient = lambda.New(awsSession)
 	httpClient = gatewayapi.GatewayClient(awsSession)
 	policyConfig = policiesclient.DefaultTransportConfig().
 		WithHost(env.AnalysisAPIHost).
@@ -56,6 +56,8 @@ func init() {
 		AlertingQueueURL: env.AlertingQueueURL,
 		AlertTable:       env.AlertsTable,
 		MetricsLogger:    metricsLogger,
+
+		MaintenanceWindows: forwarder.NewMaintenanceWindowCache(lambdaClient, env.OutputsAPI),
 	}
 }
 
@@ -105,4 +105,6 @@ type AlertItem struct {
 	LastUpdatedBy string `json:"lastUpdatedBy"`
 	// LastUpdatedByTime - stores the timestamp of the last person who modified the Alert
 	LastUpdatedByTime time.Time `json:"lastUpdatedByTime"`
+	// MaintenanceWindowID - stores the ID of the maintenance window which muted the Alert, if any
+	MaintenanceWindowID *string `json:"maintenanceWindowId"`
 }
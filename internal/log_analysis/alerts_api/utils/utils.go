@@ -65,6 +65,8 @@ func AlertItemToSummary(item *table.AlertItem) *models.AlertSummary {
 		LastUpdatedByTime: item.LastUpdatedByTime,
 		UpdateTime:        &item.UpdateTime,
 		DeliveryResponses: item.DeliveryResponses,
+
+		MaintenanceWindowID: item.MaintenanceWindowID,
 	}
 }
 
@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
@@ -33,6 +34,8 @@ import (
 type ClassifierAPI interface {
 	// Classify attempts to classify the provided log line
 	Classify(log string) (*ClassifierResult, error)
+	// Flush returns the events held back by the parsers, it is called when the input ends
+	Flush() (*ClassifierResult, error)
 	// aggregate stats
 	Stats() *ClassifierStats
 	// per-parser stats, map of LogType -> stats
@@ -91,6 +94,17 @@ func safeLogParse(logType string, parser parsers.Interface, log string) (results
 	return results, nil
 }
 
+// catch panics from parsers flushing their input, log and continue
+func safeFlush(logType string, flusher parsers.Flusher) (results []*parsers.Result, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Errorf("parser %q panic: %v", logType, r)
+			results = nil
+		}
+	}()
+	return flusher.Flush()
+}
+
 // Classify attempts to classify the provided log line
 func (c *Classifier) Classify(log string) (*ClassifierResult, error) {
 	startClassify := time.Now().UTC()
@@ -179,6 +193,44 @@ func (c *Classifier) Classify(log string) (*ClassifierResult, error) {
 	return result, nil
 }
 
+// Flush returns the events held back by the parsers which reassemble events logged over multiple lines.
+// Events are returned even if some parsers fail to flush, the errors of all parsers are combined.
+func (c *Classifier) Flush() (*ClassifierResult, error) {
+	result := &ClassifierResult{}
+	var err error
+	for _, item := range c.parsers.items {
+		flusher, ok := item.parser.(parsers.Flusher)
+		if !ok {
+			continue
+		}
+		events, flushErr := safeFlush(item.logType, flusher)
+		if flushErr != nil {
+			err = multierr.Append(err, errors.WithMessagef(flushErr, "failed to flush %q parser", item.logType))
+			continue
+		}
+		if len(events) == 0 {
+			continue
+		}
+		result.Matched = true
+		result.Events = append(result.Events, events...)
+
+		// Flushed events are counted in the stats of the parser, as they are parsed from lines already counted
+		c.stats.EventCount += uint64(len(events))
+		parserStat, ok := c.parserStats[item.logType]
+		if !ok {
+			parserStat = &ParserStats{
+				LogType: item.logType,
+			}
+			c.parserStats[item.logType] = parserStat
+		}
+		parserStat.EventCount += uint64(len(events))
+		for _, event := range events {
+			parserStat.CombinedLatency += uint64(event.PantherParseTime.Sub(event.PantherEventTime).Milliseconds())
+		}
+	}
+	return result, err
+}
+
 // aggregate stats
 type ClassifierStats struct {
 	ClassifyTimeMicroseconds    uint64 // total time parsing
@@ -207,6 +207,59 @@ func TestClassifyParserReturningEmptyResults(t *testing.T) {
 	parser.AssertExpectations(t)
 }
 
+// flushingParser is a parser holding back log lines until the input ends
+type flushingParser struct {
+	testutil.MockParser
+}
+
+func (p *flushingParser) Flush() ([]*parsers.Result, error) {
+	args := p.MethodCalled("Flush")
+	return args.Get(0).([]*parsers.Result), args.Error(1)
+}
+
+func TestClassifierFlush(t *testing.T) {
+	tm := time.Now().UTC()
+	held := &parsers.Result{
+		CoreFields: pantherlog.CoreFields{
+			PantherLogType:   "held",
+			PantherEventTime: tm,
+			PantherParseTime: tm,
+		},
+	}
+	heldParser := &flushingParser{}
+	heldParser.On("Flush").Return([]*parsers.Result{held}, nil).Once()
+	failingParser := &flushingParser{}
+	failingParser.On("Flush").Return(([]*parsers.Result)(nil), errors.New("fail")).Once()
+	classifier := NewClassifier(map[string]parsers.Interface{
+		"held":    heldParser,
+		"failing": failingParser,
+		// Parsers not holding back log lines are skipped
+		"other": testutil.AlwaysFailParser(errors.New("fail")),
+	})
+
+	result, err := classifier.Flush()
+	// Events are returned even if another parser fails to flush
+	require.Error(t, err)
+	require.Equal(t, &ClassifierResult{Events: []*parsers.Result{held}, Matched: true}, result)
+	require.Equal(t, uint64(1), classifier.Stats().EventCount)
+	require.Equal(t, uint64(1), classifier.ParserStats()["held"].EventCount)
+	require.Nil(t, classifier.ParserStats()["failing"])
+	heldParser.AssertExpectations(t)
+	failingParser.AssertExpectations(t)
+}
+
+func TestClassifierFlushPanic(t *testing.T) {
+	panicParser := &flushingParser{}
+	panicParser.On("Flush").Run(func(args mock.Arguments) { panic("test parser panic") })
+	classifier := NewClassifier(map[string]parsers.Interface{
+		"panic": panicParser,
+	})
+
+	result, err := classifier.Flush()
+	require.Error(t, err)
+	require.Equal(t, &ClassifierResult{}, result)
+}
+
 func TestClassifyNoLogline(t *testing.T) {
 	testSkipClassify("", t)
 }
@@ -20,6 +20,7 @@ package logtypes
 
 import (
 	"net/url"
+	"strings"
 	"time"
 
 	jsoniter "github.com/json-iterator/go"
@@ -273,7 +274,16 @@ func checkGlue(schema interface{}) (err error) {
 	}()
 	cols, _ := awsglue.InferJSONColumns(schema, awsglue.GlueMappings...)
 	if len(cols) == 0 {
-		err = errors.New("empty columns")
+		return errors.New("empty columns")
+	}
+	// Glue column names are case insensitive
+	names := make(map[string]string, len(cols))
+	for _, col := range cols {
+		name := strings.ToLower(col.Name)
+		if other, duplicate := names[name]; duplicate {
+			return errors.Errorf("columns %q and %q have the same name", other, col.Name)
+		}
+		names[name] = col.Name
 	}
 	return
 }
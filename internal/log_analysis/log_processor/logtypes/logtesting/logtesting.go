@@ -35,6 +35,7 @@ import (
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/omitempty"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/tcodec"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
 )
 
 // RunTestsFromYAML reads all test cases in a YAML file and runs them.
@@ -75,6 +76,7 @@ func RunTests(t *testing.T, tests ...TestCase) {
 type TestCase struct {
 	Name    string          `json:"name" yaml:"name"`
 	Input   string          `json:"input" yaml:"input"`
+	Inputs  []string        `json:"inputs" yaml:"inputs"`
 	Result  string          `json:"result" yaml:"result"`
 	Results []string        `json:"results" yaml:"results"`
 	LogType string          `json:"logType" yaml:"logType"`
@@ -83,11 +85,25 @@ type TestCase struct {
 
 // Run runs a test case
 func (c *TestCase) Run(t *testing.T) {
-	TestRegisteredParser(t, c.Resolve, c.LogType, c.Input, append([]string{c.Result}, c.Results...)...)
+	expect := append([]string{c.Result}, c.Results...)
+	if c.Inputs != nil {
+		TestRegisteredParserInputs(t, c.Resolve, c.LogType, c.Inputs, expect...)
+		return
+	}
+	TestRegisteredParser(t, c.Resolve, c.LogType, c.Input, expect...)
 }
 
 // TestRegisteredParser is a helper to run a test for a registered log parser
 func TestRegisteredParser(t *testing.T, resolve logtypes.Finder, logType, input string, expect ...string) {
+	t.Helper()
+	TestRegisteredParserInputs(t, resolve, logType, []string{input}, expect...)
+}
+
+// TestRegisteredParserInputs is a helper to run a test for a registered log parser with multiple input lines.
+// All lines are parsed in order by the same parser and the results of all lines are checked,
+// including the results held back by the parser until the input ends.
+// It is useful for parsers that combine multiple lines into a single result.
+func TestRegisteredParserInputs(t *testing.T, resolve logtypes.Finder, logType string, inputs []string, expect ...string) {
 	t.Helper()
 	assert := require.New(t)
 	if resolve == nil {
@@ -97,8 +113,18 @@ func TestRegisteredParser(t *testing.T, resolve logtypes.Finder, logType, input
 	assert.NotNil(entry, "unresolved log type parser %q", logType)
 	p, err := entry.NewParser(nil)
 	assert.NoError(err, "failed to create log parser")
-	results, err := p.ParseLog(input)
-	assert.NoError(err)
+	var results []*pantherlog.Result
+	for _, input := range inputs {
+		r, err := p.ParseLog(input)
+		assert.NoError(err)
+		results = append(results, r...)
+	}
+	// Parsers holding back lines are flushed as they are at the end of a file
+	if flusher, ok := p.(parsers.Flusher); ok {
+		r, err := flusher.Flush()
+		assert.NoError(err)
+		results = append(results, r...)
+	}
 	if len(expect) == 0 {
 		require.Nil(t, results)
 		return
@@ -70,6 +70,17 @@ func TestRegistry(t *testing.T) {
 	require.Error(t, err)
 	require.Nil(t, nilEntry)
 
+	// Ensure schemas with columns differing only in case don't pass
+	type Dup struct {
+		Foo string `json:"foo" description:"foo field"`
+		FOO string `json:"FOO" description:"FOO field"`
+	}
+	configDup := config
+	configDup.Schema = Dup{}
+	dupEntry, err := configDup.BuildEntry()
+	require.Error(t, err)
+	require.Nil(t, dupEntry)
+
 	// Ensure nil schemas don't pass
 	configNil := Config{}
 	configNil.Schema = nil
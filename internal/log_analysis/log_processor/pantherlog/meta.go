@@ -70,6 +70,8 @@ const (
 	FieldAWSInstanceID
 	FieldAWSARN
 	FieldAWSTag
+	FieldUsername
+	FieldEmail
 )
 
 // ScanValues implements ValueScanner interface
@@ -196,6 +198,16 @@ func init() {
 		NameJSON:    "p_any_aws_tags",
 		Description: "Panther added field with collection of AWS Tags associated with the row",
 	})
+	MustRegisterIndicator(FieldUsername, FieldMeta{
+		Name:        "PantherAnyUsernames",
+		NameJSON:    "p_any_usernames",
+		Description: "Panther added field with collection of usernames associated with the row",
+	})
+	MustRegisterIndicator(FieldEmail, FieldMeta{
+		Name:        "PantherAnyEmails",
+		NameJSON:    "p_any_emails",
+		Description: "Panther added field with collection of email addresses associated with the row",
+	})
 	MustRegisterScanner("ip", ValueScannerFunc(ScanIPAddress), FieldIPAddress)
 	MustRegisterScanner("domain", FieldDomainName, FieldDomainName)
 	MustRegisterScanner("md5", FieldMD5Hash, FieldMD5Hash)
@@ -204,6 +216,8 @@ func init() {
 	MustRegisterScanner("hostname", ValueScannerFunc(ScanHostname), FieldDomainName, FieldIPAddress)
 	MustRegisterScanner("url", ValueScannerFunc(ScanURL), FieldDomainName, FieldIPAddress)
 	MustRegisterScanner("trace_id", FieldTraceID, FieldTraceID)
+	MustRegisterScanner("username", FieldUsername, FieldUsername)
+	MustRegisterScanner("email", ValueScannerFunc(ScanEmail), FieldEmail)
 	MustRegisterScanner("net_addr", ValueScannerFunc(ScanNetworkAddress), FieldIPAddress, FieldDomainName)
 }
 
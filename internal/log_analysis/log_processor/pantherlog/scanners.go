@@ -148,6 +148,15 @@ func ScanIPAddress(w ValueWriter, input string) {
 	}
 }
 
+// ScanEmail scans `input` for an email address value.
+// Values that do not look like an email address (ie `user@domain`) are ignored.
+func ScanEmail(w ValueWriter, input string) {
+	input = strings.TrimSpace(input)
+	if pos := strings.LastIndexByte(input, '@'); 0 < pos && pos < len(input)-1 {
+		w.WriteValues(FieldEmail, input)
+	}
+}
+
 // checkIPAddress checks if an IP address is valid
 // TODO: [performance] Use a simpler method to check ip addresses than net.ParseIP to avoid allocations.
 func checkIPAddress(addr string) bool {
@@ -17,3 +17,19 @@ package pantherlog
  * You should have received a copy of the GNU Affero General Public License
  * along with this program.  If not, see <https://www.gnu.org/licenses/>.
  */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanEmail(t *testing.T) {
+	b := ValueBuffer{}
+	for _, input := range []string{"", "@", "jdoe", "jdoe@", "@example.com"} {
+		ScanEmail(&b, input)
+	}
+	require.True(t, b.IsEmpty())
+	ScanEmail(&b, " jdoe@example.com ")
+	require.Equal(t, []string{"jdoe@example.com"}, b.Get(FieldEmail))
+}
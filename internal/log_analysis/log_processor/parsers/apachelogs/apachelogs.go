@@ -27,12 +27,14 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
 )
 
 const (
 	TypeAccessCombined = `Apache.AccessCombined`
 	TypeAccessCommon   = `Apache.AccessCommon`
+	TypeError          = `Apache.Error`
 )
 
 // LogTypes exports the available log type entries
@@ -55,6 +57,15 @@ var logTypes = logtypes.Must("Apache",
 		Schema:       AccessCommon{},
 		NewParser:    parsers.AdapterFactory(NewAccessCommonParser()),
 	},
+	logtypes.Config{
+		Name:         TypeError,
+		Description:  `Apache HTTP server error logs using the default format`,
+		ReferenceURL: `https://httpd.apache.org/docs/current/logs.html#errorlog`,
+		Schema:       pantherlog.MustBuildEventSchema(&Error{}),
+		NewParser: parsers.FactoryFunc(func(_ interface{}) (parsers.Interface, error) {
+			return NewErrorParser(), nil
+		}),
+	},
 )
 
 // 	[day/month/year:hour:minute:second zone]
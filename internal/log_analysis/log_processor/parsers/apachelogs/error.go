@@ -0,0 +1,184 @@
+package apachelogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+// Error is an Apache HTTP server error log entry
+// nolint:lll
+type Error struct {
+	Time       pantherlog.Time   `json:"time" tcodec:"rfc3339" event_time:"true" validate:"required" description:"The time the error was logged (in the local time of the server)."`
+	Module     pantherlog.String `json:"module" description:"The module that produced the error (ie core, ssl, proxy, security2)."`
+	Level      pantherlog.String `json:"level" validate:"required" description:"The severity level of the error (ie error, warn, notice, crit)."`
+	PID        pantherlog.Int64  `json:"pid" description:"The process ID of the process that logged the error."`
+	TID        pantherlog.String `json:"tid" description:"The thread ID of the thread that logged the error."`
+	ClientIP   pantherlog.String `json:"clientIp" panther:"ip" description:"The IP address of the client that made the request."`
+	ClientPort pantherlog.Uint16 `json:"clientPort" description:"The port of the client that made the request."`
+	RemoteIP   pantherlog.String `json:"remoteIp" panther:"ip" description:"The IP address of the peer of the connection, if it differs from the client (ie when behind a proxy)."`
+	RemotePort pantherlog.Uint16 `json:"remotePort" description:"The port of the peer of the connection."`
+	ErrorCode  pantherlog.String `json:"errorCode" description:"The Apache error code of the message (ie AH00126)."`
+	Message    pantherlog.String `json:"message" description:"The error message."`
+}
+
+// Apache logs the time in the ctime format with optional microseconds (ie [Thu Nov 05 13:51:22.123456 2020]).
+// Go accepts fractional seconds when parsing even if the layout does not specify them.
+const layoutErrorTime = `Mon Jan 02 15:04:05 2006`
+
+var rxErrorCode = regexp.MustCompile(`^AH\d{5}: `)
+
+// ErrorParser parses Apache HTTP server error logs in the default format of Apache 2.2 and 2.4
+type ErrorParser struct {
+	builder pantherlog.ResultBuilder
+}
+
+var _ parsers.Interface = (*ErrorParser)(nil)
+
+// NewErrorParser creates a parser for Apache error logs
+func NewErrorParser() *ErrorParser {
+	return &ErrorParser{}
+}
+
+// ParseLog implements parsers.Interface
+func (p *ErrorParser) ParseLog(log string) ([]*parsers.Result, error) {
+	event := Error{}
+	if err := event.parse(strings.TrimSpace(log)); err != nil {
+		return nil, err
+	}
+	if err := pantherlog.ValidateStruct(&event); err != nil {
+		return nil, err
+	}
+	result, err := p.builder.BuildResult(TypeError, &event)
+	if err != nil {
+		return nil, err
+	}
+	return []*parsers.Result{result}, nil
+}
+
+// parse parses the bracketed fields preceding the message of an error log.
+// The first two fields are always the time and the level (optionally prefixed by the module as `module:level`).
+// The rest are optional `pid`, `client` and `remote` fields.
+func (event *Error) parse(log string) error {
+	field, tail, ok := nextBracket(log)
+	if !ok {
+		return errors.New("invalid Apache error log time")
+	}
+	tm, err := time.Parse(layoutErrorTime, field)
+	if err != nil {
+		return err
+	}
+	event.Time = tm.UTC()
+	if field, tail, ok = nextBracket(tail); !ok {
+		return errors.New("invalid Apache error log level")
+	}
+	if pos := strings.IndexByte(field, ':'); pos != -1 {
+		if module := field[:pos]; module != "" {
+			event.Module = null.FromString(module)
+		}
+		field = field[pos+1:]
+	}
+	event.Level = null.FromString(field)
+	for {
+		field, rest, ok := nextBracket(tail)
+		if !ok {
+			break
+		}
+		known, err := event.setField(field)
+		if err != nil {
+			return err
+		}
+		if !known {
+			// The message starts with a bracket (ie ModSecurity messages)
+			break
+		}
+		tail = rest
+	}
+	if code := rxErrorCode.FindString(tail); code != "" {
+		event.ErrorCode = null.FromString(strings.TrimSuffix(code, ": "))
+		tail = tail[len(code):]
+	}
+	event.Message = null.FromString(tail)
+	return nil
+}
+
+// setField sets the value of a `pid`, `client` or `remote` field.
+// It returns false if the field is not one of those.
+func (event *Error) setField(field string) (bool, error) {
+	pos := strings.IndexByte(field, ' ')
+	if pos == -1 {
+		return false, nil
+	}
+	name, value := field[:pos], field[pos+1:]
+	switch name {
+	case "pid":
+		// Threaded MPMs log `pid 1234:tid 140234`
+		if pos := strings.Index(value, ":tid "); pos != -1 {
+			event.TID = null.FromString(value[pos+len(":tid "):])
+			value = value[:pos]
+		}
+		pid, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return true, errors.Wrap(err, "invalid Apache error log pid")
+		}
+		event.PID = null.FromInt64(pid)
+	case "client":
+		ip, port := splitAddress(value)
+		event.ClientIP, event.ClientPort = ip, port
+	case "remote":
+		ip, port := splitAddress(value)
+		event.RemoteIP, event.RemotePort = ip, port
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+// nextBracket splits the next field surrounded by square brackets
+func nextBracket(s string) (field, tail string, ok bool) {
+	if !strings.HasPrefix(s, "[") {
+		return "", s, false
+	}
+	end := strings.IndexByte(s, ']')
+	if end == -1 {
+		return "", s, false
+	}
+	return s[1:end], strings.TrimLeft(s[end+1:], " "), true
+}
+
+// splitAddress splits an address with an optional port.
+// Apache does not surround IPv6 addresses with brackets (ie `::1:58464`) so we only split the port if the rest is a valid IP.
+func splitAddress(addr string) (pantherlog.String, pantherlog.Uint16) {
+	if pos := strings.LastIndexByte(addr, ':'); pos != -1 {
+		if port, err := strconv.ParseUint(addr[pos+1:], 10, 16); err == nil && net.ParseIP(addr[:pos]) != nil {
+			return null.FromString(addr[:pos]), null.FromUint16(uint16(port))
+		}
+	}
+	return null.FromString(addr), pantherlog.Uint16{}
+}
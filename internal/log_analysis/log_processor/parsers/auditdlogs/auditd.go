@@ -0,0 +1,432 @@
+package auditdlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+// Event is an audit event combining all records with the same serial number.
+// nolint:lll
+type Event struct {
+	Timestamp     pantherlog.Time   `json:"timestamp" tcodec:"rfc3339" event_time:"true" validate:"required" description:"The time of the event."`
+	Serial        pantherlog.Int64  `json:"serial" validate:"required" description:"The serial number of the event, unique per host until reboot."`
+	Node          pantherlog.String `json:"node" panther:"hostname" description:"The name of the host that recorded the event (requires auditd name_format)."`
+	RecordTypes   []string          `json:"recordTypes" validate:"required,min=1" description:"The types of the records of the event in the order they were logged."`
+	Key           pantherlog.String `json:"key" description:"The keys of the audit rules that triggered the event, separated by commas."`
+	Arch          pantherlog.String `json:"arch" description:"The CPU architecture of the system call in hex."`
+	Syscall       pantherlog.String `json:"syscall" description:"The system call number."`
+	SyscallName   pantherlog.String `json:"syscallName" description:"The system call name (requires log_format=ENRICHED)."`
+	Success       pantherlog.String `json:"success" description:"Whether the system call succeeded (yes/no)."`
+	Exit          pantherlog.Int64  `json:"exit" description:"The return code of the system call."`
+	PID           pantherlog.Int64  `json:"pid" description:"The process ID."`
+	PPID          pantherlog.Int64  `json:"ppid" description:"The parent process ID."`
+	UID           pantherlog.Int64  `json:"uid" description:"The user ID of the process."`
+	AUID          pantherlog.Int64  `json:"auid" description:"The audit (login) user ID. 4294967295 means the user ID is unset."`
+	EUID          pantherlog.Int64  `json:"euid" description:"The effective user ID of the process."`
+	SUID          pantherlog.Int64  `json:"suid" description:"The saved user ID of the process."`
+	FSUID         pantherlog.Int64  `json:"fsuid" description:"The file system user ID of the process."`
+	GID           pantherlog.Int64  `json:"gid" description:"The group ID of the process."`
+	EGID          pantherlog.Int64  `json:"egid" description:"The effective group ID of the process."`
+	SGID          pantherlog.Int64  `json:"sgid" description:"The saved group ID of the process."`
+	FSGID         pantherlog.Int64  `json:"fsgid" description:"The file system group ID of the process."`
+	UserName      pantherlog.String `json:"userName" panther:"username" description:"The user name of the uid (requires log_format=ENRICHED)."`
+	AuditUserName pantherlog.String `json:"auditUserName" panther:"username" description:"The user name of the auid (requires log_format=ENRICHED)."`
+	Session       pantherlog.Int64  `json:"ses" description:"The session ID of the process."`
+	TTY           pantherlog.String `json:"tty" description:"The terminal of the process."`
+	Comm          pantherlog.String `json:"comm" description:"The command name of the process."`
+	Exe           pantherlog.String `json:"exe" description:"The path to the executable of the process."`
+	Subject       pantherlog.String `json:"subj" description:"The security context of the process."`
+	Argc          pantherlog.Int64  `json:"argc" description:"The number of arguments of an execve system call."`
+	Argv          []string          `json:"argv" description:"The arguments of an execve system call or the process title."`
+	ProcTitle     pantherlog.String `json:"proctitle" description:"The full command line of the process."`
+	CWD           pantherlog.String `json:"cwd" description:"The current working directory of the process."`
+	Paths         []Path            `json:"paths" description:"The file paths passed to the system call."`
+	Operation     pantherlog.String `json:"op" description:"The operation of a user space event."`
+	Account       pantherlog.String `json:"acct" panther:"username" description:"The account name of a user space event."`
+	Hostname      pantherlog.String `json:"hostname" panther:"hostname" description:"The remote host name of a user space event."`
+	Address       pantherlog.String `json:"addr" panther:"ip" description:"The remote address of a user space event."`
+	Terminal      pantherlog.String `json:"terminal" description:"The terminal of a user space event."`
+	Result        pantherlog.String `json:"res" description:"The result of a user space event (success/failed)."`
+	Records       []Record          `json:"records" description:"All records of the event with their fields."`
+}
+
+// Path is a PATH record of an event
+// nolint:lll
+type Path struct {
+	Item     pantherlog.Int64  `json:"item" description:"The index of the path in the system call arguments."`
+	Name     pantherlog.String `json:"name" description:"The path passed to the system call."`
+	Inode    pantherlog.Int64  `json:"inode" description:"The inode number of the file."`
+	Dev      pantherlog.String `json:"dev" description:"The device of the file."`
+	Mode     pantherlog.String `json:"mode" description:"The file type and permissions in octal."`
+	OUID     pantherlog.Int64  `json:"ouid" description:"The user ID of the file owner."`
+	OGID     pantherlog.Int64  `json:"ogid" description:"The group ID of the file owner."`
+	NameType pantherlog.String `json:"nametype" description:"The type of the path (NORMAL, PARENT, CREATE, DELETE, ...)."`
+}
+
+// Record is a single record of an event
+// nolint:lll
+type Record struct {
+	Type   pantherlog.String `json:"type" validate:"required" description:"The type of the record."`
+	Fields map[string]string `json:"fields" description:"The fields of the record. Hex encoded values are decoded."`
+}
+
+func newEvent(records []*record) *Event {
+	first := records[0]
+	event := Event{
+		Timestamp: first.Timestamp,
+		Serial:    null.FromInt64(first.Serial),
+		Node:      nonEmpty(first.Node),
+	}
+	// Long command lines are split over multiple EXECVE records
+	var execve map[string]string
+	for _, r := range records {
+		event.RecordTypes = append(event.RecordTypes, r.Type)
+		event.Records = append(event.Records, Record{
+			Type:   null.FromString(r.Type),
+			Fields: r.Fields,
+		})
+		switch r.Type {
+		case "EXECVE":
+			if execve == nil {
+				execve = make(map[string]string, len(r.Fields))
+			}
+			for key, value := range r.Fields {
+				execve[key] = value
+			}
+			continue
+		case "CWD":
+			setString(&event.CWD, r.Fields["cwd"])
+			continue
+		case "PATH":
+			event.Paths = append(event.Paths, newPath(r.Fields))
+			continue
+		case "PROCTITLE":
+			setString(&event.ProcTitle, r.Fields["proctitle"])
+			if event.Argv == nil {
+				event.Argv = r.ProcTitle
+			}
+			continue
+		}
+		event.setFields(r.Fields)
+	}
+	if execve != nil {
+		event.setExecve(execve)
+	}
+	return &event
+}
+
+// setFields sets the process and user space fields of an event.
+// If a field appears in multiple records, the first value is used.
+func (event *Event) setFields(fields map[string]string) {
+	for _, f := range []struct {
+		Key   string
+		Value *pantherlog.String
+	}{
+		{"key", &event.Key},
+		{"arch", &event.Arch},
+		{"syscall", &event.Syscall},
+		{"SYSCALL", &event.SyscallName},
+		{"success", &event.Success},
+		{"UID", &event.UserName},
+		{"AUID", &event.AuditUserName},
+		{"tty", &event.TTY},
+		{"comm", &event.Comm},
+		{"exe", &event.Exe},
+		{"cwd", &event.CWD},
+		{"subj", &event.Subject},
+		{"op", &event.Operation},
+		{"acct", &event.Account},
+		{"hostname", &event.Hostname},
+		{"addr", &event.Address},
+		{"terminal", &event.Terminal},
+		{"res", &event.Result},
+	} {
+		setString(f.Value, fields[f.Key])
+	}
+	for _, f := range []struct {
+		Key   string
+		Value *pantherlog.Int64
+	}{
+		{"exit", &event.Exit},
+		{"pid", &event.PID},
+		{"ppid", &event.PPID},
+		{"uid", &event.UID},
+		{"auid", &event.AUID},
+		{"euid", &event.EUID},
+		{"suid", &event.SUID},
+		{"fsuid", &event.FSUID},
+		{"gid", &event.GID},
+		{"egid", &event.EGID},
+		{"sgid", &event.SGID},
+		{"fsgid", &event.FSGID},
+		{"ses", &event.Session},
+	} {
+		setInt64(f.Value, fields[f.Key])
+	}
+}
+
+// setExecve sets the arguments from the fields of all EXECVE records of an event.
+// The arguments are numbered across records, so the fields of all records are merged beforehand.
+func (event *Event) setExecve(fields map[string]string) {
+	setInt64(&event.Argc, fields["argc"])
+	argc := int(event.Argc.Value)
+	if argc > len(fields) {
+		argc = len(fields)
+	}
+	argv := make([]string, 0, argc)
+	for i := 0; i < argc; i++ {
+		key := "a" + strconv.Itoa(i)
+		if arg, ok := fields[key]; ok {
+			argv = append(argv, arg)
+			continue
+		}
+		// Long arguments are split in chunks (a1[0], a1[1], ...)
+		var chunks strings.Builder
+		for j := 0; ; j++ {
+			chunk, ok := fields[key+"["+strconv.Itoa(j)+"]"]
+			if !ok {
+				break
+			}
+			chunks.WriteString(chunk)
+		}
+		argv = append(argv, decodeHex(chunks.String()))
+	}
+	// EXECVE arguments are preferred to the process title which is truncated
+	event.Argv = argv
+}
+
+func newPath(fields map[string]string) Path {
+	p := Path{}
+	setInt64(&p.Item, fields["item"])
+	setString(&p.Name, fields["name"])
+	setInt64(&p.Inode, fields["inode"])
+	setString(&p.Dev, fields["dev"])
+	setString(&p.Mode, fields["mode"])
+	setInt64(&p.OUID, fields["ouid"])
+	setInt64(&p.OGID, fields["ogid"])
+	setString(&p.NameType, fields["nametype"])
+	return p
+}
+
+func nonEmpty(value string) pantherlog.String {
+	switch value {
+	case "", "?", "(null)", "(none)":
+		return pantherlog.String{}
+	default:
+		return null.FromString(value)
+	}
+}
+
+func setString(dst *pantherlog.String, value string) {
+	if dst.Exists {
+		return
+	}
+	*dst = nonEmpty(value)
+}
+
+func setInt64(dst *pantherlog.Int64, value string) {
+	if dst.Exists || value == "" {
+		return
+	}
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+		*dst = null.FromInt64(n)
+	}
+}
+
+const (
+	// maxPendingEvents bounds the events being reassembled, the oldest one is emitted when the limit is reached
+	maxPendingEvents = 100
+	// staleAfterRecords is the number of records of other events after which an incomplete event is emitted.
+	// Records of concurrent events are interleaved, but the records of an event are logged close together.
+	staleAfterRecords = 100
+)
+
+// Parser parses audit log files.
+// Records of concurrent events are interleaved, so parsers reassemble the records by serial number.
+// A system call event is complete when its PROCTITLE (always the last record) or EOE record is read.
+// Events of a single record, such as user space events (USER_LOGIN, CRED_ACQ, ...), are complete right away.
+// Other events are emitted with the records read so far once they are stale, that is once
+// staleAfterRecords records of other events were read or too many events are pending.
+// The events still pending when the input ends are emitted by Flush.
+type Parser struct {
+	builder pantherlog.ResultBuilder
+	// pending holds the records of incomplete events
+	pending map[eventKey]*pendingEvent
+	// order lists the pending events from the oldest to the newest (emitted events are skipped)
+	order []eventKey
+	// numRecords is the number of records read, used to detect stale events
+	numRecords int
+}
+
+type eventKey struct {
+	Node   string
+	Serial int64
+}
+
+type pendingEvent struct {
+	Records []*record
+	// LastRecord is the position of the last record of the event in the file
+	LastRecord int
+}
+
+var (
+	_ parsers.Interface = (*Parser)(nil)
+	_ parsers.Flusher   = (*Parser)(nil)
+)
+
+// NewParser creates a new audit log parser.
+func NewParser() *Parser {
+	return &Parser{
+		pending: make(map[eventKey]*pendingEvent),
+	}
+}
+
+// ParseLog implements parsers.Interface
+func (p *Parser) ParseLog(log string) ([]*parsers.Result, error) {
+	r, err := parseRecord(log)
+	if err != nil {
+		return nil, err
+	}
+	p.numRecords++
+	key := eventKey{Node: r.Node, Serial: r.Serial}
+
+	results, err := p.emitStale(key)
+	if err != nil {
+		return nil, err
+	}
+
+	event, ok := p.pending[key]
+	switch {
+	case r.Type == "EOE":
+		// The event was already emitted on its PROCTITLE record if it has one
+		if !ok {
+			return results, nil
+		}
+		return p.appendEmitted(results, key)
+	case !ok && isSingleRecordEvent(r.Type):
+		result, err := p.buildResult([]*record{r})
+		if err != nil {
+			return nil, err
+		}
+		return append(results, result), nil
+	case !ok:
+		event = &pendingEvent{}
+		p.pending[key] = event
+		p.order = append(p.order, key)
+	}
+	event.Records = append(event.Records, r)
+	event.LastRecord = p.numRecords
+
+	if r.Type == "PROCTITLE" {
+		return p.appendEmitted(results, key)
+	}
+	return results, nil
+}
+
+// Flush implements parsers.Flusher, it emits the pending events from the oldest to the newest
+func (p *Parser) Flush() ([]*parsers.Result, error) {
+	var results []*parsers.Result
+	for _, key := range p.order {
+		if _, ok := p.pending[key]; !ok {
+			// Already emitted
+			continue
+		}
+		var err error
+		if results, err = p.appendEmitted(results, key); err != nil {
+			return nil, err
+		}
+	}
+	p.order = nil
+	return results, nil
+}
+
+// emitStale emits the oldest pending events (except the current one) while they are stale or too many are pending
+func (p *Parser) emitStale(current eventKey) ([]*parsers.Result, error) {
+	var results []*parsers.Result
+	for len(p.order) > 0 {
+		key := p.order[0]
+		event, ok := p.pending[key]
+		if !ok {
+			// Already emitted
+			p.order = p.order[1:]
+			continue
+		}
+		stale := p.numRecords-event.LastRecord > staleAfterRecords || len(p.pending) >= maxPendingEvents
+		if key == current || !stale {
+			return results, nil
+		}
+		var err error
+		if results, err = p.appendEmitted(results, key); err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+// appendEmitted emits a pending event and appends it to the results
+func (p *Parser) appendEmitted(results []*parsers.Result, key eventKey) ([]*parsers.Result, error) {
+	event := p.pending[key]
+	delete(p.pending, key)
+	result, err := p.buildResult(event.Records)
+	if err != nil {
+		return nil, err
+	}
+	return append(results, result), nil
+}
+
+func (p *Parser) buildResult(records []*record) (*parsers.Result, error) {
+	event := newEvent(records)
+	if err := pantherlog.ValidateStruct(event); err != nil {
+		return nil, err
+	}
+	return p.builder.BuildResult(TypeAuditd, event)
+}
+
+// isSingleRecordEvent checks if a record type is logged as an event of its own.
+// These records are not part of a system call event and are not followed by an EOE record.
+func isSingleRecordEvent(recordType string) bool {
+	switch recordType {
+	case "ANOM_ABEND", "KERNEL", "LOGIN", "TRUSTED_APP":
+		return true
+	default:
+		return isUserSpaceRecord(recordType)
+	}
+}
+
+// isUserSpaceRecord checks if a record type is sent by user space programs.
+// These records are not part of a system call event and are not followed by an EOE record.
+func isUserSpaceRecord(recordType string) bool {
+	for _, prefix := range []string{"USER_", "CRED_", "DAEMON_", "SERVICE_", "SYSTEM_", "ANOM_LOGIN_", "ANOM_ADD_ACCT", "ANOM_DEL_ACCT"} {
+		if strings.HasPrefix(recordType, prefix) {
+			return true
+		}
+	}
+	switch recordType {
+	case "ADD_USER", "DEL_USER", "ADD_GROUP", "DEL_GROUP", "GRP_AUTH", "CHGRP_ID", "CHUSER_ID":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,52 @@
+// Package auditdlogs parses logs written by the Linux Audit daemon
+package auditdlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+const (
+	TypeAuditd = "Auditd.Event"
+)
+
+// LogTypes exports the available log type entries
+func LogTypes() logtypes.Group {
+	return logTypes
+}
+
+// nolint:lll
+var logTypes = logtypes.Must("Auditd",
+	logtypes.Config{
+		Name:         TypeAuditd,
+		Description:  `Linux Audit daemon events, with all records of an event (SYSCALL, EXECVE, CWD, PATH, PROCTITLE, ...) combined in a single row.`,
+		ReferenceURL: `https://access.redhat.com/documentation/en-us/red_hat_enterprise_linux/7/html/security_guide/sec-understanding_audit_log_files`,
+		Schema: pantherlog.MustBuildEventSchema(&Event{},
+			pantherlog.FieldIPAddress,
+			pantherlog.FieldDomainName,
+			pantherlog.FieldUsername,
+		),
+		NewParser: parsers.FactoryFunc(func(_ interface{}) (parsers.Interface, error) {
+			return NewParser(), nil
+		}),
+	},
+)
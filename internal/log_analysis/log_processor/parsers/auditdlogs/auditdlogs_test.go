@@ -0,0 +1,255 @@
+package auditdlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes/logtesting"
+)
+
+func TestAuditd(t *testing.T) {
+	logtesting.RunTestsFromYAML(t, LogTypes(), "./testdata/auditd_tests.yml")
+}
+
+func TestParserReassemble(t *testing.T) {
+	// nolint:lll
+	lines := []string{
+		"node=web01 type=SYSCALL msg=audit(1602494464.100:24287): arch=c000003e syscall=59 success=yes exit=0 a0=55d3c4a0 a1=55d3c4b0 a2=55d3c4c0 a3=0 items=2 ppid=2686 pid=3538 auid=1000 uid=0 gid=0 euid=0 suid=0 fsuid=0 egid=0 sgid=0 fsgid=0 tty=pts0 ses=1 comm=\"cat\" exe=\"/usr/bin/cat\" key=\"sshd_config\"\x1dARCH=x86_64 SYSCALL=execve AUID=\"jdoe\" UID=\"root\"",
+		`node=web01 type=EXECVE msg=audit(1602494464.100:24287): argc=3 a0="cat" a1="/etc/ssh/sshd_config" a2=6D792066696C65`,
+		`node=web01 type=CWD msg=audit(1602494464.100:24287): cwd=2F746D702F6D7920646972`,
+		`node=web01 type=PATH msg=audit(1602494464.100:24287): item=0 name="/usr/bin/cat" inode=1234 dev=fd:00 mode=0100755 ouid=0 ogid=0 rdev=00:00 nametype=NORMAL`,
+		`node=web01 type=PROCTITLE msg=audit(1602494464.100:24287): proctitle=636174002F6574632F7373682F737368645F636F6E666967`,
+		`node=web01 type=EOE msg=audit(1602494464.100:24287): `,
+	}
+	p := NewParser()
+	for _, line := range lines[:len(lines)-2] {
+		results, err := p.ParseLog(line)
+		require.NoError(t, err)
+		require.Empty(t, results)
+	}
+	// PROCTITLE is the last record of a system call event
+	results, err := p.ParseLog(lines[len(lines)-2])
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	eoeResults, err := p.ParseLog(lines[len(lines)-1])
+	require.NoError(t, err)
+	require.Empty(t, eoeResults)
+	logtesting.TestResult(t, `{
+		"timestamp": "2020-10-12T09:21:04.1Z",
+		"serial": 24287,
+		"node": "web01",
+		"recordTypes": ["SYSCALL", "EXECVE", "CWD", "PATH", "PROCTITLE"],
+		"key": "sshd_config",
+		"arch": "c000003e",
+		"syscall": "59",
+		"syscallName": "execve",
+		"success": "yes",
+		"exit": 0,
+		"pid": 3538,
+		"ppid": 2686,
+		"uid": 0,
+		"auid": 1000,
+		"euid": 0,
+		"suid": 0,
+		"fsuid": 0,
+		"gid": 0,
+		"egid": 0,
+		"sgid": 0,
+		"fsgid": 0,
+		"userName": "root",
+		"auditUserName": "jdoe",
+		"ses": 1,
+		"tty": "pts0",
+		"comm": "cat",
+		"exe": "/usr/bin/cat",
+		"argc": 3,
+		"argv": ["cat", "/etc/ssh/sshd_config", "my file"],
+		"proctitle": "cat /etc/ssh/sshd_config",
+		"cwd": "/tmp/my dir",
+		"paths": [
+			{
+				"item": 0,
+				"name": "/usr/bin/cat",
+				"inode": 1234,
+				"dev": "fd:00",
+				"mode": "0100755",
+				"ouid": 0,
+				"ogid": 0,
+				"nametype": "NORMAL"
+			}
+		],
+		"records": [
+			{
+				"type": "SYSCALL",
+				"fields": {
+					"arch": "c000003e", "syscall": "59", "success": "yes", "exit": "0",
+					"a0": "55d3c4a0", "a1": "55d3c4b0", "a2": "55d3c4c0", "a3": "0", "items": "2",
+					"ppid": "2686", "pid": "3538", "auid": "1000", "uid": "0", "gid": "0", "euid": "0", "suid": "0", "fsuid": "0",
+					"egid": "0", "sgid": "0", "fsgid": "0", "tty": "pts0", "ses": "1", "comm": "cat", "exe": "/usr/bin/cat",
+					"key": "sshd_config", "ARCH": "x86_64", "SYSCALL": "execve", "AUID": "jdoe", "UID": "root"
+				}
+			},
+			{
+				"type": "EXECVE",
+				"fields": {"argc": "3", "a0": "cat", "a1": "/etc/ssh/sshd_config", "a2": "my file"}
+			},
+			{
+				"type": "CWD",
+				"fields": {"cwd": "/tmp/my dir"}
+			},
+			{
+				"type": "PATH",
+				"fields": {
+					"item": "0", "name": "/usr/bin/cat", "inode": "1234", "dev": "fd:00", "mode": "0100755",
+					"ouid": "0", "ogid": "0", "rdev": "00:00", "nametype": "NORMAL"
+				}
+			},
+			{
+				"type": "PROCTITLE",
+				"fields": {"proctitle": "cat /etc/ssh/sshd_config"}
+			}
+		],
+		"p_log_type": "Auditd.Event",
+		"p_event_time": "2020-10-12T09:21:04.1Z",
+		"p_any_domain_names": ["web01"],
+		"p_any_usernames": ["jdoe", "root"]
+	}`, results[0])
+}
+
+func TestParserInterleaved(t *testing.T) {
+	// nolint:lll
+	lines := []string{
+		`type=SYSCALL msg=audit(1602494464.100:100): arch=c000003e syscall=59 success=yes exit=0 ppid=1 pid=100 auid=1000 uid=0 comm="ls" exe="/usr/bin/ls"`,
+		`type=SYSCALL msg=audit(1602494464.100:101): arch=c000003e syscall=59 success=yes exit=0 ppid=1 pid=101 auid=1000 uid=0 comm="id" exe="/usr/bin/id"`,
+		`type=EXECVE msg=audit(1602494464.100:101): argc=1 a0="id"`,
+		`type=EXECVE msg=audit(1602494464.100:100): argc=2 a0="ls" a1="/tmp"`,
+		`type=USER_LOGIN msg=audit(1602494464.100:102): pid=4123 uid=0 auid=1000 ses=7 msg='op=login id=1000 exe="/usr/sbin/sshd" res=success'`,
+		`type=EOE msg=audit(1602494464.100:100): `,
+		`type=EOE msg=audit(1602494464.100:101): `,
+	}
+	var events []*Event
+	p := NewParser()
+	for _, line := range lines {
+		results, err := p.ParseLog(line)
+		require.NoError(t, err)
+		for _, result := range results {
+			events = append(events, result.Event.(*Event))
+		}
+	}
+	require.Len(t, events, 3)
+	// Single record events are emitted right away
+	require.Equal(t, []string{"USER_LOGIN"}, events[0].RecordTypes)
+	require.Equal(t, []string{"SYSCALL", "EXECVE"}, events[1].RecordTypes)
+	require.Equal(t, []string{"ls", "/tmp"}, events[1].Argv)
+	require.Equal(t, []string{"SYSCALL", "EXECVE"}, events[2].RecordTypes)
+	require.Equal(t, []string{"id"}, events[2].Argv)
+}
+
+func TestParserStaleEvent(t *testing.T) {
+	// nolint:lll
+	lines := []string{
+		`type=AVC msg=audit(1602494464.100:100): avc:  denied  { read } for  pid=1234 comm="nginx" name="index.html" dev="sda1" ino=42 scontext=system_u:system_r:httpd_t:s0 tcontext=unconfined_u:object_r:user_home_t:s0 tclass=file permissive=0`,
+		`type=SYSCALL msg=audit(1602494464.100:100): arch=c000003e syscall=257 success=no exit=-13 ppid=1 pid=1234 auid=4294967295 uid=33 comm="nginx" exe="/usr/sbin/nginx"`,
+	}
+	p := NewParser()
+	for _, line := range lines {
+		results, err := p.ParseLog(line)
+		require.NoError(t, err)
+		require.Empty(t, results)
+	}
+	// The event is missing its EOE record, it is emitted once enough records of other events are read
+	for i := 0; i < staleAfterRecords; i++ {
+		results, err := p.ParseLog(`type=DAEMON_ROTATE msg=audit(1602494465.000:101): op=rotate-logs auid=0 pid=1 res=success`)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		require.Equal(t, []string{"DAEMON_ROTATE"}, results[0].Event.(*Event).RecordTypes)
+	}
+	results, err := p.ParseLog(`type=DAEMON_ROTATE msg=audit(1602494465.000:102): op=rotate-logs auid=0 pid=1 res=success`)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	require.Equal(t, []string{"AVC", "SYSCALL"}, results[0].Event.(*Event).RecordTypes)
+	require.Equal(t, "/usr/sbin/nginx", results[0].Event.(*Event).Exe.Value)
+	require.Equal(t, int64(-13), results[0].Event.(*Event).Exit.Value)
+	require.Equal(t, []string{"DAEMON_ROTATE"}, results[1].Event.(*Event).RecordTypes)
+
+	_, err = p.ParseLog(`not an audit record`)
+	require.Error(t, err)
+}
+
+func TestParserMaxPendingEvents(t *testing.T) {
+	p := NewParser()
+	for i := 0; i < maxPendingEvents; i++ {
+		results, err := p.ParseLog(fmt.Sprintf(`type=SYSCALL msg=audit(1602494464.100:%d): syscall=2 pid=1`, i))
+		require.NoError(t, err)
+		require.Empty(t, results)
+	}
+	// The oldest event is emitted to make room
+	results, err := p.ParseLog(`type=SYSCALL msg=audit(1602494464.100:1000): syscall=2 pid=1`)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, int64(0), results[0].Event.(*Event).Serial.Value)
+}
+
+func TestParserFlush(t *testing.T) {
+	// nolint:lll
+	lines := []string{
+		`type=SYSCALL msg=audit(1602494464.100:200): arch=c000003e syscall=59 success=yes exit=0 ppid=1 pid=200 auid=1000 uid=0 comm="ls" exe="/usr/bin/ls"`,
+		`type=PROCTITLE msg=audit(1602494464.100:200): proctitle="ls"`,
+		`type=EOE msg=audit(1602494464.100:200): `,
+		`type=LOGIN msg=audit(1602494464.200:201): pid=4123 uid=0 subj=system_u:system_r:sshd_t:s0 old-auid=4294967295 auid=1000 tty=(none) old-ses=4294967295 ses=7 res=1`,
+		`type=USER_START msg=audit(1602494464.300:202): pid=4123 uid=0 auid=1000 ses=7 msg='op=PAM:session_open acct="jdoe" exe="/usr/sbin/sshd" hostname=10.0.0.1 addr=10.0.0.1 terminal=ssh res=success'`,
+		`type=SYSCALL msg=audit(1602494464.400:203): arch=c000003e syscall=59 success=yes exit=0 ppid=1 pid=203 auid=1000 uid=0 comm="cat" exe="/usr/bin/cat"`,
+		`type=EXECVE msg=audit(1602494464.400:203): argc=2 a0="cat" a1="/etc/passwd"`,
+		`type=CWD msg=audit(1602494464.400:203): cwd="/root"`,
+		`type=PATH msg=audit(1602494464.400:203): item=0 name="/usr/bin/cat" inode=1234 dev=fd:00 mode=0100755 ouid=0 ogid=0 nametype=NORMAL`,
+	}
+	var events []*Event
+	p := NewParser()
+	for _, line := range lines {
+		results, err := p.ParseLog(line)
+		require.NoError(t, err)
+		for _, result := range results {
+			events = append(events, result.Event.(*Event))
+		}
+	}
+	// The last event is missing its PROCTITLE and EOE records, it is held back until the input ends
+	results, err := p.Flush()
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	events = append(events, results[0].Event.(*Event))
+
+	var serials []int64
+	for _, event := range events {
+		serials = append(serials, event.Serial.Value)
+	}
+	require.Equal(t, []int64{200, 201, 202, 203}, serials)
+	require.Equal(t, []string{"LOGIN"}, events[1].RecordTypes)
+	require.Equal(t, []string{"SYSCALL", "EXECVE", "CWD", "PATH"}, events[3].RecordTypes)
+	require.Equal(t, []string{"cat", "/etc/passwd"}, events[3].Argv)
+	require.Equal(t, "/root", events[3].CWD.Value)
+
+	// Nothing is left buffered
+	results, err = p.Flush()
+	require.NoError(t, err)
+	require.Empty(t, results)
+}
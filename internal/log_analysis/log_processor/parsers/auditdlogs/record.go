@@ -0,0 +1,203 @@
+package auditdlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// record is a single line of an audit log
+type record struct {
+	Node      string
+	Type      string
+	Timestamp time.Time
+	Serial    int64
+	Fields    map[string]string
+	// Arguments of the process title
+	ProcTitle []string
+}
+
+// Audit records have the form `node=host type=SYSCALL msg=audit(1602494464.100:1234): key=value ...`
+// The node part is optional and is only present if auditd is configured with a `name_format`.
+// Records written with `log_format=ENRICHED` have interpreted fields appended after a 0x1D character.
+func parseRecord(line string) (*record, error) {
+	const (
+		prefixNode = "node="
+		prefixType = "type="
+		prefixMsg  = "msg=audit("
+	)
+	r := record{}
+	line = strings.TrimSpace(line)
+	if strings.HasPrefix(line, prefixNode) {
+		node, tail := splitToken(line[len(prefixNode):])
+		r.Node, line = node, tail
+	}
+	if !strings.HasPrefix(line, prefixType) {
+		return nil, errors.New("invalid audit record type")
+	}
+	r.Type, line = splitToken(line[len(prefixType):])
+	if r.Type == "" || !strings.HasPrefix(line, prefixMsg) {
+		return nil, errors.New("invalid audit record message")
+	}
+	line = line[len(prefixMsg):]
+	end := strings.Index(line, "):")
+	if end == -1 {
+		return nil, errors.New("invalid audit record message")
+	}
+	tm, serial, err := parseEventID(line[:end])
+	if err != nil {
+		return nil, err
+	}
+	r.Timestamp, r.Serial = tm, serial
+	r.Fields = make(map[string]string)
+	splitFields(line[end+len("):"):], func(key, value string, quoted bool) {
+		if !quoted && isEncoded(r.Type, key) {
+			value = decodeHex(value)
+		}
+		r.Fields[key] = value
+	})
+	if title, ok := r.Fields["proctitle"]; ok {
+		r.ProcTitle = strings.Split(strings.TrimRight(title, "\x00"), "\x00")
+		r.Fields["proctitle"] = strings.Join(r.ProcTitle, " ")
+	}
+	if key, ok := r.Fields["key"]; ok {
+		// Multiple keys of a rule are separated by 0x01
+		r.Fields["key"] = strings.ReplaceAll(key, "\x01", ",")
+	}
+	return &r, nil
+}
+
+func splitToken(s string) (string, string) {
+	if pos := strings.IndexByte(s, ' '); pos != -1 {
+		return s[:pos], strings.TrimLeft(s[pos:], " ")
+	}
+	return s, ""
+}
+
+// parseEventID parses the `1602494464.100:1234` event id of an audit record
+func parseEventID(id string) (time.Time, int64, error) {
+	pos := strings.IndexByte(id, ':')
+	if pos == -1 {
+		return time.Time{}, 0, errors.Errorf("invalid audit event id %q", id)
+	}
+	serial, err := strconv.ParseInt(id[pos+1:], 10, 64)
+	if err != nil {
+		return time.Time{}, 0, errors.Errorf("invalid audit event serial %q", id)
+	}
+	ts, err := strconv.ParseFloat(id[:pos], 64)
+	if err != nil {
+		return time.Time{}, 0, errors.Errorf("invalid audit event timestamp %q", id)
+	}
+	// Timestamps have millisecond precision
+	msec := int64(ts*1000 + 0.5)
+	return time.Unix(0, msec*int64(time.Millisecond)).UTC(), serial, nil
+}
+
+const (
+	sepFields   = " "
+	sepEnriched = "\x1d"
+)
+
+// splitFields splits the key=value pairs of an audit record.
+// Single quoted values (ie the `msg='op=login acct="root" res=success'` of user space records) are split recursively.
+// Tokens that are not key=value pairs (ie `avc:  denied  { read } for`) are skipped.
+func splitFields(s string, fn func(key, value string, quoted bool)) {
+	const whitespace = sepFields + sepEnriched
+	for {
+		s = strings.TrimLeft(s, whitespace)
+		if s == "" {
+			return
+		}
+		eq := strings.IndexByte(s, '=')
+		if eq == -1 {
+			return
+		}
+		if sp := strings.IndexAny(s, whitespace); sp != -1 && sp < eq {
+			s = s[sp:]
+			continue
+		}
+		key := s[:eq]
+		s = s[eq+1:]
+		if s == "" {
+			return
+		}
+		switch q := s[0]; q {
+		case '"', '\'':
+			value := s[1:]
+			s = ""
+			if end := strings.IndexByte(value, q); end != -1 {
+				value, s = value[:end], value[end+1:]
+			}
+			if q == '\'' && key == "msg" {
+				splitFields(value, fn)
+				continue
+			}
+			fn(key, value, true)
+		default:
+			value := s
+			s = ""
+			if end := strings.IndexAny(value, whitespace); end != -1 {
+				value, s = value[:end], value[end:]
+			}
+			fn(key, value, false)
+		}
+	}
+}
+
+// isEncoded checks if the unquoted value of a field is hex encoded.
+// Auditd encodes values of these fields as hex when they contain spaces or control characters.
+func isEncoded(recordType, key string) bool {
+	switch key {
+	case "acct", "cmd", "comm", "cwd", "data", "dir", "exe", "file", "key", "name", "new", "ocomm", "old", "path", "proctitle", "root_dir", "vm":
+		return true
+	}
+	// Arguments of EXECVE records (a0, a1, ...)
+	// Long arguments are split in chunks (a2[0], a2[1], ...) that are decoded after they are joined.
+	return recordType == "EXECVE" && isArgKey(key)
+}
+
+func isArgKey(key string) bool {
+	if len(key) < 2 || key[0] != 'a' {
+		return false
+	}
+	_, err := strconv.ParseUint(key[1:], 10, 32)
+	return err == nil
+}
+
+// decodeHex decodes upper case hex strings
+func decodeHex(value string) string {
+	if len(value) < 2 || len(value)%2 != 0 {
+		return value
+	}
+	for i := 0; i < len(value); i++ {
+		if c := value[i]; !('0' <= c && c <= '9' || 'A' <= c && c <= 'F') {
+			return value
+		}
+	}
+	data, err := hex.DecodeString(value)
+	if err != nil {
+		return value
+	}
+	return string(data)
+}
@@ -21,19 +21,25 @@ package awslogs
 
 import (
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
 )
 
 const (
-	TypeALB               = "AWS.ALB"
-	TypeAuroraMySQLAudit  = `AWS.AuroraMySQLAudit`
-	TypeCloudTrail        = `AWS.CloudTrail`
-	TypeCloudTrailDigest  = "AWS.CloudTrailDigest"
-	TypeCloudTrailInsight = "AWS.CloudTrailInsight"
-	TypeCloudWatchEvents  = "AWS.CloudWatchEvents"
-	TypeGuardDuty         = "AWS.GuardDuty"
-	TypeS3ServerAccess    = "AWS.S3ServerAccess"
-	TypeVPCFlow           = "AWS.VPCFlow"
+	TypeALB                  = "AWS.ALB"
+	TypeAuroraMySQLAudit     = `AWS.AuroraMySQLAudit`
+	TypeClassicELB           = "AWS.ClassicELB"
+	TypeCloudFrontAccess     = "AWS.CloudFrontAccess"
+	TypeCloudTrail           = `AWS.CloudTrail`
+	TypeCloudTrailDigest     = "AWS.CloudTrailDigest"
+	TypeCloudTrailInsight    = "AWS.CloudTrailInsight"
+	TypeCloudWatchEvents     = "AWS.CloudWatchEvents"
+	TypeGuardDuty            = "AWS.GuardDuty"
+	TypeRoute53ResolverQuery = "AWS.Route53ResolverQuery"
+	TypeS3ServerAccess       = "AWS.S3ServerAccess"
+	TypeSecurityHub          = "AWS.SecurityHub"
+	TypeVPCFlow              = "AWS.VPCFlow"
+	TypeWAFWebACL            = "AWS.WAFWebACL"
 )
 
 // LogTypes exports the available log type entries
@@ -57,6 +63,24 @@ var logTypes = logtypes.Must("AWS",
 		Schema:       AuroraMySQLAudit{},
 		NewParser:    parsers.AdapterFactory(&AuroraMySQLAuditParser{}),
 	},
+	logtypes.Config{
+		Name:         TypeClassicELB,
+		Description:  `Classic Load Balancer access logs capture detailed information about requests sent to your load balancer.`,
+		ReferenceURL: `https://docs.aws.amazon.com/elasticloadbalancing/latest/classic/access-log-collection.html`,
+		Schema:       pantherlog.MustBuildEventSchema(&ClassicELB{}),
+		NewParser: parsers.FactoryFunc(func(_ interface{}) (parsers.Interface, error) {
+			return NewClassicELBParser(), nil
+		}),
+	},
+	logtypes.Config{
+		Name:         TypeCloudFrontAccess,
+		Description:  `CloudFront standard access logs contain detailed information about every user request that CloudFront receives.`,
+		ReferenceURL: `https://docs.aws.amazon.com/AmazonCloudFront/latest/DeveloperGuide/AccessLogs.html`,
+		Schema:       pantherlog.MustBuildEventSchema(&CloudFrontAccess{}),
+		NewParser: parsers.FactoryFunc(func(_ interface{}) (parsers.Interface, error) {
+			return NewCloudFrontAccessParser(), nil
+		}),
+	},
 	logtypes.Config{
 		Name:         TypeCloudTrail,
 		Description:  `AWSCloudTrail represents the content of a CloudTrail S3 object.`,
@@ -94,6 +118,23 @@ var logTypes = logtypes.Must("AWS",
 		Schema:       GuardDuty{},
 		NewParser:    parsers.AdapterFactory(&GuardDutyParser{}),
 	},
+	logtypes.Config{
+		Name:         TypeRoute53ResolverQuery,
+		Description:  `Route 53 Resolver query logs contain the DNS queries made by resources within your VPCs.`,
+		ReferenceURL: `https://docs.aws.amazon.com/Route53/latest/DeveloperGuide/resolver-query-logs.html`,
+		Schema: pantherlog.MustBuildEventSchema(&Route53ResolverQuery{},
+			pantherlog.FieldIPAddress,
+			pantherlog.FieldDomainName,
+			pantherlog.FieldAWSAccountID,
+			pantherlog.FieldAWSInstanceID,
+		),
+		NewParser: &parsers.JSONParserFactory{
+			LogType: TypeRoute53ResolverQuery,
+			NewEvent: func() interface{} {
+				return &Route53ResolverQuery{}
+			},
+		},
+	},
 	logtypes.Config{
 		Name:         TypeS3ServerAccess,
 		Description:  `S3ServerAccess is an AWS S3 Access Log.`,
@@ -101,6 +142,25 @@ var logTypes = logtypes.Must("AWS",
 		Schema:       S3ServerAccess{},
 		NewParser:    parsers.AdapterFactory(&S3ServerAccessParser{}),
 	},
+	logtypes.Config{
+		Name:         TypeSecurityHub,
+		Description:  `AWS Security Hub findings in the AWS Security Finding Format (ASFF), aggregated from GuardDuty, Inspector, Macie, IAM Access Analyzer and third-party products.`,
+		ReferenceURL: `https://docs.aws.amazon.com/securityhub/latest/userguide/securityhub-findings-format.html`,
+		Schema: pantherlog.MustBuildEventSchema(&SecurityHubFinding{},
+			pantherlog.FieldIPAddress,
+			pantherlog.FieldDomainName,
+			pantherlog.FieldMD5Hash,
+			pantherlog.FieldSHA1Hash,
+			pantherlog.FieldSHA256Hash,
+			pantherlog.FieldAWSARN,
+			pantherlog.FieldAWSAccountID,
+			pantherlog.FieldAWSInstanceID,
+			pantherlog.FieldAWSTag,
+		),
+		NewParser: parsers.FactoryFunc(func(_ interface{}) (parsers.Interface, error) {
+			return &SecurityHubParser{}, nil
+		}),
+	},
 	logtypes.Config{
 		Name:         TypeVPCFlow,
 		Description:  `VPCFlow is a VPC NetFlow log, which is a layer 3 representation of network traffic in EC2.`,
@@ -108,4 +168,23 @@ var logTypes = logtypes.Must("AWS",
 		Schema:       VPCFlow{},
 		NewParser:    parsers.AdapterFactory(&VPCFlowParser{}),
 	},
+	logtypes.Config{
+		Name:         TypeWAFWebACL,
+		Description:  `AWS WAF web ACL logs contain information about the traffic that is analyzed by your web ACL.`,
+		ReferenceURL: `https://docs.aws.amazon.com/waf/latest/developerguide/logging.html`,
+		Schema: pantherlog.MustBuildEventSchema(&WAFWebACL{},
+			pantherlog.FieldIPAddress,
+			pantherlog.FieldDomainName,
+			pantherlog.FieldAWSARN,
+			pantherlog.FieldAWSAccountID,
+			pantherlog.FieldAWSInstanceID,
+			pantherlog.FieldAWSTag,
+		),
+		NewParser: &parsers.JSONParserFactory{
+			LogType: TypeWAFWebACL,
+			NewEvent: func() interface{} {
+				return &WAFWebACL{}
+			},
+		},
+	},
 )
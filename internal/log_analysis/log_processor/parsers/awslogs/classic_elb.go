@@ -0,0 +1,187 @@
+package awslogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/csvstream"
+)
+
+const classicELBNumberOfColumns = 15
+
+// ClassicELB is an access log record of a Classic Load Balancer.
+// nolint:lll
+type ClassicELB struct {
+	Timestamp              pantherlog.Time    `json:"timestamp" validate:"required" tcodec:"rfc3339" event_time:"true" description:"The time when the load balancer received the request from the client, in ISO 8601 format."`
+	ELB                    pantherlog.String  `json:"elb" validate:"required" description:"The name of the load balancer."`
+	ClientIP               pantherlog.String  `json:"clientIp" panther:"ip" description:"The IP address of the requesting client."`
+	ClientPort             pantherlog.Uint16  `json:"clientPort" description:"The port of the requesting client."`
+	BackendIP              pantherlog.String  `json:"backendIp" panther:"ip" description:"The IP address of the registered instance that processed this request."`
+	BackendPort            pantherlog.Uint16  `json:"backendPort" description:"The port of the registered instance that processed this request."`
+	RequestProcessingTime  pantherlog.Float64 `json:"requestProcessingTime" description:"[HTTP listener] The total time elapsed, in seconds, from the time the load balancer received the request until the time it sent it to a registered instance. [TCP listener] The total time elapsed, in seconds, from the time the load balancer accepted a TCP/SSL connection from a client to the time the load balancer sends the first byte of data to a registered instance. This value is set to -1 if the load balancer can't dispatch the request to a registered instance."`
+	BackendProcessingTime  pantherlog.Float64 `json:"backendProcessingTime" description:"[HTTP listener] The total time elapsed, in seconds, from the time the load balancer sent the request to a registered instance until the instance started to send the response headers. [TCP listener] The total time elapsed, in seconds, for the load balancer to successfully establish a connection to a registered instance. This value is set to -1 if the load balancer can't dispatch the request to a registered instance."`
+	ResponseProcessingTime pantherlog.Float64 `json:"responseProcessingTime" description:"[HTTP listener] The total time elapsed (in seconds) from the time the load balancer received the response header from the registered instance until it started to send the response to the client. [TCP listener] The total time elapsed, in seconds, from the time the load balancer received the first byte from the registered instance until it started to send the response to the client. This value is set to -1 if the load balancer can't dispatch the request to a registered instance."`
+	ELBStatusCode          pantherlog.Int16   `json:"elbStatusCode" description:"[HTTP listener] The status code of the response from the load balancer."`
+	BackendStatusCode      pantherlog.Int16   `json:"backendStatusCode" description:"[HTTP listener] The status code of the response from the registered instance."`
+	ReceivedBytes          pantherlog.Int64   `json:"receivedBytes" description:"The size of the request, in bytes, received from the client (requester)."`
+	SentBytes              pantherlog.Int64   `json:"sentBytes" description:"The size of the response, in bytes, sent to the client (requester)."`
+	RequestHTTPMethod      pantherlog.String  `json:"requestHttpMethod" description:"[HTTP listener] The HTTP method of the request."`
+	RequestURL             pantherlog.String  `json:"requestUrl" panther:"url" description:"[HTTP listener] The URL of the request."`
+	RequestHTTPVersion     pantherlog.String  `json:"requestHttpVersion" description:"[HTTP listener] The HTTP version of the request."`
+	UserAgent              pantherlog.String  `json:"userAgent" description:"[HTTP/HTTPS listener] A User-Agent string that identifies the client that originated the request."`
+	SSLCipher              pantherlog.String  `json:"sslCipher" description:"[HTTPS/SSL listener] The SSL cipher."`
+	SSLProtocol            pantherlog.String  `json:"sslProtocol" description:"[HTTPS/SSL listener] The SSL protocol."`
+}
+
+// ClassicELBParser parses Classic Load Balancer access logs
+type ClassicELBParser struct {
+	reader  *csvstream.StreamingCSVReader
+	builder pantherlog.ResultBuilder
+}
+
+var _ parsers.Interface = (*ClassicELBParser)(nil)
+
+// NewClassicELBParser creates a parser for Classic Load Balancer access logs
+func NewClassicELBParser() *ClassicELBParser {
+	reader := csvstream.NewStreamingCSVReader()
+	// non-default settings
+	reader.CVSReader.Comma = ' '
+	return &ClassicELBParser{
+		reader: reader,
+	}
+}
+
+// ParseLog implements parsers.Interface
+func (p *ClassicELBParser) ParseLog(log string) ([]*parsers.Result, error) {
+	record, err := p.reader.Parse(log)
+	if err != nil {
+		return nil, err
+	}
+	if len(record) != classicELBNumberOfColumns {
+		return nil, errors.New("invalid number of columns")
+	}
+	tm, err := time.Parse(time.RFC3339Nano, record[0])
+	if err != nil {
+		return nil, err
+	}
+	event := ClassicELB{
+		Timestamp:   tm,
+		ELB:         elbString(record[1]),
+		UserAgent:   elbString(record[12]),
+		SSLCipher:   elbString(record[13]),
+		SSLProtocol: elbString(record[14]),
+	}
+	if event.ClientIP, event.ClientPort, err = elbAddress(record[2]); err != nil {
+		return nil, err
+	}
+	if event.BackendIP, event.BackendPort, err = elbAddress(record[3]); err != nil {
+		return nil, err
+	}
+	if event.RequestProcessingTime, err = elbFloat64(record[4]); err != nil {
+		return nil, err
+	}
+	if event.BackendProcessingTime, err = elbFloat64(record[5]); err != nil {
+		return nil, err
+	}
+	if event.ResponseProcessingTime, err = elbFloat64(record[6]); err != nil {
+		return nil, err
+	}
+	if event.ELBStatusCode, err = elbStatusCode(record[7]); err != nil {
+		return nil, err
+	}
+	if event.BackendStatusCode, err = elbStatusCode(record[8]); err != nil {
+		return nil, err
+	}
+	if event.ReceivedBytes, err = elbInt64(record[9]); err != nil {
+		return nil, err
+	}
+	if event.SentBytes, err = elbInt64(record[10]); err != nil {
+		return nil, err
+	}
+	// TCP listeners log the request as '- - - '
+	if request := strings.Fields(record[11]); len(request) == 3 {
+		event.RequestHTTPMethod = elbString(request[0])
+		event.RequestURL = elbString(request[1])
+		event.RequestHTTPVersion = elbString(request[2])
+	}
+	if err := pantherlog.ValidateStruct(&event); err != nil {
+		return nil, err
+	}
+	result, err := p.builder.BuildResult(TypeClassicELB, &event)
+	if err != nil {
+		return nil, err
+	}
+	return []*parsers.Result{result}, nil
+}
+
+func elbString(s string) pantherlog.String {
+	if s == "-" {
+		return pantherlog.String{}
+	}
+	return null.FromString(s)
+}
+
+func elbAddress(s string) (pantherlog.String, pantherlog.Uint16, error) {
+	if s == "-" {
+		return pantherlog.String{}, pantherlog.Uint16{}, nil
+	}
+	host, port, err := net.SplitHostPort(s)
+	if err != nil {
+		return pantherlog.String{}, pantherlog.Uint16{}, err
+	}
+	n, err := strconv.ParseUint(port, 10, 16)
+	if err != nil {
+		return pantherlog.String{}, pantherlog.Uint16{}, err
+	}
+	return null.FromString(host), null.FromUint16(uint16(n)), nil
+}
+
+func elbStatusCode(s string) (pantherlog.Int16, error) {
+	if s == "-" {
+		return pantherlog.Int16{}, nil
+	}
+	n, err := strconv.ParseInt(s, 10, 16)
+	if err != nil {
+		return pantherlog.Int16{}, err
+	}
+	return null.FromInt16(int16(n)), nil
+}
+
+func elbInt64(s string) (pantherlog.Int64, error) {
+	if s == "-" {
+		return pantherlog.Int64{}, nil
+	}
+	return parseInt64(s)
+}
+
+func elbFloat64(s string) (pantherlog.Float64, error) {
+	if s == "-" {
+		return pantherlog.Float64{}, nil
+	}
+	return parseFloat64(s)
+}
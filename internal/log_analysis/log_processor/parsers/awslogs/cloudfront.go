@@ -0,0 +1,284 @@
+package awslogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+// CloudFrontAccess is a CloudFront standard access log record.
+// nolint:lll
+type CloudFrontAccess struct {
+	Timestamp              pantherlog.Time    `json:"timestamp" validate:"required" tcodec:"rfc3339" event_time:"true" description:"The date and time on which the event occurred (UTC)."`
+	EdgeLocation           pantherlog.String  `json:"edgeLocation" validate:"required" description:"The edge location that served the request. Each edge location is identified by a three-letter code and an arbitrarily assigned number (for example, DFW3)."`
+	BytesSent              pantherlog.Int64   `json:"bytesSent" description:"The total number of bytes that CloudFront served to the viewer in response to the request, including headers."`
+	ClientIP               pantherlog.String  `json:"clientIp" validate:"required" panther:"ip" description:"The IP address of the viewer that made the request."`
+	HTTPMethod             pantherlog.String  `json:"httpMethod" description:"The HTTP request method."`
+	Host                   pantherlog.String  `json:"host" panther:"domain" description:"The domain name of the CloudFront distribution (for example, d111111abcdef8.cloudfront.net)."`
+	URIStem                pantherlog.String  `json:"uriStem" description:"The portion of the request URL that identifies the path and object (for example, /images/cat.jpg)."`
+	Status                 pantherlog.Int32   `json:"status" description:"The HTTP status code of the response. 000 indicates that the viewer closed the connection before the server responded."`
+	Referer                pantherlog.String  `json:"referer" panther:"url" description:"The value of the Referer header in the request."`
+	UserAgent              pantherlog.String  `json:"userAgent" description:"The value of the User-Agent header in the request."`
+	QueryString            pantherlog.String  `json:"queryString" description:"The query string portion of the request URL, if any."`
+	Cookie                 pantherlog.String  `json:"cookie" description:"The Cookie header in the request, including name-value pairs and the associated attributes."`
+	EdgeResultType         pantherlog.String  `json:"edgeResultType" description:"How the server classified the response after the last byte left the server (Hit, RefreshHit, Miss, LimitExceeded, CapacityExceeded, Error, Redirect)."`
+	EdgeRequestID          pantherlog.String  `json:"edgeRequestId" validate:"required" description:"An opaque string that uniquely identifies a request."`
+	HostHeader             pantherlog.String  `json:"hostHeader" panther:"hostname" description:"The value that the viewer included in the Host header of the request."`
+	Protocol               pantherlog.String  `json:"protocol" description:"The protocol of the viewer request (http, https, ws, or wss)."`
+	BytesReceived          pantherlog.Int64   `json:"bytesReceived" description:"The total number of bytes of data that the viewer included in the request, including headers."`
+	TimeTaken              pantherlog.Float64 `json:"timeTaken" description:"The number of seconds (to the thousandth of a second) between the time that a CloudFront edge server receives a viewer's request and the time that the server writes the last byte of the response."`
+	ForwardedFor           pantherlog.String  `json:"forwardedFor" description:"If the viewer used an HTTP proxy or a load balancer to send the request, the value of the X-Forwarded-For header."`
+	SSLProtocol            pantherlog.String  `json:"sslProtocol" description:"When the request used HTTPS, this field contains the SSL/TLS protocol that the viewer and server negotiated for transmitting the request and response."`
+	SSLCipher              pantherlog.String  `json:"sslCipher" description:"When the request used HTTPS, this field contains the SSL/TLS cipher that the viewer and server negotiated for encrypting the request and response."`
+	EdgeResponseResultType pantherlog.String  `json:"edgeResponseResultType" description:"How the server classified the response just before returning the response to the viewer."`
+	ProtocolVersion        pantherlog.String  `json:"protocolVersion" description:"The HTTP version that the viewer specified in the request."`
+	FLEStatus              pantherlog.String  `json:"fleStatus" description:"When field-level encryption is configured for a distribution, this field contains a code that indicates whether the request body was successfully processed."`
+	FLEEncryptedFields     pantherlog.Int64   `json:"fleEncryptedFields" description:"The number of field-level encryption fields that the server encrypted and forwarded to the origin."`
+	ClientPort             pantherlog.Uint16  `json:"clientPort" description:"The port number of the request from the viewer."`
+	TimeToFirstByte        pantherlog.Float64 `json:"timeToFirstByte" description:"The number of seconds between receiving the request and writing the first byte of the response, as measured on the server."`
+	EdgeDetailedResultType pantherlog.String  `json:"edgeDetailedResultType" description:"The same value as edgeResultType except for specific error cases where it provides more detail."`
+	ContentType            pantherlog.String  `json:"contentType" description:"The value of the HTTP Content-Type header of the response."`
+	ContentLength          pantherlog.Int64   `json:"contentLength" description:"The value of the HTTP Content-Length header of the response."`
+	RangeStart             pantherlog.Int64   `json:"rangeStart" description:"When the response contains the HTTP Content-Range header, this field contains the range start value."`
+	RangeEnd               pantherlog.Int64   `json:"rangeEnd" description:"When the response contains the HTTP Content-Range header, this field contains the range end value."`
+}
+
+var _ pantherlog.ValueWriterTo = (*CloudFrontAccess)(nil)
+
+// WriteValuesTo implements pantherlog.ValueWriterTo interface
+func (event *CloudFrontAccess) WriteValuesTo(w pantherlog.ValueWriter) {
+	// Field values are URL encoded, ie '192.0.2.10,%20203.0.113.7'
+	for _, addr := range strings.Split(event.ForwardedFor.Value, ",") {
+		if addr, err := url.PathUnescape(addr); err == nil {
+			pantherlog.ScanIPAddress(w, addr)
+		}
+	}
+}
+
+// The default fields of CloudFront standard access logs, in the order they appear in a log file
+var cloudFrontDefaultFields = []string{
+	"date",
+	"time",
+	"x-edge-location",
+	"sc-bytes",
+	"c-ip",
+	"cs-method",
+	"cs(Host)",
+	"cs-uri-stem",
+	"sc-status",
+	"cs(Referer)",
+	"cs(User-Agent)",
+	"cs-uri-query",
+	"cs(Cookie)",
+	"x-edge-result-type",
+	"x-edge-request-id",
+	"x-host-header",
+	"cs-protocol",
+	"cs-bytes",
+	"time-taken",
+	"x-forwarded-for",
+	"ssl-protocol",
+	"ssl-cipher",
+	"x-edge-response-result-type",
+	"cs-protocol-version",
+	"fle-status",
+	"fle-encrypted-fields",
+	"c-port",
+	"time-to-first-byte",
+	"x-edge-detailed-result-type",
+	"sc-content-type",
+	"sc-content-len",
+	"sc-range-start",
+	"sc-range-end",
+}
+
+// CloudFrontAccessParser parses CloudFront standard access logs.
+// Log files use the W3C extended format. The '#Fields:' directive at the top of each file defines the fields
+// of each tab-separated record. If no such directive is found the default fields are assumed.
+// The referer, user agent and query string values are URL encoded by CloudFront and are decoded.
+type CloudFrontAccessParser struct {
+	fields  []string
+	builder pantherlog.ResultBuilder
+}
+
+var _ parsers.Interface = (*CloudFrontAccessParser)(nil)
+
+// NewCloudFrontAccessParser creates a parser for CloudFront standard access logs
+func NewCloudFrontAccessParser() *CloudFrontAccessParser {
+	return &CloudFrontAccessParser{
+		fields: cloudFrontDefaultFields,
+	}
+}
+
+// ParseLog implements parsers.Interface
+func (p *CloudFrontAccessParser) ParseLog(log string) ([]*parsers.Result, error) {
+	const (
+		directiveFields  = "#Fields:"
+		directiveVersion = "#Version:"
+	)
+	if strings.HasPrefix(log, directiveFields) {
+		p.fields = strings.Fields(strings.TrimPrefix(log, directiveFields))
+		return nil, nil
+	}
+	if strings.HasPrefix(log, directiveVersion) {
+		return nil, nil
+	}
+	values := strings.Split(strings.TrimRight(log, "\r\n"), "\t")
+	if len(values) != len(p.fields) {
+		return nil, errors.Errorf("invalid number of columns %d != %d", len(values), len(p.fields))
+	}
+	event := CloudFrontAccess{}
+	var date, clock string
+	for i, value := range values {
+		if value == "-" {
+			continue
+		}
+		switch name := p.fields[i]; name {
+		case "date":
+			date = value
+		case "time":
+			clock = value
+		default:
+			if err := event.setField(name, value); err != nil {
+				return nil, errors.WithMessagef(err, "invalid %q field value", name)
+			}
+		}
+	}
+	if date != "" && clock != "" {
+		tm, err := time.Parse("2006-01-02 15:04:05", date+" "+clock)
+		if err != nil {
+			return nil, err
+		}
+		event.Timestamp = tm
+	}
+	if err := pantherlog.ValidateStruct(&event); err != nil {
+		return nil, err
+	}
+	result, err := p.builder.BuildResult(TypeCloudFrontAccess, &event)
+	if err != nil {
+		return nil, err
+	}
+	return []*parsers.Result{result}, nil
+}
+
+// nolint:gocyclo
+func (event *CloudFrontAccess) setField(name, value string) (err error) {
+	switch name {
+	case "x-edge-location":
+		event.EdgeLocation = null.FromString(value)
+	case "sc-bytes":
+		event.BytesSent, err = parseInt64(value)
+	case "c-ip":
+		event.ClientIP = null.FromString(value)
+	case "cs-method":
+		event.HTTPMethod = null.FromString(value)
+	case "cs(Host)":
+		event.Host = null.FromString(value)
+	case "cs-uri-stem":
+		event.URIStem = null.FromString(value)
+	case "sc-status":
+		var n int64
+		n, err = strconv.ParseInt(value, 10, 32)
+		event.Status = null.FromInt32(int32(n))
+	case "cs(Referer)":
+		event.Referer = unescapeField(value)
+	case "cs(User-Agent)":
+		event.UserAgent = unescapeField(value)
+	case "cs-uri-query":
+		event.QueryString = unescapeField(value)
+	case "cs(Cookie)":
+		event.Cookie = null.FromString(value)
+	case "x-edge-result-type":
+		event.EdgeResultType = null.FromString(value)
+	case "x-edge-request-id":
+		event.EdgeRequestID = null.FromString(value)
+	case "x-host-header":
+		event.HostHeader = null.FromString(value)
+	case "cs-protocol":
+		event.Protocol = null.FromString(value)
+	case "cs-bytes":
+		event.BytesReceived, err = parseInt64(value)
+	case "time-taken":
+		event.TimeTaken, err = parseFloat64(value)
+	case "x-forwarded-for":
+		event.ForwardedFor = null.FromString(value)
+	case "ssl-protocol":
+		event.SSLProtocol = null.FromString(value)
+	case "ssl-cipher":
+		event.SSLCipher = null.FromString(value)
+	case "x-edge-response-result-type":
+		event.EdgeResponseResultType = null.FromString(value)
+	case "cs-protocol-version":
+		event.ProtocolVersion = null.FromString(value)
+	case "fle-status":
+		event.FLEStatus = null.FromString(value)
+	case "fle-encrypted-fields":
+		event.FLEEncryptedFields, err = parseInt64(value)
+	case "c-port":
+		var n uint64
+		n, err = strconv.ParseUint(value, 10, 16)
+		event.ClientPort = null.FromUint16(uint16(n))
+	case "time-to-first-byte":
+		event.TimeToFirstByte, err = parseFloat64(value)
+	case "x-edge-detailed-result-type":
+		event.EdgeDetailedResultType = null.FromString(value)
+	case "sc-content-type":
+		event.ContentType = null.FromString(value)
+	case "sc-content-len":
+		event.ContentLength, err = parseInt64(value)
+	case "sc-range-start":
+		event.RangeStart, err = parseInt64(value)
+	case "sc-range-end":
+		event.RangeEnd, err = parseInt64(value)
+	}
+	return err
+}
+
+// unescapeField decodes a URL encoded field value, invalid encodings are kept as is
+func unescapeField(value string) pantherlog.String {
+	if s, err := url.PathUnescape(value); err == nil {
+		value = s
+	}
+	return null.FromString(value)
+}
+
+func parseInt64(s string) (pantherlog.Int64, error) {
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return pantherlog.Int64{}, err
+	}
+	return null.FromInt64(n), nil
+}
+
+func parseFloat64(s string) (pantherlog.Float64, error) {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return pantherlog.Float64{}, err
+	}
+	return null.FromFloat64(f), nil
+}
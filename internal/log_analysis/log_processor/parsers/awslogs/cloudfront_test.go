@@ -0,0 +1,88 @@
+package awslogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes/logtesting"
+)
+
+func TestCloudFrontAccess(t *testing.T) {
+	logtesting.RunTestsFromYAML(t, LogTypes(), "./testdata/cloudfront_tests.yml")
+}
+
+func TestCloudFrontAccessFieldsDirective(t *testing.T) {
+	parser := NewCloudFrontAccessParser()
+	results, err := parser.ParseLog("#Version: 1.0")
+	require.NoError(t, err)
+	require.Nil(t, results)
+	results, err = parser.ParseLog("#Fields: date time x-edge-location c-ip x-edge-request-id sc-status")
+	require.NoError(t, err)
+	require.Nil(t, results)
+	// Columns are mapped to the fields of the directive ("GET" is not a valid status)
+	_, err = parser.ParseLog("2019-12-04\t21:02:31\tLAX1\t392\t192.0.2.100\tGET")
+	require.Error(t, err)
+
+	log := "2019-12-04\t21:02:31\tLAX1\t192.0.2.100\tSOX4xwn4XV6Q4rgb7XiVGOHms_BGlTAC4KyHmureZmBNrjGdRLiNIQ==\t403"
+	expect := `{
+		"timestamp": "2019-12-04T21:02:31Z",
+		"edgeLocation": "LAX1",
+		"clientIp": "192.0.2.100",
+		"edgeRequestId": "SOX4xwn4XV6Q4rgb7XiVGOHms_BGlTAC4KyHmureZmBNrjGdRLiNIQ==",
+		"status": 403,
+		"p_event_time": "2019-12-04T21:02:31Z",
+		"p_log_type": "AWS.CloudFrontAccess",
+		"p_any_ip_addresses": ["192.0.2.100"]
+	}`
+	results, err = parser.ParseLog(log)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	logtesting.TestResult(t, expect, results[0])
+}
+
+func TestCloudFrontAccessURLEncoded(t *testing.T) {
+	parser := NewCloudFrontAccessParser()
+	results, err := parser.ParseLog("#Fields: date time x-edge-location c-ip x-edge-request-id cs(Referer) cs-uri-query")
+	require.NoError(t, err)
+	require.Nil(t, results)
+	// Only the header directives are skipped
+	_, err = parser.ParseLog("#Remark: not a directive of CloudFront logs")
+	require.Error(t, err)
+
+	log := "2019-12-04\t21:02:31\tLAX1\t192.0.2.100\tSOX4xwn4XV6Q4rgb7XiVGOHms_BGlTAC4KyHmureZmBNrjGdRLiNIQ==\thttps://www.example.com/search%3Fq=cat%20food\tq=cat%2520food&page=2"
+	expect := `{
+		"timestamp": "2019-12-04T21:02:31Z",
+		"edgeLocation": "LAX1",
+		"clientIp": "192.0.2.100",
+		"edgeRequestId": "SOX4xwn4XV6Q4rgb7XiVGOHms_BGlTAC4KyHmureZmBNrjGdRLiNIQ==",
+		"referer": "https://www.example.com/search?q=cat food",
+		"queryString": "q=cat%20food&page=2",
+		"p_event_time": "2019-12-04T21:02:31Z",
+		"p_log_type": "AWS.CloudFrontAccess",
+		"p_any_ip_addresses": ["192.0.2.100"],
+		"p_any_domain_names": ["www.example.com"]
+	}`
+	results, err = parser.ParseLog(log)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	logtesting.TestResult(t, expect, results[0])
+}
@@ -0,0 +1,78 @@
+package awslogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+)
+
+// Route53ResolverQuery is a DNS query logged by Route 53 Resolver query logging.
+// nolint:lll
+type Route53ResolverQuery struct {
+	Version              pantherlog.String              `json:"version" description:"The version number of the query log format."`
+	AccountID            pantherlog.String              `json:"account_id" validate:"required" panther:"aws_account_id" description:"The ID of the AWS account that created the VPC."`
+	Region               pantherlog.String              `json:"region" description:"The AWS Region that you created the VPC in."`
+	VPCID                pantherlog.String              `json:"vpc_id" description:"The ID of the VPC that the query originated in."`
+	QueryTimestamp       pantherlog.Time                `json:"query_timestamp" validate:"required" tcodec:"rfc3339" event_time:"true" description:"The date and time that the query was submitted, in ISO 8601 format and Coordinated Universal Time (UTC)."`
+	QueryName            pantherlog.String              `json:"query_name" validate:"required" description:"The domain name (example.com) or subdomain name (www.example.com) that was specified in the query."`
+	QueryType            pantherlog.String              `json:"query_type" description:"Either the DNS record type that was specified in the request, or ANY."`
+	QueryClass           pantherlog.String              `json:"query_class" description:"The class of the query."`
+	Rcode                pantherlog.String              `json:"rcode" description:"The DNS response code that Resolver returned in response to the DNS query."`
+	Answers              []Route53ResolverQueryAnswer   `json:"answers" description:"The answers that Resolver returned in response to the query."`
+	SrcAddr              pantherlog.String              `json:"srcaddr" panther:"ip" description:"The IP address of the instance that the query originated from."`
+	SrcPort              pantherlog.Uint16              `json:"srcport" description:"The port on the instance that the query originated from."`
+	Transport            pantherlog.String              `json:"transport" description:"The protocol used to submit the DNS query."`
+	SrcIDs               *Route53ResolverQuerySourceIDs `json:"srcids" description:"The IDs of the instance and resolver endpoint that the query originated from."`
+	FirewallRuleAction   pantherlog.String              `json:"firewall_rule_action" description:"The action specified by the DNS Firewall rule that matched the domain name in the query."`
+	FirewallRuleGroupID  pantherlog.String              `json:"firewall_rule_group_id" description:"The ID of the DNS Firewall rule group that matched the domain name in the query."`
+	FirewallDomainListID pantherlog.String              `json:"firewall_domain_list_id" description:"The ID of the DNS Firewall domain list that matched the domain name in the query."`
+}
+
+// Route53ResolverQueryAnswer is a DNS answer returned by Route 53 Resolver.
+// nolint:lll
+type Route53ResolverQueryAnswer struct {
+	Rdata pantherlog.String `json:"Rdata" description:"The value that Resolver returned in response to the query. For example, for an A record, this is an IP address in IPv4 format."`
+	Type  pantherlog.String `json:"Type" description:"The DNS record type (such as A, MX, or CNAME) of the value that Resolver is returning in response to the query."`
+	Class pantherlog.String `json:"Class" description:"The class of the Resolver response to the query."`
+}
+
+// Route53ResolverQuerySourceIDs are the ids of the resources that originated a DNS query.
+// nolint:lll
+type Route53ResolverQuerySourceIDs struct {
+	Instance         pantherlog.String `json:"instance" panther:"aws_instance_id" description:"The ID of the instance that the query originated from."`
+	ResolverEndpoint pantherlog.String `json:"resolver_endpoint" description:"The ID of the resolver endpoint that passes the DNS query to on-premises DNS servers."`
+}
+
+var _ pantherlog.ValueWriterTo = (*Route53ResolverQuery)(nil)
+
+// WriteValuesTo implements pantherlog.ValueWriterTo interface
+func (event *Route53ResolverQuery) WriteValuesTo(w pantherlog.ValueWriter) {
+	// Domain names in DNS queries are fully qualified and end with a dot
+	w.WriteValues(pantherlog.FieldDomainName, strings.TrimSuffix(event.QueryName.Value, "."))
+	for _, answer := range event.Answers {
+		switch answer.Type.Value {
+		case "A", "AAAA":
+			pantherlog.ScanIPAddress(w, answer.Rdata.Value)
+		case "CNAME", "NS", "PTR":
+			w.WriteValues(pantherlog.FieldDomainName, strings.TrimSuffix(answer.Rdata.Value, "."))
+		}
+	}
+}
@@ -0,0 +1,275 @@
+package awslogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+// SecurityHubFinding is a finding in the AWS Security Finding Format (ASFF).
+// nolint:lll
+type SecurityHubFinding struct {
+	SchemaVersion         pantherlog.String                 `json:"SchemaVersion" validate:"required" description:"The schema version that a finding is formatted for."`
+	ID                    pantherlog.String                 `json:"Id" validate:"required" description:"The security findings provider-specific identifier for a finding."`
+	ProductArn            pantherlog.String                 `json:"ProductArn" validate:"required" panther:"aws_arn" description:"The ARN generated by Security Hub that uniquely identifies a product that generates findings."`
+	ProductName           pantherlog.String                 `json:"ProductName" description:"The name of the product that generated the finding."`
+	CompanyName           pantherlog.String                 `json:"CompanyName" description:"The name of the company for the product that generated the finding."`
+	Region                pantherlog.String                 `json:"Region" description:"The Region from which the finding was generated."`
+	GeneratorID           pantherlog.String                 `json:"GeneratorId" validate:"required" description:"The identifier for the solution-specific component (a discrete unit of logic) that generated a finding."`
+	AWSAccountID          pantherlog.String                 `json:"AwsAccountId" validate:"required" panther:"aws_account_id" description:"The AWS account ID that a finding is generated in."`
+	Types                 []string                          `json:"Types" description:"One or more finding types in the format of namespace/category/classifier that classify a finding."`
+	FirstObservedAt       pantherlog.Time                   `json:"FirstObservedAt" tcodec:"rfc3339" description:"Indicates when the security-findings provider first observed the potential security issue that a finding captured."`
+	LastObservedAt        pantherlog.Time                   `json:"LastObservedAt" tcodec:"rfc3339" description:"Indicates when the security-findings provider most recently observed the potential security issue that a finding captured."`
+	CreatedAt             pantherlog.Time                   `json:"CreatedAt" validate:"required" tcodec:"rfc3339" description:"Indicates when the security-findings provider created the potential security issue that a finding captured."`
+	UpdatedAt             pantherlog.Time                   `json:"UpdatedAt" validate:"required" tcodec:"rfc3339" event_time:"true" description:"Indicates when the security-findings provider last updated the finding record."`
+	Severity              *SecurityHubSeverity              `json:"Severity" validate:"required" description:"A finding's severity."`
+	Confidence            pantherlog.Int32                  `json:"Confidence" description:"A finding's confidence. Confidence is defined as the likelihood that a finding accurately identifies the behavior or issue that it was intended to identify."`
+	Criticality           pantherlog.Int32                  `json:"Criticality" description:"The level of importance assigned to the resources associated with the finding."`
+	Title                 pantherlog.String                 `json:"Title" description:"A finding's title."`
+	Description           pantherlog.String                 `json:"Description" description:"A finding's description."`
+	Remediation           *SecurityHubRemediation           `json:"Remediation" description:"A data type that describes the remediation options for a finding."`
+	SourceURL             pantherlog.String                 `json:"SourceUrl" description:"A URL that links to a page about the current finding in the security-findings provider's solution."`
+	ProductFields         map[string]string                 `json:"ProductFields" description:"A data type where security-findings providers can include additional solution-specific details that aren't part of the defined AwsSecurityFinding format."`
+	UserDefinedFields     map[string]string                 `json:"UserDefinedFields" description:"A list of name/value string pairs associated with the finding. These are custom, user-defined fields added to a finding."`
+	Malware               []SecurityHubMalware              `json:"Malware" description:"A list of malware related to a finding."`
+	Network               *SecurityHubNetwork               `json:"Network" description:"The details of network-related information about a finding."`
+	NetworkPath           pantherlog.RawMessage             `json:"NetworkPath" description:"Provides information about a network path that is relevant to a finding. Each entry under NetworkPath represents a component of that path."`
+	Process               *SecurityHubProcess               `json:"Process" description:"The details of process-related information about a finding."`
+	ThreatIntelIndicators []SecurityHubThreatIntelIndicator `json:"ThreatIntelIndicators" description:"Threat intelligence details related to a finding."`
+	Resources             []SecurityHubResource             `json:"Resources" validate:"required,min=1" description:"A set of resource data types that describe the resources that the finding refers to."`
+	Compliance            *SecurityHubCompliance            `json:"Compliance" description:"This data type is exclusive to findings that are generated as the result of a check run against a specific rule in a supported security standard."`
+	VerificationState     pantherlog.String                 `json:"VerificationState" description:"Indicates the veracity of a finding."`
+	WorkflowState         pantherlog.String                 `json:"WorkflowState" description:"The workflow state of a finding (deprecated, replaced by Workflow.Status)."`
+	Workflow              *SecurityHubWorkflow              `json:"Workflow" description:"Provides information about the status of the investigation into a finding."`
+	RecordState           pantherlog.String                 `json:"RecordState" description:"The record state of a finding."`
+	RelatedFindings       []SecurityHubRelatedFinding       `json:"RelatedFindings" description:"A list of related findings."`
+	Note                  *SecurityHubNote                  `json:"Note" description:"A user-defined note added to a finding."`
+	Vulnerabilities       pantherlog.RawMessage             `json:"Vulnerabilities" description:"Provides a list of vulnerabilities associated with the findings."`
+	PatchSummary          pantherlog.RawMessage             `json:"PatchSummary" description:"Provides an overview of the patch compliance status for an instance against a selected compliance standard."`
+	Action                pantherlog.RawMessage             `json:"Action" description:"Provides details about an action that affects or that was taken on a resource."`
+	FindingProviderFields pantherlog.RawMessage             `json:"FindingProviderFields" description:"In a BatchImportFindings request, finding providers use FindingProviderFields to provide and update their own values for confidence, criticality, related findings, severity, and types."`
+	Sample                pantherlog.Bool                   `json:"Sample" description:"Indicates whether the finding is a sample finding."`
+}
+
+// SecurityHubSeverity is the severity of a finding.
+// nolint:lll
+type SecurityHubSeverity struct {
+	Label      pantherlog.String  `json:"Label" description:"The severity value of the finding (INFORMATIONAL, LOW, MEDIUM, HIGH, CRITICAL)."`
+	Normalized pantherlog.Int32   `json:"Normalized" description:"Deprecated. The normalized severity of a finding (0-100)."`
+	Original   pantherlog.String  `json:"Original" description:"The native severity from the finding product that generated the finding."`
+	Product    pantherlog.Float64 `json:"Product" description:"Deprecated. The native severity as defined by the AWS service or integrated partner product that generated the finding."`
+}
+
+// SecurityHubRemediation describes the remediation options for a finding.
+type SecurityHubRemediation struct {
+	Recommendation *SecurityHubRecommendation `json:"Recommendation" description:"A recommendation on the steps to take to remediate the issue identified by a finding."`
+}
+
+// SecurityHubRecommendation is a recommendation on how to remediate the issue identified by a finding.
+// nolint:lll
+type SecurityHubRecommendation struct {
+	Text pantherlog.String `json:"Text" description:"Describes the recommended steps to take to remediate an issue identified in a finding."`
+	URL  pantherlog.String `json:"Url" description:"A URL to a page or site that contains information about how to remediate a finding."`
+}
+
+// SecurityHubMalware is a malware related to a finding.
+type SecurityHubMalware struct {
+	Name  pantherlog.String `json:"Name" description:"The name of the malware that was observed."`
+	Type  pantherlog.String `json:"Type" description:"The type of the malware that was observed."`
+	Path  pantherlog.String `json:"Path" description:"The file system path of the malware that was observed."`
+	State pantherlog.String `json:"State" description:"The state of the malware that was observed."`
+}
+
+// SecurityHubNetwork is the network-related information about a finding.
+// nolint:lll
+type SecurityHubNetwork struct {
+	Direction         pantherlog.String     `json:"Direction" description:"The direction of network traffic associated with a finding (IN, OUT)."`
+	Protocol          pantherlog.String     `json:"Protocol" description:"The protocol of network-related information about a finding."`
+	OpenPortRange     *SecurityHubPortRange `json:"OpenPortRange" description:"The range of open ports that is present on the network."`
+	SourceIPV4        pantherlog.String     `json:"SourceIpV4" panther:"ip" description:"The source IPv4 address of network-related information about a finding."`
+	SourceIPV6        pantherlog.String     `json:"SourceIpV6" panther:"ip" description:"The source IPv6 address of network-related information about a finding."`
+	SourcePort        pantherlog.Int32      `json:"SourcePort" description:"The source port of network-related information about a finding."`
+	SourceDomain      pantherlog.String     `json:"SourceDomain" panther:"domain" description:"The source domain of network-related information about a finding."`
+	SourceMac         pantherlog.String     `json:"SourceMac" description:"The source media access control (MAC) address of network-related information about a finding."`
+	DestinationIPV4   pantherlog.String     `json:"DestinationIpV4" panther:"ip" description:"The destination IPv4 address of network-related information about a finding."`
+	DestinationIPV6   pantherlog.String     `json:"DestinationIpV6" panther:"ip" description:"The destination IPv6 address of network-related information about a finding."`
+	DestinationPort   pantherlog.Int32      `json:"DestinationPort" description:"The destination port of network-related information about a finding."`
+	DestinationDomain pantherlog.String     `json:"DestinationDomain" panther:"domain" description:"The destination domain of network-related information about a finding."`
+}
+
+// SecurityHubPortRange is a range of ports.
+type SecurityHubPortRange struct {
+	Begin pantherlog.Int32 `json:"Begin" description:"The first port in the port range."`
+	End   pantherlog.Int32 `json:"End" description:"The last port in the port range."`
+}
+
+// SecurityHubProcess is the process-related information about a finding.
+// nolint:lll
+type SecurityHubProcess struct {
+	Name         pantherlog.String `json:"Name" description:"The name of the process."`
+	Path         pantherlog.String `json:"Path" description:"The path to the process executable."`
+	PID          pantherlog.Int32  `json:"Pid" description:"The process ID."`
+	ParentPID    pantherlog.Int32  `json:"ParentPid" description:"The parent process ID."`
+	LaunchedAt   pantherlog.Time   `json:"LaunchedAt" tcodec:"rfc3339" description:"Indicates when the process was launched."`
+	TerminatedAt pantherlog.Time   `json:"TerminatedAt" tcodec:"rfc3339" description:"Indicates when the process was terminated."`
+}
+
+// SecurityHubThreatIntelIndicator is a threat intelligence indicator related to a finding.
+// nolint:lll
+type SecurityHubThreatIntelIndicator struct {
+	Type           pantherlog.String `json:"Type" description:"The type of threat intelligence indicator (DOMAIN, EMAIL_ADDRESS, HASH_MD5, HASH_SHA1, HASH_SHA256, HASH_SHA512, IPV4_ADDRESS, IPV6_ADDRESS, MUTEX, PROCESS, URL)."`
+	Value          pantherlog.String `json:"Value" description:"The value of a threat intelligence indicator."`
+	Category       pantherlog.String `json:"Category" description:"The category of a threat intelligence indicator."`
+	LastObservedAt pantherlog.Time   `json:"LastObservedAt" tcodec:"rfc3339" description:"Indicates when the most recent instance of a threat intelligence indicator was observed."`
+	Source         pantherlog.String `json:"Source" description:"The source of the threat intelligence indicator."`
+	SourceURL      pantherlog.String `json:"SourceUrl" description:"The URL to the page or site where you can get more information about the threat intelligence indicator."`
+}
+
+// SecurityHubResource is a resource that a finding refers to.
+// nolint:lll
+type SecurityHubResource struct {
+	Type               pantherlog.String     `json:"Type" validate:"required" description:"The type of the resource that details are provided for."`
+	ID                 pantherlog.String     `json:"Id" validate:"required" panther:"aws_arn" description:"The canonical identifier for the given resource type."`
+	Partition          pantherlog.String     `json:"Partition" description:"The canonical AWS partition name that the Region is assigned to."`
+	Region             pantherlog.String     `json:"Region" description:"The canonical AWS external Region name where this resource is located."`
+	ResourceRole       pantherlog.String     `json:"ResourceRole" description:"Identifies the role of the resource in the finding. A resource is either the actor or target of the finding activity."`
+	Tags               map[string]string     `json:"Tags" description:"A list of AWS tags associated with a resource at the time the finding was processed."`
+	DataClassification pantherlog.RawMessage `json:"DataClassification" description:"Contains information about sensitive data that was detected on the resource."`
+	Details            pantherlog.RawMessage `json:"Details" description:"Additional details about the resource related to a finding."`
+}
+
+// SecurityHubCompliance is the result of a check against a security standard control.
+// nolint:lll
+type SecurityHubCompliance struct {
+	Status              pantherlog.String                   `json:"Status" description:"The result of a standards check (PASSED, WARNING, FAILED, NOT_AVAILABLE)."`
+	RelatedRequirements []string                            `json:"RelatedRequirements" description:"For a control, the industry or regulatory framework requirements that are related to the control."`
+	StatusReasons       []SecurityHubComplianceStatusReason `json:"StatusReasons" description:"For findings generated from controls, a list of reasons behind the value of Status."`
+}
+
+// SecurityHubComplianceStatusReason provides additional context on the status of a compliance check.
+type SecurityHubComplianceStatusReason struct {
+	ReasonCode  pantherlog.String `json:"ReasonCode" description:"A code that represents a reason for the control status."`
+	Description pantherlog.String `json:"Description" description:"The corresponding description for the status reason code."`
+}
+
+// SecurityHubWorkflow is the status of the investigation into a finding.
+type SecurityHubWorkflow struct {
+	Status pantherlog.String `json:"Status" description:"The status of the investigation into the finding (NEW, NOTIFIED, SUPPRESSED, RESOLVED)."`
+}
+
+// SecurityHubRelatedFinding is a finding related to the current finding.
+type SecurityHubRelatedFinding struct {
+	ProductArn pantherlog.String `json:"ProductArn" description:"The ARN of the product that generated a related finding."`
+	ID         pantherlog.String `json:"Id" description:"The product-generated identifier for a related finding."`
+}
+
+// SecurityHubNote is a user-defined note added to a finding.
+type SecurityHubNote struct {
+	Text      pantherlog.String `json:"Text" description:"The text of a note."`
+	UpdatedBy pantherlog.String `json:"UpdatedBy" description:"The principal that created a note."`
+	UpdatedAt pantherlog.Time   `json:"UpdatedAt" tcodec:"rfc3339" description:"The timestamp of when the note was updated."`
+}
+
+var _ pantherlog.ValueWriterTo = (*SecurityHubFinding)(nil)
+
+// WriteValuesTo implements pantherlog.ValueWriterTo interface
+func (event *SecurityHubFinding) WriteValuesTo(w pantherlog.ValueWriter) {
+	ExtractRawMessageIndicators(w, event.NetworkPath, event.Action)
+	for _, indicator := range event.ThreatIntelIndicators {
+		value := indicator.Value.Value
+		switch indicator.Type.Value {
+		case "IPV4_ADDRESS", "IPV6_ADDRESS":
+			pantherlog.ScanIPAddress(w, value)
+		case "DOMAIN":
+			w.WriteValues(pantherlog.FieldDomainName, value)
+		case "URL":
+			pantherlog.ScanURL(w, value)
+		case "HASH_MD5":
+			w.WriteValues(pantherlog.FieldMD5Hash, value)
+		case "HASH_SHA1":
+			w.WriteValues(pantherlog.FieldSHA1Hash, value)
+		case "HASH_SHA256":
+			w.WriteValues(pantherlog.FieldSHA256Hash, value)
+		}
+	}
+	for i := range event.Resources {
+		resource := &event.Resources[i]
+		for key, value := range resource.Tags {
+			ScanTag(w, key+":"+value)
+		}
+		ExtractRawMessageIndicators(w, resource.Details)
+	}
+	for _, finding := range event.RelatedFindings {
+		ScanARN(w, finding.ProductArn.Value)
+	}
+}
+
+// SecurityHubParser parses Security Hub findings.
+// Findings are accepted either as individual ASFF JSON objects, in the `Findings` list returned by the
+// GetFindings API or wrapped in the EventBridge events Security Hub emits for imported findings.
+type SecurityHubParser struct {
+	builder pantherlog.ResultBuilder
+}
+
+var _ parsers.Interface = (*SecurityHubParser)(nil)
+
+// ParseLog implements parsers.Interface
+func (p *SecurityHubParser) ParseLog(log string) ([]*parsers.Result, error) {
+	envelope := securityHubEnvelope{}
+	if err := jsoniter.UnmarshalFromString(log, &envelope); err != nil {
+		return nil, err
+	}
+	var findings []jsoniter.RawMessage
+	switch {
+	case envelope.Detail != nil:
+		findings = envelope.Detail.Findings
+	case envelope.Findings != nil:
+		findings = envelope.Findings
+	default:
+		findings = []jsoniter.RawMessage{jsoniter.RawMessage(log)}
+	}
+	results := make([]*parsers.Result, 0, len(findings))
+	for _, data := range findings {
+		event := SecurityHubFinding{}
+		if err := jsoniter.Unmarshal(data, &event); err != nil {
+			return nil, err
+		}
+		if err := pantherlog.ValidateStruct(&event); err != nil {
+			return nil, err
+		}
+		result, err := p.builder.BuildResult(TypeSecurityHub, &event)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+type securityHubEnvelope struct {
+	// EventBridge event with 'Security Hub Findings - Imported' detail type
+	Detail *struct {
+		Findings []jsoniter.RawMessage `json:"findings"`
+	} `json:"detail"`
+	// Response of the GetFindings API
+	Findings []jsoniter.RawMessage `json:"Findings"`
+}
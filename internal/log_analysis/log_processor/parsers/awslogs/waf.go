@@ -0,0 +1,89 @@
+package awslogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+)
+
+// WAFWebACL is a log record of a request inspected by an AWS WAF web ACL.
+// nolint:lll
+type WAFWebACL struct {
+	Timestamp                   pantherlog.Time       `json:"timestamp" validate:"required" tcodec:"unix_ms" event_time:"true" description:"The timestamp in milliseconds."`
+	FormatVersion               pantherlog.Int32      `json:"formatVersion" description:"The format version for the log."`
+	WebACLID                    pantherlog.String     `json:"webaclId" validate:"required" panther:"aws_arn" description:"The GUID of the web ACL. For AWS WAF (v2) this is the ARN of the web ACL."`
+	TerminatingRuleID           pantherlog.String     `json:"terminatingRuleId" description:"The ID of the rule that terminated the request. If nothing terminates the request, the value is Default_Action."`
+	TerminatingRuleType         pantherlog.String     `json:"terminatingRuleType" description:"The type of rule that terminated the request. Possible values: RATE_BASED, REGULAR, GROUP, and MANAGED_RULE_GROUP."`
+	Action                      pantherlog.String     `json:"action" validate:"required" description:"The action. Possible values for a terminating rule: ALLOW and BLOCK. COUNT is not a valid value for a terminating rule."`
+	TerminatingRuleMatchDetails pantherlog.RawMessage `json:"terminatingRuleMatchDetails" description:"Detailed information about the terminating rule that matched the request. A terminating rule has an action that ends the inspection process against a web request."`
+	HTTPSourceName              pantherlog.String     `json:"httpSourceName" description:"The source of the request. Possible values: CF for Amazon CloudFront, APIGW for Amazon API Gateway, ALB for Application Load Balancer, and APPSYNC for AWS AppSync."`
+	HTTPSourceID                pantherlog.String     `json:"httpSourceId" description:"The source ID. This field shows the ID of the associated resource."`
+	RuleGroupList               pantherlog.RawMessage `json:"ruleGroupList" description:"The list of rule groups that acted on this request."`
+	RateBasedRuleList           pantherlog.RawMessage `json:"rateBasedRuleList" description:"The list of rate-based rules that acted on the request."`
+	NonTerminatingMatchingRules pantherlog.RawMessage `json:"nonTerminatingMatchingRules" description:"The list of non-terminating rules that match the request. Each item in the list contains the rule ID and action."`
+	RequestHeadersInserted      []WAFHTTPHeader       `json:"requestHeadersInserted" description:"The list of headers inserted for custom request handling."`
+	ResponseCodeSent            pantherlog.Int32      `json:"responseCodeSent" description:"The response code sent with a custom response."`
+	HTTPRequest                 *WAFHTTPRequest       `json:"httpRequest" validate:"required" description:"The metadata about the request."`
+	Labels                      []WAFLabel            `json:"labels" description:"The labels on the web request. These labels were applied by rules that were used to evaluate the request."`
+}
+
+// WAFHTTPRequest is the metadata about a request inspected by AWS WAF.
+// nolint:lll
+type WAFHTTPRequest struct {
+	ClientIP    pantherlog.String `json:"clientIp" panther:"ip" description:"The IP address of the client sending the request."`
+	Country     pantherlog.String `json:"country" description:"The source country of the request. If AWS WAF is unable to determine the country of origin, it sets this field to -."`
+	Headers     []WAFHTTPHeader   `json:"headers" description:"The list of headers."`
+	URI         pantherlog.String `json:"uri" description:"The URI of the request."`
+	Args        pantherlog.String `json:"args" description:"The query string."`
+	HTTPVersion pantherlog.String `json:"httpVersion" description:"The HTTP version."`
+	HTTPMethod  pantherlog.String `json:"httpMethod" description:"The HTTP method in the request."`
+	RequestID   pantherlog.String `json:"requestId" description:"The ID of the request, which is generated by the underlying host service. For Application Load Balancer, this is the trace ID. For all others, this is the request ID."`
+}
+
+// WAFHTTPHeader is an HTTP header of a request inspected by AWS WAF.
+type WAFHTTPHeader struct {
+	Name  pantherlog.String `json:"name" description:"The header name."`
+	Value pantherlog.String `json:"value" description:"The header value."`
+}
+
+// WAFLabel is a label applied to a request by a WAF rule.
+type WAFLabel struct {
+	Name pantherlog.String `json:"name" description:"The label name."`
+}
+
+var _ pantherlog.ValueWriterTo = (*WAFWebACL)(nil)
+
+// WriteValuesTo implements pantherlog.ValueWriterTo interface
+func (event *WAFWebACL) WriteValuesTo(w pantherlog.ValueWriter) {
+	// Resource ids of load balancers and API gateways are prefixed with the account id.
+	// For example '123456789012-app/my-alb/e43b35d8c2f9a1b0' or '123456789012:a1b2c3d4e5:prod'
+	if id := event.HTTPSourceID.Value; len(id) > SizeAccountID {
+		ScanAccountID(w, id[:SizeAccountID])
+	}
+	ExtractRawMessageIndicators(w, event.RuleGroupList, event.RateBasedRuleList, event.NonTerminatingMatchingRules)
+	if req := event.HTTPRequest; req != nil {
+		for _, header := range req.Headers {
+			if strings.EqualFold(header.Name.Value, "host") {
+				pantherlog.ScanNetworkAddress(w, header.Value.Value)
+			}
+		}
+	}
+}
@@ -0,0 +1,411 @@
+package ceflogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/sysloglogs"
+)
+
+// Event is a CEF event.
+// Well-known extension keys are mapped to typed columns named after their full CEF dictionary name.
+// All other extensions (including custom string/number labels) are kept in the `extensions` column.
+// nolint:lll
+type Event struct {
+	Version                      pantherlog.Uint8   `json:"version" description:"The version of the CEF format."`
+	DeviceVendor                 pantherlog.String  `json:"deviceVendor" validate:"required" description:"The vendor of the sending device."`
+	DeviceProduct                pantherlog.String  `json:"deviceProduct" validate:"required" description:"The product name of the sending device."`
+	DeviceVersion                pantherlog.String  `json:"deviceVersion" description:"The version of the sending device."`
+	SignatureID                  pantherlog.String  `json:"signatureId" validate:"required" description:"Device Event Class ID, a unique identifier per event type."`
+	Name                         pantherlog.String  `json:"name" validate:"required" description:"A human-readable and understandable description of the event."`
+	Severity                     pantherlog.String  `json:"severity" validate:"required" description:"The importance of the event (0-10 or Unknown, Low, Medium, High, Very-High)."`
+	DeviceReceiptTime            pantherlog.Time    `json:"deviceReceiptTime" tcodec:"rfc3339" event_time:"true" description:"The time at which the event related to the activity was received (rt)."`
+	StartTime                    pantherlog.Time    `json:"startTime" tcodec:"rfc3339" event_time:"true" description:"The time when the activity the event referred to started (start)."`
+	EndTime                      pantherlog.Time    `json:"endTime" tcodec:"rfc3339" description:"The time at which the activity related to the event ended (end)."`
+	DeviceAction                 pantherlog.String  `json:"deviceAction" description:"Action taken by the device (act)."`
+	ApplicationProtocol          pantherlog.String  `json:"applicationProtocol" description:"Application level protocol, example values are HTTP, HTTPS, SSHv2, Telnet, POP, IMPA, IMAPS, and so on (app)."`
+	DeviceEventCategory          pantherlog.String  `json:"deviceEventCategory" description:"Represents the category assigned by the originating device (cat)."`
+	DeviceAddress                pantherlog.String  `json:"deviceAddress" panther:"ip" description:"Identifies the device address that an event refers to (dvc)."`
+	DeviceHostName               pantherlog.String  `json:"deviceHostName" panther:"hostname" description:"The hostname of the device that an event refers to (dvchost)."`
+	DeviceExternalID             pantherlog.String  `json:"deviceExternalId" description:"A name that uniquely identifies the device generating this event (deviceExternalId)."`
+	ExternalID                   pantherlog.String  `json:"externalId" description:"The ID used by an originating device (externalId)."`
+	EventOutcome                 pantherlog.String  `json:"eventOutcome" description:"Displays the outcome, usually as 'success' or 'failure' (outcome)."`
+	Reason                       pantherlog.String  `json:"reason" description:"The reason an audit event was generated (reason)."`
+	Message                      pantherlog.String  `json:"message" description:"An arbitrary message giving more details about the event (msg)."`
+	TransportProtocol            pantherlog.String  `json:"transportProtocol" description:"Identifies the Layer-4 protocol used (proto)."`
+	SourceAddress                pantherlog.String  `json:"sourceAddress" panther:"ip" description:"Identifies the source that an event refers to in an IP network (src)."`
+	SourceHostName               pantherlog.String  `json:"sourceHostName" panther:"hostname" description:"Identifies the source that an event refers to in an IP network (shost)."`
+	SourceMacAddress             pantherlog.String  `json:"sourceMacAddress" description:"Six colon-separated hexadecimal numbers (smac)."`
+	SourceNtDomain               pantherlog.String  `json:"sourceNtDomain" description:"The Windows domain name for the source address (sntdom)."`
+	SourcePort                   pantherlog.Uint16  `json:"sourcePort" description:"The valid port numbers are 0 to 65535 (spt)."`
+	SourceProcessName            pantherlog.String  `json:"sourceProcessName" description:"The name of the event's source process (sproc)."`
+	SourceTranslatedAddress      pantherlog.String  `json:"sourceTranslatedAddress" panther:"ip" description:"Identifies the translated source that the event refers to in an IP network (sourceTranslatedAddress)."`
+	SourceUserID                 pantherlog.String  `json:"sourceUserId" description:"Identifies the source user by ID (suid)."`
+	SourceUserName               pantherlog.String  `json:"sourceUserName" panther:"username" description:"Identifies the source user by name (suser)."`
+	DestinationAddress           pantherlog.String  `json:"destinationAddress" panther:"ip" description:"Identifies the destination address that the event refers to in an IP network (dst)."`
+	DestinationHostName          pantherlog.String  `json:"destinationHostName" panther:"hostname" description:"Identifies the destination that an event refers to in an IP network (dhost)."`
+	DestinationMacAddress        pantherlog.String  `json:"destinationMacAddress" description:"Six colon-separated hexadecimal numbers (dmac)."`
+	DestinationNtDomain          pantherlog.String  `json:"destinationNtDomain" description:"The Windows domain name of the destination address (dntdom)."`
+	DestinationPort              pantherlog.Uint16  `json:"destinationPort" description:"The valid port numbers are between 0 and 65535 (dpt)."`
+	DestinationProcessName       pantherlog.String  `json:"destinationProcessName" description:"The name of the event's destination process (dproc)."`
+	DestinationTranslatedAddress pantherlog.String  `json:"destinationTranslatedAddress" panther:"ip" description:"Identifies the translated destination that the event refers to in an IP network (destinationTranslatedAddress)."`
+	DestinationUserID            pantherlog.String  `json:"destinationUserId" description:"Identifies the destination user by ID (duid)."`
+	DestinationUserName          pantherlog.String  `json:"destinationUserName" panther:"username" description:"Identifies the destination user by name (duser)."`
+	BytesIn                      pantherlog.Int64   `json:"bytesIn" description:"Number of bytes transferred inbound (in)."`
+	BytesOut                     pantherlog.Int64   `json:"bytesOut" description:"Number of bytes transferred outbound (out)."`
+	FileName                     pantherlog.String  `json:"fileName" description:"Name of the file only, without its path (fname)."`
+	FilePath                     pantherlog.String  `json:"filePath" description:"Full path to the file, including file name itself (filePath)."`
+	FileHash                     pantherlog.String  `json:"fileHash" description:"Hash of a file (fileHash)."`
+	FileSize                     pantherlog.Int64   `json:"fileSize" description:"Size of the file (fsize)."`
+	RequestURL                   pantherlog.String  `json:"requestUrl" panther:"url" description:"In the case of an HTTP request, this field contains the URL accessed (request)."`
+	RequestMethod                pantherlog.String  `json:"requestMethod" description:"The HTTP method used to access a URL (requestMethod)."`
+	RequestClientApplication     pantherlog.String  `json:"requestClientApplication" description:"The User-Agent associated with the request (requestClientApplication)."`
+	Extensions                   map[string]string  `json:"extensions" description:"Extension fields that are not mapped to a column."`
+	Syslog                       *sysloglogs.Header `json:"syslog" description:"The header of the syslog message carrying the event."`
+}
+
+var _ pantherlog.ValueWriterTo = (*Event)(nil)
+
+// WriteValuesTo implements pantherlog.ValueWriterTo interface
+func (event *Event) WriteValuesTo(w pantherlog.ValueWriter) {
+	ScanHash(w, event.FileHash.Value)
+}
+
+// ScanHash scans a hash value of unknown algorithm using its length
+func ScanHash(w pantherlog.ValueWriter, hash string) {
+	const (
+		sizeMD5    = 32
+		sizeSHA1   = 40
+		sizeSHA256 = 64
+	)
+	switch len(hash) {
+	case sizeMD5:
+		w.WriteValues(pantherlog.FieldMD5Hash, hash)
+	case sizeSHA1:
+		w.WriteValues(pantherlog.FieldSHA1Hash, hash)
+	case sizeSHA256:
+		w.WriteValues(pantherlog.FieldSHA256Hash, hash)
+	}
+}
+
+// nolint:gocyclo
+func (event *Event) setExtension(key, value string) bool {
+	switch key {
+	case "rt":
+		return setTime(&event.DeviceReceiptTime, value)
+	case "start":
+		return setTime(&event.StartTime, value)
+	case "end":
+		return setTime(&event.EndTime, value)
+	case "act":
+		event.DeviceAction = null.FromString(value)
+	case "app":
+		event.ApplicationProtocol = null.FromString(value)
+	case "cat":
+		event.DeviceEventCategory = null.FromString(value)
+	case "dvc":
+		event.DeviceAddress = null.FromString(value)
+	case "dvchost":
+		event.DeviceHostName = null.FromString(value)
+	case "deviceExternalId":
+		event.DeviceExternalID = null.FromString(value)
+	case "externalId":
+		event.ExternalID = null.FromString(value)
+	case "outcome":
+		event.EventOutcome = null.FromString(value)
+	case "reason":
+		event.Reason = null.FromString(value)
+	case "msg":
+		event.Message = null.FromString(value)
+	case "proto":
+		event.TransportProtocol = null.FromString(value)
+	case "src":
+		event.SourceAddress = null.FromString(value)
+	case "shost":
+		event.SourceHostName = null.FromString(value)
+	case "smac":
+		event.SourceMacAddress = null.FromString(value)
+	case "sntdom":
+		event.SourceNtDomain = null.FromString(value)
+	case "spt":
+		return setPort(&event.SourcePort, value)
+	case "sproc":
+		event.SourceProcessName = null.FromString(value)
+	case "sourceTranslatedAddress":
+		event.SourceTranslatedAddress = null.FromString(value)
+	case "suid":
+		event.SourceUserID = null.FromString(value)
+	case "suser":
+		event.SourceUserName = null.FromString(value)
+	case "dst":
+		event.DestinationAddress = null.FromString(value)
+	case "dhost":
+		event.DestinationHostName = null.FromString(value)
+	case "dmac":
+		event.DestinationMacAddress = null.FromString(value)
+	case "dntdom":
+		event.DestinationNtDomain = null.FromString(value)
+	case "dpt":
+		return setPort(&event.DestinationPort, value)
+	case "dproc":
+		event.DestinationProcessName = null.FromString(value)
+	case "destinationTranslatedAddress":
+		event.DestinationTranslatedAddress = null.FromString(value)
+	case "duid":
+		event.DestinationUserID = null.FromString(value)
+	case "duser":
+		event.DestinationUserName = null.FromString(value)
+	case "in":
+		return setInt64(&event.BytesIn, value)
+	case "out":
+		return setInt64(&event.BytesOut, value)
+	case "fname":
+		event.FileName = null.FromString(value)
+	case "filePath":
+		event.FilePath = null.FromString(value)
+	case "fileHash":
+		event.FileHash = null.FromString(value)
+	case "fsize":
+		return setInt64(&event.FileSize, value)
+	case "request":
+		event.RequestURL = null.FromString(value)
+	case "requestMethod":
+		event.RequestMethod = null.FromString(value)
+	case "requestClientApplication":
+		event.RequestClientApplication = null.FromString(value)
+	default:
+		return false
+	}
+	return true
+}
+
+func setTime(dst *time.Time, value string) bool {
+	tm, err := ParseTime(value)
+	if err != nil {
+		return false
+	}
+	*dst = tm
+	return true
+}
+
+func setPort(dst *pantherlog.Uint16, value string) bool {
+	n, err := strconv.ParseUint(value, 10, 16)
+	if err != nil {
+		return false
+	}
+	*dst = null.FromUint16(uint16(n))
+	return true
+}
+
+func setInt64(dst *pantherlog.Int64, value string) bool {
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return false
+	}
+	*dst = null.FromInt64(n)
+	return true
+}
+
+// Layouts of the timestamp formats allowed by the CEF specification.
+// Formats without a year are not supported as they are ambiguous.
+var timeLayouts = []string{
+	"Jan 02 2006 15:04:05.000 MST",
+	"Jan 02 2006 15:04:05.000",
+	"Jan 02 2006 15:04:05 MST",
+	"Jan 02 2006 15:04:05",
+}
+
+// ParseTime parses a CEF timestamp value.
+// Timestamps are either milliseconds since epoch or dates in 'MMM dd yyyy HH:mm:ss.SSS zzz' format
+// (the milliseconds and the timezone are optional).
+func ParseTime(value string) (time.Time, error) {
+	if msec, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(0, msec*int64(time.Millisecond)).UTC(), nil
+	}
+	for _, layout := range timeLayouts {
+		if tm, err := time.Parse(layout, value); err == nil {
+			return tm.UTC(), nil
+		}
+	}
+	return time.Time{}, errors.Errorf("invalid CEF timestamp %q", value)
+}
+
+// Parser parses CEF events
+type Parser struct {
+	builder pantherlog.ResultBuilder
+}
+
+var _ parsers.Interface = (*Parser)(nil)
+
+const (
+	prefixCEF = "CEF:"
+	// The number of pipe delimited fields in the CEF header (including the version)
+	numHeaderFields = 7
+)
+
+// ParseLog implements parsers.Interface
+func (p *Parser) ParseLog(log string) ([]*parsers.Result, error) {
+	header, msg, err := sysloglogs.SplitMessage(log, prefixCEF)
+	if err != nil {
+		return nil, err
+	}
+	event, err := ParseEvent(msg)
+	if err != nil {
+		return nil, err
+	}
+	event.Syslog = header
+	if err := pantherlog.ValidateStruct(event); err != nil {
+		return nil, err
+	}
+	result, err := p.builder.BuildResult(TypeCEF, event)
+	if err != nil {
+		return nil, err
+	}
+	return []*parsers.Result{result}, nil
+}
+
+// ParseEvent parses a CEF message (ie 'CEF:0|Vendor|Product|1.0|100|Name|5|src=10.0.0.1')
+func ParseEvent(msg string) (*Event, error) {
+	if !strings.HasPrefix(msg, prefixCEF) {
+		return nil, errors.New("invalid CEF message")
+	}
+	fields := splitHeader(strings.TrimPrefix(msg, prefixCEF))
+	if len(fields) < numHeaderFields {
+		return nil, errors.New("invalid CEF header")
+	}
+	version, err := strconv.ParseUint(strings.TrimSpace(fields[0]), 10, 8)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid CEF version")
+	}
+	event := Event{
+		Version:       null.FromUint8(uint8(version)),
+		DeviceVendor:  null.FromString(fields[1]),
+		DeviceProduct: null.FromString(fields[2]),
+		DeviceVersion: null.FromString(fields[3]),
+		SignatureID:   null.FromString(fields[4]),
+		Name:          null.FromString(fields[5]),
+		Severity:      null.FromString(fields[6]),
+	}
+	if len(fields) > numHeaderFields {
+		err := SplitExtension(fields[numHeaderFields], func(key, value string) {
+			if value == "" || event.setExtension(key, value) {
+				return
+			}
+			if event.Extensions == nil {
+				event.Extensions = make(map[string]string)
+			}
+			event.Extensions[key] = value
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &event, nil
+}
+
+// splitHeader splits the pipe delimited header fields.
+// The remainder of the message after the last header field is returned as the last element.
+func splitHeader(s string) []string {
+	fields := make([]string, 0, numHeaderFields+1)
+	var field strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s) && (s[i+1] == '|' || s[i+1] == '\\'):
+			i++
+			field.WriteByte(s[i])
+		case c == '|':
+			fields = append(fields, field.String())
+			field.Reset()
+			if len(fields) == numHeaderFields {
+				return append(fields, s[i+1:])
+			}
+		default:
+			field.WriteByte(c)
+		}
+	}
+	return append(fields, field.String())
+}
+
+// SplitExtension splits the space separated key=value pairs of a CEF extension.
+// Values can contain spaces, equal signs in values must be escaped.
+func SplitExtension(ext string, fn func(key, value string)) error {
+	ext = strings.TrimSpace(ext)
+	key := ""
+	valueStart := -1
+	for i := 0; i < len(ext); i++ {
+		switch ext[i] {
+		case '\\':
+			// Skip escaped character
+			i++
+		case '=':
+			if valueStart == -1 {
+				key = ext[:i]
+				valueStart = i + 1
+				continue
+			}
+			// The previous value ends at the last space before the current key
+			pos := strings.LastIndexByte(ext[valueStart:i], ' ')
+			if pos == -1 {
+				return errors.Errorf("invalid CEF extension %q", key)
+			}
+			fn(key, unescapeValue(strings.TrimSpace(ext[valueStart:valueStart+pos])))
+			key = ext[valueStart+pos+1 : i]
+			valueStart = i + 1
+		}
+	}
+	if valueStart == -1 {
+		if ext != "" {
+			return errors.New("invalid CEF extension")
+		}
+		return nil
+	}
+	fn(key, unescapeValue(strings.TrimSpace(ext[valueStart:])))
+	return nil
+}
+
+func unescapeValue(s string) string {
+	if strings.IndexByte(s, '\\') == -1 {
+		return s
+	}
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '\\' && i+1 < len(s) {
+			i++
+			switch c = s[i]; c {
+			case 'n':
+				c = '\n'
+			case 'r':
+				c = '\r'
+			}
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
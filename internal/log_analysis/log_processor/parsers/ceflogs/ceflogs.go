@@ -0,0 +1,55 @@
+// Package ceflogs parses logs in the ArcSight Common Event Format (CEF)
+package ceflogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+const (
+	TypeCEF = "CEF.Event"
+)
+
+// LogTypes exports the available log type entries
+func LogTypes() logtypes.Group {
+	return logTypes
+}
+
+// nolint:lll
+var logTypes = logtypes.Must("CEF",
+	logtypes.Config{
+		Name:         TypeCEF,
+		Description:  `Events in the Common Event Format (CEF), either as raw lines or carried in RFC3164/RFC5424 syslog messages.`,
+		ReferenceURL: `https://community.microfocus.com/t5/ArcSight-Connectors/ArcSight-Common-Event-Format-CEF-Implementation-Standard/ta-p/1645557`,
+		Schema: pantherlog.MustBuildEventSchema(&Event{},
+			pantherlog.FieldIPAddress,
+			pantherlog.FieldDomainName,
+			pantherlog.FieldMD5Hash,
+			pantherlog.FieldSHA1Hash,
+			pantherlog.FieldSHA256Hash,
+			pantherlog.FieldUsername,
+		),
+		NewParser: parsers.FactoryFunc(func(_ interface{}) (parsers.Interface, error) {
+			return &Parser{}, nil
+		}),
+	},
+)
@@ -0,0 +1,68 @@
+// Package ciscoumbrellalogs parses Cisco Umbrella logs exported to S3
+package ciscoumbrellalogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+)
+
+const (
+	TypeDNS   = "CiscoUmbrella.DNS"
+	TypeProxy = "CiscoUmbrella.Proxy"
+	TypeIP    = "CiscoUmbrella.IP"
+)
+
+// LogTypes exports the available log type entries
+func LogTypes() logtypes.Group {
+	return logTypes
+}
+
+// nolint:lll
+var logTypes = logtypes.Must("CiscoUmbrella",
+	logtypes.Config{
+		Name:         TypeDNS,
+		Description:  `Cisco Umbrella DNS logs exported to S3, recording the DNS requests of your identities and the action taken.`,
+		ReferenceURL: `https://docs.umbrella.com/deployment-umbrella/docs/log-formats-and-versioning`,
+		Schema: pantherlog.MustBuildEventSchema(&DNS{},
+			pantherlog.FieldIPAddress,
+			pantherlog.FieldDomainName,
+		),
+		NewParser: newParserFactory(TypeDNS, numColumnsDNS, decodeDNS),
+	},
+	logtypes.Config{
+		Name:         TypeProxy,
+		Description:  `Cisco Umbrella proxy logs exported to S3, recording the web requests proxied by the Secure Web Gateway or intelligent proxy.`,
+		ReferenceURL: `https://docs.umbrella.com/deployment-umbrella/docs/log-formats-and-versioning`,
+		Schema: pantherlog.MustBuildEventSchema(&Proxy{},
+			pantherlog.FieldIPAddress,
+			pantherlog.FieldDomainName,
+			pantherlog.FieldSHA256Hash,
+		),
+		NewParser: newParserFactory(TypeProxy, numColumnsProxy, decodeProxy),
+	},
+	logtypes.Config{
+		Name:         TypeIP,
+		Description:  `Cisco Umbrella IP logs exported to S3, recording the connections blocked by IP-layer enforcement.`,
+		ReferenceURL: `https://docs.umbrella.com/deployment-umbrella/docs/log-formats-and-versioning`,
+		Schema:       pantherlog.MustBuildEventSchema(&IP{}),
+		NewParser:    newParserFactory(TypeIP, numColumnsIP, decodeIP),
+	},
+)
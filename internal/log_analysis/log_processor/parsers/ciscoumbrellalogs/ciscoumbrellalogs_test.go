@@ -0,0 +1,57 @@
+package ciscoumbrellalogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes/logtesting"
+)
+
+func TestCiscoUmbrellaLogs(t *testing.T) {
+	logtesting.RunTestsFromYAML(t, LogTypes(), "./testdata/ciscoumbrella_tests.yml")
+}
+
+// nolint:lll
+func TestParsersRejectOtherLogTypes(t *testing.T) {
+	logs := map[string]string{
+		TypeDNS:   `"2020-11-05 13:51:22","jdoe","jdoe,Office","10.10.1.100","24.123.132.133","Blocked","1 (A)","NOERROR","malware.example.net.","Malware","AD Users","AD Users,Networks","Malware"`,
+		TypeProxy: `"2020-11-05 13:51:24","jdoe","10.10.1.100","24.123.132.133","93.184.216.34","text/html","ALLOWED","http://www.example.org/","","curl/7.64.1","200","512","1024","1000","","Search Engines","","","","","","AD Users",""`,
+		TypeIP:    `"2020-11-05 13:51:25","LAPTOP-123","10.10.1.102","50123","198.51.100.7","4444","Command and Control","Roaming Computers"`,
+	}
+	for logType, log := range logs {
+		for _, entry := range []struct {
+			logType string
+			parser  *Parser
+		}{
+			{TypeDNS, NewParser(TypeDNS, numColumnsDNS, decodeDNS)},
+			{TypeProxy, NewParser(TypeProxy, numColumnsProxy, decodeProxy)},
+			{TypeIP, NewParser(TypeIP, numColumnsIP, decodeIP)},
+		} {
+			_, err := entry.parser.ParseLog(log)
+			if entry.logType == logType {
+				require.NoError(t, err, logType)
+			} else {
+				require.Error(t, err, "%s parsed as %s", logType, entry.logType)
+			}
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package ciscoumbrellalogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+)
+
+// DNS is a Cisco Umbrella DNS log
+// nolint:lll
+type DNS struct {
+	Timestamp                pantherlog.Time   `json:"timestamp" tcodec:"rfc3339" event_time:"true" validate:"required" description:"The timestamp of the request in UTC."`
+	MostGranularIdentity     pantherlog.String `json:"mostGranularIdentity" description:"The first identity matched with this request in order of granularity."`
+	Identities               []string          `json:"identities,omitempty" description:"All identities associated with this request."`
+	InternalIP               pantherlog.String `json:"internalIp" panther:"ip" description:"The internal IP address that made the request."`
+	ExternalIP               pantherlog.String `json:"externalIp" panther:"ip" description:"The external IP address that made the request."`
+	Action                   pantherlog.String `json:"action" validate:"required,oneof=Allowed Blocked" description:"Whether the request was allowed or blocked."`
+	QueryType                pantherlog.String `json:"queryType" description:"The type of DNS request that was made (ie 1 (A))."`
+	ResponseCode             pantherlog.String `json:"responseCode" description:"The DNS return code for this request (ie NOERROR, NXDOMAIN)."`
+	Domain                   pantherlog.String `json:"domain" description:"The domain that was requested."`
+	Categories               []string          `json:"categories,omitempty" description:"The security or content categories that the destination matches."`
+	MostGranularIdentityType pantherlog.String `json:"mostGranularIdentityType" description:"The type of the first identity matched with this request."`
+	IdentityTypes            []string          `json:"identityTypes,omitempty" description:"The types of the identities associated with this request."`
+	BlockedCategories        []string          `json:"blockedCategories,omitempty" description:"The categories that resulted in the request being blocked."`
+}
+
+const numColumnsDNS = 10
+
+func decodeDNS(r *row) interface{} {
+	return &DNS{
+		Timestamp:                r.Time(0),
+		MostGranularIdentity:     r.String(1),
+		Identities:               r.List(2),
+		InternalIP:               r.IP(3),
+		ExternalIP:               r.IP(4),
+		Action:                   r.String(5),
+		QueryType:                r.String(6),
+		ResponseCode:             r.String(7),
+		Domain:                   r.String(8),
+		Categories:               r.List(9),
+		MostGranularIdentityType: r.String(10),
+		IdentityTypes:            r.List(11),
+		BlockedCategories:        r.List(12),
+	}
+}
+
+var _ pantherlog.ValueWriterTo = (*DNS)(nil)
+
+// WriteValuesTo implements pantherlog.ValueWriterTo interface
+func (event *DNS) WriteValuesTo(w pantherlog.ValueWriter) {
+	// Domains are logged as fully qualified names with a trailing dot
+	if domain := strings.TrimSuffix(event.Domain.Value, "."); domain != "" {
+		w.WriteValues(pantherlog.FieldDomainName, domain)
+	}
+}
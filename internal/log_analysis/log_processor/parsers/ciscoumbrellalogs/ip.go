@@ -0,0 +1,52 @@
+package ciscoumbrellalogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+)
+
+// IP is a Cisco Umbrella IP log.
+// IP-layer enforcement only logs blocked connections.
+// nolint:lll
+type IP struct {
+	Timestamp       pantherlog.Time   `json:"timestamp" tcodec:"rfc3339" event_time:"true" validate:"required" description:"The timestamp of the connection in UTC."`
+	Identities      []string          `json:"identities,omitempty" description:"All identities associated with this connection."`
+	SourceIP        pantherlog.String `json:"sourceIp" panther:"ip" validate:"required" description:"The IP address of the computer making the connection."`
+	SourcePort      pantherlog.Uint16 `json:"sourcePort" description:"The port of the computer making the connection."`
+	DestinationIP   pantherlog.String `json:"destinationIp" panther:"ip" validate:"required" description:"The destination IP address of the connection."`
+	DestinationPort pantherlog.Uint16 `json:"destinationPort" description:"The destination port of the connection."`
+	Categories      []string          `json:"categories,omitempty" description:"The security categories of the destination."`
+	IdentityTypes   []string          `json:"identityTypes,omitempty" description:"The types of the identities associated with this connection."`
+}
+
+const numColumnsIP = 7
+
+func decodeIP(r *row) interface{} {
+	return &IP{
+		Timestamp:       r.Time(0),
+		Identities:      r.List(1),
+		SourceIP:        r.IP(2),
+		SourcePort:      r.Uint16(3),
+		DestinationIP:   r.IP(4),
+		DestinationPort: r.Uint16(5),
+		Categories:      r.List(6),
+		IdentityTypes:   r.List(7),
+	}
+}
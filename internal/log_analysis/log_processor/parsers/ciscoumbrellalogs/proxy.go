@@ -0,0 +1,91 @@
+package ciscoumbrellalogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+)
+
+// Proxy is a Cisco Umbrella proxy log
+// nolint:lll
+type Proxy struct {
+	Timestamp         pantherlog.Time   `json:"timestamp" tcodec:"rfc3339" event_time:"true" validate:"required" description:"The timestamp of the request in UTC."`
+	Identities        []string          `json:"identities,omitempty" description:"All identities associated with this request."`
+	InternalIP        pantherlog.String `json:"internalIp" panther:"ip" description:"The internal IP address that made the request."`
+	ExternalIP        pantherlog.String `json:"externalIp" panther:"ip" description:"The external IP address that made the request."`
+	DestinationIP     pantherlog.String `json:"destinationIp" panther:"ip" description:"The destination IP address of the request."`
+	ContentType       pantherlog.String `json:"contentType" description:"The type of web content, typically text/html."`
+	Verdict           pantherlog.String `json:"verdict" validate:"required" description:"Whether the request was allowed or blocked."`
+	URL               pantherlog.String `json:"url" panther:"url" description:"The URL requested."`
+	Referer           pantherlog.String `json:"referer" panther:"url" description:"The referring domain or URL."`
+	UserAgent         pantherlog.String `json:"userAgent" description:"The browser agent that made the request."`
+	StatusCode        pantherlog.Int64  `json:"statusCode" description:"The HTTP status code returned."`
+	RequestSize       pantherlog.Int64  `json:"requestSize" description:"The size of the request in bytes."`
+	ResponseSize      pantherlog.Int64  `json:"responseSize" description:"The size of the response in bytes."`
+	ResponseBodySize  pantherlog.Int64  `json:"responseBodySize" description:"The size of the response body in bytes."`
+	SHA256            pantherlog.String `json:"sha256" panther:"sha256" description:"The SHA256 hash of the response body."`
+	Categories        []string          `json:"categories,omitempty" description:"The security or content categories that the destination matches."`
+	AVDetections      []string          `json:"avDetections,omitempty" description:"The detection names of the anti-virus engines."`
+	PUAs              []string          `json:"puas,omitempty" description:"The potentially unwanted applications detected."`
+	AMPDisposition    pantherlog.String `json:"ampDisposition" description:"The status of the files proxied and scanned by Cisco Advanced Malware Protection (AMP)."`
+	AMPMalwareName    pantherlog.String `json:"ampMalwareName" description:"The name of the malware detected by AMP."`
+	AMPScore          pantherlog.String `json:"ampScore" description:"The score of the malware detected by AMP."`
+	IdentityTypes     []string          `json:"identityTypes,omitempty" description:"The types of the identities associated with this request."`
+	BlockedCategories []string          `json:"blockedCategories,omitempty" description:"The categories that resulted in the request being blocked."`
+}
+
+const numColumnsProxy = 16
+
+func decodeProxy(r *row) interface{} {
+	return &Proxy{
+		Timestamp:         r.Time(0),
+		Identities:        r.List(1),
+		InternalIP:        r.IP(2),
+		ExternalIP:        r.IP(3),
+		DestinationIP:     r.IP(4),
+		ContentType:       r.String(5),
+		Verdict:           r.String(6),
+		URL:               r.String(7),
+		Referer:           r.String(8),
+		UserAgent:         r.String(9),
+		StatusCode:        r.Int64(10),
+		RequestSize:       r.Int64(11),
+		ResponseSize:      r.Int64(12),
+		ResponseBodySize:  r.Int64(13),
+		SHA256:            hashValue(r.String(14)),
+		Categories:        r.List(15),
+		AVDetections:      r.List(16),
+		PUAs:              r.List(17),
+		AMPDisposition:    r.String(18),
+		AMPMalwareName:    r.String(19),
+		AMPScore:          r.String(20),
+		IdentityTypes:     r.List(21),
+		BlockedCategories: r.List(22),
+	}
+}
+
+// hashValue drops the all-zero placeholder hash logged for empty responses
+func hashValue(hash pantherlog.String) pantherlog.String {
+	if strings.Trim(hash.Value, "0") == "" {
+		return pantherlog.String{}
+	}
+	return hash
+}
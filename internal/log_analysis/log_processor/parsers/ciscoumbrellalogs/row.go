@@ -0,0 +1,184 @@
+package ciscoumbrellalogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/csvstream"
+)
+
+// decodeFunc decodes the event of a log type from the columns of a log.
+type decodeFunc func(r *row) interface{}
+
+// Parser parses Cisco Umbrella logs of a specific log type
+type Parser struct {
+	logType    string
+	numColumns int
+	decode     decodeFunc
+	reader     *csvstream.StreamingCSVReader
+	builder    pantherlog.ResultBuilder
+}
+
+var _ parsers.Interface = (*Parser)(nil)
+
+// NewParser creates a parser for a Cisco Umbrella log type.
+// Logs with less than `numColumns` columns are rejected.
+func NewParser(logType string, numColumns int, decode decodeFunc) *Parser {
+	return &Parser{
+		logType:    logType,
+		numColumns: numColumns,
+		decode:     decode,
+		reader:     csvstream.NewStreamingCSVReader(),
+	}
+}
+
+func newParserFactory(logType string, numColumns int, decode decodeFunc) parsers.FactoryFunc {
+	return func(_ interface{}) (parsers.Interface, error) {
+		return NewParser(logType, numColumns, decode), nil
+	}
+}
+
+// ParseLog implements parsers.Interface
+func (p *Parser) ParseLog(log string) ([]*parsers.Result, error) {
+	cols, err := p.reader.Parse(log)
+	if err != nil {
+		return nil, err
+	}
+	// Newer log format versions append columns so we only check the minimum number of columns
+	if len(cols) < p.numColumns {
+		return nil, errors.New("invalid number of columns")
+	}
+	r := row{
+		cols: cols,
+	}
+	event := p.decode(&r)
+	if r.err != nil {
+		return nil, r.err
+	}
+	if err := pantherlog.ValidateStruct(event); err != nil {
+		return nil, err
+	}
+	result, err := p.builder.BuildResult(p.logType, event)
+	if err != nil {
+		return nil, err
+	}
+	return []*parsers.Result{result}, nil
+}
+
+// row reads typed values from the columns of a log.
+// Columns added by newer format versions are missing from logs of older versions and are read as null values.
+// The first error while reading a value is kept in `err`.
+type row struct {
+	cols []string
+	err  error
+}
+
+// Umbrella timestamps are in UTC
+const layoutTime = "2006-01-02 15:04:05"
+
+func (r *row) value(i int) string {
+	if 0 <= i && i < len(r.cols) {
+		return r.cols[i]
+	}
+	return ""
+}
+
+func (r *row) fail(i int, err error) {
+	if r.err == nil {
+		r.err = errors.Wrapf(err, "invalid value in column %d", i)
+	}
+}
+
+func (r *row) String(i int) pantherlog.String {
+	if s := r.value(i); s != "" {
+		return null.FromString(s)
+	}
+	return pantherlog.String{}
+}
+
+// IP reads an IP address column and fails if the value is not a valid IP address.
+// This helps tell apart the column layouts of the different log types.
+func (r *row) IP(i int) pantherlog.String {
+	s := r.value(i)
+	if s == "" {
+		return pantherlog.String{}
+	}
+	if net.ParseIP(s) == nil {
+		r.fail(i, errors.Errorf("invalid IP address %q", s))
+		return pantherlog.String{}
+	}
+	return null.FromString(s)
+}
+
+// List reads a column of comma separated values
+func (r *row) List(i int) []string {
+	s := r.value(i)
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
+func (r *row) Int64(i int) pantherlog.Int64 {
+	s := r.value(i)
+	if s == "" {
+		return pantherlog.Int64{}
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		r.fail(i, err)
+		return pantherlog.Int64{}
+	}
+	return null.FromInt64(n)
+}
+
+func (r *row) Uint16(i int) pantherlog.Uint16 {
+	s := r.value(i)
+	if s == "" {
+		return pantherlog.Uint16{}
+	}
+	n, err := strconv.ParseUint(s, 10, 16)
+	if err != nil {
+		r.fail(i, err)
+		return pantherlog.Uint16{}
+	}
+	return null.FromUint16(uint16(n))
+}
+
+func (r *row) Time(i int) time.Time {
+	s := r.value(i)
+	if s == "" {
+		return time.Time{}
+	}
+	tm, err := time.Parse(layoutTime, s)
+	if err != nil {
+		r.fail(i, err)
+		return time.Time{}
+	}
+	return tm
+}
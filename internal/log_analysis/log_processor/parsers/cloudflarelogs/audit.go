@@ -0,0 +1,57 @@
+package cloudflarelogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+)
+
+// Note: Only the fields identifying an audit event are marked "required" because Cloudflare allows the user to select which fields to include in the logs.
+// nolint:lll
+type AuditEvent struct {
+	ActionResult pantherlog.Bool       `json:"ActionResult" description:"Whether the action was successful"`
+	ActionType   pantherlog.String     `json:"ActionType" validate:"required" description:"Type of action taken"`
+	ActorEmail   pantherlog.String     `json:"ActorEmail" panther:"email" description:"Email of the actor"`
+	ActorID      pantherlog.String     `json:"ActorID" description:"Unique identifier of the actor in Cloudflare’s system"`
+	ActorIP      pantherlog.String     `json:"ActorIP" panther:"ip" description:"Physical network address of the actor"`
+	ActorType    pantherlog.String     `json:"ActorType" description:"Type of user that started the audit trail; user | admin | Cloudflare"`
+	ID           pantherlog.String     `json:"ID" description:"Unique identifier of an audit log"`
+	Interface    pantherlog.String     `json:"Interface" description:"Entry point or interface of the audit log"`
+	Metadata     pantherlog.RawMessage `json:"Metadata" description:"Additional audit log-specific information, metadata is organized in key:value pairs, key and value formats may vary by ResourceType"`
+	NewValue     pantherlog.String     `json:"NewValue" description:"Contains the new value for the audited item"`
+	OldValue     pantherlog.String     `json:"OldValue" description:"Contains the old value for the audited item"`
+	OwnerID      pantherlog.String     `json:"OwnerID" description:"The identifier of the user that was acting or was acted on behalf of"`
+	ResourceID   pantherlog.String     `json:"ResourceID" description:"Unique identifier of the resource within Cloudflare’s system"`
+	ResourceType pantherlog.String     `json:"ResourceType" description:"The type of resource that was changed"`
+	When         pantherlog.Time       `json:"When" validate:"required" event_time:"true" tcodec:"cloudflare" description:"When the change happened"`
+}
+
+var _ pantherlog.ValueWriterTo = (*AuditEvent)(nil)
+
+// WriteValuesTo implements pantherlog.ValueWriterTo interface
+func (event *AuditEvent) WriteValuesTo(w pantherlog.ValueWriter) {
+	// Zone level changes include the zone name in the metadata
+	if len(event.Metadata) > 0 {
+		if zone := jsoniter.Get(event.Metadata, "zone_name"); zone.ValueType() == jsoniter.StringValue {
+			pantherlog.ScanHostname(w, zone.ToString())
+		}
+	}
+}
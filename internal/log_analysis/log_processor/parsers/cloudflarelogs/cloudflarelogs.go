@@ -22,7 +22,9 @@ import (
 	"time"
 
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/tcodec"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
 )
 
 func LogTypes() logtypes.Group {
@@ -60,5 +62,29 @@ var logTypes = func() logtypes.Group {
 				return &FirewallEvent{}
 			},
 		},
+		logtypes.Config{
+			Name:         "Cloudflare.Audit",
+			Description:  `Cloudflare Audit logs`,
+			ReferenceURL: `https://developers.cloudflare.com/logs/reference/log-fields/account/audit_logs/`,
+			Schema: pantherlog.MustBuildEventSchema(&AuditEvent{},
+				pantherlog.FieldIPAddress,
+				pantherlog.FieldDomainName,
+				pantherlog.FieldEmail,
+			),
+			NewParser: &parsers.JSONParserFactory{
+				LogType: "Cloudflare.Audit",
+				NewEvent: func() interface{} {
+					return &AuditEvent{}
+				},
+			},
+		},
+		logtypes.ConfigJSON{
+			Name:         "Cloudflare.DNS",
+			Description:  `Cloudflare DNS logs`,
+			ReferenceURL: `https://developers.cloudflare.com/logs/reference/log-fields/zone/dns_logs/`,
+			NewEvent: func() interface{} {
+				return &DNSEvent{}
+			},
+		},
 	)
 }()
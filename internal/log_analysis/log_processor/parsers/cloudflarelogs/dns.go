@@ -0,0 +1,37 @@
+package cloudflarelogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+)
+
+// Note: Only the fields identifying a DNS event are marked "required" because Cloudflare allows the user to select which fields to include in the logs.
+// nolint:lll
+type DNSEvent struct {
+	ColoCode         pantherlog.String `json:"ColoCode" description:"IATA airport code of data center that received the request"`
+	EDNSSubnet       pantherlog.String `json:"EDNSSubnet" panther:"ip" description:"IPv4 or IPv6 address information corresponding to the EDNS Client Subnet (ECS) forwarded by recursive resolvers"`
+	EDNSSubnetLength pantherlog.Int64  `json:"EDNSSubnetLength" description:"Size of the EDNS Client Subnet (ECS) in bits"`
+	QueryName        pantherlog.String `json:"QueryName" validate:"required" panther:"domain" description:"Name of the query that was sent"`
+	QueryType        pantherlog.Int64  `json:"QueryType" description:"Integer value of query type"`
+	ResponseCached   pantherlog.Bool   `json:"ResponseCached" description:"Whether the response was cached or not"`
+	ResponseCode     pantherlog.Int64  `json:"ResponseCode" description:"Integer value of response code"`
+	SourceIP         pantherlog.String `json:"SourceIP" panther:"ip" description:"IP address of the client (IPv4 or IPv6)"`
+	Timestamp        pantherlog.Time   `json:"Timestamp" validate:"required" event_time:"true" tcodec:"cloudflare" description:"Timestamp at which the query occurred"`
+}
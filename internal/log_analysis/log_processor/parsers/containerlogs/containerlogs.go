@@ -0,0 +1,62 @@
+// Package containerlogs parses container logs written by Docker and CRI container runtimes
+package containerlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+const (
+	TypeDockerJSONFile = "Docker.JSONFile"
+	TypeCRILog         = "CRI.Log"
+)
+
+// LogTypes exports the available log type entries
+func LogTypes() logtypes.Group {
+	return logTypes
+}
+
+// nolint:lll
+var logTypes = logtypes.Must("Container",
+	logtypes.Config{
+		Name:         TypeDockerJSONFile,
+		Description:  `Container logs written by the Docker json-file logging driver. Kubernetes metadata is added for files named after the /var/log/containers/<pod>_<namespace>_<container>-<id>.log convention.`,
+		ReferenceURL: `https://docs.docker.com/config/containers/logging/json-file/`,
+		Schema:       pantherlog.MustBuildEventSchema(&JSONFile{}),
+		NewParser: parsers.FactoryFunc(func(params interface{}) (parsers.Interface, error) {
+			return &JSONFileParser{
+				Kubernetes: kubernetesFromParams(params),
+			}, nil
+		}),
+	},
+	logtypes.Config{
+		Name:         TypeCRILog,
+		Description:  `Container logs written by CRI container runtimes (containerd, CRI-O). Kubernetes metadata is added for files named after the /var/log/containers/<pod>_<namespace>_<container>-<id>.log convention.`,
+		ReferenceURL: `https://github.com/kubernetes/community/blob/master/contributors/design-proposals/node/kubelet-cri-logging.md`,
+		Schema:       pantherlog.MustBuildEventSchema(&CRILog{}),
+		NewParser: parsers.FactoryFunc(func(params interface{}) (parsers.Interface, error) {
+			return &CRILogParser{
+				Kubernetes: kubernetesFromParams(params),
+			}, nil
+		}),
+	},
+)
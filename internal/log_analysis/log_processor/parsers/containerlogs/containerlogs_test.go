@@ -0,0 +1,176 @@
+package containerlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes/logtesting"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+func TestDockerJSONFile(t *testing.T) {
+	logtesting.RunTestsFromYAML(t, LogTypes(), "./testdata/docker_tests.yml")
+}
+
+func TestCRILog(t *testing.T) {
+	logtesting.RunTestsFromYAML(t, LogTypes(), "./testdata/cri_tests.yml")
+}
+
+const testContainerID = "8f3c5b7f6b0f4e0c9a2d1e3f4a5b6c7d8e9f0a1b2c3d4e5f6a7b8c9d0e1f2a3b"
+
+func TestKubernetesFromKey(t *testing.T) {
+	expect := &Kubernetes{
+		PodName:       null.FromString("api-7d9f8b6c5-x2x4z"),
+		PodNamespace:  null.FromString("default"),
+		ContainerName: null.FromString("api-server"),
+		ContainerID:   null.FromString(testContainerID),
+	}
+	require.Equal(t, expect, KubernetesFromKey("node-1/var/log/containers/api-7d9f8b6c5-x2x4z_default_api-server-"+testContainerID+".log"))
+	require.Equal(t, expect, KubernetesFromKey("api-7d9f8b6c5-x2x4z_default_api-server-"+testContainerID+".log.gz"))
+	require.Nil(t, KubernetesFromKey("var/lib/docker/containers/"+testContainerID+"/"+testContainerID+"-json.log"))
+	require.Nil(t, KubernetesFromKey("var/log/containers/api_default_api-server.log"))
+}
+
+func TestKubernetesMetadata(t *testing.T) {
+	params := &parsers.ObjectInfo{
+		Bucket: "example-bucket",
+		Key:    "var/log/containers/api-7d9f8b6c5-x2x4z_default_api-server-" + testContainerID + ".log",
+	}
+	p, err := LogTypes().Find(TypeDockerJSONFile).NewParser(params)
+	require.NoError(t, err)
+	results, err := p.ParseLog(`{"log":"started\n","stream":"stdout","time":"2020-10-19T15:06:40.123456789Z"}`)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	logtesting.TestResult(t, `{
+		"log": "started",
+		"stream": "stdout",
+		"time": "2020-10-19T15:06:40.123456789Z",
+		"podName": "api-7d9f8b6c5-x2x4z",
+		"podNamespace": "default",
+		"containerName": "api-server",
+		"containerId": "`+testContainerID+`",
+		"p_log_type": "Docker.JSONFile",
+		"p_event_time": "2020-10-19T15:06:40.123456789Z"
+	}`, results[0])
+}
+
+func TestCRILogPartial(t *testing.T) {
+	params := &parsers.ObjectInfo{
+		Bucket: "example-bucket",
+		Key:    "var/log/containers/api-7d9f8b6c5-x2x4z_default_api-server-" + testContainerID + ".log",
+	}
+	p, err := LogTypes().Find(TypeCRILog).NewParser(params)
+	require.NoError(t, err)
+	lines := []string{
+		"2020-10-19T15:06:40.000000001Z stdout P a very ",
+		"2020-10-19T15:06:40.000000002Z stderr F interleaved error",
+		"2020-10-19T15:06:40.000000003Z stdout P long ",
+		"2020-10-19T15:06:40.000000004Z stdout F line",
+	}
+	var results []*parsers.Result
+	for _, line := range lines {
+		r, err := p.ParseLog(line)
+		require.NoError(t, err)
+		results = append(results, r...)
+	}
+	require.Len(t, results, 2)
+	logtesting.TestResult(t, `{
+		"time": "2020-10-19T15:06:40.000000002Z",
+		"stream": "stderr",
+		"log": "interleaved error",
+		"podName": "api-7d9f8b6c5-x2x4z",
+		"podNamespace": "default",
+		"containerName": "api-server",
+		"containerId": "`+testContainerID+`",
+		"p_log_type": "CRI.Log",
+		"p_event_time": "2020-10-19T15:06:40.000000002Z"
+	}`, results[0])
+	logtesting.TestResult(t, `{
+		"time": "2020-10-19T15:06:40.000000001Z",
+		"stream": "stdout",
+		"log": "a very long line",
+		"podName": "api-7d9f8b6c5-x2x4z",
+		"podNamespace": "default",
+		"containerName": "api-server",
+		"containerId": "`+testContainerID+`",
+		"p_log_type": "CRI.Log",
+		"p_event_time": "2020-10-19T15:06:40.000000001Z"
+	}`, results[1])
+}
+
+func TestCRILogPartialMaxSize(t *testing.T) {
+	p := &CRILogParser{}
+	half := strings.Repeat("x", maxPartialSize/2)
+	results, err := p.ParseLog("2020-10-19T15:06:40.000000001Z stdout P " + half)
+	require.NoError(t, err)
+	require.Empty(t, results)
+	// The partial lines reach the cap and are emitted without waiting for the final line
+	results, err = p.ParseLog("2020-10-19T15:06:40.000000002Z stdout P " + half)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	event := results[0].Event.(*CRILog)
+	require.Equal(t, half+half, event.Log.Value)
+	require.Equal(t, "2020-10-19T15:06:40.000000001Z", event.Time.Format(time.RFC3339Nano))
+	// The rest of the line is emitted on its own
+	results, err = p.ParseLog("2020-10-19T15:06:40.000000003Z stdout F end")
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, "end", results[0].Event.(*CRILog).Log.Value)
+}
+
+func TestCRILogFlush(t *testing.T) {
+	p := &CRILogParser{}
+	for _, line := range []string{
+		"2020-10-19T15:06:40.000000001Z stderr P error ",
+		"2020-10-19T15:06:40.000000002Z stdout P truncated ",
+		"2020-10-19T15:06:40.000000003Z stdout P line",
+	} {
+		results, err := p.ParseLog(line)
+		require.NoError(t, err)
+		require.Empty(t, results)
+	}
+	results, err := p.Flush()
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	require.Equal(t, "error ", results[0].Event.(*CRILog).Log.Value)
+	require.Equal(t, "truncated line", results[1].Event.(*CRILog).Log.Value)
+
+	results, err = p.Flush()
+	require.NoError(t, err)
+	require.Empty(t, results)
+}
+
+func TestCRILogInvalid(t *testing.T) {
+	p := &CRILogParser{}
+	for _, line := range []string{
+		`{"log":"hello\n","stream":"stdout","time":"2020-10-19T15:06:40.123456789Z"}`,
+		"2020-10-19T15:06:40.123456789Z stdin F hello",
+		"2020-10-19T15:06:40.123456789Z stdout X hello",
+		"Oct 19 15:06:40 stdout F hello",
+	} {
+		_, err := p.ParseLog(line)
+		require.Error(t, err, line)
+	}
+}
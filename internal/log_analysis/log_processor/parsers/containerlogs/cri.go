@@ -0,0 +1,168 @@
+package containerlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+// CRILog is a log line of a container written by a CRI container runtime
+// nolint:lll
+type CRILog struct {
+	Time   pantherlog.Time   `json:"time" tcodec:"rfc3339" event_time:"true" validate:"required" description:"The time the line was written, with nanosecond precision. For lines split by the runtime this is the time of the first part."`
+	Stream pantherlog.String `json:"stream" validate:"required" description:"The stream the line was written to (stdout or stderr)."`
+	Log    pantherlog.String `json:"log" description:"The log line written by the container."`
+	Kubernetes
+}
+
+// CRI log line tags
+const (
+	tagPartial = "P"
+	tagFull    = "F"
+)
+
+// maxPartialSize caps the size of the partial lines kept for a stream.
+// Partial lines reaching the cap are emitted joined, as if a final line was read.
+const maxPartialSize = 1024 * 1024
+
+// CRILogParser parses CRI logs.
+//
+// Runtimes split long lines in multiple partial lines (tagged `P`) followed by a final line (tagged `F`).
+// The parser keeps partial lines for each stream until the final line is read and emits a single event.
+// Partial lines are also emitted when they reach maxPartialSize and at the end of the input, see Flush.
+type CRILogParser struct {
+	// Kubernetes metadata added to all events
+	Kubernetes *Kubernetes
+	builder    pantherlog.ResultBuilder
+	partial    map[string]*CRILog
+}
+
+var (
+	_ parsers.Interface = (*CRILogParser)(nil)
+	_ parsers.Flusher   = (*CRILogParser)(nil)
+)
+
+// ParseLog implements parsers.Interface
+func (p *CRILogParser) ParseLog(log string) ([]*parsers.Result, error) {
+	log = strings.TrimRight(log, "\r\n")
+	// The message can contain spaces, it is the remainder after the first 3 fields
+	fields := strings.SplitN(log, " ", 4)
+	if len(fields) < 3 {
+		return nil, errors.New("invalid CRI log line")
+	}
+	tm, err := time.Parse(time.RFC3339Nano, fields[0])
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid CRI log timestamp")
+	}
+	stream := fields[1]
+	switch stream {
+	case "stdout", "stderr":
+	default:
+		return nil, errors.Errorf("invalid CRI log stream %q", stream)
+	}
+	// Additional tags might be added after the first one separated by ':'
+	tag := fields[2]
+	if pos := strings.IndexByte(tag, ':'); pos != -1 {
+		tag = tag[:pos]
+	}
+	var msg string
+	if len(fields) == 4 {
+		msg = fields[3]
+	}
+	switch tag {
+	case tagPartial:
+		event := p.appendPartial(tm, stream, msg)
+		if len(event.Log.Value) < maxPartialSize {
+			return nil, nil
+		}
+		return p.buildResults(p.popPartial(stream))
+	case tagFull:
+		event := p.popPartial(stream)
+		if event == nil {
+			event = &CRILog{
+				Time:   tm.UTC(),
+				Stream: null.FromString(stream),
+			}
+		}
+		event.Log.Value += msg
+		event.Log.Exists = true
+		return p.buildResults(event)
+	default:
+		return nil, errors.Errorf("invalid CRI log tag %q", tag)
+	}
+}
+
+// Flush implements parsers.Flusher, emitting the partial lines without a final line at the end of the input
+func (p *CRILogParser) Flush() ([]*parsers.Result, error) {
+	var events []*CRILog
+	for stream := range p.partial {
+		events = append(events, p.popPartial(stream))
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Time.Before(events[j].Time)
+	})
+	return p.buildResults(events...)
+}
+
+func (p *CRILogParser) buildResults(events ...*CRILog) ([]*parsers.Result, error) {
+	var results []*parsers.Result
+	for _, event := range events {
+		p.Kubernetes.copyTo(&event.Kubernetes)
+		result, err := p.builder.BuildResult(TypeCRILog, event)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func (p *CRILogParser) appendPartial(tm time.Time, stream, msg string) *CRILog {
+	if event, ok := p.partial[stream]; ok {
+		event.Log.Value += msg
+		return event
+	}
+	if p.partial == nil {
+		p.partial = make(map[string]*CRILog)
+	}
+	event := &CRILog{
+		Time:   tm.UTC(),
+		Stream: null.FromString(stream),
+		Log:    null.FromString(msg),
+	}
+	p.partial[stream] = event
+	return event
+}
+
+func (p *CRILogParser) popPartial(stream string) *CRILog {
+	event, ok := p.partial[stream]
+	if !ok {
+		return nil
+	}
+	delete(p.partial, stream)
+	return event
+}
@@ -0,0 +1,68 @@
+package containerlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+// JSONFile is a log line of a container written by the Docker json-file logging driver
+// nolint:lll
+type JSONFile struct {
+	Log    pantherlog.String `json:"log" description:"The log line written by the container without the trailing newline."`
+	Stream pantherlog.String `json:"stream" validate:"required" description:"The stream the line was written to (stdout or stderr)."`
+	Time   pantherlog.Time   `json:"time" tcodec:"rfc3339" event_time:"true" validate:"required" description:"The time the line was written, with nanosecond precision."`
+	Attrs  map[string]string `json:"attrs" description:"The container labels and environment variables selected with the labels and env logging options."`
+	Kubernetes
+}
+
+// JSONFileParser parses Docker json-file logs
+type JSONFileParser struct {
+	// Kubernetes metadata added to all events
+	Kubernetes *Kubernetes
+	builder    pantherlog.ResultBuilder
+}
+
+var _ parsers.Interface = (*JSONFileParser)(nil)
+
+// ParseLog implements parsers.Interface
+func (p *JSONFileParser) ParseLog(log string) ([]*parsers.Result, error) {
+	event := JSONFile{}
+	if err := pantherlog.ConfigJSON().UnmarshalFromString(log, &event); err != nil {
+		return nil, err
+	}
+	if err := pantherlog.ValidateStruct(&event); err != nil {
+		return nil, err
+	}
+	event.Log.Value = trimNewline(event.Log.Value)
+	p.Kubernetes.copyTo(&event.Kubernetes)
+	result, err := p.builder.BuildResult(TypeDockerJSONFile, &event)
+	if err != nil {
+		return nil, err
+	}
+	return []*parsers.Result{result}, nil
+}
+
+func trimNewline(s string) string {
+	s = strings.TrimSuffix(s, "\n")
+	return strings.TrimSuffix(s, "\r")
+}
@@ -0,0 +1,70 @@
+package containerlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"path"
+	"regexp"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+// Kubernetes holds the metadata of a container derived from the name of its log file
+// nolint:lll
+type Kubernetes struct {
+	PodName       pantherlog.String `json:"podName" description:"The name of the Kubernetes pod running the container."`
+	PodNamespace  pantherlog.String `json:"podNamespace" description:"The namespace of the Kubernetes pod running the container."`
+	ContainerName pantherlog.String `json:"containerName" description:"The name of the container in the pod."`
+	ContainerID   pantherlog.String `json:"containerId" description:"The ID of the container."`
+}
+
+// The kubelet names container log files `/var/log/containers/<pod>_<namespace>_<container>-<id>.log`.
+// Pod names and namespaces are DNS subdomains so they cannot contain '_'.
+// Rotated or compressed files have additional suffixes (ie .log.1, .log.gz).
+var rxKubernetesLogFile = regexp.MustCompile(`^([^_]+)_([^_]+)_(.+)-([0-9a-f]{64})\.log(\..*)?$`)
+
+// KubernetesFromKey returns the metadata of a container from the key of an S3 object.
+// It returns nil if the key does not follow the kubelet file name convention.
+func KubernetesFromKey(key string) *Kubernetes {
+	match := rxKubernetesLogFile.FindStringSubmatch(path.Base(key))
+	if match == nil {
+		return nil
+	}
+	return &Kubernetes{
+		PodName:       null.FromString(match[1]),
+		PodNamespace:  null.FromString(match[2]),
+		ContainerName: null.FromString(match[3]),
+		ContainerID:   null.FromString(match[4]),
+	}
+}
+
+func kubernetesFromParams(params interface{}) *Kubernetes {
+	if obj, ok := params.(*parsers.ObjectInfo); ok && obj != nil {
+		return KubernetesFromKey(obj.Key)
+	}
+	return nil
+}
+
+func (k *Kubernetes) copyTo(dst *Kubernetes) {
+	if k != nil {
+		*dst = *k
+	}
+}
@@ -0,0 +1,112 @@
+// Package crowdstrikelogs parses CrowdStrike Falcon Data Replicator (FDR) events
+package crowdstrikelogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+const (
+	TypeFDREvent          = "CrowdStrike.FDREvent"
+	TypeProcessRollup2    = "CrowdStrike.ProcessRollup2"
+	TypeDNSRequest        = "CrowdStrike.DNSRequest"
+	TypeNetworkConnectIP4 = "CrowdStrike.NetworkConnectIP4"
+	TypeUserLogon         = "CrowdStrike.UserLogon"
+)
+
+// LogTypes exports the available log type entries
+func LogTypes() logtypes.Group {
+	return logTypes
+}
+
+// nolint:lll
+var logTypes = logtypes.Must("CrowdStrike",
+	logtypes.Config{
+		Name:         TypeFDREvent,
+		Description:  `CrowdStrike Falcon Data Replicator events. Events with a dedicated log type (ProcessRollup2, DnsRequest, NetworkConnectIP4, UserLogon) are not handled by this log type.`,
+		ReferenceURL: `https://falcon.crowdstrike.com/support/documentation/9/falcon-data-replicator`,
+		Schema:       pantherlog.MustBuildEventSchema(&FDREvent{}),
+		NewParser: parsers.FactoryFunc(func(_ interface{}) (parsers.Interface, error) {
+			return &FDREventParser{}, nil
+		}),
+	},
+	logtypes.Config{
+		Name:         TypeProcessRollup2,
+		Description:  `CrowdStrike Falcon Data Replicator ProcessRollup2 events, generated for every process started on a host.`,
+		ReferenceURL: `https://falcon.crowdstrike.com/support/documentation/9/falcon-data-replicator`,
+		Schema: pantherlog.MustBuildEventSchema(&ProcessRollup2{},
+			pantherlog.FieldIPAddress,
+			pantherlog.FieldMD5Hash,
+			pantherlog.FieldSHA1Hash,
+			pantherlog.FieldSHA256Hash,
+		),
+		NewParser: &parsers.JSONParserFactory{
+			LogType: TypeProcessRollup2,
+			NewEvent: func() interface{} {
+				return &ProcessRollup2{}
+			},
+			Validate: validateEvent(eventProcessRollup2, eventSyntheticProcessRollup2),
+		},
+	},
+	logtypes.Config{
+		Name:         TypeDNSRequest,
+		Description:  `CrowdStrike Falcon Data Replicator DnsRequest events, generated for every DNS lookup made by a process.`,
+		ReferenceURL: `https://falcon.crowdstrike.com/support/documentation/9/falcon-data-replicator`,
+		Schema: pantherlog.MustBuildEventSchema(&DNSRequest{},
+			pantherlog.FieldIPAddress,
+			pantherlog.FieldDomainName,
+		),
+		NewParser: &parsers.JSONParserFactory{
+			LogType: TypeDNSRequest,
+			NewEvent: func() interface{} {
+				return &DNSRequest{}
+			},
+			Validate: validateEvent(eventDNSRequest),
+		},
+	},
+	logtypes.Config{
+		Name:         TypeNetworkConnectIP4,
+		Description:  `CrowdStrike Falcon Data Replicator NetworkConnectIP4 events, generated for outbound IPv4 connections made by a process.`,
+		ReferenceURL: `https://falcon.crowdstrike.com/support/documentation/9/falcon-data-replicator`,
+		Schema:       pantherlog.MustBuildEventSchema(&NetworkConnectIP4{}),
+		NewParser: &parsers.JSONParserFactory{
+			LogType: TypeNetworkConnectIP4,
+			NewEvent: func() interface{} {
+				return &NetworkConnectIP4{}
+			},
+			Validate: validateEvent(eventNetworkConnectIP4),
+		},
+	},
+	logtypes.Config{
+		Name:         TypeUserLogon,
+		Description:  `CrowdStrike Falcon Data Replicator UserLogon events, generated when a user logs on to a host.`,
+		ReferenceURL: `https://falcon.crowdstrike.com/support/documentation/9/falcon-data-replicator`,
+		Schema:       pantherlog.MustBuildEventSchema(&UserLogon{}),
+		NewParser: &parsers.JSONParserFactory{
+			LogType: TypeUserLogon,
+			NewEvent: func() interface{} {
+				return &UserLogon{}
+			},
+			Validate: validateEvent(eventUserLogon),
+		},
+	},
+)
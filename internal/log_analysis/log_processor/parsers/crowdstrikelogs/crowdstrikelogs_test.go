@@ -0,0 +1,46 @@
+package crowdstrikelogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes/logtesting"
+)
+
+func TestCrowdStrikeLogs(t *testing.T) {
+	logtesting.RunTestsFromYAML(t, LogTypes(), "./testdata/crowdstrike_tests.yml")
+}
+
+func TestDedicatedEventTypes(t *testing.T) {
+	// The event has the required fields of all log types
+	const log = `{"event_simpleName":"DnsRequest","timestamp":"1603120000123","DomainName":"www.example.com","TargetProcessId":"1","RemoteAddressIP4":"10.0.0.1","UserName":"jdoe"}`
+	_, err := (&FDREventParser{}).ParseLog(log)
+	require.Error(t, err)
+	for _, logType := range []string{TypeProcessRollup2, TypeNetworkConnectIP4, TypeUserLogon} {
+		entry := LogTypes().Find(logType)
+		require.NotNil(t, entry, logType)
+		p, err := entry.NewParser(nil)
+		require.NoError(t, err)
+		_, err = p.ParseLog(log)
+		require.Error(t, err, logType)
+	}
+}
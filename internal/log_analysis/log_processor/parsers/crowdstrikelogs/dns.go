@@ -0,0 +1,51 @@
+package crowdstrikelogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+)
+
+// DNSRequest is generated for every DNS lookup made by a process
+// nolint:lll
+type DNSRequest struct {
+	BaseEvent
+	DomainName     pantherlog.String `json:"DomainName" panther:"domain" validate:"required" description:"The domain name that was looked up."`
+	RequestType    pantherlog.String `json:"RequestType" description:"The type of the DNS record requested (ie 1 for A, 28 for AAAA)."`
+	DualRequest    pantherlog.String `json:"DualRequest" description:"Set if the request was made for both A and AAAA records."`
+	InterfaceIndex pantherlog.String `json:"InterfaceIndex" description:"The index of the network interface used for the lookup."`
+	IP4Records     pantherlog.String `json:"IP4Records" description:"The IPv4 addresses in the DNS response separated by ';'."`
+	CNAMERecords   pantherlog.String `json:"CNAMERecords" description:"The CNAME records in the DNS response separated by ';'."`
+}
+
+var _ pantherlog.ValueWriterTo = (*DNSRequest)(nil)
+
+// WriteValuesTo implements pantherlog.ValueWriterTo interface
+func (event *DNSRequest) WriteValuesTo(w pantherlog.ValueWriter) {
+	for _, addr := range strings.Split(event.IP4Records.Value, ";") {
+		pantherlog.ScanIPAddress(w, strings.TrimSpace(addr))
+	}
+	for _, name := range strings.Split(event.CNAMERecords.Value, ";") {
+		if name = strings.TrimSpace(name); name != "" {
+			w.WriteValues(pantherlog.FieldDomainName, name)
+		}
+	}
+}
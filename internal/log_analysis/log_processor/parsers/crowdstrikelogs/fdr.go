@@ -0,0 +1,201 @@
+package crowdstrikelogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"encoding/json"
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+// Names of the events with a dedicated log type
+const (
+	eventProcessRollup2          = "ProcessRollup2"
+	eventSyntheticProcessRollup2 = "SyntheticProcessRollup2"
+	eventDNSRequest              = "DnsRequest"
+	eventNetworkConnectIP4       = "NetworkConnectIP4"
+	eventUserLogon               = "UserLogon"
+)
+
+// BaseEvent holds the fields common to all FDR events
+// nolint:lll
+type BaseEvent struct {
+	EventSimpleName            pantherlog.String `json:"event_simpleName" validate:"required" description:"The name of the event (ProcessRollup2, DnsRequest, ...)."`
+	Name                       pantherlog.String `json:"name" description:"The versioned name of the event (ie ProcessRollup2V18)."`
+	AID                        pantherlog.String `json:"aid" description:"The sensor ID, a unique identifier of the host running the Falcon sensor."`
+	AIP                        pantherlog.String `json:"aip" panther:"ip" description:"The external IP address of the host as seen by the CrowdStrike cloud."`
+	CID                        pantherlog.String `json:"cid" description:"The customer ID."`
+	ID                         pantherlog.String `json:"id" description:"The unique identifier of the event."`
+	EventPlatform              pantherlog.String `json:"event_platform" description:"The platform of the host (Win, Mac, Lin)."`
+	Timestamp                  pantherlog.Time   `json:"timestamp" tcodec:"unix_ms" event_time:"true" validate:"required" description:"The time the event was received by the CrowdStrike cloud."`
+	ContextTimeStamp           pantherlog.Time   `json:"ContextTimeStamp" tcodec:"unix" description:"The time the event occurred on the host."`
+	ContextProcessID           pantherlog.String `json:"ContextProcessId" description:"The unique ID of the process that generated the event (matches TargetProcessId of its ProcessRollup2 event)."`
+	ContextThreadID            pantherlog.String `json:"ContextThreadId" description:"The unique ID of the thread that generated the event."`
+	ConfigBuild                pantherlog.String `json:"ConfigBuild" description:"The build of the sensor."`
+	ConfigStateHash            pantherlog.String `json:"ConfigStateHash" description:"A hash of the sensor configuration state."`
+	Entitlements               pantherlog.String `json:"Entitlements" description:"The entitlements of the sensor."`
+	EffectiveTransmissionClass pantherlog.String `json:"EffectiveTransmissionClass" description:"The transmission class of the event."`
+}
+
+// baseFields are the JSON keys of BaseEvent fields.
+// They are excluded from the fields of generic FDR events.
+var baseFields = map[string]bool{
+	"event_simpleName":           true,
+	"name":                       true,
+	"aid":                        true,
+	"aip":                        true,
+	"cid":                        true,
+	"id":                         true,
+	"event_platform":             true,
+	"timestamp":                  true,
+	"ContextTimeStamp":           true,
+	"ContextProcessId":           true,
+	"ContextThreadId":            true,
+	"ConfigBuild":                true,
+	"ConfigStateHash":            true,
+	"Entitlements":               true,
+	"EffectiveTransmissionClass": true,
+}
+
+func (e *BaseEvent) eventName() string {
+	return e.EventSimpleName.Value
+}
+
+// validateEvent checks that an event is valid and has one of the expected names
+func validateEvent(names ...string) func(event interface{}) error {
+	return func(event interface{}) error {
+		if err := pantherlog.ValidateStruct(event); err != nil {
+			return err
+		}
+		e, ok := event.(interface{ eventName() string })
+		if !ok {
+			return errors.New("invalid FDR event")
+		}
+		name := e.eventName()
+		for _, n := range names {
+			if name == n {
+				return nil
+			}
+		}
+		return errors.Errorf("unexpected FDR event %q", name)
+	}
+}
+
+// hasDedicatedType checks if an event is handled by a dedicated log type
+func hasDedicatedType(name string) bool {
+	switch name {
+	case eventProcessRollup2, eventSyntheticProcessRollup2, eventDNSRequest, eventNetworkConnectIP4, eventUserLogon:
+		return true
+	default:
+		return false
+	}
+}
+
+// FDREvent is a generic FDR event
+// nolint:lll
+type FDREvent struct {
+	BaseEvent
+	Fields map[string]string `json:"fields" description:"The fields of the event that are specific to its event_simpleName."`
+}
+
+// FDREventParser parses generic FDR events
+type FDREventParser struct {
+	builder pantherlog.ResultBuilder
+}
+
+var _ parsers.Interface = (*FDREventParser)(nil)
+
+// Decode numbers as json.Number to keep their original text in field values
+var jsonAPI = jsoniter.Config{
+	UseNumber:     true,
+	CaseSensitive: true,
+}.Froze()
+
+// ParseLog implements parsers.Interface
+func (p *FDREventParser) ParseLog(log string) ([]*parsers.Result, error) {
+	event := FDREvent{}
+	if err := pantherlog.ConfigJSON().UnmarshalFromString(log, &event.BaseEvent); err != nil {
+		return nil, err
+	}
+	if err := pantherlog.ValidateStruct(&event); err != nil {
+		return nil, err
+	}
+	if name := event.eventName(); hasDedicatedType(name) {
+		return nil, errors.Errorf("FDR event %q has a dedicated log type", name)
+	}
+	fields := map[string]interface{}{}
+	if err := jsonAPI.UnmarshalFromString(log, &fields); err != nil {
+		return nil, err
+	}
+	for key, value := range fields {
+		if baseFields[key] {
+			continue
+		}
+		s, ok := stringValue(value)
+		if !ok {
+			continue
+		}
+		if event.Fields == nil {
+			event.Fields = make(map[string]string, len(fields))
+		}
+		event.Fields[key] = s
+	}
+	result, err := p.builder.BuildResult(TypeFDREvent, &event)
+	if err != nil {
+		return nil, err
+	}
+	return []*parsers.Result{result}, nil
+}
+
+// stringValue converts scalar JSON values to strings and other values to JSON text
+func stringValue(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case nil:
+		return "", false
+	case string:
+		return v, v != ""
+	case json.Number:
+		return v.String(), true
+	case bool:
+		if v {
+			return "true", true
+		}
+		return "false", true
+	default:
+		s, err := jsonAPI.MarshalToString(v)
+		if err != nil {
+			return "", false
+		}
+		return s, true
+	}
+}
+
+// writeHash writes a hash indicator.
+// The sensor fills hashes it did not compute with zeros.
+func writeHash(w pantherlog.ValueWriter, field pantherlog.FieldID, hash string) {
+	if strings.Trim(hash, "0") == "" {
+		return
+	}
+	w.WriteValues(field, strings.ToLower(hash))
+}
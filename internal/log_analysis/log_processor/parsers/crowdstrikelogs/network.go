@@ -0,0 +1,37 @@
+package crowdstrikelogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+)
+
+// NetworkConnectIP4 is generated for outbound IPv4 connections made by a process
+// nolint:lll
+type NetworkConnectIP4 struct {
+	BaseEvent
+	LocalAddressIP4     pantherlog.String `json:"LocalAddressIP4" panther:"ip" description:"The local IP address of the connection."`
+	LocalPort           pantherlog.Uint16 `json:"LocalPort" description:"The local port of the connection."`
+	RemoteAddressIP4    pantherlog.String `json:"RemoteAddressIP4" panther:"ip" validate:"required" description:"The remote IP address of the connection."`
+	RemotePort          pantherlog.Uint16 `json:"RemotePort" description:"The remote port of the connection."`
+	Protocol            pantherlog.String `json:"Protocol" description:"The IP protocol number of the connection (ie 6 for TCP, 17 for UDP)."`
+	ConnectionFlags     pantherlog.String `json:"ConnectionFlags" description:"Flags describing the connection."`
+	ConnectionDirection pantherlog.String `json:"ConnectionDirection" description:"The direction of the connection (0 outbound, 1 inbound)."`
+	InContext           pantherlog.String `json:"InContext" description:"Set if the event was generated in the context of the process that made the connection."`
+}
@@ -0,0 +1,61 @@
+package crowdstrikelogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+)
+
+// ProcessRollup2 is generated for every process started on a host.
+// SyntheticProcessRollup2 events for processes that were running when the sensor started have the same fields.
+// nolint:lll
+type ProcessRollup2 struct {
+	BaseEvent
+	TargetProcessID         pantherlog.String `json:"TargetProcessId" validate:"required" description:"The unique ID of the process."`
+	ParentProcessID         pantherlog.String `json:"ParentProcessId" description:"The unique ID of the parent process."`
+	SourceProcessID         pantherlog.String `json:"SourceProcessId" description:"The unique ID of the process that created the process."`
+	SourceThreadID          pantherlog.String `json:"SourceThreadId" description:"The unique ID of the thread that created the process."`
+	RawProcessID            pantherlog.String `json:"RawProcessId" description:"The operating system ID of the process."`
+	ProcessStartTime        pantherlog.Time   `json:"ProcessStartTime" tcodec:"unix" description:"The time the process started."`
+	ProcessEndTime          pantherlog.Time   `json:"ProcessEndTime" tcodec:"unix" description:"The time the process ended."`
+	ImageFileName           pantherlog.String `json:"ImageFileName" description:"The full path of the executable of the process."`
+	CommandLine             pantherlog.String `json:"CommandLine" description:"The command line used to start the process."`
+	ParentBaseFileName      pantherlog.String `json:"ParentBaseFileName" description:"The executable name of the parent process."`
+	GrandParentBaseFileName pantherlog.String `json:"GrandParentBaseFileName" description:"The executable name of the grandparent process."`
+	MD5HashData             pantherlog.String `json:"MD5HashData" description:"The MD5 hash of the executable of the process."`
+	SHA1HashData            pantherlog.String `json:"SHA1HashData" description:"The SHA1 hash of the executable of the process."`
+	SHA256HashData          pantherlog.String `json:"SHA256HashData" description:"The SHA256 hash of the executable of the process."`
+	UserSID                 pantherlog.String `json:"UserSid" description:"The security identifier (SID) of the user that started the process."`
+	AuthenticationID        pantherlog.String `json:"AuthenticationId" description:"The logon session ID of the user that started the process."`
+	SessionID               pantherlog.String `json:"SessionId" description:"The session ID of the user that started the process."`
+	TokenType               pantherlog.String `json:"TokenType" description:"The type of the access token of the process."`
+	IntegrityLevel          pantherlog.String `json:"IntegrityLevel" description:"The integrity level of the process."`
+	ProcessGroupID          pantherlog.String `json:"ProcessGroupId" description:"The unique ID of the process group of the process."`
+	ImageSubsystem          pantherlog.String `json:"ImageSubsystem" description:"The subsystem of the executable of the process."`
+	TreeID                  pantherlog.String `json:"TreeId" description:"The ID of the process tree the process belongs to."`
+}
+
+var _ pantherlog.ValueWriterTo = (*ProcessRollup2)(nil)
+
+// WriteValuesTo implements pantherlog.ValueWriterTo interface
+func (event *ProcessRollup2) WriteValuesTo(w pantherlog.ValueWriter) {
+	writeHash(w, pantherlog.FieldMD5Hash, event.MD5HashData.Value)
+	writeHash(w, pantherlog.FieldSHA1Hash, event.SHA1HashData.Value)
+	writeHash(w, pantherlog.FieldSHA256Hash, event.SHA256HashData.Value)
+}
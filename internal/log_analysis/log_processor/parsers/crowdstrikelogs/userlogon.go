@@ -0,0 +1,45 @@
+package crowdstrikelogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+)
+
+// UserLogon is generated when a user logs on to a host
+// nolint:lll
+type UserLogon struct {
+	BaseEvent
+	UserName              pantherlog.String `json:"UserName" panther:"username" validate:"required" description:"The name of the user that logged on."`
+	UserPrincipal         pantherlog.String `json:"UserPrincipal" panther:"username" description:"The user principal name (UPN) of the user that logged on."`
+	UserSID               pantherlog.String `json:"UserSid" description:"The security identifier (SID) of the user that logged on."`
+	LogonDomain           pantherlog.String `json:"LogonDomain" description:"The domain of the user that logged on."`
+	LogonServer           pantherlog.String `json:"LogonServer" panther:"hostname" description:"The server that authenticated the logon."`
+	LogonType             pantherlog.String `json:"LogonType" description:"The type of the logon (ie 2 interactive, 3 network, 10 remote interactive)."`
+	LogonTime             pantherlog.Time   `json:"LogonTime" tcodec:"unix" description:"The time of the logon."`
+	AuthenticationID      pantherlog.String `json:"AuthenticationId" description:"The logon session ID."`
+	AuthenticationPackage pantherlog.String `json:"AuthenticationPackage" description:"The authentication package used for the logon (ie Kerberos, NTLM)."`
+	SessionID             pantherlog.String `json:"SessionId" description:"The session ID of the logon."`
+	RemoteAddressIP4      pantherlog.String `json:"RemoteAddressIP4" panther:"ip" description:"The IPv4 address of the remote host for network logons."`
+	RemoteAddressIP6      pantherlog.String `json:"RemoteAddressIP6" panther:"ip" description:"The IPv6 address of the remote host for network logons."`
+	ClientComputerName    pantherlog.String `json:"ClientComputerName" panther:"hostname" description:"The name of the remote host for network logons."`
+	UserIsAdmin           pantherlog.String `json:"UserIsAdmin" description:"Set to 1 if the user is an administrator."`
+	UserLogonFlags        pantherlog.String `json:"UserLogonFlags" description:"Flags describing the logon."`
+	PasswordLastSet       pantherlog.Time   `json:"PasswordLastSet" tcodec:"unix" description:"The time the password of the user was last set."`
+}
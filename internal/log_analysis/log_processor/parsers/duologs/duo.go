@@ -0,0 +1,134 @@
+package duologs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+)
+
+// Authentication is a Duo authentication log event
+// nolint:lll
+type Authentication struct {
+	TxID                  pantherlog.String `json:"txid" validate:"required" description:"The transaction ID of the event."`
+	EventType             pantherlog.String `json:"event_type" validate:"required" description:"The type of activity logged (authentication or enrollment)."`
+	Timestamp             pantherlog.Time   `json:"timestamp" tcodec:"unix" validate:"required" description:"The time the event occurred."`
+	ISOTimestamp          pantherlog.Time   `json:"isotimestamp" tcodec:"rfc3339" description:"The time the event occurred in ISO8601 format with sub-second precision."`
+	Result                pantherlog.String `json:"result" description:"The result of the authentication attempt (success, denied, failure, error, fraud)."`
+	Reason                pantherlog.String `json:"reason" description:"The reason for the authentication attempt result (ie user_approved, user_marked_fraud, deny_unenrolled_user)."`
+	Factor                pantherlog.String `json:"factor" description:"The authentication factor (ie duo_push, phone_call, passcode, remembered_device)."`
+	Email                 pantherlog.String `json:"email" panther:"email" description:"The email address of the user."`
+	Alias                 pantherlog.String `json:"alias" panther:"username" description:"The username alias used to log in."`
+	User                  *User             `json:"user,omitempty" description:"The user that attempted the authentication."`
+	Application           *Application      `json:"application,omitempty" description:"The application the user attempted to access."`
+	AccessDevice          *AccessDevice     `json:"access_device,omitempty" description:"The device the user used to access the application."`
+	AuthDevice            *AuthDevice       `json:"auth_device,omitempty" description:"The device the user used to approve the authentication."`
+	TrustedEndpointStatus pantherlog.String `json:"trusted_endpoint_status" description:"Whether the access device is a trusted endpoint."`
+	OODSoftware           pantherlog.String `json:"ood_software" description:"The out of date software that caused the authentication to be denied."`
+}
+
+var _ pantherlog.EventTimer = (*Authentication)(nil)
+
+// PantherEventTime implements pantherlog.EventTimer interface
+func (event *Authentication) PantherEventTime() time.Time {
+	return eventTime(event.ISOTimestamp, event.Timestamp)
+}
+
+// User is the user of an authentication event
+// nolint:lll
+type User struct {
+	Key    pantherlog.String `json:"key" description:"The user's ID."`
+	Name   pantherlog.String `json:"name" panther:"username" description:"The user's username."`
+	Groups []string          `json:"groups,omitempty" description:"The groups the user belongs to."`
+}
+
+// Application is the application of an authentication event
+// nolint:lll
+type Application struct {
+	Key  pantherlog.String `json:"key" description:"The application's integration key."`
+	Name pantherlog.String `json:"name" description:"The application's name."`
+}
+
+// Location is the geolocation of an IP address
+// nolint:lll
+type Location struct {
+	City    pantherlog.String `json:"city" description:"The city name."`
+	State   pantherlog.String `json:"state" description:"The state, county, province, or prefecture."`
+	Country pantherlog.String `json:"country" description:"The country name."`
+}
+
+// AccessDevice is the device used to access an application
+// nolint:lll
+type AccessDevice struct {
+	IP                  pantherlog.String     `json:"ip" panther:"ip" description:"The IP address of the access device."`
+	Hostname            pantherlog.String     `json:"hostname" panther:"hostname" description:"The hostname of the access device."`
+	Location            *Location             `json:"location,omitempty" description:"The geolocation of the access device IP address."`
+	Browser             pantherlog.String     `json:"browser" description:"The browser used to access the application."`
+	BrowserVersion      pantherlog.String     `json:"browser_version" description:"The browser version."`
+	OS                  pantherlog.String     `json:"os" description:"The operating system of the access device."`
+	OSVersion           pantherlog.String     `json:"os_version" description:"The operating system version of the access device."`
+	FlashVersion        pantherlog.String     `json:"flash_version" description:"The Flash plugin version."`
+	JavaVersion         pantherlog.String     `json:"java_version" description:"The Java plugin version."`
+	IsEncryptionEnabled pantherlog.RawMessage `json:"is_encryption_enabled" description:"Whether disk encryption is enabled on the access device. Reported as true, false or \"unknown\" if the check could not be performed."`
+	IsFirewallEnabled   pantherlog.RawMessage `json:"is_firewall_enabled" description:"Whether the firewall is enabled on the access device. Reported as true, false or \"unknown\" if the check could not be performed."`
+	IsPasswordSet       pantherlog.RawMessage `json:"is_password_set" description:"Whether a password is set on the access device. Reported as true, false or \"unknown\" if the check could not be performed."`
+	SecurityAgents      []SecurityAgent       `json:"security_agents,omitempty" description:"The security agents present on the access device."`
+}
+
+// SecurityAgent is a security agent installed on an access device
+// nolint:lll
+type SecurityAgent struct {
+	SecurityAgent pantherlog.String `json:"security_agent" description:"The name of the security agent."`
+	Version       pantherlog.String `json:"version" description:"The version of the security agent."`
+}
+
+// AuthDevice is the device used to approve an authentication
+// nolint:lll
+type AuthDevice struct {
+	Name     pantherlog.String `json:"name" description:"The name of the authentication device (ie a phone number or a token serial number)."`
+	IP       pantherlog.String `json:"ip" panther:"ip" description:"The IP address of the authentication device."`
+	Location *Location         `json:"location,omitempty" description:"The geolocation of the authentication device IP address."`
+}
+
+// Administrator is a Duo administrator log event
+// nolint:lll
+type Administrator struct {
+	Action       pantherlog.String `json:"action" validate:"required" description:"The type of change that was performed (ie admin_login, user_update, integration_create)."`
+	Username     pantherlog.String `json:"username" validate:"required" panther:"username" description:"The full name of the administrator that performed the action, or API if the action was performed with the Admin API."`
+	Object       pantherlog.String `json:"object" description:"The object that was acted on (ie a username or an integration name)."`
+	Description  pantherlog.String `json:"description" description:"The details of what changed, as a JSON encoded string."`
+	Timestamp    pantherlog.Time   `json:"timestamp" tcodec:"unix" validate:"required" description:"The time the event occurred."`
+	ISOTimestamp pantherlog.Time   `json:"isotimestamp" tcodec:"rfc3339" description:"The time the event occurred in ISO8601 format."`
+}
+
+var _ pantherlog.EventTimer = (*Administrator)(nil)
+
+// PantherEventTime implements pantherlog.EventTimer interface
+func (event *Administrator) PantherEventTime() time.Time {
+	return eventTime(event.ISOTimestamp, event.Timestamp)
+}
+
+// eventTime prefers the ISO timestamp which has sub-second precision
+func eventTime(iso, unix time.Time) time.Time {
+	if !iso.IsZero() {
+		return iso
+	}
+	return unix
+}
@@ -0,0 +1,64 @@
+// Package duologs parses Duo Security logs
+package duologs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+const (
+	TypeAuthentication = "Duo.Authentication"
+	TypeAdministrator  = "Duo.Administrator"
+)
+
+// LogTypes exports the available log type entries
+func LogTypes() logtypes.Group {
+	return logTypes
+}
+
+// nolint:lll
+var logTypes = logtypes.Must("Duo",
+	logtypes.Config{
+		Name:         TypeAuthentication,
+		Description:  `Duo authentication log events as returned by the Admin API (v2).`,
+		ReferenceURL: `https://duo.com/docs/adminapi#authentication-logs`,
+		Schema:       pantherlog.MustBuildEventSchema(&Authentication{}),
+		NewParser: &parsers.JSONParserFactory{
+			LogType: TypeAuthentication,
+			NewEvent: func() interface{} {
+				return &Authentication{}
+			},
+		},
+	},
+	logtypes.Config{
+		Name:         TypeAdministrator,
+		Description:  `Duo administrator log events as returned by the Admin API.`,
+		ReferenceURL: `https://duo.com/docs/adminapi#administrator-logs`,
+		Schema:       pantherlog.MustBuildEventSchema(&Administrator{}),
+		NewParser: &parsers.JSONParserFactory{
+			LogType: TypeAdministrator,
+			NewEvent: func() interface{} {
+				return &Administrator{}
+			},
+		},
+	},
+)
@@ -0,0 +1,236 @@
+package envoylogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/pkg/x/fastmatch"
+)
+
+// Access is an Envoy access log entry
+// nolint:lll
+type Access struct {
+	StartTime                      pantherlog.Time   `json:"start_time" tcodec:"rfc3339" event_time:"true" validate:"required" description:"Request start time including milliseconds."`
+	Method                         pantherlog.String `json:"method" description:"The HTTP method of the request."`
+	Path                           pantherlog.String `json:"path" description:"The path of the request (or the original path if rewritten by Envoy)."`
+	Protocol                       pantherlog.String `json:"protocol" description:"The protocol of the request (HTTP/1.1, HTTP/2)."`
+	ResponseCode                   pantherlog.Int32  `json:"response_code" description:"The HTTP response code. 0 means the downstream client disconnected."`
+	ResponseFlags                  pantherlog.String `json:"response_flags" description:"Additional details about the response or connection (ie UH for no healthy upstream, UF for upstream connection failure)."`
+	BytesReceived                  pantherlog.Int64  `json:"bytes_received" description:"Body bytes received."`
+	BytesSent                      pantherlog.Int64  `json:"bytes_sent" description:"Body bytes sent."`
+	Duration                       pantherlog.Int64  `json:"duration" description:"Total duration in milliseconds of the request from the start time to the last byte out."`
+	UpstreamServiceTime            pantherlog.Int64  `json:"upstream_service_time" description:"Time in milliseconds spent by the upstream host processing the request."`
+	XForwardedFor                  pantherlog.String `json:"x_forwarded_for" description:"The X-Forwarded-For header of the request."`
+	UserAgent                      pantherlog.String `json:"user_agent" description:"The User-Agent header of the request."`
+	RequestID                      pantherlog.String `json:"request_id" description:"The X-Request-Id header of the request."`
+	Authority                      pantherlog.String `json:"authority" description:"The :authority (Host) header of the request."`
+	UpstreamHost                   pantherlog.String `json:"upstream_host" description:"Upstream host URL (ie tcp://ip:port for TCP connections)."`
+	UpstreamCluster                pantherlog.String `json:"upstream_cluster" description:"Upstream cluster to which the upstream host belongs."`
+	UpstreamLocalAddress           pantherlog.String `json:"upstream_local_address" description:"Local address of the upstream connection."`
+	DownstreamLocalAddress         pantherlog.String `json:"downstream_local_address" description:"Local address of the downstream connection."`
+	DownstreamRemoteAddress        pantherlog.String `json:"downstream_remote_address" description:"Remote address of the downstream connection."`
+	RequestedServerName            pantherlog.String `json:"requested_server_name" description:"The SNI server name of the downstream TLS connection."`
+	RouteName                      pantherlog.String `json:"route_name" description:"Name of the route."`
+	UpstreamTransportFailureReason pantherlog.String `json:"upstream_transport_failure_reason" description:"The reason of an upstream transport failure (ie TLS handshake errors)."`
+	ConnectionTerminationDetails   pantherlog.String `json:"connection_termination_details" description:"The reason Envoy terminated the connection."`
+}
+
+var _ pantherlog.ValueWriterTo = (*Access)(nil)
+
+// WriteValuesTo implements pantherlog.ValueWriterTo interface
+//
+// There is no URL indicator field, the host of the request URL is indexed from the authority.
+// The path is not a URL on its own and is not indexed.
+func (event *Access) WriteValuesTo(w pantherlog.ValueWriter) {
+	for _, addr := range strings.Split(event.XForwardedFor.Value, ",") {
+		pantherlog.ScanIPAddress(w, addr)
+	}
+	scanAddress(w, event.Authority.Value)
+	scanAddress(w, event.RequestedServerName.Value)
+	scanAddress(w, event.UpstreamHost.Value)
+	scanAddress(w, event.UpstreamLocalAddress.Value)
+	scanAddress(w, event.DownstreamLocalAddress.Value)
+	scanAddress(w, event.DownstreamRemoteAddress.Value)
+}
+
+// scanAddress scans addresses with an optional scheme and port (ie tcp://10.0.0.1:80)
+func scanAddress(w pantherlog.ValueWriter, addr string) {
+	if pos := strings.Index(addr, "://"); pos != -1 {
+		addr = addr[pos+3:]
+	}
+	if addr == "" {
+		return
+	}
+	pantherlog.ScanNetworkAddress(w, addr)
+}
+
+// The default format of Envoy access logs
+// nolint:lll
+const defaultFormat = `[%{start_time}] "%{method} %{path} %{protocol}" %{response_code} %{response_flags} %{bytes_received} %{bytes_sent} %{duration} %{upstream_service_time} "%{x_forwarded_for}" "%{user_agent}" "%{request_id}" "%{authority}" "%{upstream_host}"`
+
+// AccessParser parses Envoy access logs
+type AccessParser struct {
+	pattern *fastmatch.Pattern
+	fields  []string
+	builder pantherlog.ResultBuilder
+}
+
+var _ parsers.Interface = (*AccessParser)(nil)
+
+// NewAccessParser creates a parser for Envoy access logs
+func NewAccessParser() *AccessParser {
+	return &AccessParser{
+		pattern: fastmatch.MustCompile(defaultFormat),
+	}
+}
+
+// ParseLog implements parsers.Interface
+func (p *AccessParser) ParseLog(log string) ([]*parsers.Result, error) {
+	log = strings.TrimSpace(log)
+	var err error
+	if strings.HasPrefix(log, "{") {
+		p.fields, err = appendJSONFields(p.fields[:0], log)
+	} else {
+		p.fields, err = p.pattern.MatchString(p.fields[:0], log)
+	}
+	if err != nil {
+		return nil, err
+	}
+	event := Access{}
+	if err := event.setFields(p.fields); err != nil {
+		return nil, err
+	}
+	if err := pantherlog.ValidateStruct(&event); err != nil {
+		return nil, err
+	}
+	result, err := p.builder.BuildResult(TypeAccess, &event)
+	if err != nil {
+		return nil, err
+	}
+	return []*parsers.Result{result}, nil
+}
+
+// Decode numbers as json.Number to keep their original text
+var jsonAPI = jsoniter.Config{
+	UseNumber: true,
+}.Froze()
+
+// appendJSONFields appends the key/value pairs of a JSON access log
+func appendJSONFields(dst []string, log string) ([]string, error) {
+	fields := map[string]interface{}{}
+	if err := jsonAPI.UnmarshalFromString(log, &fields); err != nil {
+		return dst, err
+	}
+	for key, value := range fields {
+		switch v := value.(type) {
+		case string:
+			dst = append(dst, key, v)
+		case json.Number:
+			dst = append(dst, key, v.String())
+		}
+	}
+	return dst, nil
+}
+
+// setFields sets the values of key/value pairs.
+// Envoy logs `-` for values that are not set.
+// nolint:gocyclo
+func (event *Access) setFields(fields []string) error {
+	for i := 0; i+1 < len(fields); i += 2 {
+		key, value := fields[i], fields[i+1]
+		if value == "-" || value == "" {
+			continue
+		}
+		var err error
+		switch key {
+		case "start_time":
+			var tm time.Time
+			tm, err = time.Parse(time.RFC3339Nano, value)
+			event.StartTime = tm.UTC()
+		case "method":
+			event.Method = null.FromString(value)
+		case "path":
+			event.Path = null.FromString(value)
+		case "protocol":
+			event.Protocol = null.FromString(value)
+		case "response_code":
+			var n int64
+			n, err = strconv.ParseInt(value, 10, 32)
+			event.ResponseCode = null.FromInt32(int32(n))
+		case "response_flags":
+			event.ResponseFlags = null.FromString(value)
+		case "bytes_received":
+			event.BytesReceived, err = parseInt64(value)
+		case "bytes_sent":
+			event.BytesSent, err = parseInt64(value)
+		case "duration":
+			event.Duration, err = parseInt64(value)
+		case "upstream_service_time":
+			event.UpstreamServiceTime, err = parseInt64(value)
+		case "x_forwarded_for":
+			event.XForwardedFor = null.FromString(value)
+		case "user_agent":
+			event.UserAgent = null.FromString(value)
+		case "request_id":
+			event.RequestID = null.FromString(value)
+		case "authority":
+			event.Authority = null.FromString(value)
+		case "upstream_host":
+			event.UpstreamHost = null.FromString(value)
+		case "upstream_cluster":
+			event.UpstreamCluster = null.FromString(value)
+		case "upstream_local_address":
+			event.UpstreamLocalAddress = null.FromString(value)
+		case "downstream_local_address":
+			event.DownstreamLocalAddress = null.FromString(value)
+		case "downstream_remote_address":
+			event.DownstreamRemoteAddress = null.FromString(value)
+		case "requested_server_name":
+			event.RequestedServerName = null.FromString(value)
+		case "route_name":
+			event.RouteName = null.FromString(value)
+		case "upstream_transport_failure_reason":
+			event.UpstreamTransportFailureReason = null.FromString(value)
+		case "connection_termination_details":
+			event.ConnectionTerminationDetails = null.FromString(value)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "invalid %q value", key)
+		}
+	}
+	return nil
+}
+
+func parseInt64(s string) (pantherlog.Int64, error) {
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return pantherlog.Int64{}, err
+	}
+	return null.FromInt64(n), nil
+}
@@ -0,0 +1,43 @@
+package envoylogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes/logtesting"
+)
+
+func TestEnvoyLogs(t *testing.T) {
+	logtesting.RunTestsFromYAML(t, LogTypes(), "./testdata/envoy_tests.yml")
+}
+
+func TestAccessParserRejectsInvalid(t *testing.T) {
+	p := NewAccessParser()
+	for _, log := range []string{
+		`foo bar baz`,
+		`[2020-11-05T13:51:22.118Z] "GET / HTTP/1.1" abc - 0 0 0 0 "-" "-" "-" "-" "-"`,
+		`{"method":"GET"}`,
+	} {
+		_, err := p.ParseLog(log)
+		require.Error(t, err, log)
+	}
+}
@@ -36,6 +36,13 @@ type Factory interface {
 	NewParser(params interface{}) (Interface, error)
 }
 
+// ObjectInfo describes the S3 object a parser reads logs from.
+// It is passed as params to Factory.NewParser by sources that read logs from S3 objects.
+type ObjectInfo struct {
+	Bucket string
+	Key    string
+}
+
 // FactoryFunc is a callback parser factory
 type FactoryFunc func(params interface{}) (Interface, error)
 
@@ -0,0 +1,88 @@
+package gcplogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+)
+
+const DNSLogID = "dns.googleapis.com%2Fdns_queries"
+
+// DNS is a Cloud DNS query log entry
+type DNS struct {
+	Entry
+	Payload DNSPayload `json:"jsonPayload" validate:"required" description:"The DNS query details"`
+}
+
+// PantherEventTime implements pantherlog.EventTimer interface
+func (event *DNS) PantherEventTime() time.Time {
+	return event.eventTime()
+}
+
+// WriteValuesTo implements pantherlog.ValueWriterTo interface
+func (event *DNS) WriteValuesTo(w pantherlog.ValueWriter) {
+	event.writeValuesTo(w)
+	event.Payload.writeValuesTo(w)
+}
+
+// nolint:lll
+type DNSPayload struct {
+	QueryName          pantherlog.String `json:"queryName" validate:"required" description:"The DNS query name (ie www.example.com.)."`
+	QueryType          pantherlog.String `json:"queryType" description:"The DNS query type (ie A, AAAA, CNAME)."`
+	ResponseCode       pantherlog.String `json:"responseCode" description:"The response code (ie NOERROR, NXDOMAIN)."`
+	RData              pantherlog.String `json:"rdata" description:"The DNS answers in presentation format, one record per line."`
+	AuthAnswer         pantherlog.Bool   `json:"authAnswer" description:"Whether the answer is authoritative."`
+	Protocol           pantherlog.String `json:"protocol" description:"The protocol of the query (TCP or UDP)."`
+	SourceIP           pantherlog.String `json:"sourceIP" panther:"ip" description:"The IP address that originated the query."`
+	SourceNetwork      pantherlog.String `json:"sourceNetwork" description:"The network from which the query reached the resolver."`
+	DestinationIP      pantherlog.String `json:"destinationIP" panther:"ip" description:"The target IP address, only relevant for forwarding cases."`
+	TargetType         pantherlog.String `json:"targetType" description:"The type of target resolving the query (ie public-zone, private-zone, forwarding-zone, internet)."`
+	ServerLatency      pantherlog.Int64  `json:"serverLatency" description:"The latency of the resolver in milliseconds."`
+	EgressError        pantherlog.String `json:"egressError" description:"The Egress Proxy error for forwarded queries."`
+	VMInstanceID       pantherlog.String `json:"vmInstanceIdString" description:"The Compute Engine VM instance ID of the client."`
+	VMInstanceName     pantherlog.String `json:"vmInstanceName" description:"The Compute Engine VM instance name of the client."`
+	VMProjectID        pantherlog.String `json:"vmProjectId" description:"The project ID of the client VM."`
+	VMZoneName         pantherlog.String `json:"vmZoneName" description:"The zone of the client VM."`
+	AliasQueryResponse pantherlog.String `json:"alias_query_response_code" description:"The response code of alias queries."`
+}
+
+func (p *DNSPayload) writeValuesTo(w pantherlog.ValueWriter) {
+	if name := strings.TrimSuffix(p.QueryName.Value, "."); name != "" {
+		w.WriteValues(pantherlog.FieldDomainName, name)
+	}
+	// Each line of rdata is a record in presentation format (ie `www.example.com.	300	IN	a	93.184.216.34`)
+	for _, record := range strings.Split(p.RData.Value, "\n") {
+		fields := strings.Fields(record)
+		if len(fields) < 5 {
+			continue
+		}
+		value := fields[len(fields)-1]
+		switch strings.ToUpper(fields[3]) {
+		case "A", "AAAA":
+			pantherlog.ScanIPAddress(w, value)
+		case "CNAME":
+			if value = strings.TrimSuffix(value, "."); value != "" {
+				w.WriteValues(pantherlog.FieldDomainName, value)
+			}
+		}
+	}
+}
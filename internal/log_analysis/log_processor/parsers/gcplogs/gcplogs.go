@@ -21,16 +21,23 @@ package gcplogs
 
 import (
 	"strings"
+	"time"
+
+	"github.com/pkg/errors"
 
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/numerics"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
 )
 
 const (
-	LogTypePrefix = "GCP"
-	TypeAuditLog  = LogTypePrefix + ".AuditLog"
+	LogTypePrefix        = "GCP"
+	TypeAuditLog         = LogTypePrefix + ".AuditLog"
+	TypeVPCFlow          = LogTypePrefix + ".VPCFlow"
+	TypeHTTPLoadBalancer = LogTypePrefix + ".HTTPLoadBalancer"
+	TypeDNS              = LogTypePrefix + ".DNS"
 )
 
 // LogTypes exports the available log type entries
@@ -47,6 +54,54 @@ Google Cloud services write audit log entries to these logs to help you answer t
 	ReferenceURL: `https://cloud.google.com/logging/docs/audit`,
 	Schema:       AuditLog{},
 	NewParser:    parsers.AdapterFactory(&AuditLogParser{}),
+}, logtypes.Config{
+	Name:         TypeVPCFlow,
+	Description:  `VPC Flow Logs record a sample of network flows sent from and received by VM instances, including instances used as GKE nodes.`,
+	ReferenceURL: `https://cloud.google.com/vpc/docs/using-flow-logs`,
+	Schema: pantherlog.MustBuildEventSchema(&VPCFlow{},
+		pantherlog.FieldIPAddress,
+		pantherlog.FieldDomainName,
+		pantherlog.FieldTraceID,
+	),
+	NewParser: &parsers.JSONParserFactory{
+		LogType: TypeVPCFlow,
+		NewEvent: func() interface{} {
+			return &VPCFlow{}
+		},
+		Validate: validateLogEntry(VPCFlowLogID, ""),
+	},
+}, logtypes.Config{
+	Name:         TypeHTTPLoadBalancer,
+	Description:  `External HTTP(S) Load Balancing logs contain the requests served by a load balancer.`,
+	ReferenceURL: `https://cloud.google.com/load-balancing/docs/https/https-logging-monitoring`,
+	Schema: pantherlog.MustBuildEventSchema(&HTTPLoadBalancer{},
+		pantherlog.FieldIPAddress,
+		pantherlog.FieldDomainName,
+		pantherlog.FieldTraceID,
+	),
+	NewParser: &parsers.JSONParserFactory{
+		LogType: TypeHTTPLoadBalancer,
+		NewEvent: func() interface{} {
+			return &HTTPLoadBalancer{}
+		},
+		Validate: validateLogEntry(HTTPLoadBalancerLogID, HTTPLoadBalancerResourceType),
+	},
+}, logtypes.Config{
+	Name:         TypeDNS,
+	Description:  `Cloud DNS query logs contain the DNS queries made by VMs and inbound forwarding clients in your VPC networks.`,
+	ReferenceURL: `https://cloud.google.com/dns/docs/monitoring`,
+	Schema: pantherlog.MustBuildEventSchema(&DNS{},
+		pantherlog.FieldIPAddress,
+		pantherlog.FieldDomainName,
+		pantherlog.FieldTraceID,
+	),
+	NewParser: &parsers.JSONParserFactory{
+		LogType: TypeDNS,
+		NewEvent: func() interface{} {
+			return &DNS{}
+		},
+		Validate: validateLogEntry(DNSLogID, ""),
+	},
 })
 
 // nolint:lll
@@ -84,6 +139,119 @@ func (entry *LogEntry) LogID() string {
 	return ""
 }
 
+// Entry is a log entry of the log types reading the HTTP request fields as named by the Logging API.
+// LogEntry keeps the field names of the GCP.AuditLog tables.
+// nolint:lll
+type Entry struct {
+	LogName          *string                 `json:"logName" validate:"required" description:"The resource name of the log to which this log entry belongs."`
+	Severity         *string                 `json:"severity,omitempty" description:"The severity of the log entry. The default value is LogSeverity.DEFAULT."`
+	InsertID         *string                 `json:"insertId,omitempty" description:"A unique identifier for the log entry."`
+	Resource         MonitoredResource       `json:"resource,omitempty" description:"The monitored resource that produced this log entry."`
+	Timestamp        *timestamp.RFC3339      `json:"timestamp,omitempty" description:"The time the event described by the log entry occurred."`
+	ReceiveTimestamp *timestamp.RFC3339      `json:"receiveTimestamp" validate:"required" description:"The time the log entry was received by Logging."`
+	Labels           Labels                  `json:"labels,omitempty" description:"A set of user-defined (key, value) data that provides additional information about the log entry."`
+	Operation        *LogEntryOperation      `json:"operation,omitempty" description:"Information about an operation associated with the log entry, if applicable."`
+	Trace            *string                 `json:"trace,omitempty" description:"Resource name of the trace associated with the log entry, if any."`
+	HTTPRequest      *Request                `json:"httpRequest,omitempty" description:"Information about the HTTP request associated with this log entry, if applicable."`
+	SpanID           *string                 `json:"spanId,omitempty" description:"The span ID within the trace associated with the log entry."`
+	TraceSampled     *bool                   `json:"traceSampled,omitempty" description:"The sampling decision of the trace associated with the log entry."`
+	SourceLocation   *LogEntrySourceLocation `json:"sourceLocation,omitempty" description:"Source code location information associated with the log entry, if any."`
+}
+
+// LogID extracts the log ID from a `LogName` field, see LogEntry.LogID
+func (entry *Entry) LogID() string {
+	return (&LogEntry{LogName: entry.LogName}).LogID()
+}
+
+func (entry *Entry) logEntry() *Entry {
+	return entry
+}
+
+// eventTime returns the timestamp of the entry, falling back to the time it was received by Logging
+func (entry *Entry) eventTime() time.Time {
+	if entry.Timestamp != nil {
+		return (time.Time)(*entry.Timestamp)
+	}
+	if entry.ReceiveTimestamp != nil {
+		return (time.Time)(*entry.ReceiveTimestamp)
+	}
+	return time.Time{}
+}
+
+// writeValuesTo writes the indicator values of the entry trace and HTTP request
+func (entry *Entry) writeValuesTo(w pantherlog.ValueWriter) {
+	// Trace is a resource name (projects/PROJECT_ID/traces/TRACE_ID), we keep only the ID to match other sources
+	if entry.Trace != nil && *entry.Trace != "" {
+		trace := *entry.Trace
+		if pos := strings.LastIndexByte(trace, '/'); pos != -1 {
+			trace = trace[pos+1:]
+		}
+		w.WriteValues(pantherlog.FieldTraceID, trace)
+	}
+	if entry.SpanID != nil && *entry.SpanID != "" {
+		w.WriteValues(pantherlog.FieldTraceID, *entry.SpanID)
+	}
+	if req := entry.HTTPRequest; req != nil {
+		if req.RemoteIP != nil {
+			pantherlog.ScanIPAddress(w, *req.RemoteIP)
+		}
+		if req.ServerIP != nil {
+			pantherlog.ScanIPAddress(w, *req.ServerIP)
+		}
+		if req.RequestURL != nil {
+			pantherlog.ScanURL(w, *req.RequestURL)
+		}
+		if req.Referer != nil {
+			pantherlog.ScanURL(w, *req.Referer)
+		}
+	}
+}
+
+// Request is the HTTP request of an Entry
+// nolint:lll
+type Request struct {
+	RequestMethod  *string         `json:"requestMethod,omitempty" description:"The request HTTP method."`
+	RequestURL     *string         `json:"requestUrl,omitempty" description:"The scheme (http, https), the host name, the path and the query portion of the URL that was requested."`
+	RequestSize    *numerics.Int64 `json:"requestSize,omitempty" description:"The size of the HTTP request message in bytes, including the request headers and the request body."`
+	Status         *int16          `json:"status,omitempty" description:"The response HTTP status code"`
+	ResponseSize   *numerics.Int64 `json:"responseSize,omitempty" description:"The size of the HTTP response message sent back to the client, in bytes, including the response headers and the response body."`
+	UserAgent      *string         `json:"userAgent,omitempty"  description:"The user agent sent by the client."`
+	RemoteIP       *string         `json:"remoteIp,omitempty"  description:"The IP address (IPv4 or IPv6) of the client that issued the HTTP request."`
+	ServerIP       *string         `json:"serverIp,omitempty"  description:"The IP address (IPv4 or IPv6) of the origin server that the request was sent to."`
+	Referer        *string         `json:"referer,omitempty" description:"The referer URL of the request"`
+	Latency        *string         `json:"latency,omitempty" description:"The request processing latency in seconds on the server, from the time the request was received until the response was sent."`
+	CacheLookup    *bool           `json:"cacheLookup,omitempty"  description:"Whether or not a cache lookup was attempted."`
+	CacheHit       *bool           `json:"cacheHit,omitempty"  description:"Whether or not an entity was served from cache (with or without validation)."`
+	CacheValidated *bool           `json:"cacheValidatedWithOriginServer,omitempty" description:"Whether or not an entity was served from cache (with or without validation)."`
+	CacheFillBytes *numerics.Int64 `json:"cacheFillBytes,omitempty" description:"Whether or not an entity was served from cache (with or without validation)."`
+	Protocol       *string         `json:"protocol,omitempty" description:"Protocol used for the request."`
+}
+
+// validateLogEntry checks that an entry is valid and belongs to a log ID and resource type.
+// The resource type is not checked if empty.
+func validateLogEntry(logID, resourceType string) func(event interface{}) error {
+	return func(event interface{}) error {
+		if err := pantherlog.ValidateStruct(event); err != nil {
+			return err
+		}
+		e, ok := event.(interface{ logEntry() *Entry })
+		if !ok {
+			return errors.New("invalid log entry")
+		}
+		entry := e.logEntry()
+		if id := entry.LogID(); id != logID {
+			return errors.Errorf("invalid LogID %q != %q", id, logID)
+		}
+		if resourceType == "" {
+			return nil
+		}
+		if typ := entry.Resource.Type; typ == nil || *typ != resourceType {
+			return errors.Errorf("invalid resource type, expected %q", resourceType)
+		}
+		return nil
+	}
+}
+
 // nolint:lll
 type MonitoredResource struct {
 	Type   *string `json:"type" validate:"required" description:"Type of resource that produced this log entry"`
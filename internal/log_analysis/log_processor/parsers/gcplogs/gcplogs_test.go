@@ -0,0 +1,67 @@
+package gcplogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes/logtesting"
+)
+
+func TestVPCFlow(t *testing.T) {
+	logtesting.RunTestsFromYAML(t, LogTypes(), "./testdata/vpcflow_tests.yml")
+}
+
+func TestHTTPLoadBalancer(t *testing.T) {
+	logtesting.RunTestsFromYAML(t, LogTypes(), "./testdata/httplb_tests.yml")
+}
+
+func TestDNS(t *testing.T) {
+	logtesting.RunTestsFromYAML(t, LogTypes(), "./testdata/dns_tests.yml")
+}
+
+func TestValidateLogID(t *testing.T) {
+	const log = `{"jsonPayload":{"queryName":"www.example.com."},"logName":"projects/example-project/logs/requests","receiveTimestamp":"2020-10-19T15:06:41.123Z","resource":{"labels":{},"type":"http_load_balancer"}}`
+	p, err := LogTypes().Find(TypeDNS).NewParser(nil)
+	require.NoError(t, err)
+	_, err = p.ParseLog(log)
+	require.Error(t, err)
+	p, err = LogTypes().Find(TypeHTTPLoadBalancer).NewParser(nil)
+	require.NoError(t, err)
+	_, err = p.ParseLog(log)
+	require.NoError(t, err)
+	p, err = LogTypes().Find(TypeHTTPLoadBalancer).NewParser(nil)
+	require.NoError(t, err)
+	_, err = p.ParseLog(`{"logName":"projects/example-project/logs/requests","receiveTimestamp":"2020-10-19T15:06:41.123Z","resource":{"labels":{},"type":"cloud_run_revision"}}`)
+	require.Error(t, err)
+}
+
+// Audit log entries keep the HTTP request field names they were stored with
+func TestAuditLogHTTPRequest(t *testing.T) {
+	const log = `{"protoPayload":{"@type":"type.googleapis.com/google.cloud.audit.AuditLog"},"httpRequest":{"requestURL":"https://www.example.com/","remoteIP":"198.51.100.7","serverIP":"10.128.0.5"},"logName":"projects/example-project/logs/cloudaudit.googleapis.com%2Factivity","resource":{"labels":{},"type":"http_load_balancer"},"receiveTimestamp":"2020-10-19T15:06:41.123Z"}`
+	results, err := NewAuditLogParser().Parse(log)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	data, err := jsoniter.Marshal(results[0].Event())
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"httpRequest":{"requestURL":"https://www.example.com/","remoteIP":"198.51.100.7","serverIP":"10.128.0.5"}`)
+}
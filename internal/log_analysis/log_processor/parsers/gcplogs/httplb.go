@@ -0,0 +1,65 @@
+package gcplogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+)
+
+const (
+	HTTPLoadBalancerLogID        = "requests"
+	HTTPLoadBalancerResourceType = "http_load_balancer"
+)
+
+// HTTPLoadBalancer is an External HTTP(S) Load Balancing request log entry
+type HTTPLoadBalancer struct {
+	Entry
+	Payload HTTPLoadBalancerPayload `json:"jsonPayload" description:"The load balancer details of the request"`
+}
+
+// PantherEventTime implements pantherlog.EventTimer interface
+func (event *HTTPLoadBalancer) PantherEventTime() time.Time {
+	return event.eventTime()
+}
+
+// WriteValuesTo implements pantherlog.ValueWriterTo interface
+func (event *HTTPLoadBalancer) WriteValuesTo(w pantherlog.ValueWriter) {
+	event.writeValuesTo(w)
+}
+
+// nolint:lll
+type HTTPLoadBalancerPayload struct {
+	PayloadType                pantherlog.String `json:"@type" description:"The type of the payload (type.googleapis.com/google.cloud.loadbalancing.type.LoadBalancerLogEntry)."`
+	StatusDetails              pantherlog.String `json:"statusDetails" description:"A textual description of the response code (ie response_sent_by_backend, denied_by_security_policy)."`
+	BackendTargetProjectNumber pantherlog.String `json:"backendTargetProjectNumber" description:"The project number where the backend target is located."`
+	CacheID                    pantherlog.String `json:"cacheId" description:"The location and cache instance that the cache response was served from."`
+	ProxyStatus                pantherlog.String `json:"proxyStatus" description:"The Proxy-Status response header describing errors of the proxy."`
+	EnforcedSecurityPolicy     *SecurityPolicy   `json:"enforcedSecurityPolicy" description:"The Cloud Armor security policy rule that was enforced."`
+	PreviewSecurityPolicy      *SecurityPolicy   `json:"previewSecurityPolicy" description:"The Cloud Armor security policy rule that would be enforced if it was not in preview mode."`
+}
+
+// nolint:lll
+type SecurityPolicy struct {
+	Name             pantherlog.String `json:"name" description:"The name of the security policy."`
+	Priority         pantherlog.Int32  `json:"priority" description:"The priority of the matching rule."`
+	ConfiguredAction pantherlog.String `json:"configuredAction" description:"The configured action of the matching rule (ALLOW, DENY, ...)."`
+	Outcome          pantherlog.String `json:"outcome" description:"The outcome of the action (ACCEPT, DENY)."`
+}
@@ -0,0 +1,95 @@
+package gcplogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+)
+
+const VPCFlowLogID = "compute.googleapis.com%2Fvpc_flows"
+
+// VPCFlow is a VPC Flow Logs entry
+type VPCFlow struct {
+	Entry
+	Payload VPCFlowPayload `json:"jsonPayload" validate:"required" description:"The VPC flow record"`
+}
+
+// PantherEventTime implements pantherlog.EventTimer interface
+func (event *VPCFlow) PantherEventTime() time.Time {
+	return event.eventTime()
+}
+
+// WriteValuesTo implements pantherlog.ValueWriterTo interface
+func (event *VPCFlow) WriteValuesTo(w pantherlog.ValueWriter) {
+	event.writeValuesTo(w)
+}
+
+// nolint:lll
+type VPCFlowPayload struct {
+	Connection   VPCFlowConnection     `json:"connection" validate:"required" description:"The 5-tuple describing the connection."`
+	Reporter     pantherlog.String     `json:"reporter" description:"The side which reported the flow (SRC or DEST)."`
+	StartTime    pantherlog.Time       `json:"start_time" tcodec:"rfc3339" description:"Timestamp of the first observed packet during the aggregated time interval."`
+	EndTime      pantherlog.Time       `json:"end_time" tcodec:"rfc3339" description:"Timestamp of the last observed packet during the aggregated time interval."`
+	BytesSent    pantherlog.Int64      `json:"bytes_sent" description:"Amount of bytes sent from the source to the destination."`
+	PacketsSent  pantherlog.Int64      `json:"packets_sent" description:"Number of packets sent from the source to the destination."`
+	RTTMsec      pantherlog.Int64      `json:"rtt_msec" description:"Latency as measured during the time interval, for TCP flows only."`
+	SrcInstance  *VPCFlowInstance      `json:"src_instance" description:"If the source of the connection was a VM located on the same VPC, this field is populated with VM instance details."`
+	DestInstance *VPCFlowInstance      `json:"dest_instance" description:"If the destination of the connection was a VM located on the same VPC, this field is populated with VM instance details."`
+	SrcVPC       *VPCFlowVPC           `json:"src_vpc" description:"If the source of the connection was a VM located on the same VPC, this field is populated with VPC network details."`
+	DestVPC      *VPCFlowVPC           `json:"dest_vpc" description:"If the destination of the connection was a VM located on the same VPC, this field is populated with VPC network details."`
+	SrcLocation  *VPCFlowLocation      `json:"src_location" description:"If the source of the connection was external to the VPC, this field is populated with available location metadata."`
+	DestLocation *VPCFlowLocation      `json:"dest_location" description:"If the destination of the connection was external to the VPC, this field is populated with available location metadata."`
+	SrcGKE       pantherlog.RawMessage `json:"src_gke_details" description:"GKE metadata for source endpoints (cluster, pod and service)."`
+	DestGKE      pantherlog.RawMessage `json:"dest_gke_details" description:"GKE metadata for destination endpoints (cluster, pod and service)."`
+}
+
+// nolint:lll
+type VPCFlowConnection struct {
+	SrcIP    pantherlog.String `json:"src_ip" panther:"ip" description:"Source IP address."`
+	SrcPort  pantherlog.Uint16 `json:"src_port" description:"Source port."`
+	DestIP   pantherlog.String `json:"dest_ip" panther:"ip" description:"Destination IP address."`
+	DestPort pantherlog.Uint16 `json:"dest_port" description:"Destination port."`
+	Protocol pantherlog.Int32  `json:"protocol" description:"The IANA protocol number."`
+}
+
+// nolint:lll
+type VPCFlowInstance struct {
+	ProjectID pantherlog.String `json:"project_id" description:"ID of the project containing the VM."`
+	VMName    pantherlog.String `json:"vm_name" description:"Instance name of the VM."`
+	Region    pantherlog.String `json:"region" description:"Region of the VM."`
+	Zone      pantherlog.String `json:"zone" description:"Zone of the VM."`
+}
+
+// nolint:lll
+type VPCFlowVPC struct {
+	ProjectID      pantherlog.String `json:"project_id" description:"ID of the project containing the VPC."`
+	VPCName        pantherlog.String `json:"vpc_name" description:"VPC on which the VM is operating."`
+	SubnetworkName pantherlog.String `json:"subnetwork_name" description:"Subnetwork on which the VM is operating."`
+}
+
+// nolint:lll
+type VPCFlowLocation struct {
+	Continent pantherlog.String `json:"continent" description:"Continent for external endpoints."`
+	Country   pantherlog.String `json:"country" description:"Country for external endpoints, represented as ISO 3166-1 Alpha-3 country codes."`
+	Region    pantherlog.String `json:"region" description:"Region for external endpoints."`
+	City      pantherlog.String `json:"city" description:"City for external endpoints."`
+	ASN       pantherlog.Int64  `json:"asn" description:"The autonomous system number (ASN) of the external network to which this endpoint belongs."`
+}
@@ -0,0 +1,69 @@
+package githublogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+)
+
+// Audit is a GitHub Enterprise audit log event.
+// Fields specific to each action that are not listed here are not kept.
+// nolint:lll
+type Audit struct {
+	Timestamp                pantherlog.Time       `json:"@timestamp" tcodec:"unix_ms" event_time:"true" validate:"required" description:"The time the event occurred."`
+	Action                   pantherlog.String     `json:"action" validate:"required" description:"The name of the action that was performed (ie repo.create, org.add_member, git.clone)."`
+	DocumentID               pantherlog.String     `json:"_document_id" description:"The unique identifier of the event."`
+	CreatedAt                pantherlog.Time       `json:"created_at" tcodec:"unix_ms" description:"The time the event was created."`
+	Actor                    pantherlog.String     `json:"actor" panther:"username" description:"The user that performed the action."`
+	ActorID                  pantherlog.Int64      `json:"actor_id" description:"The ID of the user that performed the action."`
+	ActorIP                  pantherlog.String     `json:"actor_ip" panther:"ip" description:"The IP address the action was performed from."`
+	ActorLocation            *ActorLocation        `json:"actor_location" description:"The location the action was performed from."`
+	Business                 pantherlog.String     `json:"business" description:"The name of the enterprise affected by the action."`
+	BusinessID               pantherlog.Int64      `json:"business_id" description:"The ID of the enterprise affected by the action."`
+	Org                      pantherlog.String     `json:"org" description:"The name of the organization affected by the action."`
+	OrgID                    pantherlog.Int64      `json:"org_id" description:"The ID of the organization affected by the action."`
+	Repo                     pantherlog.String     `json:"repo" description:"The name of the repository affected by the action (ie org/repo)."`
+	RepoID                   pantherlog.Int64      `json:"repo_id" description:"The ID of the repository affected by the action."`
+	Repository               pantherlog.String     `json:"repository" description:"The name of the repository for git events."`
+	RepositoryPublic         pantherlog.Bool       `json:"repository_public" description:"Whether the repository is public for git events."`
+	PublicRepo               pantherlog.Bool       `json:"public_repo" description:"Whether the repository affected by the action is public."`
+	Visibility               pantherlog.String     `json:"visibility" description:"The visibility of the repository affected by the action."`
+	User                     pantherlog.String     `json:"user" panther:"username" description:"The user affected by the action."`
+	UserID                   pantherlog.Int64      `json:"user_id" description:"The ID of the user affected by the action."`
+	Team                     pantherlog.String     `json:"team" description:"The team affected by the action."`
+	Permission               pantherlog.String     `json:"permission" description:"The permission granted or changed by the action."`
+	TransportProtocol        pantherlog.Int32      `json:"transport_protocol" description:"The protocol used for git events (1 for http, 2 for ssh)."`
+	TransportProtocolName    pantherlog.String     `json:"transport_protocol_name" description:"The name of the protocol used for git events (http, ssh)."`
+	OperationType            pantherlog.String     `json:"operation_type" description:"The type of the operation (create, access, modify, remove, authentication, transfer, restore)."`
+	ProgrammaticAccessType   pantherlog.String     `json:"programmatic_access_type" description:"The type of programmatic access used (ie personal access token, OAuth access token, GitHub App)."`
+	TokenID                  pantherlog.Int64      `json:"token_id" description:"The ID of the token used to perform the action."`
+	HashedToken              pantherlog.String     `json:"hashed_token" description:"The SHA256 hash of the token used to perform the action (base64 encoded)."`
+	TokenScopes              pantherlog.String     `json:"token_scopes" description:"The scopes of the token used to perform the action."`
+	UserAgent                pantherlog.String     `json:"user_agent" description:"The user agent of the client that performed the action."`
+	RequestID                pantherlog.String     `json:"request_id" description:"The ID of the request that performed the action."`
+	ExternalIdentityNameID   pantherlog.String     `json:"external_identity_nameid" panther:"username" description:"The SAML NameID of the user that performed the action."`
+	ExternalIdentityUsername pantherlog.String     `json:"external_identity_username" panther:"username" description:"The SAML username of the user that performed the action."`
+	HookID                   pantherlog.Int64      `json:"hook_id" description:"The ID of the webhook affected by the action."`
+	Config                   pantherlog.RawMessage `json:"config" description:"The webhook configuration for hook events."`
+}
+
+// ActorLocation is the location of the actor of an event
+type ActorLocation struct {
+	CountryCode pantherlog.String `json:"country_code" description:"The ISO country code of the location."`
+}
@@ -0,0 +1,161 @@
+package haproxylogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/sysloglogs"
+)
+
+// Connection holds the fields common to HTTP and TCP logs
+// nolint:lll
+type Connection struct {
+	ClientIP         pantherlog.String `json:"client_ip" panther:"ip" validate:"required" description:"The IP address of the client which initiated the connection."`
+	ClientPort       pantherlog.Uint16 `json:"client_port" description:"The TCP port of the client which initiated the connection."`
+	AcceptDate       pantherlog.Time   `json:"accept_date" tcodec:"rfc3339" event_time:"true" validate:"required" description:"The exact date when the connection was received by HAProxy."`
+	FrontendName     pantherlog.String `json:"frontend_name" description:"The name of the frontend which received and processed the connection. A trailing '~' denotes an SSL frontend."`
+	BackendName      pantherlog.String `json:"backend_name" description:"The name of the backend which was selected to manage the connection to the server."`
+	ServerName       pantherlog.String `json:"server_name" description:"The name of the last server to which the connection was sent. <NOSRV> if no server was reached."`
+	TimeQueue        pantherlog.Int64  `json:"time_queue" description:"Total time in milliseconds spent waiting in the various queues (Tw). -1 if the connection was aborted before reaching the queue."`
+	TimeConnect      pantherlog.Int64  `json:"time_connect" description:"Total time in milliseconds spent waiting for the connection to establish to the final server (Tc). -1 if the connection was aborted before a connection could be established."`
+	BytesRead        pantherlog.Int64  `json:"bytes_read" description:"Total number of bytes transmitted to the client from the server."`
+	TerminationState pantherlog.String `json:"termination_state" description:"The condition the session was in when the session ended."`
+	ActConn          pantherlog.Int64  `json:"actconn" description:"Total number of concurrent connections on the process when the session was logged."`
+	FeConn           pantherlog.Int64  `json:"feconn" description:"Total number of concurrent connections on the frontend when the session was logged."`
+	BeConn           pantherlog.Int64  `json:"beconn" description:"Total number of concurrent connections handled by the backend when the session was logged."`
+	SrvConn          pantherlog.Int64  `json:"srv_conn" description:"Total number of concurrent connections still active on the server when the session was logged."`
+	Retries          pantherlog.Int64  `json:"retries" description:"Number of connection retries experienced by this session. A '+' prefix in the log indicates a redispatch."`
+	Redispatched     pantherlog.Bool   `json:"redispatched" description:"Whether the session was redispatched to another server."`
+	SrvQueue         pantherlog.Int64  `json:"srv_queue" description:"Total number of requests which were processed before this one in the server queue."`
+	BackendQueue     pantherlog.Int64  `json:"backend_queue" description:"Total number of requests which were processed before this one in the backend's global queue."`
+}
+
+// acceptDateLayout is the layout of the accept date, logged in the local time of the HAProxy process
+const acceptDateLayout = "02/Jan/2006:15:04:05.000"
+
+// setField sets the value of a field common to HTTP and TCP logs.
+// It returns false if the field name is not a connection field.
+// nolint:gocyclo
+func (c *Connection) setField(name, value string) (bool, error) {
+	var err error
+	switch name {
+	case "client":
+		pos := strings.LastIndexByte(value, ':')
+		if pos == -1 {
+			return true, errors.Errorf("invalid client address %q", value)
+		}
+		c.ClientIP = null.FromString(value[:pos])
+		var port uint64
+		port, err = strconv.ParseUint(value[pos+1:], 10, 16)
+		c.ClientPort = null.FromUint16(uint16(port))
+	case "accept_date":
+		var tm time.Time
+		tm, err = time.Parse(acceptDateLayout, value)
+		c.AcceptDate = tm.UTC()
+	case "frontend":
+		c.FrontendName = null.FromString(value)
+	case "backend":
+		c.BackendName = null.FromString(value)
+	case "server":
+		c.ServerName = null.FromString(value)
+	case "Tw":
+		c.TimeQueue, err = parseInt64(value)
+	case "Tc":
+		c.TimeConnect, err = parseInt64(value)
+	case "bytes_read":
+		// Bytes are prefixed with '+' when 'option logasap' is set
+		c.BytesRead, err = parseInt64(strings.TrimPrefix(value, "+"))
+	case "termination_state":
+		c.TerminationState = null.FromString(value)
+	case "actconn":
+		c.ActConn, err = parseInt64(value)
+	case "feconn":
+		c.FeConn, err = parseInt64(value)
+	case "beconn":
+		c.BeConn, err = parseInt64(value)
+	case "srv_conn":
+		c.SrvConn, err = parseInt64(value)
+	case "retries":
+		redispatched := strings.HasPrefix(value, "+")
+		c.Redispatched = null.FromBool(redispatched)
+		c.Retries, err = parseInt64(strings.TrimPrefix(value, "+"))
+	case "srv_queue":
+		c.SrvQueue, err = parseInt64(value)
+	case "backend_queue":
+		c.BackendQueue, err = parseInt64(value)
+	default:
+		return false, nil
+	}
+	if err != nil {
+		return true, errors.Wrapf(err, "invalid %q value", name)
+	}
+	return true, nil
+}
+
+func parseInt64(s string) (pantherlog.Int64, error) {
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return pantherlog.Int64{}, err
+	}
+	return null.FromInt64(n), nil
+}
+
+// splitSyslog splits the syslog header preceding the client address of a log.
+// The client address is the last word before the accept date (ie `10.0.0.1:5000 [06/Feb/2009:12:14:14.655]`).
+// Logs without a syslog header (ie written to stdout) are also accepted.
+func splitSyslog(log string) (*sysloglogs.Header, string, error) {
+	log = strings.TrimSpace(log)
+	pos := acceptDateIndex(log)
+	if pos == -1 {
+		return nil, "", errors.New("invalid HAProxy log")
+	}
+	start := strings.LastIndexByte(log[:pos], ' ')
+	if start == -1 {
+		return nil, log, nil
+	}
+	header, err := sysloglogs.ParseHeader(log[:start])
+	if err != nil {
+		return nil, "", err
+	}
+	return header, log[start+1:], nil
+}
+
+// acceptDateIndex finds the position of the accept date in a log.
+// RFC5424 structured data also starts with ` [` so we look for a digit following the bracket.
+func acceptDateIndex(log string) int {
+	offset := 0
+	for {
+		pos := strings.Index(log[offset:], " [")
+		if pos == -1 {
+			return -1
+		}
+		pos += offset
+		if next := pos + 2; next < len(log) && '0' <= log[next] && log[next] <= '9' {
+			return pos
+		}
+		offset = pos + 2
+	}
+}
@@ -0,0 +1,61 @@
+// Package haproxylogs parses HAProxy logs
+package haproxylogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+const (
+	TypeHTTP = "HAProxy.HTTP"
+	TypeTCP  = "HAProxy.TCP"
+)
+
+// LogTypes exports the available log type entries
+func LogTypes() logtypes.Group {
+	return logTypes
+}
+
+// nolint:lll
+var logTypes = logtypes.Must("HAProxy",
+	logtypes.Config{
+		Name:         TypeHTTP,
+		Description:  `HAProxy HTTP logs produced with 'option httplog', optionally carried by syslog.`,
+		ReferenceURL: `https://cbonte.github.io/haproxy-dconv/2.2/configuration.html#8.2.3`,
+		Schema: pantherlog.MustBuildEventSchema(&HTTP{},
+			pantherlog.FieldIPAddress,
+			pantherlog.FieldDomainName,
+		),
+		NewParser: parsers.FactoryFunc(func(_ interface{}) (parsers.Interface, error) {
+			return NewHTTPParser(), nil
+		}),
+	},
+	logtypes.Config{
+		Name:         TypeTCP,
+		Description:  `HAProxy TCP logs produced with 'option tcplog', optionally carried by syslog.`,
+		ReferenceURL: `https://cbonte.github.io/haproxy-dconv/2.2/configuration.html#8.2.2`,
+		Schema:       pantherlog.MustBuildEventSchema(&TCP{}),
+		NewParser: parsers.FactoryFunc(func(_ interface{}) (parsers.Interface, error) {
+			return NewTCPParser(), nil
+		}),
+	},
+)
@@ -0,0 +1,172 @@
+package haproxylogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/sysloglogs"
+	"github.com/panther-labs/panther/pkg/x/fastmatch"
+)
+
+// HTTP is an HAProxy log produced with 'option httplog'
+// nolint:lll
+type HTTP struct {
+	Connection
+	TimeRequest             pantherlog.Int64   `json:"time_request" description:"Total time in milliseconds spent waiting for a full HTTP request from the client (TR). -1 if the connection was aborted before a complete request could be received."`
+	TimeResponse            pantherlog.Int64   `json:"time_response" description:"Total time in milliseconds spent waiting for the server to send a full HTTP response, not counting data (Tr). -1 if the request was aborted before a complete response could be received."`
+	TimeActive              pantherlog.Int64   `json:"time_active" description:"Total time in milliseconds elapsed between the first byte of the request was received and the last byte of the response was sent (Ta)."`
+	StatusCode              pantherlog.Int32   `json:"status_code" description:"The HTTP status code returned to the client. -1 if no response was sent."`
+	CapturedRequestCookie   pantherlog.String  `json:"captured_request_cookie" description:"An optional 'name=value' entry indicating that the client had this cookie in the request."`
+	CapturedResponseCookie  pantherlog.String  `json:"captured_response_cookie" description:"An optional 'name=value' entry indicating that the server has returned a cookie with its response."`
+	CapturedRequestHeaders  []string           `json:"captured_request_headers,omitempty" description:"The request headers captured with 'capture request header'."`
+	CapturedResponseHeaders []string           `json:"captured_response_headers,omitempty" description:"The response headers captured with 'capture response header'."`
+	HTTPMethod              pantherlog.String  `json:"http_method" description:"The HTTP method of the request."`
+	HTTPURI                 pantherlog.String  `json:"http_uri" description:"The URI of the request."`
+	HTTPVersion             pantherlog.String  `json:"http_version" description:"The HTTP version of the request."`
+	Syslog                  *sysloglogs.Header `json:"syslog,omitempty" description:"The header of the syslog message carrying the log."`
+}
+
+var _ pantherlog.ValueWriterTo = (*HTTP)(nil)
+
+// WriteValuesTo implements pantherlog.ValueWriterTo interface
+func (event *HTTP) WriteValuesTo(w pantherlog.ValueWriter) {
+	// Proxied requests have an absolute URI
+	if uri := event.HTTPURI.Value; strings.Contains(uri, "://") {
+		pantherlog.ScanURL(w, uri)
+	}
+}
+
+// The layouts of 'option httplog' logs with request and response headers captured, only request headers captured and no headers captured.
+// nolint:lll
+var httpPatterns = []*fastmatch.Pattern{
+	fastmatch.MustCompile(`%{client} [%{accept_date}] %{frontend} %{backend}/%{server} %{TR}/%{Tw}/%{Tc}/%{Tr}/%{Ta} %{status} %{bytes_read} %{req_cookie} %{res_cookie} %{termination_state} %{actconn}/%{feconn}/%{beconn}/%{srv_conn}/%{retries} %{srv_queue}/%{backend_queue} {%{req_headers}} {%{res_headers}} "%{request}"`),
+	fastmatch.MustCompile(`%{client} [%{accept_date}] %{frontend} %{backend}/%{server} %{TR}/%{Tw}/%{Tc}/%{Tr}/%{Ta} %{status} %{bytes_read} %{req_cookie} %{res_cookie} %{termination_state} %{actconn}/%{feconn}/%{beconn}/%{srv_conn}/%{retries} %{srv_queue}/%{backend_queue} {%{req_headers}} "%{request}"`),
+	fastmatch.MustCompile(`%{client} [%{accept_date}] %{frontend} %{backend}/%{server} %{TR}/%{Tw}/%{Tc}/%{Tr}/%{Ta} %{status} %{bytes_read} %{req_cookie} %{res_cookie} %{termination_state} %{actconn}/%{feconn}/%{beconn}/%{srv_conn}/%{retries} %{srv_queue}/%{backend_queue} "%{request}"`),
+}
+
+// HTTPParser parses HAProxy HTTP logs
+type HTTPParser struct {
+	fields  []string
+	builder pantherlog.ResultBuilder
+}
+
+var _ parsers.Interface = (*HTTPParser)(nil)
+
+// NewHTTPParser creates a parser for HAProxy HTTP logs
+func NewHTTPParser() *HTTPParser {
+	return &HTTPParser{}
+}
+
+// ParseLog implements parsers.Interface
+func (p *HTTPParser) ParseLog(log string) ([]*parsers.Result, error) {
+	header, msg, err := splitSyslog(log)
+	if err != nil {
+		return nil, err
+	}
+	p.fields = p.fields[:0]
+	for _, pattern := range httpPatterns {
+		if p.fields, err = pattern.MatchString(p.fields[:0], msg); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid HAProxy HTTP log")
+	}
+	event := HTTP{
+		Syslog: header,
+	}
+	for i := 0; i+1 < len(p.fields); i += 2 {
+		if err := event.setField(p.fields[i], p.fields[i+1]); err != nil {
+			return nil, err
+		}
+	}
+	if err := pantherlog.ValidateStruct(&event); err != nil {
+		return nil, err
+	}
+	result, err := p.builder.BuildResult(TypeHTTP, &event)
+	if err != nil {
+		return nil, err
+	}
+	return []*parsers.Result{result}, nil
+}
+
+func (event *HTTP) setField(name, value string) error {
+	if ok, err := event.Connection.setField(name, value); ok {
+		return err
+	}
+	var err error
+	switch name {
+	case "TR":
+		event.TimeRequest, err = parseInt64(value)
+	case "Tr":
+		event.TimeResponse, err = parseInt64(value)
+	case "Ta":
+		// Total time is prefixed with '+' when 'option logasap' is set
+		event.TimeActive, err = parseInt64(strings.TrimPrefix(value, "+"))
+	case "status":
+		var n int64
+		n, err = strconv.ParseInt(value, 10, 32)
+		event.StatusCode = null.FromInt32(int32(n))
+	case "req_cookie":
+		event.CapturedRequestCookie = optionalString(value)
+	case "res_cookie":
+		event.CapturedResponseCookie = optionalString(value)
+	case "req_headers":
+		event.CapturedRequestHeaders = strings.Split(value, "|")
+	case "res_headers":
+		event.CapturedResponseHeaders = strings.Split(value, "|")
+	case "request":
+		event.setRequest(value)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "invalid %q value", name)
+	}
+	return nil
+}
+
+// setRequest splits the request line to method, URI and version.
+// Truncated or invalid requests (ie `<BADREQ>`) are stored as URI.
+func (event *HTTP) setRequest(request string) {
+	parts := strings.SplitN(request, " ", 3)
+	switch len(parts) {
+	case 3:
+		event.HTTPVersion = null.FromString(parts[2])
+		fallthrough
+	case 2:
+		event.HTTPMethod = null.FromString(parts[0])
+		event.HTTPURI = null.FromString(parts[1])
+	default:
+		event.HTTPURI = null.FromString(request)
+	}
+}
+
+// optionalString handles the '-' placeholder for empty captures
+func optionalString(s string) pantherlog.String {
+	if s == "-" {
+		return pantherlog.String{}
+	}
+	return null.FromString(s)
+}
@@ -0,0 +1,97 @@
+package haproxylogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/sysloglogs"
+	"github.com/panther-labs/panther/pkg/x/fastmatch"
+)
+
+// TCP is an HAProxy log produced with 'option tcplog'
+// nolint:lll
+type TCP struct {
+	Connection
+	TimeTotal pantherlog.Int64   `json:"time_total" description:"Total time in milliseconds elapsed between the accept and the last close (Tt)."`
+	Syslog    *sysloglogs.Header `json:"syslog,omitempty" description:"The header of the syslog message carrying the log."`
+}
+
+// nolint:lll
+var tcpPattern = fastmatch.MustCompile(`%{client} [%{accept_date}] %{frontend} %{backend}/%{server} %{Tw}/%{Tc}/%{Tt} %{bytes_read} %{termination_state} %{actconn}/%{feconn}/%{beconn}/%{srv_conn}/%{retries} %{srv_queue}/%{backend_queue}`)
+
+// TCPParser parses HAProxy TCP logs
+type TCPParser struct {
+	fields  []string
+	builder pantherlog.ResultBuilder
+}
+
+var _ parsers.Interface = (*TCPParser)(nil)
+
+// NewTCPParser creates a parser for HAProxy TCP logs
+func NewTCPParser() *TCPParser {
+	return &TCPParser{}
+}
+
+// ParseLog implements parsers.Interface
+func (p *TCPParser) ParseLog(log string) ([]*parsers.Result, error) {
+	header, msg, err := splitSyslog(log)
+	if err != nil {
+		return nil, err
+	}
+	p.fields, err = tcpPattern.MatchString(p.fields[:0], msg)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid HAProxy TCP log")
+	}
+	event := TCP{
+		Syslog: header,
+	}
+	for i := 0; i+1 < len(p.fields); i += 2 {
+		if err := event.setField(p.fields[i], p.fields[i+1]); err != nil {
+			return nil, err
+		}
+	}
+	if err := pantherlog.ValidateStruct(&event); err != nil {
+		return nil, err
+	}
+	result, err := p.builder.BuildResult(TypeTCP, &event)
+	if err != nil {
+		return nil, err
+	}
+	return []*parsers.Result{result}, nil
+}
+
+func (event *TCP) setField(name, value string) error {
+	if ok, err := event.Connection.setField(name, value); ok {
+		return err
+	}
+	if name == "Tt" {
+		// Total time is prefixed with '+' when 'option logasap' is set
+		tt, err := parseInt64(strings.TrimPrefix(value, "+"))
+		if err != nil {
+			return errors.Wrapf(err, "invalid %q value", name)
+		}
+		event.TimeTotal = tt
+	}
+	return nil
+}
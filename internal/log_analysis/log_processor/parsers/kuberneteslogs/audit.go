@@ -0,0 +1,304 @@
+package kuberneteslogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/gcplogs"
+)
+
+// Audit is a Kubernetes API server audit event.
+// Reference: https://kubernetes.io/docs/reference/config-api/apiserver-audit.v1/#audit-k8s-io-v1-Event
+// nolint:lll
+type Audit struct {
+	Kind                     pantherlog.String     `json:"kind" validate:"required,eq=Event" description:"The kind of the object (always 'Event')"`
+	APIVersion               pantherlog.String     `json:"apiVersion" validate:"required" description:"The versioned schema of the event (audit.k8s.io/v1)"`
+	Level                    pantherlog.String     `json:"level" description:"AuditLevel at which event was generated (None, Metadata, Request, RequestResponse)"`
+	AuditID                  pantherlog.String     `json:"auditID" validate:"required" panther:"trace_id" description:"Unique audit ID, generated for each request"`
+	Stage                    pantherlog.String     `json:"stage" description:"Stage of the request handling when this event instance was generated (RequestReceived, ResponseStarted, ResponseComplete, Panic)"`
+	RequestURI               pantherlog.String     `json:"requestURI" description:"RequestURI is the request URI as sent by the client to a server"`
+	Verb                     pantherlog.String     `json:"verb" description:"Verb is the kubernetes verb associated with the request. For non-resource requests, this is the lower-cased HTTP method"`
+	User                     *UserInfo             `json:"user" description:"Authenticated user information"`
+	ImpersonatedUser         *UserInfo             `json:"impersonatedUser" description:"Impersonated user information"`
+	SourceIPs                []string              `json:"sourceIPs" description:"Source IPs, from where the request originated and intermediate proxies"`
+	UserAgent                pantherlog.String     `json:"userAgent" description:"UserAgent records the user agent string reported by the client"`
+	ObjectRef                *ObjectReference      `json:"objectRef" description:"Object reference this request is targeted at. Does not apply for List-type requests, or non-resource requests"`
+	ResponseStatus           *ResponseStatus       `json:"responseStatus" description:"The response status, populated even when the ResponseObject is not a Status type"`
+	RequestObject            pantherlog.RawMessage `json:"requestObject" description:"API object from the request, in JSON format. Only logged at Request level and higher"`
+	ResponseObject           pantherlog.RawMessage `json:"responseObject" description:"API object returned in the response, in JSON. Only logged at RequestResponse level"`
+	RequestReceivedTimestamp pantherlog.Time       `json:"requestReceivedTimestamp" tcodec:"rfc3339" event_time:"true" description:"Time the request reached the apiserver"`
+	StageTimestamp           pantherlog.Time       `json:"stageTimestamp" tcodec:"rfc3339" description:"Time the request reached current audit stage"`
+	Annotations              map[string]string     `json:"annotations" description:"An unstructured key value map stored with an audit event that may be set by plugins invoked in the request serving chain"`
+}
+
+// UserInfo holds the information about the user needed to implement the user.Info interface.
+// nolint:lll
+type UserInfo struct {
+	Username pantherlog.String                `json:"username" panther:"username" description:"The name that uniquely identifies this user among all active users"`
+	UID      pantherlog.String                `json:"uid" description:"A unique value that identifies this user across time. If this user is deleted and another user by the same name is added, they will have different UIDs"`
+	Groups   []string                         `json:"groups" description:"The names of groups this user is a part of"`
+	Extra    map[string]pantherlog.RawMessage `json:"extra" description:"Any additional information provided by the authenticator"`
+}
+
+// ObjectReference contains enough information to let you inspect or modify the referred object.
+// nolint:lll
+type ObjectReference struct {
+	Resource        pantherlog.String `json:"resource" description:"The resource type of the object"`
+	Namespace       pantherlog.String `json:"namespace" description:"The namespace of the object"`
+	Name            pantherlog.String `json:"name" description:"The name of the object"`
+	UID             pantherlog.String `json:"uid" description:"The UID of the object"`
+	APIGroup        pantherlog.String `json:"apiGroup" description:"The name of the API group that contains the referred object. The empty string represents the core API group"`
+	APIVersion      pantherlog.String `json:"apiVersion" description:"The version of the API group that contains the referred object"`
+	ResourceVersion pantherlog.String `json:"resourceVersion" description:"The resource version of the object"`
+	Subresource     pantherlog.String `json:"subresource" description:"The subresource of the object"`
+}
+
+// ResponseStatus is the status of the API response.
+// nolint:lll
+type ResponseStatus struct {
+	Status  pantherlog.String     `json:"status" description:"Status of the operation. One of: 'Success' or 'Failure'"`
+	Message pantherlog.String     `json:"message" description:"A human-readable description of the status of this operation"`
+	Reason  pantherlog.String     `json:"reason" description:"A machine-readable description of why this operation is in the 'Failure' status"`
+	Details pantherlog.RawMessage `json:"details" description:"Extended data associated with the reason"`
+	Code    pantherlog.Int32      `json:"code" description:"Suggested HTTP return code for this status"`
+}
+
+var _ pantherlog.ValueWriterTo = (*Audit)(nil)
+
+// WriteValuesTo implements pantherlog.ValueWriterTo interface
+func (event *Audit) WriteValuesTo(w pantherlog.ValueWriter) {
+	for _, ip := range event.SourceIPs {
+		pantherlog.ScanIPAddress(w, ip)
+	}
+}
+
+// AuditParser parses Kubernetes audit events.
+// Apart from the plain audit log JSON it handles the envelopes used by managed Kubernetes services:
+//   - EKS sends control plane logs to CloudWatch Logs. Events arrive either as CloudWatch Logs subscription
+//     payloads or as exported log lines prefixed with the ingestion timestamp.
+//   - GKE writes audit events to Cloud Logging as LogEntry records with an AuditLog `protoPayload`.
+type AuditParser struct {
+	builder pantherlog.ResultBuilder
+}
+
+var _ parsers.Interface = (*AuditParser)(nil)
+
+// ParseLog implements parsers.Interface
+func (p *AuditParser) ParseLog(log string) ([]*parsers.Result, error) {
+	log = trimExportTimestamp(log)
+	envelope := auditEnvelope{}
+	if err := jsoniter.UnmarshalFromString(log, &envelope); err != nil {
+		return nil, err
+	}
+	switch {
+	case envelope.MessageType != "":
+		return p.parseCloudWatch(&envelope)
+	case envelope.ProtoPayload != nil:
+		entry := cloudLoggingEntry{}
+		if err := jsoniter.UnmarshalFromString(log, &entry); err != nil {
+			return nil, err
+		}
+		event, err := entry.Audit()
+		if err != nil {
+			return nil, err
+		}
+		return p.buildResults(event)
+	default:
+		event := Audit{}
+		if err := jsoniter.UnmarshalFromString(log, &event); err != nil {
+			return nil, err
+		}
+		return p.buildResults(&event)
+	}
+}
+
+func (p *AuditParser) parseCloudWatch(envelope *auditEnvelope) ([]*parsers.Result, error) {
+	// Control messages are sent to check if the destination is reachable and contain no log events
+	if envelope.MessageType != cloudWatchDataMessage {
+		return nil, nil
+	}
+	events := make([]*Audit, len(envelope.LogEvents))
+	for i := range envelope.LogEvents {
+		event := Audit{}
+		if err := jsoniter.UnmarshalFromString(envelope.LogEvents[i].Message, &event); err != nil {
+			return nil, err
+		}
+		events[i] = &event
+	}
+	return p.buildResults(events...)
+}
+
+func (p *AuditParser) buildResults(events ...*Audit) ([]*parsers.Result, error) {
+	results := make([]*parsers.Result, 0, len(events))
+	for _, event := range events {
+		if err := pantherlog.ValidateStruct(event); err != nil {
+			return nil, err
+		}
+		result, err := p.builder.BuildResult(TypeAudit, event)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// trimExportTimestamp removes the timestamp prefix added to each line when exporting CloudWatch Logs to S3
+func trimExportTimestamp(log string) string {
+	if strings.HasPrefix(log, "{") {
+		return log
+	}
+	pos := strings.IndexByte(log, ' ')
+	if pos == -1 {
+		return log
+	}
+	if _, err := time.Parse(time.RFC3339Nano, log[:pos]); err != nil {
+		return log
+	}
+	return strings.TrimSpace(log[pos:])
+}
+
+// auditEnvelope is used to detect the format of a log line
+type auditEnvelope struct {
+	// CloudWatch Logs subscription payload
+	MessageType string               `json:"messageType"`
+	LogEvents   []cloudWatchLogEvent `json:"logEvents"`
+	// Cloud Logging LogEntry payload
+	ProtoPayload jsoniter.RawMessage `json:"protoPayload"`
+}
+
+const cloudWatchDataMessage = "DATA_MESSAGE"
+
+type cloudWatchLogEvent struct {
+	ID        string `json:"id"`
+	Timestamp int64  `json:"timestamp"`
+	Message   string `json:"message"`
+}
+
+// The service name GKE uses for Kubernetes audit log entries
+const gkeServiceName = "k8s.io"
+
+type cloudLoggingEntry struct {
+	gcplogs.LogEntry
+	Payload gcplogs.AuditLog `json:"protoPayload"`
+}
+
+// Audit converts a GKE audit log entry to an audit event.
+// GKE does not export the original audit event, so we map the fields of the AuditLog payload to their
+// audit.k8s.io/v1 counterparts.
+func (entry *cloudLoggingEntry) Audit() (*Audit, error) {
+	payload := &entry.Payload
+	if name := payload.ServiceName; name == nil || *name != gkeServiceName {
+		return nil, errors.New("log entry is not a Kubernetes audit log")
+	}
+	event := Audit{
+		Kind:           null.FromString("Event"),
+		APIVersion:     null.FromString("audit.k8s.io/v1"),
+		Verb:           fromStringPtr(verbFromMethodName(payload.MethodName)),
+		RequestObject:  payload.Request,
+		ResponseObject: payload.Response,
+		Annotations:    entry.Labels,
+	}
+	// GKE uses the audit ID as the operation id
+	if op := entry.Operation; op != nil && op.ID != nil {
+		event.AuditID = fromStringPtr(op.ID)
+	} else {
+		event.AuditID = fromStringPtr(entry.InsertID)
+	}
+	if ts := entry.Timestamp; ts != nil {
+		event.RequestReceivedTimestamp = time.Time(*ts)
+	}
+	if payload.ResourceName != nil {
+		event.ObjectRef = objectRefFromResourceName(*payload.ResourceName)
+	}
+	if info := payload.AuthenticationInfo; info != nil {
+		event.User = &UserInfo{
+			Username: fromStringPtr(info.PrincipalEmail),
+		}
+	}
+	if meta := payload.RequestMetadata; meta != nil {
+		if meta.CallerIP != nil {
+			event.SourceIPs = []string{*meta.CallerIP}
+		}
+		event.UserAgent = fromStringPtr(meta.CallerSuppliedUserAgent)
+	}
+	if status := payload.Status; status != nil && status.Message != nil {
+		event.ResponseStatus = &ResponseStatus{
+			Message: fromStringPtr(status.Message),
+		}
+	}
+	return &event, nil
+}
+
+// verbFromMethodName extracts the verb from GKE method names (ie 'io.k8s.core.v1.pods.create')
+func verbFromMethodName(methodName *string) *string {
+	if methodName == nil {
+		return nil
+	}
+	name := *methodName
+	if pos := strings.LastIndexByte(name, '.'); 0 <= pos && pos < len(name)-1 {
+		verb := name[pos+1:]
+		return &verb
+	}
+	return nil
+}
+
+// objectRefFromResourceName parses GKE resource names (ie 'core/v1/namespaces/default/pods/nginx').
+func objectRefFromResourceName(name string) *ObjectReference {
+	parts := strings.Split(name, "/")
+	const minParts = 3
+	if len(parts) < minParts {
+		return nil
+	}
+	ref := ObjectReference{
+		APIVersion: null.FromString(parts[1]),
+	}
+	// The core API group is represented by an empty string in the audit.k8s.io model
+	if group := parts[0]; group != "core" {
+		ref.APIGroup = null.FromString(group)
+	}
+	parts = parts[2:]
+	if len(parts) > 2 && parts[0] == "namespaces" {
+		ref.Namespace = null.FromString(parts[1])
+		parts = parts[2:]
+	}
+	ref.Resource = null.FromString(parts[0])
+	if len(parts) > 1 {
+		ref.Name = null.FromString(parts[1])
+	}
+	if len(parts) > 2 {
+		ref.Subresource = null.FromString(strings.Join(parts[2:], "/"))
+	}
+	return &ref
+}
+
+func fromStringPtr(s *string) pantherlog.String {
+	if s == nil {
+		return pantherlog.String{}
+	}
+	return null.FromString(*s)
+}
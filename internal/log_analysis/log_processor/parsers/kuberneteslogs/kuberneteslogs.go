@@ -0,0 +1,55 @@
+// Package kuberneteslogs has log parsers for Kubernetes clusters
+package kuberneteslogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+const (
+	LogTypePrefix = "Kubernetes"
+	TypeAudit     = LogTypePrefix + ".Audit"
+)
+
+// LogTypes exports the available log type entries
+func LogTypes() logtypes.Group {
+	return logTypes
+}
+
+// nolint:lll
+var logTypes = logtypes.Must(LogTypePrefix,
+	logtypes.Config{
+		Name: TypeAudit,
+		Description: `Kubernetes API server audit events (audit.k8s.io/v1).
+Events can be the raw audit log JSON, EKS control plane logs forwarded from CloudWatch Logs or GKE audit logs exported from Cloud Logging.`,
+		ReferenceURL: `https://kubernetes.io/docs/tasks/debug-application-cluster/audit/`,
+		// Source IPs are not a tagged string field so we need to add the indicator fields explicitly
+		Schema: pantherlog.MustBuildEventSchema(&Audit{},
+			pantherlog.FieldIPAddress,
+			pantherlog.FieldTraceID,
+			pantherlog.FieldUsername,
+		),
+		NewParser: parsers.FactoryFunc(func(_ interface{}) (parsers.Interface, error) {
+			return &AuditParser{}, nil
+		}),
+	},
+)
@@ -0,0 +1,384 @@
+package leeflogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/ceflogs"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/sysloglogs"
+)
+
+// Event is a LEEF event.
+// Predefined event attributes are mapped to typed columns, all other attributes are kept in the `attributes` column.
+// nolint:lll
+type Event struct {
+	Version        pantherlog.String  `json:"version" validate:"required" description:"The version of the LEEF format (1.0 or 2.0)."`
+	Vendor         pantherlog.String  `json:"vendor" validate:"required" description:"The vendor of the sending device."`
+	Product        pantherlog.String  `json:"product" validate:"required" description:"The product name of the sending device."`
+	ProductVersion pantherlog.String  `json:"productVersion" description:"The version of the sending device."`
+	EventID        pantherlog.String  `json:"eventId" validate:"required" description:"A unique identifier for the event type."`
+	DevTime        pantherlog.Time    `json:"devTime" tcodec:"rfc3339" event_time:"true" description:"The time the event occurred on the device."`
+	DevTimeFormat  pantherlog.String  `json:"devTimeFormat" description:"The format of the devTime attribute."`
+	Cat            pantherlog.String  `json:"cat" description:"The event category."`
+	Sev            pantherlog.Int32   `json:"sev" description:"The severity of the event (1-10)."`
+	Proto          pantherlog.String  `json:"proto" description:"The transport protocol."`
+	Src            pantherlog.String  `json:"src" panther:"ip" description:"The source IP address."`
+	Dst            pantherlog.String  `json:"dst" panther:"ip" description:"The destination IP address."`
+	SrcPort        pantherlog.Uint16  `json:"srcPort" description:"The source port."`
+	DstPort        pantherlog.Uint16  `json:"dstPort" description:"The destination port."`
+	SrcPreNAT      pantherlog.String  `json:"srcPreNAT" panther:"ip" description:"The source IP address before NAT."`
+	DstPreNAT      pantherlog.String  `json:"dstPreNAT" panther:"ip" description:"The destination IP address before NAT."`
+	SrcPostNAT     pantherlog.String  `json:"srcPostNAT" panther:"ip" description:"The source IP address after NAT."`
+	DstPostNAT     pantherlog.String  `json:"dstPostNAT" panther:"ip" description:"The destination IP address after NAT."`
+	SrcMAC         pantherlog.String  `json:"srcMAC" description:"The source MAC address."`
+	DstMAC         pantherlog.String  `json:"dstMAC" description:"The destination MAC address."`
+	SrcBytes       pantherlog.Int64   `json:"srcBytes" description:"The number of bytes sent by the source."`
+	DstBytes       pantherlog.Int64   `json:"dstBytes" description:"The number of bytes sent by the destination."`
+	SrcPackets     pantherlog.Int64   `json:"srcPackets" description:"The number of packets sent by the source."`
+	DstPackets     pantherlog.Int64   `json:"dstPackets" description:"The number of packets sent by the destination."`
+	TotalPackets   pantherlog.Int64   `json:"totalPackets" description:"The total number of packets."`
+	UsrName        pantherlog.String  `json:"usrName" panther:"username" description:"The user name associated with the event."`
+	AccountName    pantherlog.String  `json:"accountName" panther:"username" description:"The account name associated with the event."`
+	GroupID        pantherlog.String  `json:"groupID" description:"The group ID associated with the event."`
+	IdentSrc       pantherlog.String  `json:"identSrc" panther:"ip" description:"The IP address of the identity."`
+	IdentHostName  pantherlog.String  `json:"identHostName" panther:"hostname" description:"The host name of the identity."`
+	IdentNetBios   pantherlog.String  `json:"identNetBios" description:"The NetBIOS name of the identity."`
+	IdentGrpName   pantherlog.String  `json:"identGrpName" description:"The group name of the identity."`
+	URL            pantherlog.String  `json:"url" panther:"url" description:"The URL of the request."`
+	Domain         pantherlog.String  `json:"domain" description:"The domain name of the user or device."`
+	Policy         pantherlog.String  `json:"policy" description:"The policy that matched the event."`
+	Resource       pantherlog.String  `json:"resource" description:"The resource the event refers to."`
+	Role           pantherlog.String  `json:"role" description:"The role of the user."`
+	Realm          pantherlog.String  `json:"realm" description:"The realm of the user."`
+	VSrc           pantherlog.String  `json:"vSrc" panther:"ip" description:"The virtual source IP address."`
+	VSrcName       pantherlog.String  `json:"vSrcName" panther:"hostname" description:"The virtual source name."`
+	Attributes     map[string]string  `json:"attributes" description:"Event attributes that are not mapped to a column."`
+	Syslog         *sysloglogs.Header `json:"syslog" description:"The header of the syslog message carrying the event."`
+}
+
+// nolint:gocyclo
+func (event *Event) setAttribute(key, value string) bool {
+	switch key {
+	case "devTimeFormat":
+		event.DevTimeFormat = null.FromString(value)
+	case "cat":
+		event.Cat = null.FromString(value)
+	case "sev":
+		n, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return false
+		}
+		event.Sev = null.FromInt32(int32(n))
+	case "proto":
+		event.Proto = null.FromString(value)
+	case "src":
+		event.Src = null.FromString(value)
+	case "dst":
+		event.Dst = null.FromString(value)
+	case "srcPort":
+		return setPort(&event.SrcPort, value)
+	case "dstPort":
+		return setPort(&event.DstPort, value)
+	case "srcPreNAT":
+		event.SrcPreNAT = null.FromString(value)
+	case "dstPreNAT":
+		event.DstPreNAT = null.FromString(value)
+	case "srcPostNAT":
+		event.SrcPostNAT = null.FromString(value)
+	case "dstPostNAT":
+		event.DstPostNAT = null.FromString(value)
+	case "srcMAC":
+		event.SrcMAC = null.FromString(value)
+	case "dstMAC":
+		event.DstMAC = null.FromString(value)
+	case "srcBytes":
+		return setInt64(&event.SrcBytes, value)
+	case "dstBytes":
+		return setInt64(&event.DstBytes, value)
+	case "srcPackets":
+		return setInt64(&event.SrcPackets, value)
+	case "dstPackets":
+		return setInt64(&event.DstPackets, value)
+	case "totalPackets":
+		return setInt64(&event.TotalPackets, value)
+	case "usrName":
+		event.UsrName = null.FromString(value)
+	case "accountName":
+		event.AccountName = null.FromString(value)
+	case "groupID":
+		event.GroupID = null.FromString(value)
+	case "identSrc":
+		event.IdentSrc = null.FromString(value)
+	case "identHostName":
+		event.IdentHostName = null.FromString(value)
+	case "identNetBios":
+		event.IdentNetBios = null.FromString(value)
+	case "identGrpName":
+		event.IdentGrpName = null.FromString(value)
+	case "url":
+		event.URL = null.FromString(value)
+	case "domain":
+		event.Domain = null.FromString(value)
+	case "policy":
+		event.Policy = null.FromString(value)
+	case "resource":
+		event.Resource = null.FromString(value)
+	case "role":
+		event.Role = null.FromString(value)
+	case "realm":
+		event.Realm = null.FromString(value)
+	case "vSrc":
+		event.VSrc = null.FromString(value)
+	case "vSrcName":
+		event.VSrcName = null.FromString(value)
+	default:
+		return false
+	}
+	return true
+}
+
+func setPort(dst *pantherlog.Uint16, value string) bool {
+	n, err := strconv.ParseUint(value, 10, 16)
+	if err != nil {
+		return false
+	}
+	*dst = null.FromUint16(uint16(n))
+	return true
+}
+
+func setInt64(dst *pantherlog.Int64, value string) bool {
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return false
+	}
+	*dst = null.FromInt64(n)
+	return true
+}
+
+// Parser parses LEEF events
+type Parser struct {
+	builder pantherlog.ResultBuilder
+}
+
+var _ parsers.Interface = (*Parser)(nil)
+
+const prefixLEEF = "LEEF:"
+
+// ParseLog implements parsers.Interface
+func (p *Parser) ParseLog(log string) ([]*parsers.Result, error) {
+	header, msg, err := sysloglogs.SplitMessage(log, prefixLEEF)
+	if err != nil {
+		return nil, err
+	}
+	event, err := ParseEvent(msg)
+	if err != nil {
+		return nil, err
+	}
+	event.Syslog = header
+	if err := pantherlog.ValidateStruct(event); err != nil {
+		return nil, err
+	}
+	result, err := p.builder.BuildResult(TypeLEEF, event)
+	if err != nil {
+		return nil, err
+	}
+	return []*parsers.Result{result}, nil
+}
+
+// ParseEvent parses a LEEF message.
+// LEEF 1.0 messages have the form 'LEEF:1.0|Vendor|Product|Version|EventID|attributes' with tab delimited attributes.
+// LEEF 2.0 messages add a field with the attribute delimiter 'LEEF:2.0|Vendor|Product|Version|EventID|^|attributes'.
+func ParseEvent(msg string) (*Event, error) {
+	if !strings.HasPrefix(msg, prefixLEEF) {
+		return nil, errors.New("invalid LEEF message")
+	}
+	// Vendors are inconsistent on whether there is a trailing pipe for an empty LEEF 2.0 delimiter field.
+	// We split at most 7 fields and check if the sixth field is a delimiter.
+	const maxFields = 7
+	msg = strings.TrimRight(strings.TrimPrefix(msg, prefixLEEF), "\r\n")
+	fields := strings.SplitN(msg, "|", maxFields)
+	if len(fields) < 5 {
+		return nil, errors.New("invalid LEEF header")
+	}
+	event := Event{
+		Version:        null.FromString(fields[0]),
+		Vendor:         null.FromString(fields[1]),
+		Product:        null.FromString(fields[2]),
+		ProductVersion: null.FromString(fields[3]),
+		EventID:        null.FromString(fields[4]),
+	}
+	fields = fields[5:]
+	delim := "\t"
+	if len(fields) == 2 && strings.HasPrefix(event.Version.Value, "2") {
+		if d, ok := parseDelimiter(fields[0]); ok {
+			delim = d
+			fields = fields[1:]
+		}
+	}
+	if len(fields) > 0 {
+		// If the header had an extra field it belongs to the attributes
+		attrs := strings.Join(fields, "|")
+		if err := event.setAttributes(attrs, delim); err != nil {
+			return nil, err
+		}
+	}
+	return &event, nil
+}
+
+func (event *Event) setAttributes(attrs, delim string) error {
+	devTime := ""
+	for _, attr := range strings.Split(attrs, delim) {
+		if strings.TrimSpace(attr) == "" {
+			continue
+		}
+		pos := strings.IndexByte(attr, '=')
+		if pos == -1 {
+			return errors.Errorf("invalid LEEF attribute %q", attr)
+		}
+		key, value := strings.TrimSpace(attr[:pos]), attr[pos+1:]
+		if value == "" {
+			continue
+		}
+		if key == "devTime" {
+			devTime = value
+			continue
+		}
+		if event.setAttribute(key, value) {
+			continue
+		}
+		event.setUnknown(key, value)
+	}
+	if devTime == "" {
+		return nil
+	}
+	// devTime is parsed last since it depends on the devTimeFormat attribute
+	tm, err := ParseDevTime(devTime, event.DevTimeFormat.Value)
+	if err != nil {
+		event.setUnknown("devTime", devTime)
+		return nil
+	}
+	event.DevTime = tm
+	return nil
+}
+
+func (event *Event) setUnknown(key, value string) {
+	if event.Attributes == nil {
+		event.Attributes = make(map[string]string)
+	}
+	event.Attributes[key] = value
+}
+
+// parseDelimiter parses the LEEF 2.0 delimiter field.
+// The delimiter is either a single character or a hex value ('x09' or '0x09').
+func parseDelimiter(s string) (string, bool) {
+	switch n := len(s); {
+	case n == 0:
+		return "\t", true
+	case n == 1:
+		return s, true
+	case n > 4:
+		return "", false
+	}
+	hex := strings.TrimPrefix(s, "0")
+	if !strings.HasPrefix(hex, "x") {
+		return "", false
+	}
+	c, err := strconv.ParseUint(hex[1:], 16, 8)
+	if err != nil {
+		return "", false
+	}
+	return string(rune(c)), true
+}
+
+// ParseDevTime parses a devTime attribute value.
+// If no format is specified, the default LEEF format 'MMM dd yyyy HH:mm:ss' or milliseconds since epoch are used.
+func ParseDevTime(value, format string) (time.Time, error) {
+	if format == "" {
+		return ceflogs.ParseTime(value)
+	}
+	tm, err := time.Parse(GoLayout(format), value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return tm.UTC(), nil
+}
+
+// Java SimpleDateFormat patterns and their Go time layout equivalent.
+// Longer patterns precede shorter ones with the same prefix.
+var javaLayouts = []struct {
+	Java string
+	Go   string
+}{
+	{"yyyy", "2006"},
+	{"yy", "06"},
+	{"MMMM", "January"},
+	{"MMM", "Jan"},
+	{"MM", "01"},
+	{"M", "1"},
+	{"dd", "02"},
+	{"d", "2"},
+	{"EEEE", "Monday"},
+	{"EEE", "Mon"},
+	{"HH", "15"},
+	{"hh", "03"},
+	{"h", "3"},
+	{"mm", "04"},
+	{"m", "4"},
+	{"ss", "05"},
+	{"s", "5"},
+	{"SSS", "000"},
+	{"a", "PM"},
+	{"XXX", "Z07:00"},
+	{"Z", "-0700"},
+	{"z", "MST"},
+}
+
+// GoLayout converts a Java SimpleDateFormat pattern to a Go time layout.
+func GoLayout(format string) string {
+	var b strings.Builder
+	quoted := false
+next:
+	for i := 0; i < len(format); {
+		if format[i] == '\'' {
+			quoted = !quoted
+			i++
+			continue
+		}
+		if !quoted {
+			for _, l := range javaLayouts {
+				if strings.HasPrefix(format[i:], l.Java) {
+					b.WriteString(l.Go)
+					i += len(l.Java)
+					continue next
+				}
+			}
+		}
+		b.WriteByte(format[i])
+		i++
+	}
+	return b.String()
+}
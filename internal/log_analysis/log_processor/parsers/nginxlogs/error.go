@@ -0,0 +1,202 @@
+package nginxlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/pkg/x/fastmatch"
+)
+
+// Error is an Nginx error log entry
+// nolint:lll
+type Error struct {
+	Time         pantherlog.Time   `json:"time" tcodec:"rfc3339" event_time:"true" validate:"required" description:"The time the error was logged (in the local time of the server)."`
+	Level        pantherlog.String `json:"level" validate:"required" description:"The severity level of the error (debug, info, notice, warn, error, crit, alert, emerg)."`
+	PID          pantherlog.Int64  `json:"pid" description:"The process ID of the worker that logged the error."`
+	TID          pantherlog.Int64  `json:"tid" description:"The thread ID of the worker that logged the error."`
+	ConnectionID pantherlog.Int64  `json:"connectionId" description:"The serial number of the connection the error refers to."`
+	Message      pantherlog.String `json:"message" description:"The error message."`
+	Client       pantherlog.String `json:"client" panther:"ip" description:"The IP address of the client that made the request."`
+	Server       pantherlog.String `json:"server" description:"The name of the virtual server that handled the request."`
+	Request      pantherlog.String `json:"request" description:"The request line from the client."`
+	Upstream     pantherlog.String `json:"upstream" panther:"url" description:"The upstream URL the request was proxied to."`
+	Host         pantherlog.String `json:"host" description:"The Host header of the request."`
+	Referrer     pantherlog.String `json:"referrer" panther:"url" description:"The Referer header of the request."`
+}
+
+var _ pantherlog.ValueWriterTo = (*Error)(nil)
+
+// WriteValuesTo implements pantherlog.ValueWriterTo interface
+func (event *Error) WriteValuesTo(w pantherlog.ValueWriter) {
+	// The catch-all server name `_` is not a domain
+	if server := event.Server.Value; server != "" && server != "_" {
+		pantherlog.ScanHostname(w, server)
+	}
+	// The Host header can include a port
+	if host := event.Host.Value; host != "" {
+		pantherlog.ScanNetworkAddress(w, host)
+	}
+}
+
+// The time layout of Nginx error logs
+const errorTimeLayout = "2006/01/02 15:04:05"
+
+var errorPattern = fastmatch.MustCompile(`%{time} [%{level}] %{pid}#%{tid}: %{message}`)
+
+// ErrorParser parses Nginx error logs
+type ErrorParser struct {
+	fields  []string
+	builder pantherlog.ResultBuilder
+}
+
+var _ parsers.Interface = (*ErrorParser)(nil)
+
+// NewErrorParser creates a parser for Nginx error logs
+func NewErrorParser() *ErrorParser {
+	return &ErrorParser{}
+}
+
+// ParseLog implements parsers.Interface
+func (p *ErrorParser) ParseLog(log string) ([]*parsers.Result, error) {
+	var err error
+	p.fields, err = errorPattern.MatchString(p.fields[:0], strings.TrimSpace(log))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid Nginx error log")
+	}
+	event := Error{}
+	for i := 0; i+1 < len(p.fields); i += 2 {
+		if err := event.setField(p.fields[i], p.fields[i+1]); err != nil {
+			return nil, err
+		}
+	}
+	if err := pantherlog.ValidateStruct(&event); err != nil {
+		return nil, err
+	}
+	result, err := p.builder.BuildResult(TypeError, &event)
+	if err != nil {
+		return nil, err
+	}
+	return []*parsers.Result{result}, nil
+}
+
+func (event *Error) setField(name, value string) error {
+	var err error
+	switch name {
+	case "time":
+		var tm time.Time
+		tm, err = time.Parse(errorTimeLayout, value)
+		event.Time = tm.UTC()
+	case "level":
+		event.Level = null.FromString(value)
+	case "pid":
+		event.PID, err = parseInt64(value)
+	case "tid":
+		event.TID, err = parseInt64(value)
+	case "message":
+		err = event.setMessage(value)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "invalid %q value", name)
+	}
+	return nil
+}
+
+// setMessage splits the connection ID prefix (ie `*42 `) and the request context suffix of an error message.
+func (event *Error) setMessage(msg string) error {
+	if strings.HasPrefix(msg, "*") {
+		if pos := strings.IndexByte(msg, ' '); pos != -1 {
+			id, err := parseInt64(msg[1:pos])
+			if err != nil {
+				return err
+			}
+			event.ConnectionID = id
+			msg = msg[pos+1:]
+		}
+	}
+	// Nginx appends the request context as `, key: value` pairs starting with the client address.
+	if pos := strings.Index(msg, ", client: "); pos != -1 {
+		event.setContext(msg[pos+2:])
+		msg = msg[:pos]
+	}
+	event.Message = null.FromString(msg)
+	return nil
+}
+
+// setContext sets the fields of `key: value` pairs separated by `, `.
+// Values are either unquoted or surrounded by `"`.
+func (event *Error) setContext(ctx string) {
+	for ctx != "" {
+		pos := strings.Index(ctx, ": ")
+		if pos == -1 {
+			return
+		}
+		key, tail := ctx[:pos], ctx[pos+2:]
+		var value string
+		if strings.HasPrefix(tail, `"`) {
+			value, ctx = splitQuoted(tail[1:])
+		} else if end := strings.Index(tail, ", "); end != -1 {
+			value, ctx = tail[:end], tail[end+2:]
+		} else {
+			value, ctx = tail, ""
+		}
+		event.setContextValue(key, value)
+	}
+}
+
+// splitQuoted splits a quoted value from the rest of the context.
+// Nginx does not escape quotes in context values so the value ends at the first `", ` or at the last `"`.
+func splitQuoted(s string) (value, tail string) {
+	if end := strings.Index(s, `", `); end != -1 {
+		return s[:end], s[end+3:]
+	}
+	return strings.TrimSuffix(s, `"`), ""
+}
+
+func (event *Error) setContextValue(key, value string) {
+	switch key {
+	case "client":
+		event.Client = null.FromString(value)
+	case "server":
+		event.Server = null.FromString(value)
+	case "request":
+		event.Request = null.FromString(value)
+	case "upstream":
+		event.Upstream = null.FromString(value)
+	case "host":
+		event.Host = null.FromString(value)
+	case "referrer":
+		event.Referrer = null.FromString(value)
+	}
+}
+
+func parseInt64(s string) (pantherlog.Int64, error) {
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return pantherlog.Int64{}, err
+	}
+	return null.FromInt64(n), nil
+}
@@ -21,11 +21,13 @@ package nginxlogs
 
 import (
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
 )
 
 const (
 	TypeAccess = `Nginx.Access`
+	TypeError  = `Nginx.Error`
 )
 
 func LogTypes() logtypes.Group {
@@ -40,4 +42,16 @@ var logTypes = logtypes.Must("Nginx",
 		Schema:       Access{},
 		NewParser:    parsers.AdapterFactory(&AccessParser{}),
 	},
+	logtypes.Config{
+		Name:         TypeError,
+		Description:  `Error Logs for your Nginx server, including the request context of errors.`,
+		ReferenceURL: `http://nginx.org/en/docs/ngx_core_module.html#error_log`,
+		Schema: pantherlog.MustBuildEventSchema(&Error{},
+			pantherlog.FieldIPAddress,
+			pantherlog.FieldDomainName,
+		),
+		NewParser: parsers.FactoryFunc(func(_ interface{}) (parsers.Interface, error) {
+			return NewErrorParser(), nil
+		}),
+	},
 )
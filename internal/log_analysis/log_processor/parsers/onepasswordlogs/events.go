@@ -0,0 +1,87 @@
+package onepasswordlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+)
+
+// SignInAttempt is a 1Password sign-in attempt
+// nolint:lll
+type SignInAttempt struct {
+	UUID        pantherlog.String `json:"uuid" validate:"required" description:"The UUID of the event."`
+	SessionUUID pantherlog.String `json:"session_uuid" description:"The UUID of the session that created the event."`
+	Timestamp   pantherlog.Time   `json:"timestamp" tcodec:"rfc3339" event_time:"true" validate:"required" description:"When the sign-in attempt occurred."`
+	Category    pantherlog.String `json:"category" validate:"required" description:"The category of the sign-in attempt (success, credentials_failed, mfa_failed, modern_version_failed, firewall_failed, firewall_reported_success)."`
+	Type        pantherlog.String `json:"type" validate:"required" description:"Details about the sign-in attempt (ie credentials_ok, mfa_ok, password_secret_bad, mfa_missing, continued_session)."`
+	Country     pantherlog.String `json:"country" description:"The country code of the event, in ISO 3166 format."`
+	Details     *Details          `json:"details,omitempty" description:"Additional information about the sign-in attempt (ie the firewall rule that prevented a sign-in)."`
+	TargetUser  *User             `json:"target_user" validate:"required" description:"The user who attempted to sign in."`
+	Client      *Client           `json:"client,omitempty" description:"The client that was used to sign in."`
+	Location    *Location         `json:"location,omitempty" description:"The geolocation of the client IP address."`
+}
+
+// Details holds additional information about a sign-in attempt
+type Details struct {
+	Value pantherlog.String `json:"value" description:"The additional information about the sign-in attempt."`
+}
+
+// ItemUsage is a 1Password item usage event
+// nolint:lll
+type ItemUsage struct {
+	UUID        pantherlog.String `json:"uuid" validate:"required" description:"The UUID of the event."`
+	Timestamp   pantherlog.Time   `json:"timestamp" tcodec:"rfc3339" event_time:"true" validate:"required" description:"When the item was used."`
+	UsedVersion pantherlog.Int64  `json:"used_version" description:"The version of the item that was used."`
+	VaultUUID   pantherlog.String `json:"vault_uuid" validate:"required" description:"The UUID of the vault the item is in."`
+	ItemUUID    pantherlog.String `json:"item_uuid" validate:"required" description:"The UUID of the item that was used."`
+	Action      pantherlog.String `json:"action" description:"The action performed on the item (ie fill, reveal, secure-copy, enter-item-edit-mode, server-create, server-update)."`
+	User        *User             `json:"user,omitempty" description:"The user who used the item."`
+	Client      *Client           `json:"client,omitempty" description:"The client that was used to access the item."`
+	Location    *Location         `json:"location,omitempty" description:"The geolocation of the client IP address."`
+}
+
+// User is a 1Password user
+// nolint:lll
+type User struct {
+	UUID  pantherlog.String `json:"uuid" description:"The UUID of the user."`
+	Name  pantherlog.String `json:"name" description:"The name of the user."`
+	Email pantherlog.String `json:"email" panther:"email" description:"The email address of the user."`
+}
+
+// Client is the 1Password client of an event
+// nolint:lll
+type Client struct {
+	AppName         pantherlog.String `json:"app_name" description:"The name of the 1Password app."`
+	AppVersion      pantherlog.String `json:"app_version" description:"The version number of the 1Password app."`
+	PlatformName    pantherlog.String `json:"platform_name" description:"The name of the platform running the app (ie the browser)."`
+	PlatformVersion pantherlog.String `json:"platform_version" description:"The version of the platform running the app."`
+	OSName          pantherlog.String `json:"os_name" description:"The name of the operating system."`
+	OSVersion       pantherlog.String `json:"os_version" description:"The version of the operating system."`
+	IPAddress       pantherlog.String `json:"ip_address" panther:"ip" description:"The IP address the client used."`
+}
+
+// Location is the geolocation of the IP address of an event
+// nolint:lll
+type Location struct {
+	Country   pantherlog.String  `json:"country" description:"The country name."`
+	Region    pantherlog.String  `json:"region" description:"The region name."`
+	City      pantherlog.String  `json:"city" description:"The city name."`
+	Latitude  pantherlog.Float64 `json:"latitude" description:"The latitude of the location."`
+	Longitude pantherlog.Float64 `json:"longitude" description:"The longitude of the location."`
+}
@@ -0,0 +1,64 @@
+// Package onepasswordlogs parses 1Password Business events
+package onepasswordlogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+const (
+	TypeSignInAttempt = "OnePassword.SignInAttempt"
+	TypeItemUsage     = "OnePassword.ItemUsage"
+)
+
+// LogTypes exports the available log type entries
+func LogTypes() logtypes.Group {
+	return logTypes
+}
+
+// nolint:lll
+var logTypes = logtypes.Must("OnePassword",
+	logtypes.Config{
+		Name:         TypeSignInAttempt,
+		Description:  `1Password Business sign-in attempts as returned by the Events API.`,
+		ReferenceURL: `https://developer.1password.com/docs/events-api/reference/#signinattempt-object`,
+		Schema:       pantherlog.MustBuildEventSchema(&SignInAttempt{}),
+		NewParser: &parsers.JSONParserFactory{
+			LogType: TypeSignInAttempt,
+			NewEvent: func() interface{} {
+				return &SignInAttempt{}
+			},
+		},
+	},
+	logtypes.Config{
+		Name:         TypeItemUsage,
+		Description:  `1Password Business item usage events as returned by the Events API.`,
+		ReferenceURL: `https://developer.1password.com/docs/events-api/reference/#itemusage-object`,
+		Schema:       pantherlog.MustBuildEventSchema(&ItemUsage{}),
+		NewParser: &parsers.JSONParserFactory{
+			LogType: TypeItemUsage,
+			NewEvent: func() interface{} {
+				return &ItemUsage{}
+			},
+		},
+	},
+)
@@ -0,0 +1,103 @@
+package opentelemetrylogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strconv"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Sort map keys so that attributes are always stored in the same order
+var jsonAPI = jsoniter.Config{
+	SortMapKeys: true,
+}.Froze()
+
+// keyValue is an attribute of a resource, scope or log record
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+// anyValue is the union of the value types an attribute or body can have.
+// Only one of the fields is set.
+type anyValue struct {
+	StringValue *string             `json:"stringValue"`
+	BoolValue   *bool               `json:"boolValue"`
+	IntValue    jsoniter.RawMessage `json:"intValue"`
+	DoubleValue *float64            `json:"doubleValue"`
+	ArrayValue  *struct {
+		Values []anyValue `json:"values"`
+	} `json:"arrayValue"`
+	KvlistValue *struct {
+		Values []keyValue `json:"values"`
+	} `json:"kvlistValue"`
+	// Bytes are base64 encoded and kept as is
+	BytesValue *string `json:"bytesValue"`
+}
+
+// value converts the union to a plain JSON value
+func (v *anyValue) value() interface{} {
+	switch {
+	case v.StringValue != nil:
+		return *v.StringValue
+	case v.BoolValue != nil:
+		return *v.BoolValue
+	case v.IntValue != nil:
+		// 64-bit integers are encoded as strings by the protobuf JSON mapping
+		s := string(v.IntValue)
+		if unquoted, err := strconv.Unquote(s); err == nil {
+			s = unquoted
+		}
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return n
+		}
+		return s
+	case v.DoubleValue != nil:
+		return *v.DoubleValue
+	case v.ArrayValue != nil:
+		values := make([]interface{}, len(v.ArrayValue.Values))
+		for i := range v.ArrayValue.Values {
+			values[i] = v.ArrayValue.Values[i].value()
+		}
+		return values
+	case v.KvlistValue != nil:
+		return attributesMap(v.KvlistValue.Values)
+	case v.BytesValue != nil:
+		return *v.BytesValue
+	default:
+		return nil
+	}
+}
+
+func attributesMap(attrs []keyValue) map[string]interface{} {
+	m := make(map[string]interface{}, len(attrs))
+	for i := range attrs {
+		m[attrs[i].Key] = attrs[i].Value.value()
+	}
+	return m
+}
+
+// attributesJSON converts a list of attributes to a JSON object
+func attributesJSON(attrs []keyValue) ([]byte, error) {
+	if len(attrs) == 0 {
+		return nil, nil
+	}
+	return jsonAPI.Marshal(attributesMap(attrs))
+}
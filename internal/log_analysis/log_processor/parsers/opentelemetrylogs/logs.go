@@ -0,0 +1,277 @@
+package opentelemetrylogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strconv"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+// LogRecord is a single OpenTelemetry log record along with its resource and instrumentation scope
+// nolint:lll
+type LogRecord struct {
+	Time                   pantherlog.Time       `json:"time" tcodec:"rfc3339" event_time:"true" validate:"required" description:"The time when the event occurred. If the time is not set, the time the event was observed by the collection system is used."`
+	ObservedTime           pantherlog.Time       `json:"observedTime" tcodec:"rfc3339" description:"The time when the event was observed by the collection system."`
+	SeverityNumber         pantherlog.Int32      `json:"severityNumber" description:"The numerical value of the severity (1-24), normalized to values described in the OpenTelemetry Log Data Model."`
+	SeverityText           pantherlog.String     `json:"severityText" description:"The severity text (also known as log level) as known at the source."`
+	Body                   pantherlog.RawMessage `json:"body" description:"The body of the log record. It can be a string or a structured value."`
+	Attributes             pantherlog.RawMessage `json:"attributes" description:"Additional attributes that describe the specific event occurrence."`
+	DroppedAttributesCount pantherlog.Int64      `json:"droppedAttributesCount" description:"The number of attributes that were discarded due to limits."`
+	Flags                  pantherlog.Int64      `json:"flags" description:"The trace flags as defined in the W3C Trace Context specification."`
+	TraceID                pantherlog.String     `json:"traceId" panther:"trace_id" description:"The hex encoded trace ID of the request that produced the log record."`
+	SpanID                 pantherlog.String     `json:"spanId" panther:"trace_id" description:"The hex encoded span ID of the request that produced the log record."`
+	Resource               *Resource             `json:"resource,omitempty" description:"The resource that produced the log record."`
+	Scope                  *Scope                `json:"scope,omitempty" description:"The instrumentation scope that produced the log record."`
+}
+
+// Resource is the entity producing telemetry (ie a service or a host)
+// nolint:lll
+type Resource struct {
+	Attributes             pantherlog.RawMessage `json:"attributes" description:"The attributes of the resource (ie service.name, host.name)."`
+	DroppedAttributesCount pantherlog.Int64      `json:"droppedAttributesCount" description:"The number of attributes that were discarded due to limits."`
+	SchemaURL              pantherlog.String     `json:"schemaUrl" description:"The schema URL of the resource attributes."`
+}
+
+// Scope is the instrumentation scope (ie library) that produced the log record
+// nolint:lll
+type Scope struct {
+	Name                   pantherlog.String     `json:"name" description:"The name of the instrumentation scope."`
+	Version                pantherlog.String     `json:"version" description:"The version of the instrumentation scope."`
+	Attributes             pantherlog.RawMessage `json:"attributes" description:"The attributes of the instrumentation scope."`
+	DroppedAttributesCount pantherlog.Int64      `json:"droppedAttributesCount" description:"The number of attributes that were discarded due to limits."`
+	SchemaURL              pantherlog.String     `json:"schemaUrl" description:"The schema URL of the log records of the scope."`
+}
+
+// LogsParser parses OTLP JSON log exports into one event per log record
+type LogsParser struct {
+	builder pantherlog.ResultBuilder
+}
+
+var _ parsers.Interface = (*LogsParser)(nil)
+
+// ParseLog implements parsers.Interface
+func (p *LogsParser) ParseLog(log string) ([]*parsers.Result, error) {
+	data := logsData{}
+	if err := jsoniter.UnmarshalFromString(log, &data); err != nil {
+		return nil, err
+	}
+	if data.ResourceLogs == nil {
+		return nil, errors.New("missing resourceLogs")
+	}
+	var results []*parsers.Result
+	for i := range data.ResourceLogs {
+		resourceLogs := &data.ResourceLogs[i]
+		resource, err := resourceLogs.resource()
+		if err != nil {
+			return nil, err
+		}
+		scopeLogs := resourceLogs.ScopeLogs
+		// Exporters prior to OTLP 0.19 use `instrumentationLibraryLogs`
+		if scopeLogs == nil {
+			scopeLogs = resourceLogs.InstrumentationLibraryLogs
+		}
+		for j := range scopeLogs {
+			scope, err := scopeLogs[j].scope()
+			if err != nil {
+				return nil, err
+			}
+			for k := range scopeLogs[j].LogRecords {
+				event, err := scopeLogs[j].LogRecords[k].logRecord()
+				if err != nil {
+					return nil, err
+				}
+				event.Resource = resource
+				event.Scope = scope
+				if err := pantherlog.ValidateStruct(event); err != nil {
+					return nil, err
+				}
+				result, err := p.builder.BuildResult(TypeLogs, event)
+				if err != nil {
+					return nil, err
+				}
+				results = append(results, result)
+			}
+		}
+	}
+	return results, nil
+}
+
+// logsData is the OTLP JSON encoding of a LogsData message.
+// See https://github.com/open-telemetry/opentelemetry-proto/blob/main/opentelemetry/proto/logs/v1/logs.proto
+type logsData struct {
+	ResourceLogs []resourceLogs `json:"resourceLogs"`
+}
+
+type resourceLogs struct {
+	Resource *struct {
+		Attributes             []keyValue `json:"attributes"`
+		DroppedAttributesCount uint32     `json:"droppedAttributesCount"`
+	} `json:"resource"`
+	ScopeLogs                  []scopeLogs `json:"scopeLogs"`
+	InstrumentationLibraryLogs []scopeLogs `json:"instrumentationLibraryLogs"`
+	SchemaURL                  string      `json:"schemaUrl"`
+}
+
+func (r *resourceLogs) resource() (*Resource, error) {
+	resource := Resource{
+		SchemaURL: nonEmpty(r.SchemaURL),
+	}
+	if r.Resource != nil {
+		attrs, err := attributesJSON(r.Resource.Attributes)
+		if err != nil {
+			return nil, err
+		}
+		resource.Attributes = attrs
+		resource.DroppedAttributesCount = nonZero(r.Resource.DroppedAttributesCount)
+	}
+	return &resource, nil
+}
+
+type instrumentationScope struct {
+	Name                   string     `json:"name"`
+	Version                string     `json:"version"`
+	Attributes             []keyValue `json:"attributes"`
+	DroppedAttributesCount uint32     `json:"droppedAttributesCount"`
+}
+
+type scopeLogs struct {
+	Scope *instrumentationScope `json:"scope"`
+	// Exporters prior to OTLP 0.19 use `instrumentationLibrary`
+	InstrumentationLibrary *instrumentationScope `json:"instrumentationLibrary"`
+	LogRecords             []logRecord           `json:"logRecords"`
+	SchemaURL              string                `json:"schemaUrl"`
+}
+
+func (s *scopeLogs) scope() (*Scope, error) {
+	scope := Scope{
+		SchemaURL: nonEmpty(s.SchemaURL),
+	}
+	src := s.Scope
+	if src == nil {
+		src = s.InstrumentationLibrary
+	}
+	if src != nil {
+		attrs, err := attributesJSON(src.Attributes)
+		if err != nil {
+			return nil, err
+		}
+		scope.Name = nonEmpty(src.Name)
+		scope.Version = nonEmpty(src.Version)
+		scope.Attributes = attrs
+		scope.DroppedAttributesCount = nonZero(src.DroppedAttributesCount)
+	}
+	return &scope, nil
+}
+
+// logRecord is the OTLP JSON encoding of a LogRecord message.
+// 64-bit integers are encoded as strings by the protobuf JSON mapping but numbers are also accepted.
+type logRecord struct {
+	TimeUnixNano           jsoniter.RawMessage `json:"timeUnixNano"`
+	ObservedTimeUnixNano   jsoniter.RawMessage `json:"observedTimeUnixNano"`
+	SeverityNumber         int32               `json:"severityNumber"`
+	SeverityText           string              `json:"severityText"`
+	Body                   *anyValue           `json:"body"`
+	Attributes             []keyValue          `json:"attributes"`
+	DroppedAttributesCount uint32              `json:"droppedAttributesCount"`
+	Flags                  uint32              `json:"flags"`
+	TraceID                string              `json:"traceId"`
+	SpanID                 string              `json:"spanId"`
+}
+
+func (r *logRecord) logRecord() (*LogRecord, error) {
+	tm, err := parseUnixNano(r.TimeUnixNano)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid timeUnixNano")
+	}
+	observed, err := parseUnixNano(r.ObservedTimeUnixNano)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid observedTimeUnixNano")
+	}
+	if tm.IsZero() {
+		tm = observed
+	}
+	attrs, err := attributesJSON(r.Attributes)
+	if err != nil {
+		return nil, err
+	}
+	event := LogRecord{
+		Time:                   tm,
+		ObservedTime:           observed,
+		SeverityText:           nonEmpty(r.SeverityText),
+		Attributes:             attrs,
+		DroppedAttributesCount: nonZero(r.DroppedAttributesCount),
+		Flags:                  nonZero(r.Flags),
+		TraceID:                nonEmpty(r.TraceID),
+		SpanID:                 nonEmpty(r.SpanID),
+	}
+	// SEVERITY_NUMBER_UNSPECIFIED is the default value
+	if r.SeverityNumber != 0 {
+		event.SeverityNumber = null.FromInt32(r.SeverityNumber)
+	}
+	if r.Body != nil {
+		body, err := jsonAPI.Marshal(r.Body.value())
+		if err != nil {
+			return nil, err
+		}
+		event.Body = body
+	}
+	return &event, nil
+}
+
+// parseUnixNano parses a timestamp in nanoseconds since the epoch encoded as a JSON string or number.
+// A zero or missing value means the timestamp is not set.
+func parseUnixNano(raw jsoniter.RawMessage) (time.Time, error) {
+	s := string(raw)
+	if s == "" || s == "null" {
+		return time.Time{}, nil
+	}
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if n == 0 {
+		return time.Time{}, nil
+	}
+	return time.Unix(0, n).UTC(), nil
+}
+
+// The protobuf JSON mapping omits fields set to their default value so we treat default values as unset
+func nonEmpty(s string) pantherlog.String {
+	if s == "" {
+		return pantherlog.String{}
+	}
+	return null.FromString(s)
+}
+
+func nonZero(n uint32) pantherlog.Int64 {
+	if n == 0 {
+		return pantherlog.Int64{}
+	}
+	return null.FromInt64(int64(n))
+}
@@ -0,0 +1,48 @@
+// Package opentelemetrylogs parses OpenTelemetry logs exported in OTLP JSON format
+package opentelemetrylogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+const (
+	TypeLogs = "OpenTelemetry.Logs"
+)
+
+// LogTypes exports the available log type entries
+func LogTypes() logtypes.Group {
+	return logTypes
+}
+
+// nolint:lll
+var logTypes = logtypes.Must("OpenTelemetry",
+	logtypes.Config{
+		Name:         TypeLogs,
+		Description:  `OpenTelemetry log records exported in OTLP JSON format (ie by the file or S3 exporters of the OpenTelemetry Collector). Each log record is stored along with its resource and instrumentation scope.`,
+		ReferenceURL: `https://opentelemetry.io/docs/specs/otlp/#json-protobuf-encoding`,
+		Schema:       pantherlog.MustBuildEventSchema(&LogRecord{}),
+		NewParser: parsers.FactoryFunc(func(_ interface{}) (parsers.Interface, error) {
+			return &LogsParser{}, nil
+		}),
+	},
+)
@@ -0,0 +1,99 @@
+package opentelemetrylogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes/logtesting"
+)
+
+func TestLogsParser(t *testing.T) {
+	// nolint:lll
+	log := `{"resourceLogs":[{"resource":{"attributes":[{"key":"service.name","value":{"stringValue":"checkout"}},{"key":"host.name","value":{"stringValue":"ip-10-0-1-15"}}]},"scopeLogs":[{"scope":{"name":"io.opentelemetry.slf4j","version":"1.0.0"},"logRecords":[{"timeUnixNano":"1604584282118000000","observedTimeUnixNano":"1604584282120000000","severityNumber":9,"severityText":"INFO","body":{"stringValue":"order placed"},"attributes":[{"key":"order.id","value":{"intValue":"42"}},{"key":"order.total","value":{"doubleValue":19.5}},{"key":"order.gift","value":{"boolValue":false}},{"key":"order.items","value":{"arrayValue":{"values":[{"stringValue":"sku-1"},{"stringValue":"sku-2"}]}}}],"flags":1,"traceId":"5b8efff798038103d269b633813fc60c","spanId":"eee19b7ec3c1b174"},{"observedTimeUnixNano":1604584283000000000,"severityNumber":17,"severityText":"ERROR","body":{"kvlistValue":{"values":[{"key":"error","value":{"stringValue":"payment declined"}},{"key":"code","value":{"intValue":402}}]}}}],"schemaUrl":"https://opentelemetry.io/schemas/1.21.0"}]}]}`
+	expect := []string{
+		`{
+			"time": "2020-11-05T13:51:22.118Z",
+			"observedTime": "2020-11-05T13:51:22.12Z",
+			"severityNumber": 9,
+			"severityText": "INFO",
+			"body": "order placed",
+			"attributes": {"order.gift":false,"order.id":42,"order.items":["sku-1","sku-2"],"order.total":19.5},
+			"flags": 1,
+			"traceId": "5b8efff798038103d269b633813fc60c",
+			"spanId": "eee19b7ec3c1b174",
+			"resource": {
+				"attributes": {"host.name":"ip-10-0-1-15","service.name":"checkout"}
+			},
+			"scope": {
+				"name": "io.opentelemetry.slf4j",
+				"version": "1.0.0",
+				"schemaUrl": "https://opentelemetry.io/schemas/1.21.0"
+			},
+			"p_log_type": "OpenTelemetry.Logs",
+			"p_event_time": "2020-11-05T13:51:22.118Z",
+			"p_any_trace_ids": ["5b8efff798038103d269b633813fc60c", "eee19b7ec3c1b174"]
+		}`,
+		`{
+			"time": "2020-11-05T13:51:23Z",
+			"observedTime": "2020-11-05T13:51:23Z",
+			"severityNumber": 17,
+			"severityText": "ERROR",
+			"body": {"code":402,"error":"payment declined"},
+			"resource": {
+				"attributes": {"host.name":"ip-10-0-1-15","service.name":"checkout"}
+			},
+			"scope": {
+				"name": "io.opentelemetry.slf4j",
+				"version": "1.0.0",
+				"schemaUrl": "https://opentelemetry.io/schemas/1.21.0"
+			},
+			"p_log_type": "OpenTelemetry.Logs",
+			"p_event_time": "2020-11-05T13:51:23Z"
+		}`,
+	}
+	logtesting.TestRegisteredParser(t, LogTypes(), TypeLogs, log, expect...)
+}
+
+func TestLogsParserInstrumentationLibrary(t *testing.T) {
+	// nolint:lll
+	log := `{"resourceLogs":[{"instrumentationLibraryLogs":[{"instrumentationLibrary":{"name":"legacy"},"logRecords":[{"timeUnixNano":"1604584282000000000","body":{"stringValue":"hello"}}]}]}]}`
+	expect := `{
+		"time": "2020-11-05T13:51:22Z",
+		"body": "hello",
+		"resource": {},
+		"scope": {"name": "legacy"},
+		"p_log_type": "OpenTelemetry.Logs",
+		"p_event_time": "2020-11-05T13:51:22Z"
+	}`
+	logtesting.TestRegisteredParser(t, LogTypes(), TypeLogs, log, expect)
+}
+
+func TestLogsParserRejectsOtherJSON(t *testing.T) {
+	for _, log := range []string{
+		`{"resourceSpans":[]}`,
+		`{"resourceLogs":[{"scopeLogs":[{"logRecords":[{"body":{"stringValue":"no time"}}]}]}]}`,
+		`{"resourceLogs":[{"scopeLogs":[{"logRecords":[{"timeUnixNano":"abc"}]}]}]}`,
+	} {
+		_, err := (&LogsParser{}).ParseLog(log)
+		require.Error(t, err, log)
+	}
+}
@@ -0,0 +1,244 @@
+package paloaltologs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/csvstream"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/sysloglogs"
+)
+
+// Common holds the columns shared by all PAN-OS log types
+// nolint:lll
+type Common struct {
+	ReceiveTime           pantherlog.Time    `json:"receiveTime" tcodec:"rfc3339" description:"Time the log was received at the management plane."`
+	SerialNumber          pantherlog.String  `json:"serialNumber" description:"Serial number of the firewall that generated the log."`
+	Type                  pantherlog.String  `json:"type" validate:"required" description:"Type of log (TRAFFIC, THREAT, SYSTEM, CONFIG)."`
+	Subtype               pantherlog.String  `json:"subtype" description:"Subtype of the log."`
+	GeneratedTime         pantherlog.Time    `json:"generatedTime" tcodec:"rfc3339" event_time:"true" validate:"required" description:"Time the log was generated on the dataplane."`
+	SequenceNumber        pantherlog.Int64   `json:"sequenceNumber" description:"A 64-bit log entry identifier incremented sequentially; each log type has a unique number space."`
+	ActionFlags           pantherlog.String  `json:"actionFlags" description:"A bit field indicating if the log was forwarded to Panorama."`
+	DeviceGroupHierarchy1 pantherlog.String  `json:"deviceGroupHierarchyLevel1" description:"The first level of the device group hierarchy of the firewall."`
+	DeviceGroupHierarchy2 pantherlog.String  `json:"deviceGroupHierarchyLevel2" description:"The second level of the device group hierarchy of the firewall."`
+	DeviceGroupHierarchy3 pantherlog.String  `json:"deviceGroupHierarchyLevel3" description:"The third level of the device group hierarchy of the firewall."`
+	DeviceGroupHierarchy4 pantherlog.String  `json:"deviceGroupHierarchyLevel4" description:"The fourth level of the device group hierarchy of the firewall."`
+	VirtualSystemName     pantherlog.String  `json:"virtualSystemName" description:"The name of the virtual system associated with the session."`
+	DeviceName            pantherlog.String  `json:"deviceName" panther:"hostname" description:"The hostname of the firewall on which the session was logged."`
+	Syslog                *sysloglogs.Header `json:"syslog" description:"The header of the syslog message carrying the log."`
+}
+
+// Column indexes of the header shared by all log types
+const (
+	colReceiveTime   = 1
+	colSerialNumber  = 2
+	colType          = 3
+	colSubtype       = 4
+	colGeneratedTime = 6
+	numHeaderColumns = colGeneratedTime + 1
+)
+
+// setHeader sets the columns shared by all log types
+func (c *Common) setHeader(r *row) {
+	c.ReceiveTime = r.Time(colReceiveTime)
+	c.SerialNumber = r.String(colSerialNumber)
+	c.Type = r.String(colType)
+	c.Subtype = r.String(colSubtype)
+	c.GeneratedTime = r.Time(colGeneratedTime)
+}
+
+// setDevice sets the sequence number, action flags and device columns.
+// The position of these columns depends on the log type.
+func (c *Common) setDevice(r *row, colSequenceNumber, colDeviceGroup int) {
+	c.SequenceNumber = r.Int64(colSequenceNumber)
+	c.ActionFlags = r.String(colSequenceNumber + 1)
+	c.DeviceGroupHierarchy1 = r.String(colDeviceGroup)
+	c.DeviceGroupHierarchy2 = r.String(colDeviceGroup + 1)
+	c.DeviceGroupHierarchy3 = r.String(colDeviceGroup + 2)
+	c.DeviceGroupHierarchy4 = r.String(colDeviceGroup + 3)
+	c.VirtualSystemName = r.String(colDeviceGroup + 4)
+	c.DeviceName = r.String(colDeviceGroup + 5)
+}
+
+// row reads typed values from the columns of a log.
+// Columns added by newer PAN-OS versions are missing from logs of older versions and are read as null values.
+// The first error while reading a value is kept in `err`.
+type row struct {
+	cols []string
+	err  error
+}
+
+// PAN-OS timestamps are in the timezone of the firewall, we read them as UTC
+const layoutTime = "2006/01/02 15:04:05"
+
+func (r *row) value(i int) string {
+	if 0 <= i && i < len(r.cols) {
+		return r.cols[i]
+	}
+	return ""
+}
+
+func (r *row) fail(i int, err error) {
+	if r.err == nil {
+		r.err = errors.Wrapf(err, "invalid value in column %d", i)
+	}
+}
+
+func (r *row) String(i int) pantherlog.String {
+	if s := r.value(i); s != "" {
+		return null.FromString(s)
+	}
+	return pantherlog.String{}
+}
+
+func (r *row) Int64(i int) pantherlog.Int64 {
+	s := r.value(i)
+	if s == "" {
+		return pantherlog.Int64{}
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		r.fail(i, err)
+		return pantherlog.Int64{}
+	}
+	return null.FromInt64(n)
+}
+
+func (r *row) Uint16(i int) pantherlog.Uint16 {
+	s := r.value(i)
+	if s == "" {
+		return pantherlog.Uint16{}
+	}
+	n, err := strconv.ParseUint(s, 10, 16)
+	if err != nil {
+		r.fail(i, err)
+		return pantherlog.Uint16{}
+	}
+	return null.FromUint16(uint16(n))
+}
+
+func (r *row) Time(i int) time.Time {
+	s := r.value(i)
+	if s == "" {
+		return time.Time{}
+	}
+	tm, err := time.Parse(layoutTime, s)
+	if err != nil {
+		r.fail(i, err)
+		return time.Time{}
+	}
+	return tm
+}
+
+// decodeFunc decodes the event of a log type from the columns of a log.
+type decodeFunc func(r *row) (interface{}, *Common, error)
+
+// Parser parses PAN-OS logs of a specific log type
+type Parser struct {
+	logType string
+	decode  decodeFunc
+	reader  *csvstream.StreamingCSVReader
+	builder pantherlog.ResultBuilder
+}
+
+var _ parsers.Interface = (*Parser)(nil)
+
+// NewParser creates a parser for a PAN-OS log type
+func NewParser(logType string, decode decodeFunc) *Parser {
+	reader := csvstream.NewStreamingCSVReader()
+	// non-default settings
+	reader.CVSReader.LazyQuotes = true
+	return &Parser{
+		logType: logType,
+		decode:  decode,
+		reader:  reader,
+	}
+}
+
+// ParseLog implements parsers.Interface
+func (p *Parser) ParseLog(log string) ([]*parsers.Result, error) {
+	header, msg, err := splitSyslog(log)
+	if err != nil {
+		return nil, err
+	}
+	cols, err := p.reader.Parse(msg)
+	if err != nil {
+		return nil, err
+	}
+	if len(cols) < numHeaderColumns {
+		return nil, errors.New("invalid number of columns")
+	}
+	r := row{
+		cols: cols,
+	}
+	event, common, err := p.decode(&r)
+	if err != nil {
+		return nil, err
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	common.Syslog = header
+	if err := pantherlog.ValidateStruct(event); err != nil {
+		return nil, err
+	}
+	result, err := p.builder.BuildResult(p.logType, event)
+	if err != nil {
+		return nil, err
+	}
+	return []*parsers.Result{result}, nil
+}
+
+// splitSyslog splits the syslog header from the CSV message of a log.
+// The first column of PAN-OS logs is a number without spaces, so the message starts after the last space before the first comma.
+// Logs without a syslog header (ie exported from the web interface) are also accepted.
+func splitSyslog(log string) (*sysloglogs.Header, string, error) {
+	log = strings.TrimSpace(log)
+	comma := strings.IndexByte(log, ',')
+	if comma == -1 {
+		return nil, "", errors.New("invalid PAN-OS log")
+	}
+	pos := strings.LastIndexByte(log[:comma], ' ')
+	if pos == -1 {
+		return nil, log, nil
+	}
+	header, err := sysloglogs.ParseHeader(log[:pos])
+	if err != nil {
+		return nil, "", err
+	}
+	return header, log[pos+1:], nil
+}
+
+// scanURL scans URLs that are logged without a scheme (ie www.example.com/index.html)
+func scanURL(w pantherlog.ValueWriter, u string) {
+	if u == "" {
+		return
+	}
+	if !strings.Contains(u, "://") {
+		u = "http://" + u
+	}
+	pantherlog.ScanURL(w, u)
+}
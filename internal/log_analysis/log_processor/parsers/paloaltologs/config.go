@@ -0,0 +1,65 @@
+package paloaltologs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+)
+
+// Config is a PAN-OS config log
+// nolint:lll
+type Config struct {
+	Common
+	Host               pantherlog.String `json:"host" panther:"hostname" description:"Hostname or IP address of the client machine."`
+	VirtualSystem      pantherlog.String `json:"virtualSystem" description:"Virtual System associated with the configuration log."`
+	Command            pantherlog.String `json:"command" description:"Command performed by the Admin (add, clone, commit, delete, edit, move, rename, set)."`
+	Admin              pantherlog.String `json:"admin" panther:"username" description:"Username of the Administrator performing the configuration."`
+	Client             pantherlog.String `json:"client" description:"Client used by the Administrator (Web, CLI)."`
+	Result             pantherlog.String `json:"result" description:"Result of the configuration action (Submitted, Succeeded, Failed, Unauthorized)."`
+	ConfigurationPath  pantherlog.String `json:"configurationPath" description:"The path of the configuration command issued."`
+	BeforeChangeDetail pantherlog.String `json:"beforeChangeDetail" description:"The full xpath before the configuration change (PAN-OS 9.0 or later)."`
+	AfterChangeDetail  pantherlog.String `json:"afterChangeDetail" description:"The full xpath after the configuration change (PAN-OS 9.0 or later)."`
+}
+
+func decodeConfig(r *row) (interface{}, *Common, error) {
+	if err := checkType(r, "CONFIG"); err != nil {
+		return nil, nil, err
+	}
+	event := Config{}
+	event.setHeader(r)
+	event.Host = r.String(7)
+	event.VirtualSystem = r.String(8)
+	event.Command = r.String(9)
+	event.Admin = r.String(10)
+	event.Client = r.String(11)
+	event.Result = r.String(12)
+	event.ConfigurationPath = r.String(13)
+	// PAN-OS 9.0 added the before and after change detail columns before the sequence number.
+	// Older versions have the action flags (ie 0x0) right after the sequence number.
+	if strings.HasPrefix(r.value(15), "0x") {
+		event.setDevice(r, 14, 16)
+	} else {
+		event.BeforeChangeDetail = r.String(14)
+		event.AfterChangeDetail = r.String(15)
+		event.setDevice(r, 16, 18)
+	}
+	return &event, &event.Common, nil
+}
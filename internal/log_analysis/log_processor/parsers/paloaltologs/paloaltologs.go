@@ -0,0 +1,104 @@
+// Package paloaltologs parses Palo Alto Networks PAN-OS firewall logs forwarded as syslog CSV messages
+package paloaltologs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+const (
+	TypeTraffic = "PaloAlto.Traffic"
+	TypeThreat  = "PaloAlto.Threat"
+	TypeURL     = "PaloAlto.URL"
+	TypeSystem  = "PaloAlto.System"
+	TypeConfig  = "PaloAlto.Config"
+)
+
+// LogTypes exports the available log type entries
+func LogTypes() logtypes.Group {
+	return logTypes
+}
+
+func newFactory(logType string, decode decodeFunc) parsers.Factory {
+	return parsers.FactoryFunc(func(_ interface{}) (parsers.Interface, error) {
+		return NewParser(logType, decode), nil
+	})
+}
+
+// nolint:lll
+var logTypes = logtypes.Must("PaloAlto",
+	logtypes.Config{
+		Name:         TypeTraffic,
+		Description:  `PAN-OS traffic logs with the start and end of sessions.`,
+		ReferenceURL: `https://docs.paloaltonetworks.com/pan-os/10-0/pan-os-admin/monitoring/use-syslog-for-monitoring/syslog-field-descriptions/traffic-log-fields.html`,
+		Schema: pantherlog.MustBuildEventSchema(&Traffic{},
+			pantherlog.FieldIPAddress,
+			pantherlog.FieldDomainName,
+			pantherlog.FieldUsername,
+		),
+		NewParser: newFactory(TypeTraffic, decodeTraffic),
+	},
+	logtypes.Config{
+		Name:         TypeThreat,
+		Description:  `PAN-OS threat logs (virus, spyware, vulnerability, file, wildfire, ...) excluding URL filtering logs.`,
+		ReferenceURL: `https://docs.paloaltonetworks.com/pan-os/10-0/pan-os-admin/monitoring/use-syslog-for-monitoring/syslog-field-descriptions/threat-log-fields.html`,
+		Schema: pantherlog.MustBuildEventSchema(&Threat{},
+			pantherlog.FieldIPAddress,
+			pantherlog.FieldDomainName,
+			pantherlog.FieldSHA256Hash,
+			pantherlog.FieldUsername,
+		),
+		NewParser: newFactory(TypeThreat, decodeThreat),
+	},
+	logtypes.Config{
+		Name:         TypeURL,
+		Description:  `PAN-OS URL filtering logs (threat logs with the url subtype).`,
+		ReferenceURL: `https://docs.paloaltonetworks.com/pan-os/10-0/pan-os-admin/monitoring/use-syslog-for-monitoring/syslog-field-descriptions/url-filtering-log-fields.html`,
+		Schema: pantherlog.MustBuildEventSchema(&URL{},
+			pantherlog.FieldIPAddress,
+			pantherlog.FieldDomainName,
+			pantherlog.FieldUsername,
+		),
+		NewParser: newFactory(TypeURL, decodeURL),
+	},
+	logtypes.Config{
+		Name:         TypeSystem,
+		Description:  `PAN-OS system logs with events of the firewall itself.`,
+		ReferenceURL: `https://docs.paloaltonetworks.com/pan-os/10-0/pan-os-admin/monitoring/use-syslog-for-monitoring/syslog-field-descriptions/system-log-fields.html`,
+		Schema: pantherlog.MustBuildEventSchema(&System{},
+			pantherlog.FieldIPAddress,
+			pantherlog.FieldDomainName,
+		),
+		NewParser: newFactory(TypeSystem, decodeSystem),
+	},
+	logtypes.Config{
+		Name:         TypeConfig,
+		Description:  `PAN-OS config logs with configuration changes.`,
+		ReferenceURL: `https://docs.paloaltonetworks.com/pan-os/10-0/pan-os-admin/monitoring/use-syslog-for-monitoring/syslog-field-descriptions/config-log-fields.html`,
+		Schema: pantherlog.MustBuildEventSchema(&Config{},
+			pantherlog.FieldIPAddress,
+			pantherlog.FieldDomainName,
+			pantherlog.FieldUsername,
+		),
+		NewParser: newFactory(TypeConfig, decodeConfig),
+	},
+)
@@ -0,0 +1,56 @@
+package paloaltologs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes/logtesting"
+)
+
+func TestPaloAltoLogs(t *testing.T) {
+	logtesting.RunTestsFromYAML(t, LogTypes(), "./testdata/paloalto_tests.yml")
+}
+
+func TestSyslogBSD(t *testing.T) {
+	log := `<14>Oct 12 09:21:04 fw01 1,2020/10/12 09:24:04,001801000001,SYSTEM,auth,0,2020/10/12 09:24:03,,auth-fail,,0,0,general,medium,"failed authentication",6917529027641081860,0x0,0,0,0,0,,fw01`
+	p := NewParser(TypeSystem, decodeSystem)
+	results, err := p.ParseLog(log)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	event := results[0].Event.(*System)
+	require.NotNil(t, event.Syslog)
+	require.Equal(t, "fw01", event.Syslog.Hostname.Value)
+	require.Equal(t, "auth-fail", event.EventID.Value)
+}
+
+func TestRejectSubtype(t *testing.T) {
+	lines := []string{
+		`1,2020/10/12 09:23:04,001801000001,THREAT,url,2049,2020/10/12 09:23:03,10.0.0.10,93.184.216.34`,
+		`1,2020/10/12 09:22:04,001801000001,THREAT,virus,2049,2020/10/12 09:22:03,93.184.216.34,10.0.0.10`,
+	}
+	_, err := NewParser(TypeThreat, decodeThreat).ParseLog(lines[0])
+	require.Error(t, err)
+	_, err = NewParser(TypeURL, decodeURL).ParseLog(lines[1])
+	require.Error(t, err)
+	_, err = NewParser(TypeTraffic, decodeTraffic).ParseLog(lines[1])
+	require.Error(t, err)
+}
@@ -0,0 +1,91 @@
+package paloaltologs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+)
+
+// Session holds the session columns shared by traffic, threat and URL logs
+// nolint:lll
+type Session struct {
+	SourceAddress      pantherlog.String `json:"sourceAddress" panther:"ip" description:"Original session source IP address."`
+	DestinationAddress pantherlog.String `json:"destinationAddress" panther:"ip" description:"Original session destination IP address."`
+	NATSourceIP        pantherlog.String `json:"natSourceIp" panther:"ip" description:"If Source NAT performed, the post-NAT Source IP address."`
+	NATDestinationIP   pantherlog.String `json:"natDestinationIp" panther:"ip" description:"If Destination NAT performed, the post-NAT Destination IP address."`
+	Rule               pantherlog.String `json:"rule" description:"Name of the rule that the session matched."`
+	SourceUser         pantherlog.String `json:"sourceUser" panther:"username" description:"Username of the user who initiated the session."`
+	DestinationUser    pantherlog.String `json:"destinationUser" panther:"username" description:"Username of the user to which the session was destined."`
+	Application        pantherlog.String `json:"application" description:"Application associated with the session."`
+	VirtualSystem      pantherlog.String `json:"virtualSystem" description:"Virtual System associated with the session."`
+	SourceZone         pantherlog.String `json:"sourceZone" description:"Zone the session was sourced from."`
+	DestinationZone    pantherlog.String `json:"destinationZone" description:"Zone the session was destined to."`
+	InboundInterface   pantherlog.String `json:"inboundInterface" description:"Interface that the session was sourced from."`
+	OutboundInterface  pantherlog.String `json:"outboundInterface" description:"Interface that the session was destined to."`
+	LogAction          pantherlog.String `json:"logAction" description:"Log Forwarding Profile that was applied to the session."`
+	SessionID          pantherlog.Int64  `json:"sessionId" description:"An internal numerical identifier applied to each session."`
+	RepeatCount        pantherlog.Int64  `json:"repeatCount" description:"Number of sessions with same Source IP, Destination IP, Application, and Subtype seen within 5 seconds."`
+	SourcePort         pantherlog.Uint16 `json:"sourcePort" description:"Source port utilized by the session."`
+	DestinationPort    pantherlog.Uint16 `json:"destinationPort" description:"Destination port utilized by the session."`
+	NATSourcePort      pantherlog.Uint16 `json:"natSourcePort" description:"Post-NAT source port."`
+	NATDestinationPort pantherlog.Uint16 `json:"natDestinationPort" description:"Post-NAT destination port."`
+	Flags              pantherlog.String `json:"flags" description:"32-bit field that provides details on session."`
+	Protocol           pantherlog.String `json:"protocol" description:"IP protocol associated with the session."`
+	Action             pantherlog.String `json:"action" description:"Action taken for the session."`
+}
+
+// Session columns start right after the log header
+const colSession = numHeaderColumns
+
+func (s *Session) setColumns(r *row) {
+	const c = colSession
+	s.SourceAddress = r.String(c)
+	s.DestinationAddress = r.String(c + 1)
+	s.NATSourceIP = r.String(c + 2)
+	s.NATDestinationIP = r.String(c + 3)
+	s.Rule = r.String(c + 4)
+	s.SourceUser = r.String(c + 5)
+	s.DestinationUser = r.String(c + 6)
+	s.Application = r.String(c + 7)
+	s.VirtualSystem = r.String(c + 8)
+	s.SourceZone = r.String(c + 9)
+	s.DestinationZone = r.String(c + 10)
+	s.InboundInterface = r.String(c + 11)
+	s.OutboundInterface = r.String(c + 12)
+	s.LogAction = r.String(c + 13)
+	// c + 14 is FUTURE_USE
+	s.SessionID = r.Int64(c + 15)
+	s.RepeatCount = r.Int64(c + 16)
+	s.SourcePort = r.Uint16(c + 17)
+	s.DestinationPort = r.Uint16(c + 18)
+	s.NATSourcePort = r.Uint16(c + 19)
+	s.NATDestinationPort = r.Uint16(c + 20)
+	s.Flags = r.String(c + 21)
+	s.Protocol = r.String(c + 22)
+	s.Action = r.String(c + 23)
+}
+
+func checkType(r *row, logType string) error {
+	if typ := r.value(colType); typ != logType {
+		return errors.Errorf("invalid log type %q", typ)
+	}
+	return nil
+}
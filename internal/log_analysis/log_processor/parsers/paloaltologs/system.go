@@ -0,0 +1,51 @@
+package paloaltologs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+)
+
+// System is a PAN-OS system log
+// nolint:lll
+type System struct {
+	Common
+	VirtualSystem pantherlog.String `json:"virtualSystem" description:"Virtual System associated with the event."`
+	EventID       pantherlog.String `json:"eventId" description:"Name of the event."`
+	Object        pantherlog.String `json:"object" description:"Name of the object associated with the system event."`
+	Module        pantherlog.String `json:"module" description:"This field is valid only when the value of the Subtype field is general."`
+	Severity      pantherlog.String `json:"severity" description:"Severity associated with the event (informational, low, medium, high, critical)."`
+	Description   pantherlog.String `json:"description" description:"Detailed description of the event."`
+}
+
+func decodeSystem(r *row) (interface{}, *Common, error) {
+	if err := checkType(r, "SYSTEM"); err != nil {
+		return nil, nil, err
+	}
+	event := System{}
+	event.setHeader(r)
+	event.VirtualSystem = r.String(7)
+	event.EventID = r.String(8)
+	event.Object = r.String(9)
+	event.Module = r.String(12)
+	event.Severity = r.String(13)
+	event.Description = r.String(14)
+	event.setDevice(r, 15, 17)
+	return &event, &event.Common, nil
+}
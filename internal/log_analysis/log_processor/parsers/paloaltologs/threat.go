@@ -0,0 +1,145 @@
+package paloaltologs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+)
+
+// Threat is a PAN-OS threat log
+// nolint:lll
+type Threat struct {
+	Common
+	Session
+	Miscellaneous      pantherlog.String `json:"miscellaneous" description:"The name of the file or the URL associated with the threat."`
+	ThreatID           pantherlog.String `json:"threatId" description:"Palo Alto Networks identifier for known and custom threats with a description (ie Eicar Test File(39040))."`
+	Category           pantherlog.String `json:"category" description:"For WildFire subtype, it is the verdict on the file. For other subtypes, it is the category of the URL."`
+	Severity           pantherlog.String `json:"severity" description:"Severity associated with the threat (informational, low, medium, high, critical)."`
+	Direction          pantherlog.String `json:"direction" description:"Indicates the direction of the attack, client-to-server or server-to-client."`
+	SourceCountry      pantherlog.String `json:"sourceCountry" description:"Source country or Internal region for private addresses."`
+	DestinationCountry pantherlog.String `json:"destinationCountry" description:"Destination country or Internal region for private addresses."`
+	ContentType        pantherlog.String `json:"contentType" description:"Content type of the HTTP response data."`
+	PCAPID             pantherlog.String `json:"pcapId" description:"The packet capture (pcap) ID."`
+	FileDigest         pantherlog.String `json:"fileDigest" panther:"sha256" description:"The SHA256 hash of the file submitted to WildFire."`
+	Cloud              pantherlog.String `json:"cloud" description:"The FQDN of the WildFire appliance or cloud that analyzed the file."`
+	FileType           pantherlog.String `json:"fileType" description:"The type of file submitted to WildFire."`
+	Sender             pantherlog.String `json:"sender" description:"The sender of an email with a file submitted to WildFire."`
+	Subject            pantherlog.String `json:"subject" description:"The subject of an email with a file submitted to WildFire."`
+	Recipient          pantherlog.String `json:"recipient" description:"The recipient of an email with a file submitted to WildFire."`
+	ReportID           pantherlog.String `json:"reportId" description:"The ID of the WildFire report."`
+	ThreatCategory     pantherlog.String `json:"threatCategory" description:"The category of the threat."`
+	ContentVersion     pantherlog.String `json:"contentVersion" description:"The version of the applications and threats content that generated the log."`
+	RuleUUID           pantherlog.String `json:"ruleUuid" description:"The UUID that permanently identifies the rule."`
+}
+
+func decodeThreat(r *row) (interface{}, *Common, error) {
+	if err := checkType(r, "THREAT"); err != nil {
+		return nil, nil, err
+	}
+	if r.value(colSubtype) == subtypeURL {
+		return nil, nil, errors.Errorf("URL filtering logs are parsed as %s", TypeURL)
+	}
+	event := Threat{}
+	event.setHeader(r)
+	event.setColumns(r)
+	event.Miscellaneous = r.String(31)
+	event.ThreatID = r.String(32)
+	event.Category = r.String(33)
+	event.Severity = r.String(34)
+	event.Direction = r.String(35)
+	event.setDevice(r, 36, 54)
+	event.SourceCountry = r.String(38)
+	event.DestinationCountry = r.String(39)
+	event.ContentType = r.String(41)
+	event.PCAPID = r.String(42)
+	event.FileDigest = r.String(43)
+	event.Cloud = r.String(44)
+	event.FileType = r.String(47)
+	event.Sender = r.String(50)
+	event.Subject = r.String(51)
+	event.Recipient = r.String(52)
+	event.ReportID = r.String(53)
+	event.ThreatCategory = r.String(69)
+	event.ContentVersion = r.String(70)
+	event.RuleUUID = r.String(76)
+	return &event, &event.Common, nil
+}
+
+const subtypeURL = "url"
+
+// URL is a PAN-OS URL filtering log
+// nolint:lll
+type URL struct {
+	Common
+	Session
+	URL                pantherlog.String `json:"url" description:"The URL of the request (without a scheme)."`
+	Category           pantherlog.String `json:"category" description:"The category of the URL."`
+	Severity           pantherlog.String `json:"severity" description:"Severity associated with the log."`
+	Direction          pantherlog.String `json:"direction" description:"Indicates the direction of the request, client-to-server or server-to-client."`
+	SourceCountry      pantherlog.String `json:"sourceCountry" description:"Source country or Internal region for private addresses."`
+	DestinationCountry pantherlog.String `json:"destinationCountry" description:"Destination country or Internal region for private addresses."`
+	ContentType        pantherlog.String `json:"contentType" description:"Content type of the HTTP response data."`
+	URLIndex           pantherlog.Int64  `json:"urlIndex" description:"Used in URL Filtering and WildFire subtypes to correlate URL filtering logs with threat logs."`
+	UserAgent          pantherlog.String `json:"userAgent" description:"The User Agent field specifies the web browser that the user used to access the URL."`
+	XForwardedFor      pantherlog.String `json:"xForwardedFor" panther:"ip" description:"The IP address of the user who requested the web page when the firewall is deployed behind a proxy."`
+	Referer            pantherlog.String `json:"referer" description:"The URL of the web page that linked the user to the requested web page."`
+	HTTPMethod         pantherlog.String `json:"httpMethod" description:"The HTTP Method used in the web request."`
+	HTTPHeaders        pantherlog.String `json:"httpHeaders" description:"The HTTP headers logged by the URL filtering profile."`
+	URLCategoryList    pantherlog.String `json:"urlCategoryList" description:"A list of the URL filtering categories that the firewall used to enforce policy."`
+	RuleUUID           pantherlog.String `json:"ruleUuid" description:"The UUID that permanently identifies the rule."`
+}
+
+var _ pantherlog.ValueWriterTo = (*URL)(nil)
+
+// WriteValuesTo implements pantherlog.ValueWriterTo interface
+func (event *URL) WriteValuesTo(w pantherlog.ValueWriter) {
+	scanURL(w, event.URL.Value)
+	scanURL(w, event.Referer.Value)
+}
+
+func decodeURL(r *row) (interface{}, *Common, error) {
+	if err := checkType(r, "THREAT"); err != nil {
+		return nil, nil, err
+	}
+	if subtype := r.value(colSubtype); subtype != subtypeURL {
+		return nil, nil, errors.Errorf("invalid URL filtering log subtype %q", subtype)
+	}
+	event := URL{}
+	event.setHeader(r)
+	event.setColumns(r)
+	event.URL = r.String(31)
+	event.Category = r.String(33)
+	event.Severity = r.String(34)
+	event.Direction = r.String(35)
+	event.setDevice(r, 36, 54)
+	event.SourceCountry = r.String(38)
+	event.DestinationCountry = r.String(39)
+	event.ContentType = r.String(41)
+	event.URLIndex = r.Int64(45)
+	event.UserAgent = r.String(46)
+	event.XForwardedFor = r.String(48)
+	event.Referer = r.String(49)
+	event.HTTPMethod = r.String(63)
+	event.HTTPHeaders = r.String(74)
+	event.URLCategoryList = r.String(75)
+	event.RuleUUID = r.String(76)
+	return &event, &event.Common, nil
+}
@@ -0,0 +1,83 @@
+package paloaltologs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+)
+
+// Traffic is a PAN-OS traffic log
+// nolint:lll
+type Traffic struct {
+	Common
+	Session
+	Bytes              pantherlog.Int64  `json:"bytes" description:"Number of total bytes (transmit and receive) for the session."`
+	BytesSent          pantherlog.Int64  `json:"bytesSent" description:"Number of bytes in the client-to-server direction of the session."`
+	BytesReceived      pantherlog.Int64  `json:"bytesReceived" description:"Number of bytes in the server-to-client direction of the session."`
+	Packets            pantherlog.Int64  `json:"packets" description:"Number of total packets (transmit and receive) for the session."`
+	StartTime          pantherlog.Time   `json:"startTime" tcodec:"rfc3339" description:"Time of session start."`
+	ElapsedTime        pantherlog.Int64  `json:"elapsedTime" description:"Elapsed time of the session in seconds."`
+	Category           pantherlog.String `json:"category" description:"URL category associated with the session (if applicable)."`
+	SourceCountry      pantherlog.String `json:"sourceCountry" description:"Source country or Internal region for private addresses."`
+	DestinationCountry pantherlog.String `json:"destinationCountry" description:"Destination country or Internal region for private addresses."`
+	PacketsSent        pantherlog.Int64  `json:"packetsSent" description:"Number of client-to-server packets for the session."`
+	PacketsReceived    pantherlog.Int64  `json:"packetsReceived" description:"Number of server-to-client packets for the session."`
+	SessionEndReason   pantherlog.String `json:"sessionEndReason" description:"The reason a session terminated."`
+	ActionSource       pantherlog.String `json:"actionSource" description:"Specifies whether the action taken to allow or block an application was defined in the application or policy."`
+	SourceVMUUID       pantherlog.String `json:"sourceVmUuid" description:"Identifies the source universal unique identifier for a guest virtual machine in the VMware NSX environment."`
+	DestinationVMUUID  pantherlog.String `json:"destinationVmUuid" description:"Identifies the destination universal unique identifier for a guest virtual machine in the VMware NSX environment."`
+	TunnelID           pantherlog.String `json:"tunnelId" description:"ID of the tunnel being inspected or the International Mobile Subscriber Identity (IMSI) ID of the mobile user."`
+	MonitorTag         pantherlog.String `json:"monitorTag" description:"Monitor name you configured for the Tunnel Inspection policy rule or the International Mobile Equipment Identity (IMEI) ID of the mobile device."`
+	ParentSessionID    pantherlog.Int64  `json:"parentSessionId" description:"ID of the session in which this session is tunneled."`
+	ParentStartTime    pantherlog.Time   `json:"parentStartTime" tcodec:"rfc3339" description:"Time the parent tunnel session began."`
+	TunnelType         pantherlog.String `json:"tunnelType" description:"Type of tunnel, such as GRE or IPSec."`
+	RuleUUID           pantherlog.String `json:"ruleUuid" description:"The UUID that permanently identifies the rule."`
+}
+
+func decodeTraffic(r *row) (interface{}, *Common, error) {
+	if err := checkType(r, "TRAFFIC"); err != nil {
+		return nil, nil, err
+	}
+	event := Traffic{}
+	event.setHeader(r)
+	event.setColumns(r)
+	event.Bytes = r.Int64(31)
+	event.BytesSent = r.Int64(32)
+	event.BytesReceived = r.Int64(33)
+	event.Packets = r.Int64(34)
+	event.StartTime = r.Time(35)
+	event.ElapsedTime = r.Int64(36)
+	event.Category = r.String(37)
+	event.setDevice(r, 39, 47)
+	event.SourceCountry = r.String(41)
+	event.DestinationCountry = r.String(42)
+	event.PacketsSent = r.Int64(44)
+	event.PacketsReceived = r.Int64(45)
+	event.SessionEndReason = r.String(46)
+	event.ActionSource = r.String(53)
+	event.SourceVMUUID = r.String(54)
+	event.DestinationVMUUID = r.String(55)
+	event.TunnelID = r.String(56)
+	event.MonitorTag = r.String(57)
+	event.ParentSessionID = r.Int64(58)
+	event.ParentStartTime = r.Time(59)
+	event.TunnelType = r.String(60)
+	event.RuleUUID = r.String(65)
+	return &event, &event.Common, nil
+}
@@ -56,6 +56,13 @@ type Interface interface {
 	ParseLog(log string) ([]*Result, error)
 }
 
+// Flusher is implemented by parsers that hold back log lines across calls to ParseLog,
+// such as parsers reassembling events logged over multiple lines.
+type Flusher interface {
+	// Flush returns the results of the log lines held back, it is called when the input ends.
+	Flush() ([]*Result, error)
+}
+
 // Result is the result of parsing a log event.
 // It is an alias of `pantherlog.Result` to help with the refactoring.
 type Result = pantherlog.Result
@@ -0,0 +1,119 @@
+package slacklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+)
+
+// AuditLog is an entry of the Slack Audit Logs API
+// nolint:lll
+type AuditLog struct {
+	ID         pantherlog.String     `json:"id" validate:"required" description:"The unique identifier of the audit log entry."`
+	DateCreate pantherlog.Time       `json:"date_create" tcodec:"unix" event_time:"true" validate:"required" description:"The time the action occurred."`
+	Action     pantherlog.String     `json:"action" validate:"required" description:"The action that occurred (ie user_login, file_downloaded, user_channel_join)."`
+	Actor      *Actor                `json:"actor" description:"The user that performed the action."`
+	Entity     *Entity               `json:"entity" description:"The entity the action was performed on."`
+	Context    *Context              `json:"context" description:"The location and client the action was performed from."`
+	Details    pantherlog.RawMessage `json:"details" description:"Additional details about the action, specific to each action."`
+}
+
+// Actor is the actor of an audit log entry
+type Actor struct {
+	Type pantherlog.String `json:"type" description:"The type of the actor (user)."`
+	User *User             `json:"user" description:"The user that performed the action."`
+}
+
+// Entity is the entity of an audit log entry.
+// Only the field matching the entity type is set.
+// nolint:lll
+type Entity struct {
+	Type       pantherlog.String     `json:"type" description:"The type of the entity (user, channel, file, app, workspace, enterprise, workflow, ...)."`
+	User       *User                 `json:"user" description:"The user the action was performed on."`
+	Channel    *Channel              `json:"channel" description:"The channel the action was performed on."`
+	File       *File                 `json:"file" description:"The file the action was performed on."`
+	App        *App                  `json:"app" description:"The app the action was performed on."`
+	Workspace  *Workspace            `json:"workspace" description:"The workspace the action was performed on."`
+	Enterprise *Workspace            `json:"enterprise" description:"The enterprise organization the action was performed on."`
+	Workflow   pantherlog.RawMessage `json:"workflow" description:"The workflow the action was performed on."`
+	Message    pantherlog.RawMessage `json:"message" description:"The message the action was performed on."`
+}
+
+// User is a Slack user
+// nolint:lll
+type User struct {
+	ID    pantherlog.String `json:"id" description:"The ID of the user."`
+	Name  pantherlog.String `json:"name" panther:"username" description:"The name of the user."`
+	Email pantherlog.String `json:"email" panther:"username" description:"The email address of the user."`
+	Team  pantherlog.String `json:"team" description:"The ID of the workspace of the user."`
+}
+
+// Channel is a Slack channel
+// nolint:lll
+type Channel struct {
+	ID              pantherlog.String `json:"id" description:"The ID of the channel."`
+	Name            pantherlog.String `json:"name" description:"The name of the channel."`
+	Privacy         pantherlog.String `json:"privacy" description:"The privacy of the channel (public, private, im, mpim)."`
+	IsShared        pantherlog.Bool   `json:"is_shared" description:"Whether the channel is shared with another organization."`
+	IsOrgShared     pantherlog.Bool   `json:"is_org_shared" description:"Whether the channel is shared with multiple workspaces of the organization."`
+	TeamsSharedWith []string          `json:"teams_shared_with" description:"The IDs of the workspaces the channel is shared with."`
+}
+
+// File is a Slack file
+type File struct {
+	ID       pantherlog.String `json:"id" description:"The ID of the file."`
+	Name     pantherlog.String `json:"name" description:"The name of the file."`
+	Filetype pantherlog.String `json:"filetype" description:"The type of the file."`
+	Title    pantherlog.String `json:"title" description:"The title of the file."`
+}
+
+// App is a Slack app
+// nolint:lll
+type App struct {
+	ID                  pantherlog.String `json:"id" description:"The ID of the app."`
+	Name                pantherlog.String `json:"name" description:"The name of the app."`
+	IsDistributed       pantherlog.Bool   `json:"is_distributed" description:"Whether the app is available in the Slack App Directory."`
+	IsDirectoryApproved pantherlog.Bool   `json:"is_directory_approved" description:"Whether the app is approved in the Slack App Directory."`
+	IsWorkflowApp       pantherlog.Bool   `json:"is_workflow_app" description:"Whether the app is a workflow app."`
+	Scopes              []string          `json:"scopes" description:"The OAuth scopes of the app."`
+}
+
+// Workspace is a Slack workspace or enterprise organization
+type Workspace struct {
+	ID     pantherlog.String `json:"id" description:"The ID of the workspace."`
+	Name   pantherlog.String `json:"name" description:"The name of the workspace."`
+	Domain pantherlog.String `json:"domain" description:"The Slack subdomain of the workspace."`
+}
+
+// Context is the context of an audit log entry
+// nolint:lll
+type Context struct {
+	Location  *Location         `json:"location" description:"The workspace or enterprise organization the action occurred in."`
+	UserAgent pantherlog.String `json:"ua" description:"The user agent of the client that performed the action."`
+	IPAddress pantherlog.String `json:"ip_address" panther:"ip" description:"The IP address the action was performed from."`
+	SessionID pantherlog.Int64  `json:"session_id" description:"The ID of the session of the user that performed the action."`
+}
+
+// Location is the workspace or enterprise organization an action occurred in
+type Location struct {
+	Type   pantherlog.String `json:"type" description:"The type of the location (workspace, enterprise)."`
+	ID     pantherlog.String `json:"id" description:"The ID of the location."`
+	Name   pantherlog.String `json:"name" description:"The name of the location."`
+	Domain pantherlog.String `json:"domain" description:"The Slack subdomain of the location."`
+}
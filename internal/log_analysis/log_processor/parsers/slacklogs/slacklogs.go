@@ -0,0 +1,51 @@
+// Package slacklogs parses Slack Enterprise Grid audit logs
+package slacklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+const (
+	TypeAuditLogs = "Slack.AuditLogs"
+)
+
+// LogTypes exports the available log type entries
+func LogTypes() logtypes.Group {
+	return logTypes
+}
+
+// nolint:lll
+var logTypes = logtypes.Must("Slack",
+	logtypes.Config{
+		Name:         TypeAuditLogs,
+		Description:  `Slack Enterprise Grid audit log entries from the Audit Logs API.`,
+		ReferenceURL: `https://api.slack.com/admins/audit-logs`,
+		Schema:       pantherlog.MustBuildEventSchema(&AuditLog{}),
+		NewParser: &parsers.JSONParserFactory{
+			LogType: TypeAuditLogs,
+			NewEvent: func() interface{} {
+				return &AuditLog{}
+			},
+		},
+	},
+)
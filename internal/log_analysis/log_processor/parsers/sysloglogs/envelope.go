@@ -0,0 +1,133 @@
+package sysloglogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/influxdata/go-syslog/v3"
+	"github.com/influxdata/go-syslog/v3/rfc3164"
+	"github.com/influxdata/go-syslog/v3/rfc5424"
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+)
+
+// Header is the header of a syslog message that carries a log in another format (ie CEF, LEEF).
+// Parsers of such formats can embed it in their events to keep the envelope information.
+// nolint:lll
+type Header struct {
+	Priority  pantherlog.Uint8  `json:"priority" description:"Priority is calculated by (Facility * 8 + Severity). The lower this value, the higher importance of the log message."`
+	Facility  pantherlog.Uint8  `json:"facility" description:"Facility value helps determine which process created the message. Eg: 0 = kernel messages, 3 = system daemons."`
+	Severity  pantherlog.Uint8  `json:"severity" description:"Severity indicates how severe the message is. Eg: 0=Emergency to 7=Debug."`
+	Timestamp pantherlog.Time   `json:"timestamp" tcodec:"rfc3339" event_time:"true" description:"Timestamp of the syslog message in UTC."`
+	Hostname  pantherlog.String `json:"hostname" panther:"hostname" description:"Hostname identifies the machine that originally sent the syslog message."`
+	Appname   pantherlog.String `json:"appname" description:"Appname identifies the device or application that originated the syslog message."`
+	ProcID    pantherlog.String `json:"procid" description:"ProcID is often the process ID, but can be any value used to enable log analyzers to detect discontinuities in syslog reporting."`
+	MsgID     pantherlog.String `json:"msgid" description:"MsgID identifies the type of message."`
+}
+
+// SplitMessage looks for a message starting with `prefix` in a log line.
+// It returns the syslog header preceding the message or nil if the log line starts with `prefix`.
+func SplitMessage(log, prefix string) (*Header, string, error) {
+	pos := strings.Index(log, prefix)
+	if pos == -1 {
+		return nil, "", errors.Errorf("no %q message found", prefix)
+	}
+	if pos == 0 {
+		return nil, log, nil
+	}
+	header, err := ParseHeader(log[:pos])
+	if err != nil {
+		return nil, "", err
+	}
+	return header, log[pos:], nil
+}
+
+// ParseHeader parses the header of an RFC5424 or RFC3164 syslog message.
+// Headers without a priority value are accepted (as written to files by most syslog daemons).
+func ParseHeader(header string) (*Header, error) {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return nil, errors.New("empty syslog header")
+	}
+	hasPriority := strings.HasPrefix(header, "<")
+	if !hasPriority {
+		// Use the priority a relay assigns to messages without one (see RFC3164 section 4.3.3)
+		header = "<13>" + header
+	}
+	msg, err := parseHeader(header)
+	if err != nil {
+		return nil, err
+	}
+	if !hasPriority && msg.Timestamp == nil {
+		return nil, errors.New("invalid syslog header")
+	}
+	h := Header{
+		Hostname: fromStringPtr(msg.Hostname),
+		Appname:  fromStringPtr(msg.Appname),
+		ProcID:   fromStringPtr(msg.ProcID),
+		MsgID:    fromStringPtr(msg.MsgID),
+	}
+	if msg.Timestamp != nil {
+		h.Timestamp = msg.Timestamp.UTC()
+	}
+	if hasPriority {
+		h.Priority = fromUint8Ptr(msg.Priority)
+		h.Facility = fromUint8Ptr(msg.Facility)
+		h.Severity = fromUint8Ptr(msg.Severity)
+	}
+	return &h, nil
+}
+
+func parseHeader(header string) (*syslog.Base, error) {
+	// RFC5424 headers have a version number right after the priority
+	if pos := strings.IndexByte(header, '>'); 0 < pos && pos+2 < len(header) {
+		if v := header[pos+1]; '1' <= v && v <= '9' && header[pos+2] == ' ' {
+			p := rfc5424.NewParser(rfc5424.WithBestEffort())
+			// Add an empty message so that the parser reaches the end of the header
+			msg, err := p.Parse([]byte(header + " -"))
+			if m, ok := msg.(*rfc5424.SyslogMessage); ok && m != nil {
+				return &m.Base, nil
+			}
+			return nil, errors.Wrap(err, "invalid RFC5424 header")
+		}
+	}
+	p := rfc3164.NewParser(rfc3164.WithBestEffort(), rfc3164.WithRFC3339(), rfc3164.WithYear(rfc3164.CurrentYear{}))
+	msg, err := p.Parse([]byte(header + " "))
+	if m, ok := msg.(*rfc3164.SyslogMessage); ok && m != nil {
+		return &m.Base, nil
+	}
+	return nil, errors.Wrap(err, "invalid RFC3164 header")
+}
+
+func fromStringPtr(s *string) pantherlog.String {
+	if s == nil {
+		return pantherlog.String{}
+	}
+	return null.FromString(*s)
+}
+
+func fromUint8Ptr(n *uint8) pantherlog.Uint8 {
+	if n == nil {
+		return pantherlog.Uint8{}
+	}
+	return null.FromUint8(*n)
+}
@@ -0,0 +1,79 @@
+package sysloglogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+)
+
+func TestSplitMessage(t *testing.T) {
+	header, msg, err := SplitMessage(`<134>1 2020-10-12T09:21:04Z fw01 app 123 ID47 - CEF:0|a|b`, "CEF:")
+	require.NoError(t, err)
+	require.Equal(t, "CEF:0|a|b", msg)
+	require.Equal(t, &Header{
+		Priority:  null.FromUint8(134),
+		Facility:  null.FromUint8(16),
+		Severity:  null.FromUint8(6),
+		Timestamp: time.Date(2020, 10, 12, 9, 21, 4, 0, time.UTC),
+		Hostname:  null.FromString("fw01"),
+		Appname:   null.FromString("app"),
+		ProcID:    null.FromString("123"),
+		MsgID:     null.FromString("ID47"),
+	}, header)
+
+	header, msg, err = SplitMessage(`CEF:0|a|b`, "CEF:")
+	require.NoError(t, err)
+	require.Equal(t, "CEF:0|a|b", msg)
+	require.Nil(t, header)
+
+	_, _, err = SplitMessage(`LEEF:1.0|a|b`, "CEF:")
+	require.Error(t, err)
+	_, _, err = SplitMessage(`not a syslog header CEF:0|a|b`, "CEF:")
+	require.Error(t, err)
+}
+
+func TestParseHeader(t *testing.T) {
+	header, err := ParseHeader(`<13>2020-10-12T09:21:04Z host app[12]:`)
+	require.NoError(t, err)
+	require.Equal(t, &Header{
+		Priority:  null.FromUint8(13),
+		Facility:  null.FromUint8(1),
+		Severity:  null.FromUint8(5),
+		Timestamp: time.Date(2020, 10, 12, 9, 21, 4, 0, time.UTC),
+		Hostname:  null.FromString("host"),
+		Appname:   null.FromString("app"),
+		ProcID:    null.FromString("12"),
+	}, header)
+
+	// Syslog daemons omit the priority when writing messages to files
+	header, err = ParseHeader(`2020-10-12T09:21:04Z host`)
+	require.NoError(t, err)
+	require.Equal(t, &Header{
+		Timestamp: time.Date(2020, 10, 12, 9, 21, 4, 0, time.UTC),
+		Hostname:  null.FromString("host"),
+	}, header)
+
+	_, err = ParseHeader(" ")
+	require.Error(t, err)
+}
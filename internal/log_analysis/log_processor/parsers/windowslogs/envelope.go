@@ -0,0 +1,277 @@
+package windowslogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+)
+
+// Envelope holds the system properties common to all Windows events
+// nolint:lll
+type Envelope struct {
+	TimeCreated  pantherlog.Time   `json:"timeCreated" tcodec:"rfc3339" event_time:"true" validate:"required" description:"The time the event was logged."`
+	EventID      pantherlog.Int32  `json:"eventId" validate:"required" description:"The identifier of the event type."`
+	Channel      pantherlog.String `json:"channel" description:"The channel the event was logged to (Security, System, Microsoft-Windows-Sysmon/Operational, ...)."`
+	Provider     pantherlog.String `json:"provider" description:"The name of the provider that logged the event."`
+	ProviderGUID pantherlog.String `json:"providerGuid" description:"The GUID of the provider that logged the event."`
+	Computer     pantherlog.String `json:"computer" panther:"hostname" description:"The name of the computer on which the event occurred."`
+	RecordID     pantherlog.Int64  `json:"recordId" description:"The record number assigned to the event when it was logged."`
+	Task         pantherlog.String `json:"task" description:"The task (category) of the event."`
+	Opcode       pantherlog.String `json:"opcode" description:"The opcode of the event."`
+	Level        pantherlog.String `json:"level" description:"The level of the event (Information, Warning, Error, ...)."`
+	Keywords     []string          `json:"keywords" description:"The keywords of the event (Audit Success, Audit Failure, ...)."`
+	ProcessID    pantherlog.Int64  `json:"processId" description:"The ID of the process that logged the event."`
+	ThreadID     pantherlog.Int64  `json:"threadId" description:"The ID of the thread that logged the event."`
+	UserID       pantherlog.String `json:"userId" description:"The security identifier (SID) of the user the event was logged for."`
+	UserName     pantherlog.String `json:"userName" panther:"username" description:"The name of the user the event was logged for."`
+	UserDomain   pantherlog.String `json:"userDomain" description:"The domain of the user the event was logged for."`
+	Message      pantherlog.String `json:"message" description:"The rendered message of the event."`
+	Collector    pantherlog.String `json:"collector" description:"The agent that shipped the event (winlogbeat or nxlog)."`
+}
+
+const (
+	collectorWinlogbeat = "winlogbeat"
+	collectorNXLog      = "nxlog"
+)
+
+// Decode numbers as json.Number to keep their original text in event data values
+var jsonAPI = jsoniter.Config{
+	UseNumber:     true,
+	CaseSensitive: true,
+}.Froze()
+
+// parseEvent parses a Winlogbeat or NXLog JSON event.
+// It returns the event system properties and the event data fields as strings.
+func parseEvent(log string) (*Envelope, map[string]string, error) {
+	fields := map[string]interface{}{}
+	if err := jsonAPI.UnmarshalFromString(log, &fields); err != nil {
+		return nil, nil, err
+	}
+	if winlog, ok := fields["winlog"].(map[string]interface{}); ok {
+		return parseWinlogbeat(fields, winlog)
+	}
+	if _, ok := fields["EventID"]; ok {
+		return parseNXLog(fields)
+	}
+	return nil, nil, errors.New("unknown Windows event layout")
+}
+
+// Winlogbeat nests event properties under `winlog` (https://www.elastic.co/guide/en/beats/winlogbeat/current/exported-fields-winlog.html)
+func parseWinlogbeat(fields, winlog map[string]interface{}) (*Envelope, map[string]string, error) {
+	tm, err := time.Parse(time.RFC3339Nano, getString(fields, "@timestamp"))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "invalid winlogbeat timestamp")
+	}
+	process, _ := winlog["process"].(map[string]interface{})
+	thread, _ := process["thread"].(map[string]interface{})
+	user, _ := winlog["user"].(map[string]interface{})
+	logInfo, _ := fields["log"].(map[string]interface{})
+	env := Envelope{
+		TimeCreated:  tm.UTC(),
+		EventID:      getInt32(winlog, "event_id"),
+		Channel:      getNonEmpty(winlog, "channel"),
+		Provider:     getNonEmpty(winlog, "provider_name"),
+		ProviderGUID: getNonEmpty(winlog, "provider_guid"),
+		Computer:     getNonEmpty(winlog, "computer_name"),
+		RecordID:     getInt64(winlog, "record_id"),
+		Task:         getNonEmpty(winlog, "task"),
+		Opcode:       getNonEmpty(winlog, "opcode"),
+		Level:        getNonEmpty(logInfo, "level"),
+		ProcessID:    getInt64(process, "pid"),
+		ThreadID:     getInt64(thread, "id"),
+		UserID:       getNonEmpty(user, "identifier"),
+		UserName:     getNonEmpty(user, "name"),
+		UserDomain:   getNonEmpty(user, "domain"),
+		Message:      getNonEmpty(fields, "message"),
+		Collector:    null.FromString(collectorWinlogbeat),
+	}
+	if keywords, ok := winlog["keywords"].([]interface{}); ok {
+		for _, k := range keywords {
+			if k, ok := k.(string); ok {
+				env.Keywords = append(env.Keywords, k)
+			}
+		}
+	}
+	data := make(map[string]string)
+	for _, key := range []string{"event_data", "user_data"} {
+		values, _ := winlog[key].(map[string]interface{})
+		for key, value := range values {
+			if s, ok := stringValue(value); ok {
+				data[key] = s
+			}
+		}
+	}
+	return &env, data, nil
+}
+
+// Properties set by the NXLog im_msvistalog module (https://nxlog.co/documentation/nxlog-user-guide/im_msvistalog.html)
+// All other fields are event data.
+var nxlogFields = map[string]bool{
+	"EventTime":         true,
+	"EventReceivedTime": true,
+	"Hostname":          true,
+	"Keywords":          true,
+	"EventType":         true,
+	"SeverityValue":     true,
+	"Severity":          true,
+	"EventID":           true,
+	"SourceName":        true,
+	"ProviderGuid":      true,
+	"Version":           true,
+	"Task":              true,
+	"OpcodeValue":       true,
+	"Opcode":            true,
+	"RecordNumber":      true,
+	"ActivityID":        true,
+	"RelatedActivityID": true,
+	"ProcessID":         true,
+	"ThreadID":          true,
+	"Channel":           true,
+	"Domain":            true,
+	"AccountName":       true,
+	"UserID":            true,
+	"AccountType":       true,
+	"Message":           true,
+	"Category":          true,
+	"SourceModuleName":  true,
+	"SourceModuleType":  true,
+}
+
+// NXLog writes EventTime in the local time of the host unless configured otherwise.
+// Timestamps without a timezone are read as UTC.
+var nxlogTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+}
+
+// NXLog writes event properties and event data at the top level
+func parseNXLog(fields map[string]interface{}) (*Envelope, map[string]string, error) {
+	tm, err := parseTime(getString(fields, "EventTime"), nxlogTimeLayouts...)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "invalid NXLog event time")
+	}
+	env := Envelope{
+		TimeCreated:  tm,
+		EventID:      getInt32(fields, "EventID"),
+		Channel:      getNonEmpty(fields, "Channel"),
+		Provider:     getNonEmpty(fields, "SourceName"),
+		ProviderGUID: getNonEmpty(fields, "ProviderGuid"),
+		Computer:     getNonEmpty(fields, "Hostname"),
+		RecordID:     getInt64(fields, "RecordNumber"),
+		Task:         getNonEmpty(fields, "Category"),
+		Opcode:       getNonEmpty(fields, "Opcode"),
+		Level:        getNonEmpty(fields, "Severity"),
+		ProcessID:    getInt64(fields, "ProcessID"),
+		ThreadID:     getInt64(fields, "ThreadID"),
+		UserID:       getNonEmpty(fields, "UserID"),
+		UserName:     getNonEmpty(fields, "AccountName"),
+		UserDomain:   getNonEmpty(fields, "Domain"),
+		Message:      getNonEmpty(fields, "Message"),
+		Collector:    null.FromString(collectorNXLog),
+	}
+	// NXLog only provides the keywords as a bitmask, the audit keywords are set in EventType
+	switch getString(fields, "EventType") {
+	case "AUDIT_SUCCESS":
+		env.Keywords = []string{"Audit Success"}
+	case "AUDIT_FAILURE":
+		env.Keywords = []string{"Audit Failure"}
+	}
+	data := make(map[string]string)
+	for key, value := range fields {
+		if nxlogFields[key] {
+			continue
+		}
+		if s, ok := stringValue(value); ok {
+			data[key] = s
+		}
+	}
+	return &env, data, nil
+}
+
+func parseTime(value string, layouts ...string) (time.Time, error) {
+	for _, layout := range layouts {
+		if tm, err := time.Parse(layout, value); err == nil {
+			return tm.UTC(), nil
+		}
+	}
+	return time.Time{}, errors.Errorf("invalid timestamp %q", value)
+}
+
+func stringValue(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case nil:
+		return "", false
+	case string:
+		return v, true
+	case json.Number:
+		return v.String(), true
+	case bool:
+		return strconv.FormatBool(v), true
+	default:
+		s, err := jsonAPI.MarshalToString(v)
+		return s, err == nil
+	}
+}
+
+func getString(fields map[string]interface{}, key string) string {
+	s, _ := stringValue(fields[key])
+	return s
+}
+
+func getNonEmpty(fields map[string]interface{}, key string) pantherlog.String {
+	if s := getString(fields, key); s != "" {
+		return null.FromString(s)
+	}
+	return pantherlog.String{}
+}
+
+func getInt64(fields map[string]interface{}, key string) pantherlog.Int64 {
+	if n, err := strconv.ParseInt(getString(fields, key), 10, 64); err == nil {
+		return null.FromInt64(n)
+	}
+	return pantherlog.Int64{}
+}
+
+func getInt32(fields map[string]interface{}, key string) pantherlog.Int32 {
+	if n, err := strconv.ParseInt(getString(fields, key), 10, 32); err == nil {
+		return null.FromInt32(int32(n))
+	}
+	return pantherlog.Int32{}
+}
+
+// isEmptyValue checks for values used by Windows events for missing data
+func isEmptyValue(value string) bool {
+	switch strings.TrimSpace(value) {
+	case "", "-":
+		return true
+	default:
+		return false
+	}
+}
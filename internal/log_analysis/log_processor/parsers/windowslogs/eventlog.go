@@ -0,0 +1,91 @@
+package windowslogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+// EventLog is a Windows event with its event data
+// nolint:lll
+type EventLog struct {
+	Envelope
+	EventData map[string]string `json:"eventData" description:"The event data fields of the event (ie TargetUserName, IpAddress, LogonType for event 4624)."`
+}
+
+var _ pantherlog.ValueWriterTo = (*EventLog)(nil)
+
+// WriteValuesTo implements pantherlog.ValueWriterTo interface
+func (event *EventLog) WriteValuesTo(w pantherlog.ValueWriter) {
+	for key, value := range event.EventData {
+		if isEmptyValue(value) {
+			continue
+		}
+		switch {
+		case strings.HasSuffix(key, "UserName"), key == "AccountName", key == "SamAccountName":
+			w.WriteValues(pantherlog.FieldUsername, value)
+		case strings.HasSuffix(key, "Address"):
+			scanIPAddress(w, value)
+		case strings.HasSuffix(key, "WorkstationName"), key == "Workstation", key == "ClientName":
+			pantherlog.ScanHostname(w, strings.TrimLeft(value, `\`))
+		}
+	}
+}
+
+// scanIPAddress scans IP addresses including IPv4 addresses mapped to IPv6 (ie ::ffff:10.0.0.1)
+func scanIPAddress(w pantherlog.ValueWriter, addr string) {
+	pantherlog.ScanIPAddress(w, strings.TrimPrefix(addr, "::ffff:"))
+}
+
+// EventLogParser parses Windows events
+type EventLogParser struct {
+	builder pantherlog.ResultBuilder
+}
+
+var _ parsers.Interface = (*EventLogParser)(nil)
+
+// ParseLog implements parsers.Interface
+func (p *EventLogParser) ParseLog(log string) ([]*parsers.Result, error) {
+	env, data, err := parseEvent(log)
+	if err != nil {
+		return nil, err
+	}
+	if isSysmon(env) {
+		return nil, errors.Errorf("sysmon events are parsed as %s", TypeSysmon)
+	}
+	event := EventLog{
+		Envelope: *env,
+	}
+	if len(data) > 0 {
+		event.EventData = data
+	}
+	if err := pantherlog.ValidateStruct(&event); err != nil {
+		return nil, err
+	}
+	result, err := p.builder.BuildResult(TypeEventLog, &event)
+	if err != nil {
+		return nil, err
+	}
+	return []*parsers.Result{result}, nil
+}
@@ -0,0 +1,308 @@
+package windowslogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog/null"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+// Sysmon is a Sysmon event.
+// Event data of process creation (1), network connection (3), image load (7), file creation (11) and DNS query (22) events
+// are mapped to typed columns with the same name as the Sysmon field. All other event data are kept in `eventData`.
+// ProcessId is mapped to `SysmonProcessId`, as column names are case insensitive and `processId` is the ID of the
+// process that logged the event.
+// nolint:lll
+type Sysmon struct {
+	Envelope
+	RuleName            pantherlog.String `json:"RuleName" description:"The name of the rule that triggered the event."`
+	UtcTime             pantherlog.Time   `json:"UtcTime" tcodec:"rfc3339" description:"The time the event occurred in UTC."`
+	ProcessGUID         pantherlog.String `json:"ProcessGuid" description:"The unique identifier of the process."`
+	ProcessID           pantherlog.Int64  `json:"SysmonProcessId" description:"The ID of the process (the ProcessId event data field)."`
+	Image               pantherlog.String `json:"Image" description:"The file path of the process executable."`
+	User                pantherlog.String `json:"User" panther:"username" description:"The name of the account that runs the process (DOMAIN\\user)."`
+	FileVersion         pantherlog.String `json:"FileVersion" description:"The file version of the executable."`
+	Description         pantherlog.String `json:"Description" description:"The description of the executable."`
+	Product             pantherlog.String `json:"Product" description:"The product name of the executable."`
+	Company             pantherlog.String `json:"Company" description:"The company name of the executable."`
+	OriginalFileName    pantherlog.String `json:"OriginalFileName" description:"The original file name of the executable."`
+	CommandLine         pantherlog.String `json:"CommandLine" description:"The command line of the process."`
+	CurrentDirectory    pantherlog.String `json:"CurrentDirectory" description:"The working directory of the process."`
+	LogonGUID           pantherlog.String `json:"LogonGuid" description:"The logon GUID of the process."`
+	LogonID             pantherlog.String `json:"LogonId" description:"The logon ID of the process."`
+	TerminalSessionID   pantherlog.Int64  `json:"TerminalSessionId" description:"The terminal session ID of the process."`
+	IntegrityLevel      pantherlog.String `json:"IntegrityLevel" description:"The integrity level of the process."`
+	Hashes              pantherlog.String `json:"Hashes" description:"The hashes of the executable or the loaded image (ie SHA1=...,MD5=...,SHA256=...,IMPHASH=...)."`
+	ParentProcessGUID   pantherlog.String `json:"ParentProcessGuid" description:"The unique identifier of the parent process."`
+	ParentProcessID     pantherlog.Int64  `json:"ParentProcessId" description:"The ID of the parent process."`
+	ParentImage         pantherlog.String `json:"ParentImage" description:"The file path of the parent process executable."`
+	ParentCommandLine   pantherlog.String `json:"ParentCommandLine" description:"The command line of the parent process."`
+	ParentUser          pantherlog.String `json:"ParentUser" panther:"username" description:"The name of the account that runs the parent process."`
+	Protocol            pantherlog.String `json:"Protocol" description:"The protocol of the network connection."`
+	Initiated           pantherlog.Bool   `json:"Initiated" description:"Whether the process initiated the network connection."`
+	SourceIsIPv6        pantherlog.Bool   `json:"SourceIsIpv6" description:"Whether the source address is an IPv6 address."`
+	SourceIP            pantherlog.String `json:"SourceIp" description:"The source IP address of the network connection."`
+	SourceHostname      pantherlog.String `json:"SourceHostname" panther:"hostname" description:"The source host name of the network connection."`
+	SourcePort          pantherlog.Uint16 `json:"SourcePort" description:"The source port of the network connection."`
+	SourcePortName      pantherlog.String `json:"SourcePortName" description:"The name of the source port of the network connection."`
+	DestinationIsIPv6   pantherlog.Bool   `json:"DestinationIsIpv6" description:"Whether the destination address is an IPv6 address."`
+	DestinationIP       pantherlog.String `json:"DestinationIp" description:"The destination IP address of the network connection."`
+	DestinationHostname pantherlog.String `json:"DestinationHostname" panther:"hostname" description:"The destination host name of the network connection."`
+	DestinationPort     pantherlog.Uint16 `json:"DestinationPort" description:"The destination port of the network connection."`
+	DestinationPortName pantherlog.String `json:"DestinationPortName" description:"The name of the destination port of the network connection."`
+	ImageLoaded         pantherlog.String `json:"ImageLoaded" description:"The file path of the loaded image."`
+	Signed              pantherlog.Bool   `json:"Signed" description:"Whether the loaded image is signed."`
+	Signature           pantherlog.String `json:"Signature" description:"The signer of the loaded image."`
+	SignatureStatus     pantherlog.String `json:"SignatureStatus" description:"The status of the signature of the loaded image."`
+	TargetFilename      pantherlog.String `json:"TargetFilename" description:"The path of the created file."`
+	CreationUtcTime     pantherlog.Time   `json:"CreationUtcTime" tcodec:"rfc3339" description:"The creation time of the file."`
+	QueryName           pantherlog.String `json:"QueryName" panther:"domain" description:"The domain name of the DNS query."`
+	QueryStatus         pantherlog.String `json:"QueryStatus" description:"The status code of the DNS query."`
+	QueryResults        pantherlog.String `json:"QueryResults" description:"The results of the DNS query separated by ';'."`
+	EventData           map[string]string `json:"eventData" description:"The event data fields that are not mapped to a column."`
+}
+
+var _ pantherlog.ValueWriterTo = (*Sysmon)(nil)
+
+// WriteValuesTo implements pantherlog.ValueWriterTo interface
+func (event *Sysmon) WriteValuesTo(w pantherlog.ValueWriter) {
+	scanIPAddress(w, event.SourceIP.Value)
+	scanIPAddress(w, event.DestinationIP.Value)
+	// Hashes are formatted as `SHA1=...,MD5=...,SHA256=...,IMPHASH=...`
+	for _, hash := range strings.Split(event.Hashes.Value, ",") {
+		pos := strings.IndexByte(hash, '=')
+		if pos == -1 {
+			continue
+		}
+		switch algo, value := hash[:pos], strings.ToLower(hash[pos+1:]); algo {
+		case "MD5":
+			w.WriteValues(pantherlog.FieldMD5Hash, value)
+		case "SHA1":
+			w.WriteValues(pantherlog.FieldSHA1Hash, value)
+		case "SHA256":
+			w.WriteValues(pantherlog.FieldSHA256Hash, value)
+		}
+	}
+	// Query results are formatted as `type:  5 example.com;::ffff:10.0.0.1;`
+	for _, answer := range strings.Split(event.QueryResults.Value, ";") {
+		answer = strings.TrimSpace(answer)
+		if answer == "" {
+			continue
+		}
+		if strings.HasPrefix(answer, "type:") {
+			if fields := strings.Fields(answer); len(fields) == 3 {
+				w.WriteValues(pantherlog.FieldDomainName, fields[2])
+			}
+			continue
+		}
+		scanIPAddress(w, answer)
+	}
+}
+
+// nolint:gocyclo
+func (event *Sysmon) setField(key, value string) bool {
+	var dst *pantherlog.String
+	switch key {
+	case "UtcTime":
+		return setTime(&event.UtcTime, value)
+	case "CreationUtcTime":
+		return setTime(&event.CreationUtcTime, value)
+	case "ProcessId":
+		return setInt64(&event.ProcessID, value)
+	case "ParentProcessId":
+		return setInt64(&event.ParentProcessID, value)
+	case "TerminalSessionId":
+		return setInt64(&event.TerminalSessionID, value)
+	case "SourcePort":
+		return setPort(&event.SourcePort, value)
+	case "DestinationPort":
+		return setPort(&event.DestinationPort, value)
+	case "Initiated":
+		return setBool(&event.Initiated, value)
+	case "SourceIsIpv6":
+		return setBool(&event.SourceIsIPv6, value)
+	case "DestinationIsIpv6":
+		return setBool(&event.DestinationIsIPv6, value)
+	case "Signed":
+		return setBool(&event.Signed, value)
+	case "RuleName":
+		dst = &event.RuleName
+	case "ProcessGuid":
+		dst = &event.ProcessGUID
+	case "Image":
+		dst = &event.Image
+	case "User":
+		dst = &event.User
+	case "FileVersion":
+		dst = &event.FileVersion
+	case "Description":
+		dst = &event.Description
+	case "Product":
+		dst = &event.Product
+	case "Company":
+		dst = &event.Company
+	case "OriginalFileName":
+		dst = &event.OriginalFileName
+	case "CommandLine":
+		dst = &event.CommandLine
+	case "CurrentDirectory":
+		dst = &event.CurrentDirectory
+	case "LogonGuid":
+		dst = &event.LogonGUID
+	case "LogonId":
+		dst = &event.LogonID
+	case "IntegrityLevel":
+		dst = &event.IntegrityLevel
+	case "Hashes":
+		dst = &event.Hashes
+	case "ParentProcessGuid":
+		dst = &event.ParentProcessGUID
+	case "ParentImage":
+		dst = &event.ParentImage
+	case "ParentCommandLine":
+		dst = &event.ParentCommandLine
+	case "ParentUser":
+		dst = &event.ParentUser
+	case "Protocol":
+		dst = &event.Protocol
+	case "SourceIp":
+		dst = &event.SourceIP
+	case "SourceHostname":
+		dst = &event.SourceHostname
+	case "SourcePortName":
+		dst = &event.SourcePortName
+	case "DestinationIp":
+		dst = &event.DestinationIP
+	case "DestinationHostname":
+		dst = &event.DestinationHostname
+	case "DestinationPortName":
+		dst = &event.DestinationPortName
+	case "ImageLoaded":
+		dst = &event.ImageLoaded
+	case "Signature":
+		dst = &event.Signature
+	case "SignatureStatus":
+		dst = &event.SignatureStatus
+	case "TargetFilename":
+		dst = &event.TargetFilename
+	case "QueryName":
+		dst = &event.QueryName
+	case "QueryStatus":
+		dst = &event.QueryStatus
+	case "QueryResults":
+		dst = &event.QueryResults
+	default:
+		return false
+	}
+	if !isEmptyValue(value) {
+		*dst = null.FromString(value)
+	}
+	return true
+}
+
+func setTime(dst *time.Time, value string) bool {
+	// Sysmon times are formatted as `2020-10-12 09:21:04.100`
+	tm, err := time.Parse("2006-01-02 15:04:05.999999999", value)
+	if err != nil {
+		return false
+	}
+	*dst = tm.UTC()
+	return true
+}
+
+func setInt64(dst *pantherlog.Int64, value string) bool {
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return false
+	}
+	*dst = null.FromInt64(n)
+	return true
+}
+
+func setPort(dst *pantherlog.Uint16, value string) bool {
+	n, err := strconv.ParseUint(value, 10, 16)
+	if err != nil {
+		return false
+	}
+	*dst = null.FromUint16(uint16(n))
+	return true
+}
+
+func setBool(dst *pantherlog.Bool, value string) bool {
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false
+	}
+	*dst = null.FromBool(b)
+	return true
+}
+
+const (
+	sysmonChannel  = "Microsoft-Windows-Sysmon/Operational"
+	sysmonProvider = "Microsoft-Windows-Sysmon"
+)
+
+func isSysmon(env *Envelope) bool {
+	return env.Channel.Value == sysmonChannel || env.Provider.Value == sysmonProvider
+}
+
+// SysmonParser parses Sysmon events
+type SysmonParser struct {
+	builder pantherlog.ResultBuilder
+}
+
+var _ parsers.Interface = (*SysmonParser)(nil)
+
+// ParseLog implements parsers.Interface
+func (p *SysmonParser) ParseLog(log string) ([]*parsers.Result, error) {
+	env, data, err := parseEvent(log)
+	if err != nil {
+		return nil, err
+	}
+	if !isSysmon(env) {
+		return nil, errors.New("not a sysmon event")
+	}
+	event := Sysmon{
+		Envelope: *env,
+	}
+	for key, value := range data {
+		if event.setField(key, value) {
+			continue
+		}
+		if event.EventData == nil {
+			event.EventData = make(map[string]string)
+		}
+		event.EventData[key] = value
+	}
+	if err := pantherlog.ValidateStruct(&event); err != nil {
+		return nil, err
+	}
+	result, err := p.builder.BuildResult(TypeSysmon, &event)
+	if err != nil {
+		return nil, err
+	}
+	return []*parsers.Result{result}, nil
+}
@@ -0,0 +1,69 @@
+// Package windowslogs parses Windows event logs shipped as JSON by Winlogbeat or NXLog
+package windowslogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+const (
+	TypeEventLog = "Windows.EventLog"
+	TypeSysmon   = "Windows.Sysmon"
+)
+
+// LogTypes exports the available log type entries
+func LogTypes() logtypes.Group {
+	return logTypes
+}
+
+// nolint:lll
+var logTypes = logtypes.Must("Windows",
+	logtypes.Config{
+		Name:         TypeEventLog,
+		Description:  `Windows event log events (Security, System, Application, ...) shipped as JSON by Winlogbeat or NXLog. Sysmon events are handled by the Windows.Sysmon log type.`,
+		ReferenceURL: `https://docs.microsoft.com/en-us/windows/win32/wes/windows-event-log`,
+		Schema: pantherlog.MustBuildEventSchema(&EventLog{},
+			pantherlog.FieldIPAddress,
+			pantherlog.FieldDomainName,
+			pantherlog.FieldUsername,
+		),
+		NewParser: parsers.FactoryFunc(func(_ interface{}) (parsers.Interface, error) {
+			return &EventLogParser{}, nil
+		}),
+	},
+	logtypes.Config{
+		Name:         TypeSysmon,
+		Description:  `Sysmon events shipped as JSON by Winlogbeat or NXLog.`,
+		ReferenceURL: `https://docs.microsoft.com/en-us/sysinternals/downloads/sysmon`,
+		Schema: pantherlog.MustBuildEventSchema(&Sysmon{},
+			pantherlog.FieldIPAddress,
+			pantherlog.FieldDomainName,
+			pantherlog.FieldMD5Hash,
+			pantherlog.FieldSHA1Hash,
+			pantherlog.FieldSHA256Hash,
+			pantherlog.FieldUsername,
+		),
+		NewParser: parsers.FactoryFunc(func(_ interface{}) (parsers.Interface, error) {
+			return &SysmonParser{}, nil
+		}),
+	},
+)
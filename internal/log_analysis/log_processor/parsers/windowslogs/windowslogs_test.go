@@ -0,0 +1,48 @@
+package windowslogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes/logtesting"
+)
+
+func TestEventLog(t *testing.T) {
+	logtesting.RunTestsFromYAML(t, LogTypes(), "./testdata/eventlog_tests.yml")
+}
+
+func TestSysmon(t *testing.T) {
+	logtesting.RunTestsFromYAML(t, LogTypes(), "./testdata/sysmon_tests.yml")
+}
+
+func TestSysmonEventLogSeparation(t *testing.T) {
+	// nolint:lll
+	sysmon := `{"@timestamp":"2020-10-12T09:21:07Z","winlog":{"channel":"Microsoft-Windows-Sysmon/Operational","provider_name":"Microsoft-Windows-Sysmon","event_id":11,"computer_name":"WS01"}}`
+	// nolint:lll
+	security := `{"@timestamp":"2020-10-12T09:21:07Z","winlog":{"channel":"Security","provider_name":"Microsoft-Windows-Security-Auditing","event_id":4624,"computer_name":"WS01"}}`
+	_, err := (&EventLogParser{}).ParseLog(sysmon)
+	require.Error(t, err)
+	_, err = (&SysmonParser{}).ParseLog(security)
+	require.Error(t, err)
+	_, err = (&SysmonParser{}).ParseLog(`{"foo":"bar"}`)
+	require.Error(t, err)
+}
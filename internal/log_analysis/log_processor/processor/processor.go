@@ -140,7 +140,10 @@ func NewFactory(resolver logtypes.Resolver) Factory {
 				},
 			}, nil
 		case models.IntegrationTypeAWS3:
-			c, err := sources.BuildClassifier(src, resolver)
+			c, err := sources.BuildClassifier(src, resolver, &parsers.ObjectInfo{
+				Bucket: input.S3Bucket,
+				Key:    input.S3ObjectKey,
+			})
 			if err != nil {
 				return nil, err
 			}
@@ -166,6 +169,7 @@ func (p *Processor) run(outputChan chan<- *parsers.Result) error {
 			if err == io.EOF { // we are done
 				err = nil // not really an error
 				p.processLogLine(line, outputChan)
+				p.flush(outputChan)
 			}
 			break
 		}
@@ -200,6 +204,25 @@ func (p *Processor) processLogLine(line string, outputChan chan<- *parsers.Resul
 	}
 }
 
+// flush sends the events held back by parsers reassembling events logged over multiple lines
+func (p *Processor) flush(outputChan chan<- *parsers.Result) {
+	result, err := p.classifier.Flush()
+	if err != nil {
+		p.operation.LogWarn(errors.Wrap(err, "failed to flush parsers"),
+			zap.String("sourceId", p.input.Source.IntegrationID),
+			zap.String("sourceLabel", p.input.Source.IntegrationLabel),
+			zap.String("s3Bucket", p.input.S3Bucket),
+			zap.String("s3ObjectKey", p.input.S3ObjectKey),
+		)
+	}
+	if result == nil {
+		return
+	}
+	for _, event := range result.Events {
+		outputChan <- event
+	}
+}
+
 func (p *Processor) logStats(err error) {
 	p.operation.Stop()
 	p.operation.Log(err, zap.Any(statsKey, *p.classifier.Stats()))
@@ -125,6 +125,38 @@ func TestProcess(t *testing.T) {
 	require.Equal(t, testLogEvents, destination.nEvents)
 }
 
+func TestProcessFlush(t *testing.T) {
+	destination := (&testDestination{}).standardMock()
+
+	dataStream := makeDataStream()
+	f := NewFactory(testResolver)
+	p, err := f(dataStream)
+	require.NoError(t, err)
+	mockClassifier := &testClassifier{}
+	p.classifier = mockClassifier
+
+	// The events held back by the parsers are sent when the input ends
+	mockClassifier.On("Classify", mock.Anything).Return(&classification.ClassifierResult{
+		Events:  []*parsers.Result{newTestLog()},
+		Matched: true,
+	}, nil)
+	mockClassifier.On("Flush").Return(&classification.ClassifierResult{
+		Events:  []*parsers.Result{newTestLog(), newTestLog()},
+		Matched: true,
+	}, nil).Once()
+	mockClassifier.On("Stats", mock.Anything).Return(&classification.ClassifierStats{})
+	mockClassifier.On("ParserStats", mock.Anything).Return(map[string]*classification.ParserStats{})
+
+	newProcessorFunc := func(*common.DataStream) (*Processor, error) { return p, nil }
+	streamChan := make(chan *common.DataStream, 1)
+	streamChan <- dataStream
+	close(streamChan)
+	err = Process(streamChan, destination, newProcessorFunc)
+	require.NoError(t, err)
+	require.Equal(t, testLogEvents+2, destination.nEvents)
+	mockClassifier.AssertExpectations(t)
+}
+
 func TestProcessDataStreamError(t *testing.T) {
 	logs := mockLogger()
 
@@ -271,6 +303,7 @@ func TestProcessClassifyFailure(t *testing.T) {
 		Events:  []*parsers.Result{newTestLog()},
 		Matched: true,
 	}, nil)
+	mockClassifier.On("Flush").Return(&classification.ClassifierResult{}, nil)
 	mockClassifier.On("Stats", mock.Anything).Return(mockStats)
 	mockClassifier.On("ParserStats", mock.Anything).Return(mockParserStats)
 
@@ -470,6 +503,11 @@ func (c *testClassifier) Classify(log string) (*classification.ClassifierResult,
 	return args.Get(0).(*classification.ClassifierResult), args.Error(1)
 }
 
+func (c *testClassifier) Flush() (*classification.ClassifierResult, error) {
+	args := c.Called()
+	return args.Get(0).(*classification.ClassifierResult), args.Error(1)
+}
+
 func (c *testClassifier) Stats() *classification.ClassifierStats {
 	args := c.Called()
 	return args.Get(0).(*classification.ClassifierStats)
@@ -486,6 +524,7 @@ func (c *testClassifier) standardMocks(cStats *classification.ClassifierStats, p
 		Events:  []*parsers.Result{newTestLog()},
 		Matched: true,
 	}, nil).After(parseDelay)
+	c.On("Flush").Return(&classification.ClassifierResult{}, nil)
 	c.On("Stats", mock.Anything).Return(cStats)
 	c.On("ParserStats", mock.Anything).Return(pStats)
 }
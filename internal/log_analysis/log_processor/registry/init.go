@@ -23,21 +23,37 @@ import (
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
 	// Packages that export log types
 	apachelogs "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/apachelogs"
+	auditdlogs "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/auditdlogs"
 	awslogs "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/awslogs"
+	ceflogs "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/ceflogs"
+	ciscoumbrellalogs "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/ciscoumbrellalogs"
 	cloudflarelogs "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/cloudflarelogs"
+	containerlogs "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/containerlogs"
+	crowdstrikelogs "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/crowdstrikelogs"
+	duologs "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/duologs"
+	envoylogs "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/envoylogs"
 	fastlylogs "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/fastlylogs"
 	fluentdsyslogs "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/fluentdsyslogs"
 	gcplogs "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/gcplogs"
+	githublogs "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/githublogs"
 	gitlablogs "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/gitlablogs"
 	gravitationallogs "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/gravitationallogs"
+	haproxylogs "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/haproxylogs"
 	juniperlogs "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/juniperlogs"
+	kuberneteslogs "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/kuberneteslogs"
 	laceworklogs "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/laceworklogs"
+	leeflogs "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/leeflogs"
 	nginxlogs "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/nginxlogs"
+	onepasswordlogs "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/onepasswordlogs"
+	opentelemetrylogs "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/opentelemetrylogs"
 	osquerylogs "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/osquerylogs"
 	osseclogs "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/osseclogs"
+	paloaltologs "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/paloaltologs"
+	slacklogs "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/slacklogs"
 	sophoslogs "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/sophoslogs"
 	suricatalogs "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/suricatalogs"
 	sysloglogs "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/sysloglogs"
+	windowslogs "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/windowslogs"
 	zeeklogs "github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/zeeklogs"
 )
 
@@ -47,36 +63,68 @@ func init() {
 
 		apachelogs.LogTypes(),
 
+		auditdlogs.LogTypes(),
+
 		awslogs.LogTypes(),
 
+		ceflogs.LogTypes(),
+
+		ciscoumbrellalogs.LogTypes(),
+
 		cloudflarelogs.LogTypes(),
 
+		containerlogs.LogTypes(),
+
+		crowdstrikelogs.LogTypes(),
+
+		duologs.LogTypes(),
+
+		envoylogs.LogTypes(),
+
 		fastlylogs.LogTypes(),
 
 		fluentdsyslogs.LogTypes(),
 
 		gcplogs.LogTypes(),
 
+		githublogs.LogTypes(),
+
 		gitlablogs.LogTypes(),
 
 		gravitationallogs.LogTypes(),
 
+		haproxylogs.LogTypes(),
+
 		juniperlogs.LogTypes(),
 
+		kuberneteslogs.LogTypes(),
+
 		laceworklogs.LogTypes(),
 
+		leeflogs.LogTypes(),
+
 		nginxlogs.LogTypes(),
 
+		onepasswordlogs.LogTypes(),
+
+		opentelemetrylogs.LogTypes(),
+
 		osquerylogs.LogTypes(),
 
 		osseclogs.LogTypes(),
 
+		paloaltologs.LogTypes(),
+
+		slacklogs.LogTypes(),
+
 		sophoslogs.LogTypes(),
 
 		suricatalogs.LogTypes(),
 
 		sysloglogs.LogTypes(),
 
+		windowslogs.LogTypes(),
+
 		zeeklogs.LogTypes(),
 	)
 	// Register all log types in the group with the availableLogTypes
@@ -19,11 +19,36 @@ package registry
  */
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
 )
 
 func TestPanic(t *testing.T) {
 	assert.Panics(t, func() { Lookup("doesnotexist") }, "Failed to panic, this is very dangerous!")
 }
+
+// Schemas listing their indicator fields explicitly must list all fields tagged in the event,
+// otherwise the indicator values are written to columns missing from the table.
+func TestIndicatorColumns(t *testing.T) {
+	for _, entry := range LogTypes().Entries() {
+		schema := entry.Schema()
+		columns := make(map[string]bool)
+		cols, _ := awsglue.InferJSONColumns(schema, awsglue.GlueMappings...)
+		for _, col := range cols {
+			columns[col.Name] = true
+		}
+		// Schemas not built with the panther fields declare their indicator columns some other way
+		if !columns[pantherlog.FieldLogTypeJSON] {
+			continue
+		}
+		for _, id := range pantherlog.FieldSetFromType(reflect.TypeOf(schema)).Indicators() {
+			name := pantherlog.FieldNameJSON(id)
+			assert.True(t, columns[name], "log type %q is missing the %q column", entry.String(), name)
+		}
+	}
+}
@@ -66,8 +66,9 @@ func LoadSourceS3(bucketName, objectKey string) (*models.SourceIntegration, erro
 	return result, nil
 }
 
-// BuildClassifier builds a classifier for a source
-func BuildClassifier(src *models.SourceIntegration, r logtypes.Resolver) (classification.ClassifierAPI, error) {
+// BuildClassifier builds a classifier for a source.
+// The params are passed to the factories of the parsers (ie *parsers.ObjectInfo for logs read from S3 objects).
+func BuildClassifier(src *models.SourceIntegration, r logtypes.Resolver, params interface{}) (classification.ClassifierAPI, error) {
 	parserIndex := map[string]parsers.Interface{}
 	for _, logType := range src.RequiredLogTypes() {
 		entry, err := r.Resolve(context.TODO(), logType)
@@ -78,7 +79,7 @@ func BuildClassifier(src *models.SourceIntegration, r logtypes.Resolver) (classi
 			zap.L().Warn("unresolved log type", zap.String("logType", logType), zap.String("sourceId", src.IntegrationID))
 			continue
 		}
-		parser, err := entry.NewParser(nil)
+		parser, err := entry.NewParser(params)
 		if err != nil {
 			return nil, errors.WithMessagef(err, "failed to create %q parser", logType)
 		}
@@ -106,6 +107,23 @@ func (p *sourceFieldsParser) ParseLog(log string) ([]*pantherlog.Result, error)
 	if err != nil {
 		return nil, err
 	}
+	return p.setSourceFields(results), nil
+}
+
+// Flush implements parsers.Flusher for the parsers holding back log lines
+func (p *sourceFieldsParser) Flush() ([]*pantherlog.Result, error) {
+	flusher, ok := p.Interface.(parsers.Flusher)
+	if !ok {
+		return nil, nil
+	}
+	results, err := flusher.Flush()
+	if err != nil {
+		return nil, err
+	}
+	return p.setSourceFields(results), nil
+}
+
+func (p *sourceFieldsParser) setSourceFields(results []*pantherlog.Result) []*pantherlog.Result {
 	for _, result := range results {
 		if result.EventIncludesPantherFields {
 			if event, ok := result.Event.(parsers.PantherSourceSetter); ok {
@@ -116,5 +134,5 @@ func (p *sourceFieldsParser) ParseLog(log string) ([]*pantherlog.Result, error)
 		result.PantherSourceID = p.SourceID
 		result.PantherSourceLabel = p.SourceLabel
 	}
-	return results, nil
+	return results
 }
@@ -22,6 +22,7 @@ import (
 	"strings"
 
 	"github.com/pkg/errors"
+	"go.uber.org/multierr"
 
 	"github.com/panther-labs/panther/api/lambda/source/models"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/classification"
@@ -68,12 +69,28 @@ func (c *SQSClassifier) Classify(log string) (*classification.ClassifierResult,
 	return cls.Classify(msg.Payload)
 }
 
+// Flush returns the events held back by the classifiers of all sources
+func (c *SQSClassifier) Flush() (*classification.ClassifierResult, error) {
+	result := &classification.ClassifierResult{}
+	var err error
+	for _, cls := range c.classifiers {
+		flushed, flushErr := cls.Flush()
+		err = multierr.Append(err, flushErr)
+		if flushed == nil {
+			continue
+		}
+		result.Matched = result.Matched || flushed.Matched
+		result.Events = append(result.Events, flushed.Events...)
+	}
+	return result, err
+}
+
 func (c *SQSClassifier) buildSourceClassifier(id string) (classification.ClassifierAPI, error) {
 	src, err := c.LoadSource(id)
 	if err != nil {
 		return nil, err
 	}
-	return BuildClassifier(src, c.Resolver)
+	return BuildClassifier(src, c.Resolver, nil)
 }
 
 func (c *SQSClassifier) Stats() *classification.ClassifierStats {
@@ -39,6 +39,8 @@ import (
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager/s3manageriface"
+	"github.com/aws/aws-sdk-go/service/ses"
+	"github.com/aws/aws-sdk-go/service/ses/sesiface"
 	"github.com/aws/aws-sdk-go/service/sns"
 	"github.com/aws/aws-sdk-go/service/sns/snsiface"
 	"github.com/aws/aws-sdk-go/service/sqs"
@@ -71,6 +73,11 @@ func (m *S3Mock) GetObjectWithContext(ctx aws.Context, input *s3.GetObjectInput,
 	return args.Get(0).(*s3.GetObjectOutput), args.Error(1)
 }
 
+func (m *S3Mock) PutObject(input *s3.PutObjectInput) (*s3.PutObjectOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*s3.PutObjectOutput), args.Error(1)
+}
+
 func (m *S3Mock) GetBucketLocation(input *s3.GetBucketLocationInput) (*s3.GetBucketLocationOutput, error) {
 	args := m.Called(input)
 	return args.Get(0).(*s3.GetBucketLocationOutput), args.Error(1)
@@ -158,6 +165,11 @@ func (m *DynamoDBMock) Scan(input *dynamodb.ScanInput) (*dynamodb.ScanOutput, er
 	return args.Get(0).(*dynamodb.ScanOutput), args.Error(1)
 }
 
+func (m *DynamoDBMock) Query(input *dynamodb.QueryInput) (*dynamodb.QueryOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*dynamodb.QueryOutput), args.Error(1)
+}
+
 type SqsMock struct {
 	sqsiface.SQSAPI
 	mock.Mock
@@ -345,11 +357,26 @@ func (m *SnsMock) ConfirmSubscription(input *sns.ConfirmSubscriptionInput) (*sns
 	return args.Get(0).(*sns.ConfirmSubscriptionOutput), args.Error(1)
 }
 
+type SesMock struct {
+	sesiface.SESAPI
+	mock.Mock
+}
+
+func (m *SesMock) SendRawEmail(input *ses.SendRawEmailInput) (*ses.SendRawEmailOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*ses.SendRawEmailOutput), args.Error(1)
+}
+
 type FirehoseMock struct {
 	firehoseiface.FirehoseAPI
 	mock.Mock
 }
 
+func (m *FirehoseMock) PutRecord(input *firehose.PutRecordInput) (*firehose.PutRecordOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*firehose.PutRecordOutput), args.Error(1)
+}
+
 func (m *FirehoseMock) PutRecordBatchWithContext(
 	ctx aws.Context,
 	input *firehose.PutRecordBatchInput,
@@ -92,6 +92,15 @@ func Compile(pattern string) (*Pattern, error) {
 	}, nil
 }
 
+// MustCompile compiles a pattern or panics
+func MustCompile(pattern string) *Pattern {
+	p, err := Compile(pattern)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
 func (d *delimiter) reset(tag, match, prev string) {
 	quote := prevQuote(prev)
 	if quote != nextQuote(match) {